@@ -9,6 +9,8 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -26,6 +28,7 @@ func getLots(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewAuctionLots(lots.Lots))
 
 	// Get lots by status
 	openLots := []api.LotDetails{}
@@ -7,6 +7,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -24,6 +26,7 @@ func getStatus(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewAuctionStatus(status))
 
 	// Print & return
 	fmt.Printf(
@@ -0,0 +1,187 @@
+package fleet
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The flag for choosing which contexts a fleet command covers
+var contextsFlag = cli.StringFlag{
+	Name:  "contexts, x",
+	Usage: "A comma-separated list of the contexts to include (default: all of them)",
+}
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Manage and monitor several Smart Nodes at once through saved contexts",
+		Subcommands: []cli.Command{
+
+			{
+				Name:    "context",
+				Aliases: []string{"c"},
+				Usage:   "Manage the saved contexts; use one for any command with 'rocketpool --context <name> ...'",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the saved contexts",
+						UsageText: "rocketpool fleet context list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return listContexts(c)
+
+						},
+					},
+
+					{
+						Name:      "add",
+						Aliases:   []string{"a"},
+						Usage:     "Save a context for a Smart Node on this machine, on another machine over SSH, or behind a daemon's API server over HTTP",
+						UsageText: "rocketpool fleet context add [options] name",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "host",
+								Usage: "The SSH `host` (or host:port) of the Smart Node's machine",
+							},
+							cli.StringFlag{
+								Name:  "user",
+								Usage: "The SSH user (default: the current user)",
+							},
+							cli.StringFlag{
+								Name:  "key",
+								Usage: "The `path` of the SSH private key; keys with a passphrase should be loaded into ssh-agent instead",
+							},
+							cli.StringFlag{
+								Name:  "known-hosts",
+								Usage: "The `path` of the known hosts file used to verify the machine (default: ~/.ssh/known_hosts)",
+							},
+							cli.StringFlag{
+								Name:  "cli-path",
+								Usage: "The `path` of the Rocket Pool CLI on the Smart Node's machine (default: rocketpool)",
+							},
+							cli.StringFlag{
+								Name:  "url",
+								Usage: "The URL of the Smart Node's API server (see 'rocketpool api-server' on the daemon)",
+							},
+							cli.StringFlag{
+								Name:  "token-file",
+								Usage: "The `path` of a file with the API server's token",
+							},
+							cli.StringFlag{
+								Name:  "node-config-path",
+								Usage: "The Smart Node's config `path` on its machine (default: ~/.rocketpool)",
+							},
+							cli.StringFlag{
+								Name:  "node-daemon-path",
+								Usage: "The `path` of the Smart Node's daemon on its machine, if it runs outside of docker",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return addContext(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "remove",
+						Aliases:   []string{"r"},
+						Usage:     "Remove a saved context",
+						UsageText: "rocketpool fleet context remove name",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return removeContext(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "status",
+				Aliases:   []string{"s"},
+				Usage:     "Show the node status, minipools and service version of every context in one table",
+				UsageText: "rocketpool fleet status [options]",
+				Flags:     []cli.Flag{contextsFlag},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getStatus(c)
+
+				},
+			},
+
+			{
+				Name:      "rewards",
+				Aliases:   []string{"r"},
+				Usage:     "Show the RPL and ETH rewards of every context in one table",
+				UsageText: "rocketpool fleet rewards [options]",
+				Flags:     []cli.Flag{contextsFlag},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getRewards(c)
+
+				},
+			},
+
+			{
+				Name:      "update",
+				Aliases:   []string{"u"},
+				Usage:     "Compare the service version of every context with this CLI's, and update the ones reachable over SSH that are behind",
+				UsageText: "rocketpool fleet update [options]",
+				Flags: []cli.Flag{
+					contextsFlag,
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the updates",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return update(c)
+
+				},
+			},
+		},
+	})
+}
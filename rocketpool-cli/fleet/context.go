@@ -0,0 +1,130 @@
+package fleet
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+func listContexts(c *cli.Context) error {
+
+	// Load the contexts
+	contexts, err := rocketpool.LoadContexts(c.GlobalString("config-path"))
+	if err != nil {
+		return err
+	}
+	result := results.FleetContexts{
+		Contexts: make([]results.FleetContext, len(contexts)),
+	}
+	for i, context := range contexts {
+		result.Contexts[i] = results.FleetContext{
+			Name:       context.Name,
+			Target:     context.Target(),
+			ConfigPath: context.ConfigPath,
+			DaemonPath: context.DaemonPath,
+		}
+	}
+	output.SetResult(c, result)
+	if len(contexts) == 0 {
+		fmt.Println("There are no saved contexts yet. Use `rocketpool fleet context add` to create one.")
+		return nil
+	}
+
+	// Print them
+	table := newTable()
+	table.row("NAME", "TARGET", "CONFIG PATH", "DAEMON PATH")
+	for _, context := range contexts {
+		table.row(context.Name, context.Target(), valueOrDefault(context.ConfigPath, "default"), valueOrDefault(context.DaemonPath, "docker"))
+	}
+	return table.flush()
+
+}
+
+func addContext(c *cli.Context, name string) error {
+
+	// Load the contexts
+	configPath := c.GlobalString("config-path")
+	contexts, err := rocketpool.LoadContexts(configPath)
+	if err != nil {
+		return err
+	}
+	for _, context := range contexts {
+		if context.Name == name {
+			return fmt.Errorf("context [%s] already exists; remove it first to replace it", name)
+		}
+	}
+
+	// Build the new context
+	context := rocketpool.Context{
+		Name:           name,
+		Host:           c.String("host"),
+		User:           c.String("user"),
+		KeyPath:        c.String("key"),
+		KnownHostsPath: c.String("known-hosts"),
+		CliPath:        c.String("cli-path"),
+		Url:            c.String("url"),
+		ConfigPath:     c.String("node-config-path"),
+		DaemonPath:     c.String("node-daemon-path"),
+	}
+	if tokenFile := c.String("token-file"); tokenFile != "" {
+		tokenBytes, err := os.ReadFile(tokenFile)
+		if err != nil {
+			return fmt.Errorf("error reading token file: %w", err)
+		}
+		context.Token = strings.TrimSpace(string(tokenBytes))
+	}
+	if err := context.Validate(); err != nil {
+		return err
+	}
+
+	// Save it
+	contexts = append(contexts, context)
+	if err := rocketpool.SaveContexts(configPath, contexts); err != nil {
+		return err
+	}
+	fmt.Printf("Saved context [%s] targeting %s.\n", name, context.Target())
+	fmt.Printf("Run any command against it with `rocketpool --context %s ...`.\n", name)
+	return nil
+
+}
+
+func removeContext(c *cli.Context, name string) error {
+
+	// Load the contexts
+	configPath := c.GlobalString("config-path")
+	contexts, err := rocketpool.LoadContexts(configPath)
+	if err != nil {
+		return err
+	}
+
+	// Remove the context
+	remaining := []rocketpool.Context{}
+	for _, context := range contexts {
+		if context.Name != name {
+			remaining = append(remaining, context)
+		}
+	}
+	if len(remaining) == len(contexts) {
+		return fmt.Errorf("context [%s] does not exist", name)
+	}
+	if err := rocketpool.SaveContexts(configPath, remaining); err != nil {
+		return err
+	}
+	fmt.Printf("Removed context [%s].\n", name)
+	return nil
+
+}
+
+// Get a value, or a placeholder if it's blank
+func valueOrDefault(value string, placeholder string) string {
+	if value == "" {
+		return placeholder
+	}
+	return value
+}
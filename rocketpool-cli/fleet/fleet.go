@@ -0,0 +1,108 @@
+package fleet
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+// Colors
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+)
+
+// Get the contexts a fleet command covers
+func getContexts(c *cli.Context) ([]rocketpool.Context, error) {
+	contexts, err := rocketpool.LoadContexts(c.GlobalString("config-path"))
+	if err != nil {
+		return nil, err
+	}
+	if len(contexts) == 0 {
+		return nil, fmt.Errorf("there are no saved contexts yet; use `rocketpool fleet context add` to create one")
+	}
+
+	// Filter them if requested
+	selection := c.String("contexts")
+	if selection == "" {
+		return contexts, nil
+	}
+	selected := []rocketpool.Context{}
+	for _, name := range strings.Split(selection, ",") {
+		name = strings.TrimSpace(name)
+		found := false
+		for _, context := range contexts {
+			if context.Name == name {
+				selected = append(selected, context)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("context [%s] does not exist", name)
+		}
+	}
+	return selected, nil
+}
+
+// Run a query against every context at the same time and return the results in the same order as the contexts
+func fanOut[T any](c *cli.Context, contexts []rocketpool.Context, query func(rp *rocketpool.Client) T) []T {
+	results := make([]T, len(contexts))
+	wg := new(sync.WaitGroup)
+	for i := range contexts {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rp := rocketpool.NewClientFromContext(c, &contexts[i])
+			defer rp.Close()
+			results[i] = query(rp)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// A table printed with aligned columns
+type table struct {
+	writer *tabwriter.Writer
+}
+
+// Create a new table that prints to the terminal
+func newTable() *table {
+	return &table{
+		writer: tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0),
+	}
+}
+
+// Add a row to the table
+func (t *table) row(cells ...string) {
+	fmt.Fprintln(t.writer, strings.Join(cells, "\t"))
+}
+
+// Print the table
+func (t *table) flush() error {
+	return t.writer.Flush()
+}
+
+// Print the errors from the contexts that couldn't be queried
+func printErrors(contexts []rocketpool.Context, errs []error) {
+	printedHeader := false
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !printedHeader {
+			fmt.Printf("\n%sSome contexts could not be queried:%s\n", colorRed, colorReset)
+			printedHeader = true
+		}
+		fmt.Printf("  %s: %s\n", contexts[i].Name, err.Error())
+	}
+}
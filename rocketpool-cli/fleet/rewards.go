@@ -0,0 +1,98 @@
+package fleet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The rewards of one context's Smart Node
+type contextRewards struct {
+	rewards api.NodeRewardsResponse
+	err     error
+}
+
+func getRewards(c *cli.Context) error {
+
+	// Get the contexts
+	contexts, err := getContexts(c)
+	if err != nil {
+		return err
+	}
+
+	// Query every context
+	responses := fanOut(c, contexts, func(rp *rocketpool.Client) contextRewards {
+		rewards, err := rp.NodeRewards()
+		return contextRewards{
+			rewards: rewards,
+			err:     err,
+		}
+	})
+
+	// Print the table
+	var totals api.NodeRewardsResponse
+	errs := make([]error, len(contexts))
+	result := results.FleetRewards{
+		Contexts: make([]results.FleetContextRewards, len(contexts)),
+	}
+	table := newTable()
+	table.row("CONTEXT", "EFFECTIVE RPL", "EST. RPL THIS INTERVAL", "CLAIMED RPL", "UNCLAIMED RPL", "CLAIMED ETH", "UNCLAIMED ETH", "BEACON ETH")
+	for i, response := range responses {
+		errs[i] = response.err
+		result.Contexts[i].Context = contexts[i].Name
+		if response.err != nil {
+			result.Contexts[i].Error = response.err.Error()
+			table.row(contexts[i].Name, "error", "-", "-", "-", "-", "-", "-")
+			continue
+		}
+		nodeRewards := results.NewNodeRewards(response.rewards)
+		result.Contexts[i].Rewards = &nodeRewards
+		if !response.rewards.Registered {
+			table.row(contexts[i].Name, "not registered", "-", "-", "-", "-", "-", "-")
+			continue
+		}
+
+		rewards := response.rewards
+		table.row(
+			contexts[i].Name,
+			fmt.Sprintf("%.2f", rewards.EffectiveRplStake),
+			fmt.Sprintf("%.4f", rewards.EstimatedRewards+rewards.EstimatedTrustedRplRewards),
+			fmt.Sprintf("%.4f", rewards.CumulativeRplRewards+rewards.CumulativeTrustedRplRewards),
+			fmt.Sprintf("%.4f", rewards.UnclaimedRplRewards+rewards.UnclaimedTrustedRplRewards),
+			fmt.Sprintf("%.4f", rewards.CumulativeEthRewards),
+			fmt.Sprintf("%.4f", rewards.UnclaimedEthRewards),
+			fmt.Sprintf("%.4f", rewards.BeaconRewards),
+		)
+
+		totals.EffectiveRplStake += rewards.EffectiveRplStake
+		totals.EstimatedRewards += rewards.EstimatedRewards + rewards.EstimatedTrustedRplRewards
+		totals.CumulativeRplRewards += rewards.CumulativeRplRewards + rewards.CumulativeTrustedRplRewards
+		totals.UnclaimedRplRewards += rewards.UnclaimedRplRewards + rewards.UnclaimedTrustedRplRewards
+		totals.CumulativeEthRewards += rewards.CumulativeEthRewards
+		totals.UnclaimedEthRewards += rewards.UnclaimedEthRewards
+		totals.BeaconRewards += rewards.BeaconRewards
+	}
+	table.row(
+		"TOTAL",
+		fmt.Sprintf("%.2f", totals.EffectiveRplStake),
+		fmt.Sprintf("%.4f", totals.EstimatedRewards),
+		fmt.Sprintf("%.4f", totals.CumulativeRplRewards),
+		fmt.Sprintf("%.4f", totals.UnclaimedRplRewards),
+		fmt.Sprintf("%.4f", totals.CumulativeEthRewards),
+		fmt.Sprintf("%.4f", totals.UnclaimedEthRewards),
+		fmt.Sprintf("%.4f", totals.BeaconRewards),
+	)
+	if err := table.flush(); err != nil {
+		return err
+	}
+	output.SetResult(c, result)
+
+	printErrors(contexts, errs)
+	return nil
+
+}
@@ -0,0 +1,134 @@
+package fleet
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The status of one context's Smart Node
+type contextStatus struct {
+	version          string
+	node             api.NodeStatusResponse
+	minipools        api.MinipoolStatusResponse
+	activeValidators int
+	beaconBalance    *big.Int
+	err              error
+}
+
+func getStatus(c *cli.Context) error {
+
+	// Get the contexts
+	contexts, err := getContexts(c)
+	if err != nil {
+		return err
+	}
+
+	// Query every context
+	statuses := fanOut(c, contexts, func(rp *rocketpool.Client) contextStatus {
+		status := contextStatus{
+			beaconBalance: big.NewInt(0),
+		}
+		status.version, status.err = rp.GetServiceVersion()
+		if status.err != nil {
+			return status
+		}
+		status.node, status.err = rp.NodeStatus()
+		if status.err != nil || !status.node.Registered {
+			return status
+		}
+		status.minipools, status.err = rp.MinipoolStatus()
+		if status.err != nil {
+			return status
+		}
+		for _, mp := range status.minipools.Minipools {
+			if mp.Validator.Active {
+				status.activeValidators++
+			}
+			if mp.Validator.NodeBalance != nil {
+				status.beaconBalance.Add(status.beaconBalance, mp.Validator.NodeBalance)
+			}
+		}
+		return status
+	})
+
+	// Print the table
+	totalEth := big.NewInt(0)
+	totalRpl := big.NewInt(0)
+	totalBeaconBalance := big.NewInt(0)
+	totalMinipools := 0
+	totalActiveValidators := 0
+	errs := make([]error, len(contexts))
+	result := results.FleetStatus{
+		Contexts: make([]results.FleetContextStatus, len(contexts)),
+	}
+	table := newTable()
+	table.row("CONTEXT", "VERSION", "NODE", "ETH", "RPL STAKED", "COLLATERAL", "MINIPOOLS", "ACTIVE", "BEACON SHARE (ETH)")
+	for i, status := range statuses {
+		errs[i] = status.err
+		result.Contexts[i] = results.FleetContextStatus{
+			Context: contexts[i].Name,
+			Version: status.version,
+		}
+		if status.err != nil {
+			result.Contexts[i].Error = status.err.Error()
+			table.row(contexts[i].Name, valueOrDefault(status.version, "-"), "error", "-", "-", "-", "-", "-", "-")
+			continue
+		}
+		result.Contexts[i].AccountAddress = status.node.AccountAddress
+		result.Contexts[i].Registered = status.node.Registered
+		result.Contexts[i].EthBalance = status.node.AccountBalances.ETH
+		if !status.node.Registered {
+			table.row(contexts[i].Name, status.version, status.node.AccountAddress.Hex(), fmt.Sprintf("%.4f", eth.WeiToEth(status.node.AccountBalances.ETH)), "not registered", "-", "-", "-", "-")
+			continue
+		}
+
+		minipools := status.node.MinipoolCounts.Total - status.node.MinipoolCounts.Finalised
+		result.Contexts[i].RplStake = status.node.RplStake
+		result.Contexts[i].BorrowedCollateralRatio = status.node.BorrowedCollateralRatio
+		result.Contexts[i].Minipools = minipools
+		result.Contexts[i].ActiveValidators = status.activeValidators
+		result.Contexts[i].NodeBeaconBalance = status.beaconBalance
+		table.row(
+			contexts[i].Name,
+			status.version,
+			status.node.AccountAddress.Hex(),
+			fmt.Sprintf("%.4f", eth.WeiToEth(status.node.AccountBalances.ETH)),
+			fmt.Sprintf("%.2f", eth.WeiToEth(status.node.RplStake)),
+			fmt.Sprintf("%.2f%%", status.node.BorrowedCollateralRatio*100),
+			fmt.Sprint(minipools),
+			fmt.Sprint(status.activeValidators),
+			fmt.Sprintf("%.4f", eth.WeiToEth(status.beaconBalance)),
+		)
+
+		totalEth.Add(totalEth, status.node.AccountBalances.ETH)
+		totalRpl.Add(totalRpl, status.node.RplStake)
+		totalBeaconBalance.Add(totalBeaconBalance, status.beaconBalance)
+		totalMinipools += minipools
+		totalActiveValidators += status.activeValidators
+	}
+	table.row(
+		"TOTAL", "", "",
+		fmt.Sprintf("%.4f", eth.WeiToEth(totalEth)),
+		fmt.Sprintf("%.2f", eth.WeiToEth(totalRpl)),
+		"",
+		fmt.Sprint(totalMinipools),
+		fmt.Sprint(totalActiveValidators),
+		fmt.Sprintf("%.4f", eth.WeiToEth(totalBeaconBalance)),
+	)
+	if err := table.flush(); err != nil {
+		return err
+	}
+	output.SetResult(c, result)
+
+	printErrors(contexts, errs)
+	return nil
+
+}
@@ -0,0 +1,94 @@
+package fleet
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The versions on one context's machine
+type contextVersions struct {
+	service string
+	cli     string
+	err     error
+}
+
+func update(c *cli.Context) error {
+
+	// Get the contexts
+	contexts, err := getContexts(c)
+	if err != nil {
+		return err
+	}
+	targetVersion := shared.RocketPoolVersion
+
+	// Query every context; the CLI can only be checked on machines reachable over SSH
+	results := fanOut(c, contexts, func(rp *rocketpool.Client) contextVersions {
+		versions := contextVersions{}
+		versions.service, versions.err = rp.GetServiceVersion()
+		if versions.err != nil || !rp.Context().IsSsh() {
+			return versions
+		}
+		versions.cli, versions.err = rp.GetContextCliVersion()
+		return versions
+	})
+
+	// Print the table and find the contexts to update
+	toUpdate := []int{}
+	errs := make([]error, len(contexts))
+	table := newTable()
+	table.row("CONTEXT", "TARGET", "SERVICE", "CLI", "STATUS")
+	for i, result := range results {
+		errs[i] = result.err
+		status := ""
+		switch {
+		case result.err != nil:
+			status = "error"
+		case result.service == targetVersion:
+			status = "up to date"
+		case !contexts[i].IsSsh():
+			status = "update manually (not reachable over SSH)"
+		case result.cli != targetVersion:
+			status = fmt.Sprintf("update the CLI on that machine to v%s first", targetVersion)
+		default:
+			status = "will update"
+			toUpdate = append(toUpdate, i)
+		}
+		table.row(contexts[i].Name, targetVersion, valueOrDefault(result.service, "-"), valueOrDefault(result.cli, "-"), status)
+	}
+	if err := table.flush(); err != nil {
+		return err
+	}
+	printErrors(contexts, errs)
+
+	if len(toUpdate) == 0 {
+		fmt.Println("\nThere are no contexts to update.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	fmt.Println()
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sThe Rocket Pool service on %d machine(s) will be updated to v%s and restarted, one machine at a time.%s\nAre you sure you want to continue?", colorYellow, len(toUpdate), targetVersion, colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Update the contexts one at a time so a bad release doesn't take the whole fleet down at once
+	for _, i := range toUpdate {
+		fmt.Printf("\n%s=== Updating %s (%s) ===%s\n", colorGreen, contexts[i].Name, contexts[i].Target(), colorReset)
+		rp := rocketpool.NewClientFromContext(c, &contexts[i])
+		err := rp.UpdateContextService(fmt.Sprintf("v%s", targetVersion))
+		rp.Close()
+		if err != nil {
+			return fmt.Errorf("error updating context [%s]; the remaining contexts were not updated: %w", contexts[i].Name, err)
+		}
+	}
+
+	fmt.Printf("\nUpdated %d context(s) to v%s.\n", len(toUpdate), targetVersion)
+	return nil
+
+}
@@ -38,6 +38,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "scrub-check",
+				Usage:     "Run the Oracle DAO's scrub checks against the node's prelaunch minipools, reporting any deposit with the wrong withdrawal credentials",
+				UsageText: "rocketpool minipool scrub-check",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getScrubCheck(c)
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -566,6 +583,97 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "overrides",
+				Aliases:   []string{"o"},
+				Usage:     "List the minipools that have a custom graffiti or fee recipient",
+				UsageText: "rocketpool minipool overrides",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getOverrides(c)
+
+				},
+			},
+
+			{
+				Name:      "set-graffiti",
+				Usage:     "Set a custom graffiti for the blocks proposed by a minipool's validator, instead of the node-wide graffiti (not supported by Teku)",
+				UsageText: "rocketpool minipool set-graffiti minipool-address graffiti",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool-address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return setGraffitiOverride(c, minipoolAddress, c.Args().Get(1))
+
+				},
+			},
+
+			{
+				Name:      "set-fee-recipient",
+				Usage:     "Set a custom fee recipient for a minipool's validator, instead of the node-wide fee recipient. Only addresses that won't get the node penalized are allowed.",
+				UsageText: "rocketpool minipool set-fee-recipient minipool-address fee-recipient [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the new fee recipient",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool-address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					feeRecipient, err := cliutils.ValidateAddress("fee-recipient", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return setFeeRecipientOverride(c, minipoolAddress, feeRecipient)
+
+				},
+			},
+
+			{
+				Name:      "clear-overrides",
+				Usage:     "Remove a minipool's custom graffiti and fee recipient so it uses the node-wide settings again",
+				UsageText: "rocketpool minipool clear-overrides minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool-address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return clearOverrides(c, minipoolAddress)
+
+				},
+			},
 		},
 	})
 }
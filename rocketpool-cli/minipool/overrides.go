@@ -0,0 +1,216 @@
+package minipool
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+func getOverrides(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+
+	// Collect the minipools with overrides
+	minipools := map[common.Address]bool{}
+	for minipool := range cfg.GraffitiOverrides {
+		minipools[common.HexToAddress(minipool)] = true
+	}
+	for minipool := range cfg.FeeRecipientOverrides {
+		minipools[common.HexToAddress(minipool)] = true
+	}
+	addresses := []common.Address{}
+	for address := range minipools {
+		addresses = append(addresses, address)
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].Hex() < addresses[j].Hex()
+	})
+	cc, _ := cfg.GetSelectedConsensusClient()
+	result := results.MinipoolOverrides{
+		GraffitiSupported: cc != cfgtypes.ConsensusClient_Teku,
+		Minipools:         make([]results.MinipoolOverride, len(addresses)),
+	}
+	for i, address := range addresses {
+		result.Minipools[i].Address = address
+		if graffiti, exists := cfg.GetGraffitiOverride(address); exists {
+			formatted := cfg.FormatGraffiti(graffiti)
+			result.Minipools[i].Graffiti = &formatted
+		}
+		if feeRecipient, exists := cfg.GetFeeRecipientOverride(address); exists {
+			result.Minipools[i].FeeRecipient = &feeRecipient
+		}
+	}
+	output.SetResult(c, result)
+	if len(minipools) == 0 {
+		fmt.Println("None of your minipools have a custom graffiti or fee recipient; they all use the node-wide defaults.")
+		return nil
+	}
+	if !result.GraffitiSupported && len(cfg.GraffitiOverrides) > 0 {
+		fmt.Printf("%sNOTE: Teku doesn't support per-validator graffiti, so the graffiti overrides below aren't applied; your minipools use the node-wide graffiti instead.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Print them
+	for _, address := range addresses {
+		fmt.Printf("Minipool %s:\n", address.Hex())
+		if graffiti, exists := cfg.GetGraffitiOverride(address); exists {
+			fmt.Printf("\tGraffiti:      %s\n", cfg.FormatGraffiti(graffiti))
+		} else {
+			fmt.Println("\tGraffiti:      (default)")
+		}
+		if feeRecipient, exists := cfg.GetFeeRecipientOverride(address); exists {
+			fmt.Printf("\tFee recipient: %s\n", feeRecipient.Hex())
+		} else {
+			fmt.Println("\tFee recipient: (default)")
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+func setGraffitiOverride(c *cli.Context, minipoolAddress common.Address, graffiti string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if cc, _ := cfg.GetSelectedConsensusClient(); cc == cfgtypes.ConsensusClient_Teku {
+		return fmt.Errorf("Teku doesn't support per-validator graffiti, so minipools on this node can only use the node-wide graffiti.")
+	}
+	if len(graffiti) > cfg.ConsensusCommon.Graffiti.MaxLength {
+		return fmt.Errorf("The graffiti can be at most %d characters long.", cfg.ConsensusCommon.Graffiti.MaxLength)
+	}
+
+	// Make sure the minipool belongs to the node
+	status, err := rp.MinipoolStatus()
+	if err != nil {
+		return err
+	}
+	found := false
+	for _, mp := range status.Minipools {
+		if mp.Address == minipoolAddress {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("Minipool %s does not belong to the node.", minipoolAddress.Hex())
+	}
+
+	// Save it
+	cfg.SetGraffitiOverride(minipoolAddress, graffiti)
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving configuration: %w", err)
+	}
+
+	fmt.Printf("Minipool %s will now propose blocks with the graffiti \"%s\".\n", minipoolAddress.Hex(), cfg.FormatGraffiti(graffiti))
+	fmt.Println("The node daemon will apply this to your Validator Client within the next few minutes.")
+	return nil
+
+}
+
+func setFeeRecipientOverride(c *cli.Context, minipoolAddress common.Address, feeRecipient common.Address) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Make sure the fee recipient won't get the node penalized
+	canSet, err := rp.CanSetFeeRecipientOverride(minipoolAddress, feeRecipient)
+	if err != nil {
+		return err
+	}
+	if !canSet.CanSet {
+		fmt.Printf("%sCannot use %s as the fee recipient for minipool %s; proposing with it would get the node penalized.%s\n", colorRed, feeRecipient.Hex(), minipoolAddress.Hex(), colorReset)
+		if canSet.IsInSmoothingPool {
+			fmt.Printf("Your node is opted into the Smoothing Pool, so its validators must use the Smoothing Pool (%s) as their fee recipient.\n", canSet.SmoothingPoolAddress.Hex())
+		} else if canSet.IsInOptOutCooldown {
+			fmt.Printf("Your node recently opted out of the Smoothing Pool, so its validators must keep using the Smoothing Pool (%s) as their fee recipient until the opt-out is finalized.\n", canSet.SmoothingPoolAddress.Hex())
+		} else {
+			fmt.Printf("Your node's validators must use either your fee distributor (%s) or the Smoothing Pool (%s) as their fee recipient.\n", canSet.FeeDistributorAddress.Hex(), canSet.SmoothingPoolAddress.Hex())
+		}
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want minipool %s to use %s as its fee recipient?", minipoolAddress.Hex(), feeRecipient.Hex()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save it
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	cfg.SetFeeRecipientOverride(minipoolAddress, &feeRecipient)
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving configuration: %w", err)
+	}
+
+	fmt.Printf("Minipool %s will now use %s as its fee recipient.\n", minipoolAddress.Hex(), feeRecipient.Hex())
+	fmt.Println("The node daemon will apply this to your Validator Client within the next few minutes. If your Smoothing Pool status changes so this address would be penalized, the daemon will fall back to the default fee recipient automatically.")
+	return nil
+
+}
+
+func clearOverrides(c *cli.Context, minipoolAddress common.Address) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	_, hasGraffiti := cfg.GetGraffitiOverride(minipoolAddress)
+	_, hasFeeRecipient := cfg.GetFeeRecipientOverride(minipoolAddress)
+	if !hasGraffiti && !hasFeeRecipient {
+		fmt.Printf("Minipool %s doesn't have any overrides.\n", minipoolAddress.Hex())
+		return nil
+	}
+
+	// Save it
+	cfg.SetGraffitiOverride(minipoolAddress, "")
+	cfg.SetFeeRecipientOverride(minipoolAddress, nil)
+	if err := rp.SaveConfig(cfg); err != nil {
+		return fmt.Errorf("Error saving configuration: %w", err)
+	}
+
+	fmt.Printf("Minipool %s will now use the node-wide graffiti and fee recipient.\n", minipoolAddress.Hex())
+	fmt.Println("The node daemon will apply this to your Validator Client within the next few minutes.")
+	return nil
+
+}
@@ -0,0 +1,83 @@
+package minipool
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/scrub"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+func getScrubCheck(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Run the checks
+	response, err := rp.MinipoolScrubCheck()
+	if err != nil {
+		return err
+	}
+	output.SetResult(c, results.NewMinipoolScrubCheck(response))
+	if len(response.Minipools) == 0 {
+		fmt.Println("The node does not have any prelaunch minipools.")
+		return nil
+	}
+
+	// Print the results
+	fmt.Printf("Checked %d prelaunch minipool(s) at block %d.\n\n", len(response.Minipools), response.BlockNumber)
+	failedCount := 0
+	for _, check := range response.Minipools {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		fmt.Printf("Address:              %s\n", check.Address.Hex())
+		fmt.Printf("Validator pubkey:     %s\n", check.Pubkey.Hex())
+		fmt.Printf("Prelaunch since:      %s\n", check.PrelaunchTime.Format(time.RFC822))
+		fmt.Printf("Scrub period ends:    %s\n", check.ScrubPeriodEnd.Format(time.RFC822))
+		switch {
+		case check.IsFailed():
+			failedCount++
+			fmt.Printf("Result:               %sFAILED%s (%s)\n", colorRed, colorReset, check.Reason)
+		case check.Result == scrub.CheckResult_NoDeposit:
+			fmt.Printf("Result:               %sPENDING%s (%s)\n", colorYellow, colorReset, check.Reason)
+		case check.Result == scrub.CheckResult_Error:
+			fmt.Printf("Result:               %sERROR%s (%s)\n", colorYellow, colorReset, check.Reason)
+		default:
+			fmt.Printf("Result:               passed (%s)\n", check.Reason)
+		}
+		if check.Result != scrub.CheckResult_Vacant {
+			fmt.Printf("Expected creds:       %s\n", check.ExpectedWithdrawalCredentials.Hex())
+		}
+		if check.Result == scrub.CheckResult_BeaconMismatch || check.Result == scrub.CheckResult_DepositMismatch {
+			fmt.Printf("Actual creds:         %s\n", check.ActualWithdrawalCredentials.Hex())
+		}
+		if check.OffendingDeposit != nil {
+			deposit := check.OffendingDeposit
+			fmt.Printf("Offending deposit:    TX %s (block %d, TX index %d, deposit index %d)\n", deposit.TxHash.Hex(), deposit.BlockNumber, deposit.TxIndex, deposit.DepositIndex)
+		}
+		for _, deposit := range check.InvalidDeposits {
+			fmt.Printf("Ignored deposit:      TX %s (block %d) has an invalid signature: %s\n", deposit.TxHash.Hex(), deposit.BlockNumber, deposit.SignatureError)
+		}
+		for _, note := range check.Notes {
+			fmt.Printf("Note:                 %s\n", note)
+		}
+		fmt.Printf("\n")
+	}
+
+	// Print the summary
+	if failedCount > 0 {
+		fmt.Printf("%s%d minipool(s) failed the scrub check and will be scrubbed by the Oracle DAO.%s\n", colorRed, failedCount, colorReset)
+	} else {
+		fmt.Println("No minipools failed the scrub check.")
+	}
+	return nil
+
+}
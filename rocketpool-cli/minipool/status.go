@@ -11,7 +11,9 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/hex"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -34,6 +36,7 @@ func getStatus(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewMinipoolStatus(status.Minipools))
 
 	// Get minipools by status
 	statusMinipools := map[string][]api.MinipoolDetails{}
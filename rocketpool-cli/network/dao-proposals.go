@@ -8,7 +8,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/urfave/cli"
 )
 
@@ -42,6 +44,7 @@ func getActiveDAOProposals(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewNetworkDaoProposals(snapshotProposalsResponse, currentVotingDelegate.VotingDelegate))
 
 	// Voting status
 	fmt.Printf("%s=== DAO Snapshot Voting ===%s\n", colorGreen, colorReset)
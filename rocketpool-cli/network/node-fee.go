@@ -6,6 +6,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 func getNodeFee(c *cli.Context) error {
@@ -22,6 +24,7 @@ func getNodeFee(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewNetworkNodeFee(response))
 
 	// Print & return
 	fmt.Printf("The current network node commission rate is %f%%.\n", response.NodeFee*100)
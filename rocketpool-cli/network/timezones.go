@@ -7,6 +7,8 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 func getTimezones(c *cli.Context) error {
@@ -23,6 +25,7 @@ func getTimezones(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewNetworkTimezones(response))
 
 	// Sort it by the timezone name
 	var maxNameLength int
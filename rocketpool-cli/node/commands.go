@@ -32,6 +32,83 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "proposals",
+				Usage:     "Show the blocks proposed by the node's validators, how much they were worth, and how that compares to the Smoothing Pool",
+				UsageText: "rocketpool node proposals [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The number of recent proposals to show (0 for all of them)",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getProposals(c)
+
+				},
+			},
+
+			{
+				Name:      "deferred-txs",
+				Usage:     "Show the automatic transactions the node daemon is waiting for cheaper gas to submit, and when they're expected to be submitted",
+				UsageText: "rocketpool node deferred-txs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDeferredTxs(c)
+
+				},
+			},
+
+			{
+				Name:      "export-ledger",
+				Usage:     "Export the node's rewards claims, minipool deposits, minipool and fee distributor payouts, RPL staking and the gas spent on its transactions, with the RPL price at each block, for accounting",
+				UsageText: "rocketpool node export-ledger [--from date] [--to date] [--format csv|json] [--file path]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "from",
+						Usage: "The start of the ledger, as a date (YYYY-MM-DD) or an RFC 3339 time; defaults to the node's registration",
+					},
+					cli.StringFlag{
+						Name:  "to",
+						Usage: "The end of the ledger, as a date (YYYY-MM-DD, including the whole day) or an RFC 3339 time; defaults to now",
+					},
+					cli.StringFlag{
+						Name:  "format",
+						Usage: "The format of the ledger: 'csv' or 'json'",
+						Value: "csv",
+					},
+					cli.StringFlag{
+						Name:  "file, f",
+						Usage: "The `path` of the file to save the ledger to, instead of printing it",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return exportLedger(c)
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
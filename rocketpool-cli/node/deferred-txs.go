@@ -0,0 +1,63 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+func getDeferredTxs(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the schedule
+	response, err := rp.NodeDeferredTxs()
+	if err != nil {
+		return err
+	}
+	output.SetResult(c, results.NewNodeDeferredTxs(response))
+	if !response.HasSchedule || len(response.Actions) == 0 {
+		fmt.Println("The node daemon isn't waiting to submit any automatic transactions.")
+		return nil
+	}
+
+	// Print the deferred transactions
+	fmt.Printf("The node daemon is waiting for cheaper gas to submit %d automatic transaction(s). Last updated at %s.\n\n", len(response.Actions), response.UpdatedTime.Format(time.RFC822))
+	for _, action := range response.Actions {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		fmt.Printf("%s%s%s\n", colorGreen, action.Description, colorReset)
+		fmt.Printf("Waiting since:        %s\n", action.FirstSeen.Format(time.RFC822))
+		fmt.Printf("Last checked:         %s\n", action.LastChecked.Format(time.RFC822))
+		fmt.Printf("Reason:               %s\n", action.Reason)
+		fmt.Printf("Last max fee:         %.2f Gwei (target: below %.2f Gwei)\n", action.LastMaxFeeGwei, action.TargetMaxFeeGwei)
+		if action.MaxCostEth > 0 {
+			fmt.Printf("Last cost:            %.6f ETH (limit: %.6f ETH)\n", action.LastCostEth, action.MaxCostEth)
+		} else {
+			fmt.Printf("Last cost:            %.6f ETH\n", action.LastCostEth)
+		}
+		if action.Deadline.IsZero() {
+			fmt.Printf("Deadline:             none\n")
+		} else {
+			fmt.Printf("Deadline:             %s\n", action.Deadline.Format(time.RFC822))
+		}
+		if action.EstimatedSubmission.IsZero() {
+			fmt.Printf("Expected submission:  unknown (gas hasn't been low enough recently)\n")
+		} else {
+			fmt.Printf("Expected submission:  %s\n", action.EstimatedSubmission.Format(time.RFC822))
+		}
+		fmt.Printf("\n")
+	}
+	return nil
+
+}
@@ -0,0 +1,192 @@
+package node
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The layout of the dates accepted for the ledger's time range
+const ledgerDateLayout string = "2006-01-02"
+
+// The columns of the CSV ledger
+var ledgerCsvHeader = []string{"time", "block", "type", "asset", "amount", "amount_wei", "rpl_price_eth", "eth_value", "interval", "tx_hash", "contract"}
+
+// The ledger as it's written in JSON
+type ledgerDocument struct {
+	NodeAddress common.Address    `json:"nodeAddress"`
+	FromBlock   uint64            `json:"fromBlock"`
+	ToBlock     uint64            `json:"toBlock"`
+	FromTime    time.Time         `json:"fromTime"`
+	ToTime      time.Time         `json:"toTime"`
+	Entries     []api.LedgerEntry `json:"entries"`
+}
+
+func exportLedger(c *cli.Context) error {
+
+	// Get the time range; a date on its own covers the whole day
+	fromTime, err := parseLedgerTime("from", c.String("from"), false)
+	if err != nil {
+		return err
+	}
+	toTime, err := parseLedgerTime("to", c.String("to"), true)
+	if err != nil {
+		return err
+	}
+	if fromTime > 0 && toTime > 0 && fromTime > toTime {
+		return fmt.Errorf("Invalid time range - the start (%s) is after the end (%s)", c.String("from"), c.String("to"))
+	}
+	format := c.String("format")
+	if format != "csv" && format != "json" {
+		return fmt.Errorf("Invalid format '%s' - valid formats are 'csv' and 'json'", format)
+	}
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// The ledger goes to stdout unless a file was given, so the messages go to stderr
+	fmt.Fprintln(os.Stderr, "Reconstructing the node's ledger from the chain; this can take several minutes for long ranges...")
+	response, err := rp.NodeExportLedger(fromTime, toTime)
+	if err != nil {
+		return err
+	}
+	for _, warning := range response.Warnings {
+		fmt.Fprintf(os.Stderr, "%sWARNING: %s%s\n", colorYellow, warning, colorReset)
+	}
+
+	output.SetResult(c, results.NewNodeLedger(response))
+
+	// Write the ledger; in a machine-readable mode it's already in the result, so it's only written if a file was given
+	path := c.String("file")
+	if path != "" || output.GetRecorder(c) == nil {
+		var out io.Writer = os.Stdout
+		if path != "" {
+			file, err := os.Create(path)
+			if err != nil {
+				return fmt.Errorf("error creating %s: %w", path, err)
+			}
+			defer file.Close()
+			out = file
+		}
+		switch format {
+		case "csv":
+			err = writeLedgerCsv(out, response.Entries)
+		case "json":
+			err = writeLedgerJson(out, response)
+		}
+		if err != nil {
+			return fmt.Errorf("error writing the ledger: %w", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Exported %d entries from blocks %d to %d (%s to %s).\n", len(response.Entries), response.FromBlock, response.ToBlock, response.FromTime.Format(time.RFC822), response.ToTime.Format(time.RFC822))
+	if path != "" {
+		fmt.Fprintf(os.Stderr, "The ledger was saved to %s.\n", path)
+	}
+	return nil
+
+}
+
+// Parse a time for the ledger's range into unix seconds, or 0 if it's empty; a date on its own is the start of the day, or its end if endOfDay is set
+func parseLedgerTime(name string, value string, endOfDay bool) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if date, err := time.ParseInLocation(ledgerDateLayout, value, time.Local); err == nil {
+		if endOfDay {
+			date = date.AddDate(0, 0, 1).Add(-time.Second)
+		}
+		return uint64(date.Unix()), nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid %s time '%s' - expected a date (YYYY-MM-DD) or an RFC 3339 time", name, value)
+	}
+	if parsed.Unix() <= 0 {
+		return 0, fmt.Errorf("Invalid %s time '%s' - it must be after 1970", name, value)
+	}
+	return uint64(parsed.Unix()), nil
+}
+
+// Write the ledger entries as CSV, with the amounts in whole ETH or RPL and their value in ETH at the entry's block
+func writeLedgerCsv(out io.Writer, entries []api.LedgerEntry) error {
+	writer := csv.NewWriter(out)
+	if err := writer.Write(ledgerCsvHeader); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		rplPrice := ""
+		ethValue := ""
+		if entry.Asset == "ETH" {
+			ethValue = formatLedgerAmount(entry.Amount)
+		}
+		if entry.RplPrice != nil {
+			rplPrice = formatLedgerAmount(entry.RplPrice)
+			if entry.Asset == "RPL" {
+				value := big.NewInt(0).Mul(entry.Amount, entry.RplPrice)
+				value.Quo(value, big.NewInt(1e18))
+				ethValue = formatLedgerAmount(value)
+			}
+		}
+		interval := ""
+		if entry.Interval != nil {
+			interval = strconv.FormatUint(*entry.Interval, 10)
+		}
+		if err := writer.Write([]string{
+			entry.Time.UTC().Format(time.RFC3339),
+			strconv.FormatUint(entry.Block, 10),
+			string(entry.Type),
+			entry.Asset,
+			formatLedgerAmount(entry.Amount),
+			entry.Amount.String(),
+			rplPrice,
+			ethValue,
+			interval,
+			entry.TxHash.Hex(),
+			entry.Contract.Hex(),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// Write the ledger as JSON, with the amounts in wei
+func writeLedgerJson(out io.Writer, response api.NodeExportLedgerResponse) error {
+	bytes, err := json.MarshalIndent(ledgerDocument{
+		NodeAddress: response.NodeAddress,
+		FromBlock:   response.FromBlock,
+		ToBlock:     response.ToBlock,
+		FromTime:    response.FromTime,
+		ToTime:      response.ToTime,
+		Entries:     response.Entries,
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(bytes))
+	return err
+}
+
+// Format an amount in wei as an exact decimal in ETH or RPL
+func formatLedgerAmount(wei *big.Int) string {
+	return new(big.Rat).SetFrac(wei, big.NewInt(1e18)).FloatString(18)
+}
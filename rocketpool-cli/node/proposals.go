@@ -0,0 +1,96 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getProposals(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the proposals
+	response, err := rp.NodeProposals(c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	output.SetResult(c, results.NewNodeProposals(response))
+	if response.ProposalCount == 0 {
+		fmt.Println("The node daemon hasn't seen any blocks proposed by your validators yet.")
+		fmt.Println("Proposals are recorded as they happen while the node daemon is running; blocks proposed before it started tracking them are not included.")
+		return nil
+	}
+
+	// Print the interval summaries
+	fmt.Printf("%s=== Rewards Intervals ===%s\n", colorGreen, colorReset)
+	for _, interval := range response.Intervals {
+		if interval.ProposalCount == 0 && !interval.HasSmoothingPoolStats {
+			continue
+		}
+		fmt.Printf("Interval %d", interval.Index)
+		if !interval.Start.IsZero() {
+			fmt.Printf(" (started %s)", interval.Start.Local().Format(time.RFC822))
+		}
+		fmt.Println(":")
+		fmt.Printf("\tProposals:             %d (%d from relays)\n", interval.ProposalCount, interval.RelayProposalCount)
+		fmt.Printf("\tTotal value:           %.6f ETH\n", math.RoundDown(eth.WeiToEth(interval.TotalPayloadValue), 6))
+		if interval.HasSmoothingPoolStats {
+			fmt.Printf("\tSmoothing Pool:        %.6f ETH across %d nodes (%.6f ETH per node)\n", math.RoundDown(eth.WeiToEth(interval.SmoothingPoolBalance), 6), interval.SmoothingPoolNodeCount, math.RoundDown(eth.WeiToEth(interval.SmoothingPoolNodeAvg), 6))
+		}
+	}
+	fmt.Printf("\nAll recorded proposals were worth a total of %.6f ETH.\n", math.RoundDown(eth.WeiToEth(response.TotalPayloadValue), 6))
+	fmt.Println("NOTE: The Smoothing Pool figures are the balance last seen during each interval; they are only an approximation of what an average node earned.")
+	fmt.Println()
+
+	// Print the proposals
+	fmt.Printf("%s=== Proposals ===%s\n", colorGreen, colorReset)
+	if len(response.Proposals) < response.ProposalCount {
+		fmt.Printf("Showing the %d most recent of %d proposals.\n\n", len(response.Proposals), response.ProposalCount)
+	}
+	for _, proposal := range response.Proposals {
+		source := "Local block"
+		if proposal.RelayName != "" {
+			source = fmt.Sprintf("Relay (%s)", proposal.RelayName)
+		} else if proposal.IsFromRelay {
+			source = "Relay"
+		}
+		feeRecipient := proposal.FeeRecipient
+		if proposal.IsMevBlock {
+			feeRecipient = proposal.MevRecipient
+		}
+		fmt.Printf("Slot %d (%s):\n", proposal.Slot, proposal.Time.Local().Format(time.RFC822))
+		fmt.Printf("\tMinipool:      %s\n", proposal.MinipoolAddress.Hex())
+		fmt.Printf("\tBlock:         %d\n", proposal.BlockNumber)
+		fmt.Printf("\tSource:        %s\n", source)
+		if proposal.PayloadValue != nil {
+			fmt.Printf("\tValue:         %.6f ETH\n", math.RoundDown(eth.WeiToEth(proposal.PayloadValue), 6))
+		} else {
+			fmt.Println("\tValue:         (unknown)")
+		}
+		if proposal.IsCorrect {
+			fmt.Printf("\tFee recipient: %s\n", feeRecipient.Hex())
+		} else {
+			fmt.Printf("\tFee recipient: %s%s (INCORRECT, expected %s)%s\n", colorRed, feeRecipient.Hex(), proposal.ExpectedFeeRecipient.Hex(), colorReset)
+		}
+		fmt.Println()
+	}
+
+	if !response.QueryRelayData {
+		fmt.Println("NOTE: Values are estimated from the Execution client. Enable \"Query Relay Data\" in the MEV-Boost section of `rocketpool service config` to look up which relay delivered each block.")
+	}
+	return nil
+
+}
@@ -8,7 +8,9 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 const signatureVersion = 1
@@ -54,6 +56,7 @@ func signMessage(c *cli.Context) error {
 		Signature: response.SignedData,
 		Version:   fmt.Sprint(signatureVersion),
 	}
+	output.SetResult(c, results.NodeSignedMessage(formattedSignature))
 	bytes, err := json.MarshalIndent(formattedSignature, "", "    ")
 	if err != nil {
 		return err
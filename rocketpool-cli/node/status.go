@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
@@ -13,7 +14,9 @@ import (
 
 	"github.com/rocket-pool/smartnode/addons/rescue_node"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -76,6 +79,7 @@ func getStatus(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewNodeStatus(status))
 
 	// Account address & balances
 	fmt.Printf("%s=== Account and Balances ===%s\n", colorGreen, colorReset)
@@ -300,6 +304,31 @@ func getStatus(c *cli.Context) error {
 
 		fmt.Println()
 
+		// Fee recipient audit
+		if status.ProposalCount > 0 {
+			fmt.Printf("%s=== Proposals ===%s\n", colorGreen, colorReset)
+			if status.IncorrectProposalCount == 0 {
+				fmt.Printf("All %d of the node's audited proposals used a correct fee recipient.\n", status.ProposalCount)
+			} else {
+				fmt.Printf("%s%d of the node's %d audited proposals used an incorrect fee recipient! Please check your Validator Client's fee recipient settings.%s\n", colorRed, status.IncorrectProposalCount, status.ProposalCount, colorReset)
+			}
+			fmt.Println("Most recent proposals:")
+			for _, proposal := range status.RecentProposals {
+				feeRecipient := proposal.FeeRecipient
+				blockType := "local"
+				if proposal.IsMevBlock {
+					feeRecipient = proposal.MevRecipient
+					blockType = fmt.Sprintf("MEV, %.6f ETH", math.RoundDown(eth.WeiToEth(proposal.MevReward), 6))
+				}
+				result := fmt.Sprintf("%scorrect%s", colorGreen, colorReset)
+				if !proposal.IsCorrect {
+					result = fmt.Sprintf("%sINCORRECT (expected %s)%s", colorRed, proposal.ExpectedFeeRecipient.Hex(), colorReset)
+				}
+				fmt.Printf("\tSlot %d (%s), minipool %s [%s]: fee recipient %s is %s\n", proposal.Slot, proposal.Time.Local().Format(time.RFC822), proposal.MinipoolAddress.Hex(), blockType, feeRecipient.Hex(), result)
+			}
+			fmt.Println()
+		}
+
 		// RPL stake details
 		fmt.Printf("%s=== RPL Stake ===%s\n", colorGreen, colorReset)
 		fmt.Println("NOTE: The following figures take *any pending bond reductions* into account.")
@@ -50,6 +50,40 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "participation",
+				Usage:     "Get the duty participation history of the oracle DAO members, as recorded by the watchtower",
+				UsageText: "rocketpool odao participation",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getParticipation(c)
+
+				},
+			},
+
+			{
+				Name:      "watchtower-status",
+				Usage:     "Get the last run, last success, next run and last error of each of the watchtower's tasks",
+				UsageText: "rocketpool odao watchtower-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getWatchtowerStatus(c)
+
+				},
+			},
+
 			{
 				Name:      "member-settings",
 				Aliases:   []string{"b"},
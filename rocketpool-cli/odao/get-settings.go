@@ -8,6 +8,8 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 func getMemberSettings(c *cli.Context) error {
@@ -24,6 +26,7 @@ func getMemberSettings(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewOdaoMemberSettings(response))
 
 	// Log & return
 	fmt.Printf("ODAO Voting Quorum Threshold: %f%%\n", response.Quorum*100)
@@ -50,6 +53,7 @@ func getProposalSettings(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewOdaoProposalSettings(response))
 
 	// Log & return
 	fmt.Printf("Cooldown Between Proposals: %s\n", time.Duration(response.Cooldown*1000000000))
@@ -75,6 +79,7 @@ func getMinipoolSettings(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewOdaoMinipoolSettings(response))
 
 	// Log & return
 	fmt.Printf("Scrub Period: %s\n", time.Duration(response.ScrubPeriod*1000000000))
@@ -0,0 +1,101 @@
+package odao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/participation"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+func getParticipation(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the participation history
+	response, err := rp.TNDAOParticipation()
+	if err != nil {
+		return err
+	}
+	output.SetResult(c, results.NewOdaoParticipation(response))
+	if !response.HasHistory {
+		fmt.Println("No participation history has been recorded yet. It is recorded by the watchtower while your node is an oracle DAO member.")
+		return nil
+	}
+
+	// Print the member summaries
+	fmt.Printf("Participation recorded up to block %d.\n", response.LastScannedBlock)
+	fmt.Printf("Missed counts cover the latest %d rounds of each duty.\n", response.RecentWindow)
+	fmt.Println()
+	for _, member := range response.Members {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		id := member.ID
+		if id == "" {
+			id = "(no longer a member)"
+		}
+		if member.IsLocalNode {
+			id += " (this node)"
+		}
+		fmt.Printf("Member ID:            %s\n", id)
+		fmt.Printf("Node address:         %s\n", member.Address.Hex())
+		for _, duty := range member.Duties {
+			rate := float64(0)
+			if duty.Expected > 0 {
+				rate = float64(duty.Submitted) / float64(duty.Expected) * 100
+			}
+			lastSubmitted := "---"
+			if !duty.LastSubmittedTime.IsZero() {
+				lastSubmitted = duty.LastSubmittedTime.Format(time.RFC822)
+			}
+			fmt.Printf("%-22s%d / %d (%.1f%%), %d missed recently, last submitted %s\n", getDutyName(duty.Duty)+":", duty.Submitted, duty.Expected, rate, duty.MissedRecent, lastSubmitted)
+		}
+		fmt.Printf("\n")
+	}
+
+	// Print the latest rounds
+	fmt.Println("Latest rounds:")
+	for i := len(response.RecentRounds) - 1; i >= 0; i-- {
+		round := response.RecentRounds[i]
+		name := getDutyName(round.Duty)
+		if round.Target != "" {
+			name += " (" + round.Target + ")"
+		}
+		status := "consensus reached"
+		if !round.ConsensusReached {
+			status = "no consensus"
+		}
+		if round.ResponsibleMember != nil {
+			status = fmt.Sprintf("turn of %s", round.ResponsibleMember.Hex())
+		}
+		fmt.Printf("%s  %s round %d: %d of %d members submitted, %s\n", round.Time.Format(time.RFC822), name, round.Round, len(round.Submitters), len(round.Members), status)
+	}
+	return nil
+
+}
+
+// Get the display name of a duty
+func getDutyName(duty participation.Duty) string {
+	switch duty {
+	case participation.Duty_Balances:
+		return "Balances"
+	case participation.Duty_Prices:
+		return "RPL prices"
+	case participation.Duty_L2Prices:
+		return "L2 RPL prices"
+	case participation.Duty_RewardsTree:
+		return "Rewards trees"
+	case participation.Duty_ScrubVote:
+		return "Scrub votes"
+	}
+	return string(duty)
+}
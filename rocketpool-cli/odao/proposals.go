@@ -11,7 +11,9 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 func filterProposalState(state string, stateFilter string) bool {
@@ -68,6 +70,9 @@ func getProposals(c *cli.Context, stateFilter string) error {
 	proposalStateInputs := []string{"pending", "active", "succeeded", "executed", "cancelled", "defeated", "expired"}
 
 	// Print & return
+	result := results.DaoProposals{
+		Proposals: []results.DaoProposal{},
+	}
 	count := 0
 	for i, stateName := range proposalStates {
 		proposals, ok := stateProposals[stateName]
@@ -90,11 +95,13 @@ func getProposals(c *cli.Context, stateFilter string) error {
 			for _, member := range allMembers.Members {
 				if bytes.Equal(proposal.ProposerAddress.Bytes(), member.Address.Bytes()) {
 					fmt.Printf("%d: %s - Proposed by: %s (%s)\n", proposal.ID, proposal.Message, member.ID, proposal.ProposerAddress)
+					result.Proposals = append(result.Proposals, results.NewDaoProposal(proposal, member.ID))
 					printed = true
 				}
 			}
 			if !printed {
 				fmt.Printf("%d: %s - Proposed by: %s (no longer on the ODAO)\n", proposal.ID, proposal.Message, proposal.ProposerAddress)
+				result.Proposals = append(result.Proposals, results.NewDaoProposal(proposal, ""))
 			}
 		}
 
@@ -102,6 +109,7 @@ func getProposals(c *cli.Context, stateFilter string) error {
 
 		fmt.Println()
 	}
+	output.SetResult(c, result)
 	if count == 0 {
 		fmt.Println("There are no matching oracle DAO proposals.")
 	}
@@ -151,6 +159,7 @@ func getProposal(c *cli.Context, id uint64) error {
 			memberID = member.ID
 		}
 	}
+	output.SetResult(c, results.NewDaoProposal(*proposal, memberID))
 
 	// Main details
 	fmt.Printf("Proposal ID:          %d\n", proposal.ID)
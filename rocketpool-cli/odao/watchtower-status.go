@@ -0,0 +1,71 @@
+package odao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+func getWatchtowerStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the task status
+	response, err := rp.TNDAOWatchtowerStatus()
+	if err != nil {
+		return err
+	}
+	output.SetResult(c, results.NewOdaoWatchtowerStatus(response))
+	if !response.HasStatus {
+		fmt.Println("The watchtower hasn't saved the status of its tasks yet. Make sure the watchtower is running.")
+		return nil
+	}
+
+	// Print the tasks
+	fmt.Printf("Status last updated at %s.\n\n", response.UpdatedTime.Format(time.RFC822))
+	for _, task := range response.Tasks {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("\n")
+		fmt.Printf("Task:                 %s\n", task.Name)
+		fmt.Printf("Interval:             %s\n", task.Interval)
+		if task.IsRunning {
+			fmt.Printf("Last run:             %s (running now)\n", formatTaskTime(task.LastRun))
+		} else {
+			fmt.Printf("Last run:             %s (took %s)\n", formatTaskTime(task.LastRun), task.LastDuration.Round(time.Millisecond))
+		}
+		fmt.Printf("Last success:         %s\n", formatTaskTime(task.LastSuccess))
+		if !task.IsRunning {
+			fmt.Printf("Next run:             %s\n", formatTaskTime(task.NextRun))
+		}
+		if task.LastError != "" {
+			fmt.Printf("Last error:           %s at %s\n", task.LastError, formatTaskTime(task.LastErrorTime))
+		}
+		if task.ConsecutiveFailures > 0 {
+			fmt.Printf("Consecutive failures: %d\n", task.ConsecutiveFailures)
+		}
+		if task.SkippedRuns > 0 {
+			fmt.Printf("Skipped runs:         %d\n", task.SkippedRuns)
+		}
+		fmt.Printf("\n")
+	}
+	return nil
+
+}
+
+// Format the time of a task event, which may not have happened yet
+func formatTaskTime(t time.Time) string {
+	if t.IsZero() {
+		return "---"
+	}
+	return t.Format(time.RFC822)
+}
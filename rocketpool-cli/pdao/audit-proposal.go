@@ -0,0 +1,167 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func auditProposal(c *cli.Context, proposalID uint64) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check for Houston
+	houston, err := rp.IsHoustonDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if Houston has been deployed: %w", err)
+	}
+	if !houston.IsHoustonDeployed {
+		fmt.Println("This command cannot be used until Houston has been deployed.")
+		return nil
+	}
+
+	// Run the audit
+	fmt.Printf("Rebuilding the voting tree for proposal %d, this may take a while...\n\n", proposalID)
+	response, err := rp.PDAOAuditProposal(proposalID)
+	if err != nil {
+		return err
+	}
+	if !response.DoesExist {
+		fmt.Printf("Proposal with ID %d does not exist.\n", proposalID)
+		return nil
+	}
+	output.SetResult(c, results.NewPdaoProposalAudit(response))
+
+	// Proposal info
+	fmt.Printf("%s=== Proposal %d ===%s\n", colorGreen, response.ProposalID, colorReset)
+	fmt.Printf("State:          %s\n", types.ProtocolDaoProposalStates[response.State])
+	fmt.Printf("Proposer:       %s\n", response.Proposer.Hex())
+	fmt.Printf("Target block:   %d\n", response.TargetBlock)
+	fmt.Printf("Voting starts:  %s\n", response.VotingStartTime.Format(time.RFC822))
+	fmt.Printf("Node count:     %d\n", response.NodeCount)
+	fmt.Printf("Local root:     %s (%.6f voting power)\n", response.LocalRoot.Hash.Hex(), formatVotingPower(response.LocalRoot.Sum))
+	fmt.Printf("Proposed root:  %s (%.6f voting power)\n", response.ProposedRoot.Hash.Hex(), formatVotingPower(response.ProposedRoot.Sum))
+	if response.LocalRoot.Hash == response.ProposedRoot.Hash && response.LocalRoot.Sum.Cmp(response.ProposedRoot.Sum) == 0 {
+		fmt.Printf("%sThe proposed root matches the local voting tree.%s\n\n", colorGreen, colorReset)
+	} else {
+		fmt.Printf("%sThe proposed root does NOT match the local voting tree.%s\n\n", colorYellow, colorReset)
+	}
+
+	// Root submissions
+	fmt.Printf("%s=== Submitted Roots and Pollards ===%s\n", colorGreen, colorReset)
+	mismatchedNodes := map[common.Address]proposals.TreeNodeMismatch{}
+	mismatchedNodeOrder := []common.Address{}
+	for _, submission := range response.RootSubmissions {
+		fmt.Printf("Index %d submitted by %s at %s (%s)\n", submission.Index, submission.Submitter.Hex(), submission.Timestamp.Format(time.RFC822), getChallengeStateName(submission.ChallengeState))
+		if len(submission.Mismatches) == 0 {
+			fmt.Printf("\t%sMatches the local tree.%s\n", colorGreen, colorReset)
+			continue
+		}
+		fmt.Printf("\t%s%d tree node(s) don't match the local tree:%s\n", colorYellow, len(submission.Mismatches), colorReset)
+		for _, mismatch := range submission.Mismatches {
+			fmt.Printf("\t\tIndex %d (%s): local %.6f, proposed %.6f\n", mismatch.Index, describeTreeNodeMismatch(mismatch), formatVotingPower(mismatch.LocalNode.Sum), formatVotingPower(mismatch.ProposedNode.Sum))
+			if mismatch.NodeAddress != nil {
+				if _, exists := mismatchedNodes[*mismatch.NodeAddress]; !exists {
+					mismatchedNodeOrder = append(mismatchedNodeOrder, *mismatch.NodeAddress)
+				}
+				mismatchedNodes[*mismatch.NodeAddress] = mismatch
+			}
+		}
+	}
+	if len(response.RootSubmissions) == 0 {
+		fmt.Println("No roots have been submitted for this proposal.")
+	}
+	fmt.Println()
+
+	// Nodes with mismatching voting power
+	if len(mismatchedNodeOrder) > 0 {
+		fmt.Printf("%s=== Nodes with Mismatching Voting Power ===%s\n", colorGreen, colorReset)
+		for _, address := range mismatchedNodeOrder {
+			mismatch := mismatchedNodes[address]
+			kind := "voting power"
+			if mismatch.IsDelegationTotal {
+				kind = "delegated voting power"
+			}
+			fmt.Printf("%s: local %s %.6f, proposed %.6f\n", address.Hex(), kind, formatVotingPower(mismatch.LocalNode.Sum), formatVotingPower(mismatch.ProposedNode.Sum))
+		}
+		fmt.Println()
+	}
+
+	// Challenges
+	fmt.Printf("%s=== Challenges ===%s\n", colorGreen, colorReset)
+	for _, challenge := range response.Challenges {
+		fmt.Printf("Index %d challenged by %s at %s (%s)", challenge.Index, challenge.Challenger.Hex(), challenge.Timestamp.Format(time.RFC822), getChallengeStateName(challenge.State))
+		if challenge.CanBeDefeated {
+			fmt.Printf(" - %sunanswered, can be used to defeat the proposal%s", colorYellow, colorReset)
+		}
+		fmt.Println()
+	}
+	if len(response.Challenges) == 0 {
+		fmt.Println("This proposal has not been challenged.")
+	}
+	fmt.Println()
+
+	// Defeatability
+	if response.IsDefeatable {
+		fmt.Printf("%sThis proposal can currently be defeated with index %d.%s\n", colorYellow, response.DefeatIndex, colorReset)
+		fmt.Printf("Use `rocketpool pdao proposals defeat %d %d` to defeat it.\n", response.ProposalID, response.DefeatIndex)
+	} else {
+		fmt.Println("This proposal cannot currently be defeated.")
+	}
+	fmt.Println("This audit is read-only; no transactions were sent.")
+	return nil
+
+}
+
+// Describe which part of the voting trees a mismatching tree node covers
+func describeTreeNodeMismatch(mismatch proposals.TreeNodeMismatch) string {
+	if mismatch.TreeOwner == nil {
+		return fmt.Sprintf("network subtree of nodes %d to %d", mismatch.FirstNodeIndex, mismatch.LastNodeIndex)
+	}
+	if mismatch.IsDelegationTotal {
+		return fmt.Sprintf("delegation total for %s", mismatch.TreeOwner.Hex())
+	}
+	if mismatch.NodeAddress != nil {
+		return fmt.Sprintf("delegator %s in the delegation tree of %s", mismatch.NodeAddress.Hex(), mismatch.TreeOwner.Hex())
+	}
+	return fmt.Sprintf("subtree of nodes %d to %d in the delegation tree of %s", mismatch.FirstNodeIndex, mismatch.LastNodeIndex, mismatch.TreeOwner.Hex())
+}
+
+// Get the display name of a challenge state
+func getChallengeStateName(state types.ChallengeState) string {
+	switch state {
+	case types.ChallengeState_Unchallenged:
+		return "unchallenged"
+	case types.ChallengeState_Challenged:
+		return "challenged"
+	case types.ChallengeState_Responded:
+		return "responded"
+	case types.ChallengeState_Paid:
+		return "paid"
+	}
+	return "unknown"
+}
+
+// Convert a voting power amount for display
+func formatVotingPower(amount *big.Int) float64 {
+	if amount == nil {
+		return 0
+	}
+	return math.RoundDown(eth.WeiToEth(amount), 6)
+}
@@ -103,6 +103,104 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "delegates",
+				Aliases:   []string{"dg"},
+				Usage:     "Show the onchain voting power of each delegate, the nodes that delegate to you, and how your delegate has voted",
+				UsageText: "rocketpool pdao delegates [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The number of delegates to show, ordered by voting power (0 for all)",
+						Value: 10,
+					},
+					cli.Uint64Flag{
+						Name:  "proposals, p",
+						Usage: "The number of recent proposals to show in your delegate's voting history (0 for all)",
+						Value: 10,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDelegates(c)
+
+				},
+			},
+
+			{
+				Name:      "voting-power",
+				Aliases:   []string{"vp"},
+				Usage:     "Show your node's current voting power, how it has changed over past proposals, and what it would be with more RPL or minipools",
+				UsageText: "rocketpool pdao voting-power [options]",
+				Flags: []cli.Flag{
+					cli.Float64Flag{
+						Name:  "stake-rpl, r",
+						Usage: "Estimate your voting power if you staked this much more RPL",
+					},
+					cli.Uint64Flag{
+						Name:  "minipools, m",
+						Usage: "Estimate your voting power if you added this many minipools",
+					},
+					cli.Float64Flag{
+						Name:  "bond-amount, b",
+						Usage: "The bond amount, in ETH, of the minipools added with --minipools",
+						Value: 8,
+					},
+					cli.Uint64Flag{
+						Name:  "proposals, p",
+						Usage: "The number of recent proposals to show voting power for (0 for all)",
+						Value: 10,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.Float64("stake-rpl") < 0 {
+						return fmt.Errorf("Invalid RPL amount '%f' - must be at least 0", c.Float64("stake-rpl"))
+					}
+					if c.Float64("bond-amount") <= 0 {
+						return fmt.Errorf("Invalid bond amount '%f' - must be greater than 0", c.Float64("bond-amount"))
+					}
+
+					// Run
+					return getVotingPower(c)
+
+				},
+			},
+
+			{
+				Name:      "audit-proposal",
+				Aliases:   []string{"ap"},
+				Usage:     "Independently rebuild a proposal's voting tree and check every submitted root, pollard and challenge against it without sending any transactions",
+				UsageText: "rocketpool pdao audit-proposal proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal-id", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					return auditProposal(c, proposalId)
+
+				},
+			},
+
 			{
 				Name:      "claim-bonds",
 				Aliases:   []string{"cb"},
@@ -0,0 +1,119 @@
+package pdao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	utilsStrings "github.com/rocket-pool/rocketpool-go/utils/strings"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getDelegates(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check for Houston
+	houston, err := rp.IsHoustonDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if Houston has been deployed: %w", err)
+	}
+	if !houston.IsHoustonDeployed {
+		fmt.Println("This command cannot be used until Houston has been deployed.")
+		return nil
+	}
+
+	// Get the delegate info
+	fmt.Println("Loading the voting power of every node, this may take a moment...")
+	response, err := rp.PDAODelegates()
+	if err != nil {
+		return err
+	}
+	output.SetResult(c, results.NewPdaoDelegates(response))
+	fmt.Println()
+
+	// Top delegates
+	fmt.Printf("%s=== Onchain Voting Power by Delegate (block %d) ===%s\n", colorGreen, response.BlockNumber, colorReset)
+	fmt.Printf("Network total initialized voting power: %.6f\n\n", math.RoundDown(eth.WeiToEth(response.TotalVotingPower), 6))
+	limit := c.Uint64("limit")
+	for i, delegate := range response.Delegates {
+		if limit > 0 && uint64(i) >= limit {
+			fmt.Printf("... and %d more delegates.\n", len(response.Delegates)-i)
+			break
+		}
+		share := float64(0)
+		if response.TotalVotingPower.Sign() > 0 {
+			share = eth.WeiToEth(delegate.VotingPower) / eth.WeiToEth(response.TotalVotingPower) * 100
+		}
+		marker := ""
+		if delegate.Delegate == response.AccountAddress {
+			marker = fmt.Sprintf(" %s(your node)%s", colorBlue, colorReset)
+		} else if delegate.Delegate == response.OnchainVotingDelegate {
+			marker = fmt.Sprintf(" %s(your delegate)%s", colorBlue, colorReset)
+		}
+		fmt.Printf("%3d. %s  %14.6f (%6.2f%%)  %d node(s)%s\n", i+1, delegate.Delegate.Hex(), math.RoundDown(eth.WeiToEth(delegate.VotingPower), 6), share, delegate.DelegatorCount, marker)
+	}
+	fmt.Println()
+
+	// Delegators
+	fmt.Printf("%s=== Your Delegators ===%s\n", colorGreen, colorReset)
+	fmt.Printf("The node's local voting power: %.6f\n", math.RoundDown(eth.WeiToEth(response.NodeVotingPower), 6))
+	if len(response.Delegators) == 0 {
+		fmt.Println("No other nodes delegate their voting power to your node.")
+	} else {
+		fmt.Printf("%d other node(s) delegate their voting power to your node, for a total of %.6f including your own:\n", len(response.Delegators), math.RoundDown(eth.WeiToEth(response.DelegatedVotingPower), 6))
+		for _, delegator := range response.Delegators {
+			fmt.Printf("\t%s  %14.6f\n", delegator.NodeAddress.Hex(), math.RoundDown(eth.WeiToEth(delegator.VotingPower), 6))
+		}
+	}
+	fmt.Println()
+
+	// Delegate voting history
+	fmt.Printf("%s=== Your Delegate's Voting History ===%s\n", colorGreen, colorReset)
+	if response.OnchainVotingDelegate == response.AccountAddress {
+		fmt.Println("Your node is its own delegate, so these are the votes it has cast directly.")
+	} else {
+		fmt.Printf("Your node delegates to %s%s%s.\n", colorBlue, response.OnchainVotingDelegateFormatted, colorReset)
+	}
+	if len(response.DelegateVotes) == 0 {
+		fmt.Println("There haven't been any proposals to vote on yet.")
+		return nil
+	}
+	votes := response.DelegateVotes
+	proposalLimit := c.Uint64("proposals")
+	if proposalLimit > 0 && uint64(len(votes)) > proposalLimit {
+		votes = votes[uint64(len(votes))-proposalLimit:]
+		fmt.Printf("Showing the %d most recent of %d proposals.\n", proposalLimit, len(response.DelegateVotes))
+	}
+	fmt.Println()
+	awaitingCount := 0
+	for i := len(votes) - 1; i >= 0; i-- {
+		vote := votes[i]
+		fmt.Printf("%d: %s - %s\n", vote.ProposalID, utilsStrings.Sanitize(vote.Message), types.ProtocolDaoProposalStates[vote.State])
+		if vote.IsAwaitingDelegate {
+			awaitingCount++
+			fmt.Printf("\tDelegate vote: %sNOT VOTED YET (phase 1 ends %s, %s)%s\n", colorYellow, vote.Phase1EndTime.Format(time.RFC822), getTimeDifference(vote.Phase1EndTime), colorReset)
+		} else {
+			fmt.Printf("\tDelegate vote: %s\n", types.VoteDirections[vote.DelegateVoteDirection])
+		}
+		if response.OnchainVotingDelegate != response.AccountAddress && vote.NodeVoteDirection != types.VoteDirection_NoVote {
+			fmt.Printf("\tYour override: %s\n", types.VoteDirections[vote.NodeVoteDirection])
+		}
+	}
+	if awaitingCount > 0 {
+		fmt.Printf("\n%sYour delegate hasn't voted on %d proposal(s) in phase 1 yet.%s If they don't vote before phase 1 ends, you can still vote directly in phase 2 with `rocketpool pdao proposals vote`.\n", colorYellow, awaitingCount, colorReset)
+	}
+	return nil
+
+}
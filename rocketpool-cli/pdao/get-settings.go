@@ -7,6 +7,8 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 func getSettings(c *cli.Context) error {
@@ -32,6 +34,7 @@ func getSettings(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewPdaoSettings(response))
 
 	// Auction
 	fmt.Println("== Auction Settings ==")
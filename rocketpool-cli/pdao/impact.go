@@ -0,0 +1,64 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// Print the before / after table for a proposal's simulated impact on the network
+func printProposalImpact(response api.PDAOProposalImpactResponse) {
+	if !response.IsSettingChange {
+		return
+	}
+
+	fmt.Printf("%s=== Impact Preview ===%s\n", colorGreen, colorReset)
+	for _, change := range response.Changes {
+		fmt.Printf("Sets %s / %s to %s\n", change.Contract, change.Setting, change.Value)
+	}
+	if !response.HasImpact {
+		fmt.Println("This proposal doesn't change any of the values the preview can simulate (RPL collateral limits, RPL rewards allocations, or deposit pool limits).")
+		fmt.Println()
+		return
+	}
+
+	fmt.Printf("Simulated against the network state at block %d:\n\n", response.ElBlockNumber)
+	fmt.Printf("%-56s %24s %24s\n", "", "Current", "After Proposal")
+	for _, row := range response.Rows {
+		before := formatImpactValue(row.Unit, row.Before)
+		after := formatImpactValue(row.Unit, row.After)
+		if before != after {
+			fmt.Printf("%-56s %24s %s%24s%s\n", row.Name, before, colorYellow, after, colorReset)
+		} else {
+			fmt.Printf("%-56s %24s %24s\n", row.Name, before, after)
+		}
+	}
+	if !response.IsNodeRegistered {
+		fmt.Println("\nYour node isn't registered, so the figures specific to your node have been omitted.")
+	}
+	fmt.Println("\nNOTE: This is a projection based on the current state of the network. The actual effect will depend on the network state when the proposal is executed.")
+	fmt.Println()
+}
+
+// Format a value from the impact table
+func formatImpactValue(unit api.PDAOImpactUnit, value *big.Int) string {
+	if value == nil {
+		return "-"
+	}
+	switch unit {
+	case api.PDAOImpactUnit_Eth:
+		return fmt.Sprintf("%.6f ETH", math.RoundDown(eth.WeiToEth(value), 6))
+	case api.PDAOImpactUnit_Rpl:
+		return fmt.Sprintf("%.6f RPL", math.RoundDown(eth.WeiToEth(value), 6))
+	case api.PDAOImpactUnit_Percent:
+		return fmt.Sprintf("%.4f%%", eth.WeiToEth(value)*100)
+	case api.PDAOImpactUnit_Bool:
+		return fmt.Sprint(value.Sign() != 0)
+	default:
+		return value.String()
+	}
+}
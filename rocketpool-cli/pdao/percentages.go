@@ -6,7 +6,9 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/urfave/cli"
 )
 
@@ -33,6 +35,7 @@ func getRewardsPercentages(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewPdaoRewardsPercentages(response))
 
 	// Print the settings
 	fmt.Printf("Node Operators: %.2f%% (%s)\n", eth.WeiToEth(response.Node)*100, response.Node.String())
@@ -117,6 +120,15 @@ func proposeRewardsPercentages(c *cli.Context) error {
 		return nil
 	}
 
+	// Show what the change would do to the network
+	fmt.Println("Simulating the proposal against the current network state, this may take a moment...")
+	impact, err := rp.PDAORewardsPercentagesImpact(nodePercent, odaoPercent, pdaoPercent)
+	if err != nil {
+		fmt.Printf("%sWARNING: couldn't simulate the proposal's impact: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	} else {
+		printProposalImpact(impact)
+	}
+
 	// Assign max fee
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
 	if err != nil {
@@ -15,6 +15,8 @@ import (
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	utilsMath "github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
@@ -52,6 +54,7 @@ func getProposals(c *cli.Context, stateFilter string) error {
 	}
 	if !houston.IsHoustonDeployed {
 		fmt.Println("This command cannot be used until Houston has been deployed.")
+		output.SetResult(c, results.NewPdaoProposals(nil))
 		return nil
 	}
 
@@ -76,7 +79,7 @@ func getProposals(c *cli.Context, stateFilter string) error {
 	proposalStateInputs := []string{"pending", "phase1", "phase2", "succeeded", "executed", "destroyed", "vetoed", "quorum-not-met", "defeated", "expired"}
 
 	// Print & return
-	count := 0
+	matchingProposals := []api.PDAOProposalWithNodeVoteDirection{}
 	for i, stateName := range proposalStates {
 		proposals, ok := stateProposals[stateName]
 		if !ok {
@@ -87,6 +90,7 @@ func getProposals(c *cli.Context, stateFilter string) error {
 		if filterProposalState(proposalStateInputs[i], stateFilter) {
 			continue
 		}
+		matchingProposals = append(matchingProposals, proposals...)
 
 		// Proposal state count
 		fmt.Printf("%d %s proposal(s):\n", len(proposals), stateName)
@@ -101,13 +105,12 @@ func getProposals(c *cli.Context, stateFilter string) error {
 			fmt.Printf("%d: %s - Proposed by: %s\n", proposal.ID, proposal.Message, proposal.ProposerAddress)
 		}
 
-		count += len(proposals)
-
 		fmt.Println()
 	}
-	if count == 0 {
+	if len(matchingProposals) == 0 {
 		fmt.Println("There are no matching Protocol DAO proposals.")
 	}
+	output.SetResult(c, results.NewPdaoProposals(matchingProposals))
 	return nil
 
 }
@@ -152,6 +155,7 @@ func getProposal(c *cli.Context, id uint64) error {
 	}
 
 	proposal.Message = utilsStrings.Sanitize(proposal.Message)
+	output.SetResult(c, results.NewPdaoProposal(*proposal))
 
 	// Main details
 	fmt.Printf("Proposal ID:            %d\n", proposal.ID)
@@ -198,6 +202,21 @@ func getProposal(c *cli.Context, id uint64) error {
 		fmt.Printf("Node has voted:         no\n")
 	}
 
+	// Impact preview - proposals that can still be voted on or executed
+	switch proposal.State {
+	case types.ProtocolDaoProposalState_Pending,
+		types.ProtocolDaoProposalState_ActivePhase1,
+		types.ProtocolDaoProposalState_ActivePhase2,
+		types.ProtocolDaoProposalState_Succeeded:
+		fmt.Println()
+		impact, err := rp.PDAOProposalImpact(proposal.ID)
+		if err != nil {
+			fmt.Printf("%sWARNING: couldn't simulate the proposal's impact: %s%s\n", colorYellow, err.Error(), colorReset)
+		} else {
+			printProposalImpact(impact)
+		}
+	}
+
 	return nil
 }
 
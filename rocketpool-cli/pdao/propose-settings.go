@@ -326,6 +326,15 @@ func proposeSetting(c *cli.Context, contract string, setting string, value strin
 		return nil
 	}
 
+	// Show what the change would do to the network
+	fmt.Println("Simulating the proposal against the current network state, this may take a moment...")
+	impact, err := rp.PDAOSettingImpact(contract, setting, value)
+	if err != nil {
+		fmt.Printf("%sWARNING: couldn't simulate the proposal's impact: %s%s\n\n", colorYellow, err.Error(), colorReset)
+	} else {
+		printProposalImpact(impact)
+	}
+
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canPropose.GasInfo, rp, c.Bool("yes"))
 	if err != nil {
@@ -12,13 +12,16 @@ import (
 	"github.com/rocket-pool/rocketpool-go/utils/strings"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
 
 const (
-	colorBlue  string = "\033[36m"
-	colorReset string = "\033[0m"
-	colorGreen string = "\033[32m"
+	colorBlue   string = "\033[36m"
+	colorReset  string = "\033[0m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
 )
 
 func getStatus(c *cli.Context) error {
@@ -55,6 +58,7 @@ func getStatus(c *cli.Context) error {
 		fmt.Errorf("error checking for claimable bonds: %w", err)
 	}
 	claimableBonds := claimableBondsResponse.ClaimableBonds
+	output.SetResult(c, results.NewPdaoStatus(response, len(claimableBonds) > 0))
 
 	// Snapshot voting status
 	fmt.Printf("%s=== Snapshot Voting ===%s\n", colorGreen, colorReset)
@@ -0,0 +1,113 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	utilsStrings "github.com/rocket-pool/rocketpool-go/utils/strings"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getVotingPower(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check for Houston
+	houston, err := rp.IsHoustonDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if Houston has been deployed: %w", err)
+	}
+	if !houston.IsHoustonDeployed {
+		fmt.Println("This command cannot be used until Houston has been deployed.")
+		return nil
+	}
+
+	// Get the what-if parameters
+	additionalRpl := eth.EthToWei(c.Float64("stake-rpl"))
+	bondAmount := eth.EthToWei(c.Float64("bond-amount"))
+	additionalMinipools := c.Uint64("minipools")
+
+	// Get the voting power
+	fmt.Println("Loading your voting power, this may take a moment...")
+	response, err := rp.PDAOVotingPower(additionalRpl, additionalMinipools, bondAmount)
+	if err != nil {
+		return err
+	}
+
+	// Trim the history
+	proposalLimit := c.Uint64("proposals")
+	if proposalLimit > 0 && uint64(len(response.History)) > proposalLimit {
+		response.History = response.History[uint64(len(response.History))-proposalLimit:]
+	}
+
+	output.SetResult(c, results.NewPdaoVotingPower(response))
+	fmt.Println()
+
+	// Current voting power
+	fmt.Printf("%s=== Current Voting Power (finalized block %d) ===%s\n", colorGreen, response.BlockNumber, colorReset)
+	fmt.Printf("Your node's voting power:          %.6f\n", formatVotingPower(response.VotingPower))
+	fmt.Printf("Voting power delegated to you:     %.6f (including your own if you haven't delegated it)\n", formatVotingPower(response.DelegatedVotingPower))
+	if response.TotalVotingPower.Sign() > 0 {
+		share := eth.WeiToEth(response.VotingPower) / eth.WeiToEth(response.TotalVotingPower) * 100
+		fmt.Printf("Network total voting power:        %.6f (your share: %.4f%%)\n", formatVotingPower(response.TotalVotingPower), share)
+	}
+	fmt.Println()
+	fmt.Println("Voting power is the square root of your staked RPL, capped at the amount worth the maximum per-minipool stake of your bonded ETH:")
+	printVotingPowerEstimate(response.Current, response.MaxStakeFraction)
+	fmt.Println()
+
+	// What-if estimate
+	if response.WhatIf != nil {
+		fmt.Printf("%s=== What If ===%s\n", colorGreen, colorReset)
+		if additionalRpl.Sign() > 0 {
+			fmt.Printf("Staking %.6f more RPL\n", math.RoundDown(eth.WeiToEth(additionalRpl), 6))
+		}
+		if additionalMinipools > 0 {
+			fmt.Printf("Adding %d minipool(s) with a %.2f ETH bond\n", additionalMinipools, eth.WeiToEth(bondAmount))
+		}
+		printVotingPowerEstimate(*response.WhatIf, response.MaxStakeFraction)
+		change := big.NewInt(0).Sub(response.WhatIf.VotingPower, response.Current.VotingPower)
+		fmt.Printf("Change in voting power: %+.6f\n", eth.WeiToEth(change))
+		if response.WhatIf.RplStake.Cmp(response.WhatIf.MaxVotingStake) > 0 {
+			fmt.Printf("%sNOTE: %.6f RPL of this stake would be above the cap and would not count towards voting power.%s\n", colorYellow, math.RoundDown(eth.WeiToEth(big.NewInt(0).Sub(response.WhatIf.RplStake, response.WhatIf.MaxVotingStake)), 6), colorReset)
+		}
+		fmt.Println()
+	}
+
+	// History
+	fmt.Printf("%s=== Voting Power at Past Proposals ===%s\n", colorGreen, colorReset)
+	if len(response.History) == 0 {
+		fmt.Println("There haven't been any proposals yet.")
+		return nil
+	}
+	for _, entry := range response.History {
+		fmt.Printf("%d: %s (block %d, %s)\n", entry.ProposalID, utilsStrings.Sanitize(entry.Message), entry.TargetBlock, entry.CreatedTime.Format(time.RFC822))
+		fmt.Printf("\tYour voting power: %.6f\n", formatVotingPower(entry.VotingPower))
+		if entry.IsSnapshotCached {
+			fmt.Printf("\tDelegated to you:  %.6f of %.6f total\n", formatVotingPower(entry.DelegatedVotingPower), formatVotingPower(entry.TotalVotingPower))
+		}
+	}
+	return nil
+
+}
+
+// Print the inputs and result of a voting power estimate
+func printVotingPowerEstimate(estimate api.PDAOVotingPowerEstimate, maxStakeFraction *big.Int) {
+	fmt.Printf("\tStaked RPL:              %.6f\n", math.RoundDown(eth.WeiToEth(estimate.RplStake), 6))
+	fmt.Printf("\tBonded ETH:              %.6f\n", math.RoundDown(eth.WeiToEth(estimate.BondedEth), 6))
+	fmt.Printf("\tRPL cap (%.0f%% of bond): %.6f\n", eth.WeiToEth(maxStakeFraction)*100, math.RoundDown(eth.WeiToEth(estimate.MaxVotingStake), 6))
+	fmt.Printf("\tVoting power:            %.6f\n", formatVotingPower(estimate.VotingPower))
+}
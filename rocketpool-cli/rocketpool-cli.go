@@ -8,17 +8,22 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/rocketpool-cli/auction"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/fleet"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/minipool"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/network"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/node"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/odao"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/pdao"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/queue"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/schema"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/security"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
+	"github.com/rocket-pool/smartnode/rocketpool-cli/top"
 	"github.com/rocket-pool/smartnode/rocketpool-cli/wallet"
 	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 // Run
@@ -76,10 +81,19 @@ ______           _        _    ______           _
 			Usage: "Rocket Pool config asset `path`",
 			Value: "~/.rocketpool",
 		},
+		cli.StringFlag{
+			Name:  "context",
+			Usage: "Run the command against the Smart Node of a saved context `name` instead of this machine's (see 'rocketpool fleet context')",
+		},
 		cli.StringFlag{
 			Name:  "daemon-path, d",
 			Usage: "Interact with a Rocket Pool service daemon at a `path` on the host OS, running outside of docker",
 		},
+		cli.StringFlag{
+			Name:  "output, o",
+			Usage: "Print the result as `format` (text, json or yaml); json and yaml print the command's result in an envelope on stdout and everything else on stderr, and only work for the commands listed by 'rocketpool schema --list'",
+			Value: "text",
+		},
 		cli.Float64Flag{
 			Name:  "maxFee, f",
 			Usage: "The max fee (including the priority fee) you want a transaction to cost, in gwei",
@@ -109,6 +123,7 @@ ______           _        _    ______           _
 
 	// Register commands
 	auction.RegisterCommands(app, "auction", []string{"a"})
+	fleet.RegisterCommands(app, "fleet", []string{"f"})
 	minipool.RegisterCommands(app, "minipool", []string{"m"})
 	network.RegisterCommands(app, "network", []string{"e"})
 	node.RegisterCommands(app, "node", []string{"n"})
@@ -117,8 +132,13 @@ ______           _        _    ______           _
 	queue.RegisterCommands(app, "queue", []string{"q"})
 	security.RegisterCommands(app, "security", []string{"c"})
 	service.RegisterCommands(app, "service", []string{"s"})
+	top.RegisterCommands(app, "top", []string{"t"})
 	wallet.RegisterCommands(app, "wallet", []string{"w"})
 
+	// Print an envelope after each command in a machine-readable mode; the schema command prints its schemas directly
+	output.WrapCommands(app.Commands)
+	schema.RegisterCommands(app, "schema", []string{})
+
 	app.Before = func(c *cli.Context) error {
 		// Check user ID
 		if os.Getuid() == 0 && !c.GlobalBool("allow-root") {
@@ -140,6 +160,30 @@ ______           _        _    ______           _
 			c.App.Metadata["nonce"] = nonce
 		}
 
+		// If set, load the context to target
+		contextName := c.GlobalString("context")
+		if contextName != "" {
+			context, err := rocketpool.GetContext(c.GlobalString("config-path"), contextName)
+			if err == nil {
+				err = context.Validate()
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid context: %s\n", err.Error())
+				os.Exit(1)
+			}
+
+			// Save the context on Metadata so every client created for this command targets it
+			c.App.Metadata["context"] = context
+		}
+
+		// Set up the output format
+		outputMode, err := output.ParseMode(c.GlobalString("output"))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err.Error())
+			os.Exit(1)
+		}
+		output.Setup(c, outputMode)
+
 		return nil
 	}
 
@@ -0,0 +1,33 @@
+package schema
+
+import (
+	"github.com/urfave/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Print the JSON Schema of the machine-readable output ('--output json' or '--output yaml')",
+		UsageText: "rocketpool schema [options] [command]",
+		Description: "Without an argument, prints the schema of the envelope every command prints in a machine-readable mode.\n" +
+			"   With a command (for example 'node status'), prints the schema of that command's envelope, including its result.",
+		Flags: []cli.Flag{
+			cli.BoolFlag{
+				Name:  "list, l",
+				Usage: "List the commands that support machine-readable output",
+			},
+			cli.StringFlag{
+				Name:  "dir, d",
+				Usage: "Write the envelope schema and the schema of every command to files in `path` instead of printing them",
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Run
+			return printSchema(c)
+
+		},
+	})
+}
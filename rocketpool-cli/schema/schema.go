@@ -0,0 +1,93 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// The name of the generic envelope's schema file
+const envelopeSchemaFile string = "envelope.schema.json"
+
+func printSchema(c *cli.Context) error {
+
+	// List the commands
+	if c.Bool("list") {
+		for _, command := range results.GetCommandNames() {
+			fmt.Println(command)
+		}
+		return nil
+	}
+
+	// Write every schema to a folder
+	if c.String("dir") != "" {
+		return writeSchemas(os.ExpandEnv(c.String("dir")))
+	}
+
+	// Print the schema of one command's envelope
+	if c.NArg() > 0 {
+		command := strings.Join(c.Args(), " ")
+		schema, err := getCommandSchema(command)
+		if err != nil {
+			return err
+		}
+		return output.Print(c, schema)
+	}
+
+	// Print the generic envelope's schema
+	return output.Print(c, output.GenerateEnvelopeSchema("", nil))
+
+}
+
+// Get the schema of a command's envelope, including its result
+func getCommandSchema(command string) (output.Schema, error) {
+	resultType, exists := results.GetResultType(command)
+	if !exists {
+		return nil, fmt.Errorf("command [%s] doesn't support machine-readable output; run 'rocketpool schema --list' to see the commands that do", command)
+	}
+	return output.GenerateEnvelopeSchema(command, output.GenerateSchema(resultType)), nil
+}
+
+// Write the generic envelope's schema and the envelope schema of every command to a folder
+func writeSchemas(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating schema folder [%s]: %w", dir, err)
+	}
+
+	if err := writeSchema(filepath.Join(dir, envelopeSchemaFile), output.GenerateEnvelopeSchema("", nil)); err != nil {
+		return err
+	}
+	commands := results.GetCommandNames()
+	for _, command := range commands {
+		schema, err := getCommandSchema(command)
+		if err != nil {
+			return err
+		}
+		filename := fmt.Sprintf("%s.schema.json", strings.ReplaceAll(command, " ", "-"))
+		if err := writeSchema(filepath.Join(dir, filename), schema); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("Wrote the schemas of the envelope and %d commands to %s.\n", len(commands), dir)
+	return nil
+}
+
+// Write a schema to a file
+func writeSchema(path string, schema output.Schema) error {
+	bytes, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing schema: %w", err)
+	}
+	if err := os.WriteFile(path, append(bytes, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing schema file [%s]: %w", path, err)
+	}
+	return nil
+}
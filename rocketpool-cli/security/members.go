@@ -6,7 +6,9 @@ import (
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 )
 
 func getMembers(c *cli.Context) error {
@@ -33,6 +35,7 @@ func getMembers(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	output.SetResult(c, results.NewSecurityMembers(members.Members))
 
 	// Print & return
 	if len(members.Members) > 0 {
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/results"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
+)
+
+// Settings
+const (
+	relayStatusPath    string        = "/eth/v1/builder/status"
+	relayStatusTimeout time.Duration = 5 * time.Second
+)
+
+// The result of probing a relay's status endpoint
+type relayCheckResult struct {
+	relay   cfgtypes.MevRelay
+	enabled bool
+	latency time.Duration
+	err     error
+}
+
+// Probes the status endpoint of each MEV-Boost relay and reports its latency
+func checkRelays(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smartnode.")
+	}
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+
+	// Print the registry info
+	registry, registryErr := cfg.MevBoost.GetRelayRegistry()
+	if registry.Source == "" {
+		fmt.Printf("Using the built-in relay registry (version %d).\n", registry.Version)
+	} else {
+		fmt.Printf("Using the relay registry at %s (version %d).\n", registry.Source, registry.Version)
+	}
+	result := results.ServiceRelays{
+		RegistrySource:  registry.Source,
+		RegistryVersion: registry.Version,
+		Relays:          []results.RelayCheck{},
+	}
+	if registryErr != nil {
+		result.RegistryError = registryErr.Error()
+		fmt.Printf("%sWARNING: %s%s\n", colorYellow, registryErr.Error(), colorReset)
+	}
+	fmt.Println()
+
+	// Get the relays to check
+	enabledRelays := map[string]bool{}
+	if cfg.EnableMevBoost.Value == true && cfg.MevBoost.Mode.Value == cfgtypes.Mode_Local {
+		for _, relay := range cfg.MevBoost.GetEnabledMevRelays() {
+			enabledRelays[relay.Urls[network]] = true
+		}
+	}
+	checks := []*relayCheckResult{}
+	relays := cfg.MevBoost.GetAvailableRelays()
+	relays = append(relays, cfg.MevBoost.GetCustomRelays()...)
+	for _, relay := range relays {
+		relayUrl := relay.Urls[network]
+		if relayUrl == "" {
+			continue
+		}
+		if !c.Bool("all") && !enabledRelays[relayUrl] {
+			continue
+		}
+		checks = append(checks, &relayCheckResult{
+			relay:   relay,
+			enabled: enabledRelays[relayUrl],
+		})
+	}
+	if len(checks) == 0 {
+		output.SetResult(c, result)
+		fmt.Println("You don't have any MEV-Boost relays enabled. Use `--all` to check every relay available on this network.")
+		return nil
+	}
+
+	// Probe them all at once
+	client := http.Client{
+		Timeout: relayStatusTimeout,
+	}
+	var wg sync.WaitGroup
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check *relayCheckResult) {
+			defer wg.Done()
+			check.latency, check.err = probeRelay(&client, check.relay.Urls[network])
+		}(check)
+	}
+	wg.Wait()
+	for _, check := range checks {
+		relayCheck := results.RelayCheck{
+			ID:        string(check.relay.ID),
+			Name:      check.relay.Name,
+			Url:       check.relay.Urls[network],
+			Enabled:   check.enabled,
+			Reachable: check.err == nil,
+		}
+		if check.err != nil {
+			relayCheck.Error = check.err.Error()
+		} else {
+			relayCheck.Latency = check.latency
+		}
+		result.Relays = append(result.Relays, relayCheck)
+	}
+	output.SetResult(c, result)
+
+	// Print the results
+	healthyCount := 0
+	for _, check := range checks {
+		name := check.relay.Name
+		if c.Bool("all") && check.enabled {
+			name += " (enabled)"
+		}
+		if check.err != nil {
+			fmt.Printf("%s%-40s  ERROR: %s%s\n", colorRed, name, check.err.Error(), colorReset)
+			continue
+		}
+		healthyCount++
+		latencyColor := colorGreen
+		if check.latency > time.Second {
+			latencyColor = colorYellow
+		}
+		fmt.Printf("%-40s  %sOK (%d ms)%s\n", name, latencyColor, check.latency.Milliseconds(), colorReset)
+	}
+	fmt.Printf("\n%d of %d relays are reachable.\n", healthyCount, len(checks))
+	return nil
+
+}
+
+// Calls a relay's status endpoint and returns how long it took to respond
+func probeRelay(client *http.Client, relayUrl string) (time.Duration, error) {
+
+	// Build the status URL, dropping the relay's public key and any query parameters
+	parsedUrl, err := url.Parse(relayUrl)
+	if err != nil {
+		return 0, fmt.Errorf("invalid relay URL: %w", err)
+	}
+	statusUrl := url.URL{
+		Scheme: parsedUrl.Scheme,
+		Host:   parsedUrl.Host,
+		Path:   relayStatusPath,
+	}
+
+	// Send the request
+	start := time.Now()
+	response, err := client.Get(statusUrl.String())
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(io.Discard, response.Body)
+	latency := time.Since(start)
+	if response.StatusCode != http.StatusOK {
+		return latency, fmt.Errorf("relay responded with HTTP status %d", response.StatusCode)
+	}
+	return latency, nil
+
+}
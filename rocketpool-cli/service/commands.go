@@ -447,7 +447,30 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					}
 
 					// Run command
-					return checkCpuFeatures()
+					return checkCpuFeatures(c)
+
+				},
+			},
+
+			{
+				Name:      "check-relays",
+				Usage:     "Checks the status endpoint of each of your MEV-Boost relays and reports how long it took to respond",
+				UsageText: "rocketpool service check-relays [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Check every relay available on the current network, not just the ones you have enabled",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return checkRelays(c)
 
 				},
 			},
@@ -553,6 +576,74 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "migrate-out",
+				Usage:     "Stops validating on this machine and exports the node wallet, validator keys, slashing protection data, rolling records and settings into an encrypted bundle for moving the node to a new machine",
+				UsageText: "rocketpool service migrate-out [options] bundle-path",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "passphrase, p",
+						Usage: "The passphrase to encrypt the bundle with (will be prompted for if not provided)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the migration (in native mode, you'll still be asked to confirm that the validator client has been stopped)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					targetPath := c.Args().Get(0)
+
+					// Run command
+					return migrateOut(c, targetPath)
+
+				},
+			},
+
+			{
+				Name:      "migrate-in",
+				Usage:     "Restores a bundle created with `migrate-out` onto this machine, then starts validating once the old machine's validators have been provably offline",
+				UsageText: "rocketpool service migrate-in [options] bundle-path",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "passphrase, p",
+						Usage: "The passphrase the bundle was encrypted with (will be prompted for if not provided)",
+					},
+					cli.Uint64Flag{
+						Name:  "epochs, e",
+						Usage: "The number of epochs without any validator activity required before the validator client on this machine is started",
+						Value: defaultMigrationQuietEpochs,
+					},
+					cli.BoolFlag{
+						Name:  "restore-config",
+						Usage: "Replace this machine's Smart Node settings with the ones from the bundle (paths and the Docker project name are kept)",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the migration (in native mode, you'll still be asked to confirm that the validator client has been stopped)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					bundlePath := c.Args().Get(0)
+					if c.Uint64("epochs") == 0 {
+						return fmt.Errorf("Invalid epochs '0' - at least one quiet epoch is required")
+					}
+
+					// Run command
+					return migrateIn(c, bundlePath)
+
+				},
+			},
+
 			{
 				Name:      "terminate",
 				Aliases:   []string{"t"},
@@ -19,6 +19,13 @@ var alertingParametersNativeMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_FeeRecipientMismatch":        nil,
+	"alertEnabled_PDAOProposals":               nil,
+	"alertEnabled_ODAOProposals":               nil,
+	"alertEnabled_SecurityCouncilProposals":    nil,
+	"alertEnabled_OracleDaoDutyMissed":         nil,
+	"alertEnabled_RplPriceDivergence":          nil,
+	"alertEnabled_MinipoolScrubCheckFailed":    nil,
 }
 
 var alertingParametersDockerMode map[string]interface{} = map[string]interface{}{
@@ -43,6 +50,13 @@ var alertingParametersDockerMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_FeeRecipientMismatch":        nil,
+	"alertEnabled_PDAOProposals":               nil,
+	"alertEnabled_ODAOProposals":               nil,
+	"alertEnabled_SecurityCouncilProposals":    nil,
+	"alertEnabled_OracleDaoDutyMissed":         nil,
+	"alertEnabled_RplPriceDivergence":          nil,
+	"alertEnabled_MinipoolScrubCheckFailed":    nil,
 }
 
 // The page wrapper for the alerting config
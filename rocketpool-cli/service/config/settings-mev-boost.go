@@ -8,25 +8,20 @@ import (
 
 // The page wrapper for the MEV-boost config
 type MevBoostConfigPage struct {
-	home                  *settingsHome
-	page                  *page
-	layout                *standardLayout
-	masterConfig          *config.RocketPoolConfig
-	enableBox             *parameterizedFormItem
-	modeBox               *parameterizedFormItem
-	selectionModeBox      *parameterizedFormItem
-	localItems            []*parameterizedFormItem
-	externalItems         []*parameterizedFormItem
-	regulatedAllMevBox    *parameterizedFormItem
-	unregulatedAllMevBox  *parameterizedFormItem
-	flashbotsBox          *parameterizedFormItem
-	bloxrouteMaxProfitBox *parameterizedFormItem
-	bloxrouteRegulatedBox *parameterizedFormItem
-	edenBox               *parameterizedFormItem
-	ultrasoundBox         *parameterizedFormItem
-	aestusBox             *parameterizedFormItem
-	titanGlobalBox        *parameterizedFormItem
-	titanRegionalBox      *parameterizedFormItem
+	home                 *settingsHome
+	page                 *page
+	layout               *standardLayout
+	masterConfig         *config.RocketPoolConfig
+	enableBox            *parameterizedFormItem
+	modeBox              *parameterizedFormItem
+	selectionModeBox     *parameterizedFormItem
+	localItems           []*parameterizedFormItem
+	externalItems        []*parameterizedFormItem
+	regulatedAllMevBox   *parameterizedFormItem
+	unregulatedAllMevBox *parameterizedFormItem
+	relayBoxes           map[cfgtypes.MevRelayID]*parameterizedFormItem
+	customRelaysBox      *parameterizedFormItem
+	queryRelayDataBox    *parameterizedFormItem
 }
 
 // Creates a new page for the MEV-Boost settings
@@ -79,18 +74,22 @@ func (configPage *MevBoostConfigPage) createContent() {
 	configPage.localItems = createParameterizedFormItems(localParams, configPage.layout.descriptionBox)
 	configPage.externalItems = createParameterizedFormItems(externalParams, configPage.layout.descriptionBox)
 
-	configPage.flashbotsBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.FlashbotsRelay)
-	configPage.bloxrouteMaxProfitBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.BloxRouteMaxProfitRelay)
-	configPage.bloxrouteRegulatedBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.BloxRouteRegulatedRelay)
-	configPage.edenBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.EdenRelay)
-	configPage.ultrasoundBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.UltrasoundRelay)
-	configPage.aestusBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.AestusRelay)
-	configPage.titanGlobalBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.TitanGlobalRelay)
-	configPage.titanRegionalBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.TitanRegionalRelay)
+	// Create a checkbox for each relay in the registry
+	configPage.relayBoxes = map[cfgtypes.MevRelayID]*parameterizedFormItem{}
+	relayBoxes := []*parameterizedFormItem{}
+	relayRegistry, _ := configPage.masterConfig.MevBoost.GetRelayRegistry()
+	for i, relay := range relayRegistry.Relays {
+		relayBox := createParameterizedCheckbox(configPage.masterConfig.MevBoost.RelayParameters[i])
+		configPage.relayBoxes[relay.ID] = relayBox
+		relayBoxes = append(relayBoxes, relayBox)
+	}
+	configPage.customRelaysBox = createParameterizedStringField(&configPage.masterConfig.MevBoost.CustomRelays)
+	configPage.queryRelayDataBox = createParameterizedCheckbox(&configPage.masterConfig.MevBoost.QueryRelayData)
 
 	// Map the parameters to the form items in the layout
 	configPage.layout.mapParameterizedFormItems(configPage.enableBox, configPage.modeBox, configPage.selectionModeBox)
-	configPage.layout.mapParameterizedFormItems(configPage.flashbotsBox, configPage.bloxrouteMaxProfitBox, configPage.bloxrouteRegulatedBox, configPage.edenBox, configPage.ultrasoundBox, configPage.aestusBox, configPage.titanGlobalBox, configPage.titanRegionalBox)
+	configPage.layout.mapParameterizedFormItems(relayBoxes...)
+	configPage.layout.mapParameterizedFormItems(configPage.customRelaysBox, configPage.queryRelayDataBox)
 	configPage.layout.mapParameterizedFormItems(configPage.localItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.externalItems...)
 
@@ -137,6 +136,7 @@ func (configPage *MevBoostConfigPage) handleModeChanged() {
 				// Only show these to Docker users, not Hybrid users
 				configPage.layout.addFormItems(configPage.externalItems)
 			}
+			configPage.layout.form.AddFormItem(configPage.queryRelayDataBox.item)
 		}
 	}
 
@@ -164,26 +164,14 @@ func (configPage *MevBoostConfigPage) handleSelectionModeChanged() {
 	case cfgtypes.MevSelectionMode_Relay:
 		relays := configPage.masterConfig.MevBoost.GetAvailableRelays()
 		for _, relay := range relays {
-			switch relay.ID {
-			case cfgtypes.MevRelayID_Flashbots:
-				configPage.layout.form.AddFormItem(configPage.flashbotsBox.item)
-			case cfgtypes.MevRelayID_BloxrouteMaxProfit:
-				configPage.layout.form.AddFormItem(configPage.bloxrouteMaxProfitBox.item)
-			case cfgtypes.MevRelayID_BloxrouteRegulated:
-				configPage.layout.form.AddFormItem(configPage.bloxrouteRegulatedBox.item)
-			case cfgtypes.MevRelayID_Eden:
-				configPage.layout.form.AddFormItem(configPage.edenBox.item)
-			case cfgtypes.MevRelayID_Ultrasound:
-				configPage.layout.form.AddFormItem(configPage.ultrasoundBox.item)
-			case cfgtypes.MevRelayID_Aestus:
-				configPage.layout.form.AddFormItem(configPage.aestusBox.item)
-			case cfgtypes.MevRelayID_TitanGlobal:
-				configPage.layout.form.AddFormItem(configPage.titanGlobalBox.item)
-			case cfgtypes.MevRelayID_TitanRegional:
-				configPage.layout.form.AddFormItem(configPage.titanRegionalBox.item)
+			relayBox, exists := configPage.relayBoxes[relay.ID]
+			if exists {
+				configPage.layout.form.AddFormItem(relayBox.item)
 			}
 		}
 	}
+	configPage.layout.form.AddFormItem(configPage.customRelaysBox.item)
+	configPage.layout.form.AddFormItem(configPage.queryRelayDataBox.item)
 
 	configPage.layout.addFormItems(configPage.localItems)
 }
@@ -0,0 +1,405 @@
+package service
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/utils/bundle"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+const (
+	defaultMigrationQuietEpochs uint64 = 3
+)
+
+// Stops the validator client on this machine and exports everything needed to run the node elsewhere into an encrypted bundle
+func migrateOut(c *cli.Context, targetPath string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. This machine does not have a Smart Node to migrate.")
+	}
+
+	// Check the wallet
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized, so there is nothing to migrate.")
+		return nil
+	}
+
+	// Resolve the target path
+	targetPath, err = homedir.Expand(targetPath)
+	if err != nil {
+		return fmt.Errorf("Error expanding bundle path: %w", err)
+	}
+	targetIsDir := false
+	if info, err := os.Stat(targetPath); err == nil {
+		if !info.IsDir() {
+			return fmt.Errorf("[%s] already exists. Please choose a different path for the bundle.", targetPath)
+		}
+		targetIsDir = true
+	}
+
+	// Warn the user
+	fmt.Printf("%s=== NODE MIGRATION: SOURCE MACHINE ===%s\n", colorYellow, colorReset)
+	fmt.Println("This will stop the validator client and node daemon on this machine, then export the following into an encrypted bundle:")
+	fmt.Println("\t- your node wallet and its password")
+	fmt.Println("\t- your validator keys and your validator client's slashing protection data")
+	fmt.Println("\t- your rolling rewards records")
+	fmt.Println("\t- your Smart Node settings")
+	fmt.Println()
+	fmt.Printf("%sOnce the bundle has been created, you must NEVER start the validator client on this machine again.\nDoing so while the new machine is validating will get your validators slashed.%s\n\n", colorRed, colorReset)
+	if !(c.Bool("yes") || cliutils.ConfirmWithIAgree("Please type 'I agree' to confirm that you understand the above and want to continue.")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the bundle passphrase
+	passphrase := c.String("passphrase")
+	if passphrase == "" {
+		passphrase = promptMigrationPassphrase(true)
+	} else if _, err := cliutils.ValidateNodePassword("passphrase", passphrase); err != nil {
+		return err
+	}
+
+	// Stop validating
+	if err := stopValidatorForMigration(rp, cfg); err != nil {
+		return err
+	}
+
+	// Create the bundle
+	fmt.Println("Creating the migration bundle...")
+	response, err := rp.CreateMigrationBundle(passphrase)
+	if err != nil {
+		return err
+	}
+
+	// Copy it to the requested location
+	sourcePath, err := homedir.Expand(filepath.Join(cfg.Smartnode.GetMigrationFolder(false), response.BundleFile))
+	if err != nil {
+		return fmt.Errorf("Error expanding bundle path: %w", err)
+	}
+	if targetIsDir {
+		targetPath = filepath.Join(targetPath, response.BundleFile)
+	}
+	if err := copyFile(sourcePath, targetPath); err != nil {
+		return fmt.Errorf("The bundle was created at %s but could not be copied to %s: %w", sourcePath, targetPath, err)
+	}
+
+	// Print the summary
+	fmt.Println()
+	fmt.Printf("%sThe migration bundle was created successfully.%s\n", colorGreen, colorReset)
+	fmt.Printf("Bundle:         %s\n", targetPath)
+	fmt.Printf("Node address:   %s\n", response.NodeAddress.Hex())
+	fmt.Printf("Validators:     %d\n", response.ValidatorCount)
+	fmt.Printf("Stopped epoch:  %d\n", response.StopEpoch)
+	fmt.Printf("Contents:       %s\n", strings.Join(response.Entries, ", "))
+	fmt.Println()
+	fmt.Println("Copy the bundle to the new machine and run `rocketpool service migrate-in <bundle>` there.")
+	fmt.Println("It will wait until your validators have been silent on-chain before starting the new validator client.")
+	fmt.Printf("%sDo NOT run `rocketpool service start` on this machine again. Once the migration is complete, run `rocketpool wallet purge` here to delete the old keys.%s\n", colorYellow, colorReset)
+	return nil
+
+}
+
+// Restores an encrypted migration bundle onto this machine and starts validating once the old machine has provably gone quiet
+func migrateIn(c *cli.Context, bundlePath string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` and `rocketpool service start` to set up the Smart Node on this machine before migrating into it.")
+	}
+
+	// Make sure this machine doesn't already have a wallet
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized {
+		fmt.Printf("This machine already has a node wallet (%s). A migration bundle can only be restored onto a machine without one.\n", status.AccountAddress.Hex())
+		return nil
+	}
+
+	// Check the bundle
+	bundlePath, err = homedir.Expand(bundlePath)
+	if err != nil {
+		return fmt.Errorf("Error expanding bundle path: %w", err)
+	}
+	if _, err := os.Stat(bundlePath); err != nil {
+		return fmt.Errorf("Error reading bundle [%s]: %w", bundlePath, err)
+	}
+
+	// Warn the user
+	quietEpochs := c.Uint64("epochs")
+	fmt.Printf("%s=== NODE MIGRATION: TARGET MACHINE ===%s\n", colorYellow, colorReset)
+	fmt.Println("This will restore your node wallet, validator keys, slashing protection data and rolling records from the bundle.")
+	fmt.Printf("The validator client on this machine will stay stopped until none of your validators have been seen on-chain for %d epochs after the old machine stopped, and that epoch has been finalized.\n\n", quietEpochs)
+	fmt.Printf("%sMake sure the validator client on the old machine is stopped and will stay stopped before continuing.%s\n\n", colorRed, colorReset)
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to continue?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Get the bundle passphrase
+	passphrase := c.String("passphrase")
+	if passphrase == "" {
+		passphrase = promptMigrationPassphrase(false)
+	}
+
+	// Make sure nothing on this machine validates while the keys are restored
+	if err := stopValidatorForMigration(rp, cfg); err != nil {
+		return err
+	}
+
+	// Stage the bundle where the daemon can see it
+	migrationFolder, err := homedir.Expand(cfg.Smartnode.GetMigrationFolder(false))
+	if err != nil {
+		return fmt.Errorf("Error expanding migration folder path: %w", err)
+	}
+	if err := os.MkdirAll(migrationFolder, 0755); err != nil {
+		return fmt.Errorf("Error creating migration folder: %w", err)
+	}
+	bundleFile := filepath.Base(bundlePath)
+	if !strings.HasSuffix(bundleFile, bundle.FileExtension) {
+		bundleFile += bundle.FileExtension
+	}
+	stagedPath := filepath.Join(migrationFolder, bundleFile)
+	if stagedPath != bundlePath {
+		// Replace any copy left behind by a previous attempt
+		if err := os.Remove(stagedPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("Error removing previously staged bundle [%s]: %w", stagedPath, err)
+		}
+		if err := copyFile(bundlePath, stagedPath); err != nil {
+			return fmt.Errorf("Error copying the bundle into %s: %w", migrationFolder, err)
+		}
+	}
+
+	// Restore it
+	fmt.Println("Restoring the migration bundle...")
+	response, err := rp.RestoreMigrationBundle(bundleFile, passphrase)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%sRestored %s for node %s (%d validators, created %s).%s\n\n", colorGreen, strings.Join(response.Entries, ", "), response.NodeAddress.Hex(), response.ValidatorCount, response.CreatedAt.Local().Format(time.RFC822), colorReset)
+
+	// Restore the settings if requested
+	if response.Settings != "" && (c.Bool("restore-config") || (!c.Bool("yes") && cliutils.Confirm("The bundle contains the old machine's Smart Node settings. Would you like to use them on this machine? Paths and the Docker project name from this machine will be kept."))) {
+		if err := restoreMigratedSettings(rp, cfg, response.Settings); err != nil {
+			return err
+		}
+		fmt.Println("Restored the Smart Node settings from the old machine.")
+	}
+
+	// Wait for the old validators to go quiet
+	if err := waitForValidatorSilence(rp, response.StopEpoch, quietEpochs); err != nil {
+		return err
+	}
+
+	// Start validating
+	if cfg.IsNativeMode {
+		fmt.Println("Please start your validator client and node daemon services now.")
+	} else {
+		fmt.Println("Starting the Smart Node...")
+		if err := rp.StartService(getComposeFiles(c)); err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+	fmt.Printf("%sThe migration is complete and this machine is now validating.%s\n", colorGreen, colorReset)
+	fmt.Println("If the old machine used a different validator client, run `rocketpool wallet rebuild` to regenerate your validator keys for this one.")
+	return nil
+
+}
+
+// Stops the validator client and node daemon so no validator duties can be performed on this machine.
+// In native mode the user has to stop them and confirm it, even with --yes, since the keys would be moved while they're still validating otherwise.
+func stopValidatorForMigration(rp *rocketpool.Client, cfg *config.RocketPoolConfig) error {
+
+	// Native mode has no containers to manage
+	if cfg.IsNativeMode {
+		if !cliutils.Confirm("Native mode detected. Please stop your validator client and node daemon services now. Have they been stopped?") {
+			return fmt.Errorf("the validator client must be stopped to migrate")
+		}
+		return nil
+	}
+
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return fmt.Errorf("Error getting container prefix: %w", err)
+	}
+	validatorContainerName, err := getContainerNameForValidatorDuties("", rp)
+	if err != nil {
+		return fmt.Errorf("Error getting validator container name: %w", err)
+	}
+
+	// Stop the node daemon first so it can't restart the validator in the meantime
+	for _, containerName := range []string{prefix + NodeContainerSuffix, validatorContainerName} {
+		status, err := rp.GetDockerStatus(containerName)
+		if err != nil {
+			// The container doesn't exist, so it can't be validating
+			continue
+		}
+		if status != "running" && status != "paused" && status != "restarting" {
+			continue
+		}
+		fmt.Printf("Stopping %s...\n", containerName)
+		response, err := rp.StopContainer(containerName)
+		if err != nil {
+			return fmt.Errorf("Error stopping container [%s]: %w", containerName, err)
+		}
+		if response != containerName {
+			return fmt.Errorf("Unexpected response when stopping container [%s]: %s", containerName, response)
+		}
+	}
+	return nil
+
+}
+
+// Watches the chain until none of the node's validators have been live for the requested number of epochs after the stop epoch,
+// and the stop epoch has been finalized
+func waitForValidatorSilence(rp *rocketpool.Client, stopEpoch uint64, requiredEpochs uint64) error {
+
+	fmt.Printf("Waiting for %d quiet epochs after epoch %d...\n", requiredEpochs, stopEpoch)
+	quietEpochs := map[uint64]bool{}
+	for {
+		liveness, err := rp.GetValidatorLiveness()
+		if err != nil {
+			return err
+		}
+		if liveness.ValidatorCount == 0 {
+			fmt.Println("This node doesn't have any active validators, so there is nothing to wait for.")
+			return nil
+		}
+
+		// Activity up to the stop epoch is the old machine's last duties, so only later epochs count; abort immediately if anything was seen in one
+		if liveness.Epoch > stopEpoch {
+			if len(liveness.LiveValidators) > 0 {
+				fmt.Println(colorReset)
+				return fmt.Errorf("%sDOPPELGANGER DETECTED: validators %s performed duties in epoch %d, so they are still running somewhere else!\nThe validator client on this machine has NOT been started. Stop the validator client on the old machine, then run `rocketpool service start` here once it has been offline for at least %d epochs.%s",
+					colorRed, strings.Join(liveness.LiveValidators, ", "), liveness.Epoch, requiredEpochs, colorReset)
+			}
+			quietEpochs[liveness.Epoch] = true
+		}
+
+		finalized := liveness.FinalizedEpoch > stopEpoch
+		if uint64(len(quietEpochs)) >= requiredEpochs && finalized {
+			fmt.Printf("%s\r", clearLine)
+			fmt.Printf("%sNo validator activity seen for %d epochs, and epoch %d has been finalized. It is now safe to start validating.%s\n", colorGreen, len(quietEpochs), stopEpoch, colorReset)
+			return nil
+		}
+
+		fmt.Printf("%s\rQuiet epochs: %d/%d, last checked epoch: %d, finalized epoch: %d", clearLine, len(quietEpochs), requiredEpochs, liveness.Epoch, liveness.FinalizedEpoch)
+		pollInterval := time.Duration(liveness.SecondsPerEpoch/4) * time.Second
+		if pollInterval == 0 {
+			pollInterval = 30 * time.Second
+		}
+		time.Sleep(pollInterval)
+	}
+
+}
+
+// Applies the settings from the old machine, keeping the ones that are specific to this machine
+func restoreMigratedSettings(rp *rocketpool.Client, cfg *config.RocketPoolConfig, settings string) error {
+
+	var masterMap map[string]map[string]string
+	if err := yaml.Unmarshal([]byte(settings), &masterMap); err != nil {
+		return fmt.Errorf("Error parsing the bundled settings: %w", err)
+	}
+	migratedCfg := config.NewRocketPoolConfig(cfg.RocketPoolDirectory, cfg.IsNativeMode)
+	if err := migratedCfg.Deserialize(masterMap); err != nil {
+		return fmt.Errorf("Error loading the bundled settings: %w", err)
+	}
+	if migratedCfg.Smartnode.Network.Value != cfg.Smartnode.Network.Value {
+		return fmt.Errorf("The bundled settings are for the %v network, but this machine is configured for %v", migratedCfg.Smartnode.Network.Value, cfg.Smartnode.Network.Value)
+	}
+
+	// Keep this machine's paths and container names
+	migratedCfg.RocketPoolDirectory = cfg.RocketPoolDirectory
+	migratedCfg.IsNativeMode = cfg.IsNativeMode
+	migratedCfg.Version = cfg.Version
+	migratedCfg.Smartnode.DataPath.Value = cfg.Smartnode.DataPath.Value
+	migratedCfg.Smartnode.ProjectName.Value = cfg.Smartnode.ProjectName.Value
+	migratedCfg.Smartnode.WatchtowerStatePath.Value = cfg.Smartnode.WatchtowerStatePath.Value
+	migratedCfg.Smartnode.RecordsPath.Value = cfg.Smartnode.RecordsPath.Value
+
+	if errors := migratedCfg.Validate(); len(errors) > 0 {
+		return fmt.Errorf("The bundled settings are not valid on this machine:\n%s", strings.Join(errors, "\n"))
+	}
+	return rp.SaveConfig(migratedCfg)
+
+}
+
+// Prompt for the passphrase protecting a migration bundle
+func promptMigrationPassphrase(confirm bool) string {
+	for {
+		passphrase := cliutils.PromptPassword(
+			"Please enter the passphrase for the migration bundle:",
+			fmt.Sprintf("^.{%d,}$", passwords.MinPasswordLength),
+			fmt.Sprintf("The passphrase must be at least %d characters long. Please try again:", passwords.MinPasswordLength),
+		)
+		if !confirm {
+			return passphrase
+		}
+		confirmation := cliutils.PromptPassword("Please confirm the passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+// Copy a file, refusing to overwrite an existing one
+func copyFile(sourcePath string, targetPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+
+	target, err := os.OpenFile(targetPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		return err
+	}
+	return target.Close()
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	sharedConfig "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/types/results"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/sys"
 	"github.com/shirou/gopsutil/v3/disk"
 )
@@ -1315,97 +1317,122 @@ func serviceVersion(c *cli.Context) error {
 		return err
 	}
 
+	result := results.ServiceVersion{
+		ClientVersion:  c.App.Version,
+		ServiceVersion: serviceVersion,
+		IsNativeMode:   cfg.IsNativeMode,
+	}
+
 	// Handle native mode
 	if cfg.IsNativeMode {
+		output.SetResult(c, result)
 		fmt.Printf("Rocket Pool client version: %s\n", c.App.Version)
 		fmt.Printf("Rocket Pool service version: %s\n", serviceVersion)
 		fmt.Println("Configured for Native Mode")
 		return nil
 	}
 
-	// Get the execution client string
-	var eth1ClientString string
-	eth1ClientMode := cfg.ExecutionClientMode.Value.(cfgtypes.Mode)
-	switch eth1ClientMode {
+	// Get the execution client
+	eth1Client := results.ServiceClient{
+		Mode: cfg.ExecutionClientMode.Value.(cfgtypes.Mode),
+	}
+	switch eth1Client.Mode {
 	case cfgtypes.Mode_Local:
-		eth1Client := cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient)
-		format := "%s (Locally managed)\n\tImage: %s"
-		switch eth1Client {
+		switch cfg.ExecutionClient.Value.(cfgtypes.ExecutionClient) {
 		case cfgtypes.ExecutionClient_Geth:
-			eth1ClientString = fmt.Sprintf(format, "Geth", cfg.Geth.ContainerTag.Value.(string))
+			eth1Client.Name, eth1Client.Image = "Geth", cfg.Geth.ContainerTag.Value.(string)
 		case cfgtypes.ExecutionClient_Nethermind:
-			eth1ClientString = fmt.Sprintf(format, "Nethermind", cfg.Nethermind.ContainerTag.Value.(string))
+			eth1Client.Name, eth1Client.Image = "Nethermind", cfg.Nethermind.ContainerTag.Value.(string)
 		case cfgtypes.ExecutionClient_Besu:
-			eth1ClientString = fmt.Sprintf(format, "Besu", cfg.Besu.ContainerTag.Value.(string))
+			eth1Client.Name, eth1Client.Image = "Besu", cfg.Besu.ContainerTag.Value.(string)
 		case cfgtypes.ExecutionClient_Reth:
-			eth1ClientString = fmt.Sprintf(format, "Reth", cfg.Reth.ContainerTag.Value.(string))
+			eth1Client.Name, eth1Client.Image = "Reth", cfg.Reth.ContainerTag.Value.(string)
 		default:
-			return fmt.Errorf("unknown local execution client [%v]", eth1Client)
+			return fmt.Errorf("unknown local execution client [%v]", cfg.ExecutionClient.Value)
 		}
 
 	case cfgtypes.Mode_External:
-		eth1ClientString = "Externally managed"
+		// The Smart Node doesn't know which client it is
 
 	default:
-		return fmt.Errorf("unknown execution client mode [%v]", eth1ClientMode)
+		return fmt.Errorf("unknown execution client mode [%v]", eth1Client.Mode)
 	}
 
-	// Get the consensus client string
-	var eth2ClientString string
-	eth2ClientMode := cfg.ConsensusClientMode.Value.(cfgtypes.Mode)
-	switch eth2ClientMode {
+	// Get the consensus client
+	eth2Client := results.ServiceClient{
+		Mode: cfg.ConsensusClientMode.Value.(cfgtypes.Mode),
+	}
+	switch eth2Client.Mode {
 	case cfgtypes.Mode_Local:
-		eth2Client := cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient)
-		format := "%s (Locally managed)\n\tImage: %s"
-		switch eth2Client {
+		switch cfg.ConsensusClient.Value.(cfgtypes.ConsensusClient) {
 		case cfgtypes.ConsensusClient_Lighthouse:
-			eth2ClientString = fmt.Sprintf(format, "Lighthouse", cfg.Lighthouse.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.Image = "Lighthouse", cfg.Lighthouse.ContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Lodestar:
-			eth2ClientString = fmt.Sprintf(format, "Lodestar", cfg.Lodestar.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.Image = "Lodestar", cfg.Lodestar.ContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Nimbus:
-			eth2ClientString = fmt.Sprintf(format+"\n\tVC image: %s", "Nimbus", cfg.Nimbus.BnContainerTag.Value.(string), cfg.Nimbus.VcContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.Image, eth2Client.VcImage = "Nimbus", cfg.Nimbus.BnContainerTag.Value.(string), cfg.Nimbus.VcContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Prysm:
-			eth2ClientString = fmt.Sprintf(format+"\n\tVC image: %s", "Prysm", cfg.Prysm.BnContainerTag.Value.(string), cfg.Prysm.VcContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.Image, eth2Client.VcImage = "Prysm", cfg.Prysm.BnContainerTag.Value.(string), cfg.Prysm.VcContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Teku:
-			eth2ClientString = fmt.Sprintf(format, "Teku", cfg.Teku.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.Image = "Teku", cfg.Teku.ContainerTag.Value.(string)
 		default:
-			return fmt.Errorf("unknown local consensus client [%v]", eth2Client)
+			return fmt.Errorf("unknown local consensus client [%v]", cfg.ConsensusClient.Value)
 		}
 
 	case cfgtypes.Mode_External:
-		eth2Client := cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient)
-		format := "%s (Externally managed)\n\tVC Image: %s"
-		switch eth2Client {
+		switch cfg.ExternalConsensusClient.Value.(cfgtypes.ConsensusClient) {
 		case cfgtypes.ConsensusClient_Lighthouse:
-			eth2ClientString = fmt.Sprintf(format, "Lighthouse", cfg.ExternalLighthouse.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.VcImage = "Lighthouse", cfg.ExternalLighthouse.ContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Lodestar:
-			eth2ClientString = fmt.Sprintf(format, "Lodestar", cfg.ExternalLodestar.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.VcImage = "Lodestar", cfg.ExternalLodestar.ContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Nimbus:
-			eth2ClientString = fmt.Sprintf(format, "Nimbus", cfg.ExternalNimbus.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.VcImage = "Nimbus", cfg.ExternalNimbus.ContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Prysm:
-			eth2ClientString = fmt.Sprintf(format, "Prysm", cfg.ExternalPrysm.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.VcImage = "Prysm", cfg.ExternalPrysm.ContainerTag.Value.(string)
 		case cfgtypes.ConsensusClient_Teku:
-			eth2ClientString = fmt.Sprintf(format, "Teku", cfg.ExternalTeku.ContainerTag.Value.(string))
+			eth2Client.Name, eth2Client.VcImage = "Teku", cfg.ExternalTeku.ContainerTag.Value.(string)
 		default:
-			return fmt.Errorf("unknown external consensus client [%v]", eth2Client)
+			return fmt.Errorf("unknown external consensus client [%v]", cfg.ExternalConsensusClient.Value)
 		}
 
 	default:
-		return fmt.Errorf("unknown consensus client mode [%v]", eth2ClientMode)
+		return fmt.Errorf("unknown consensus client mode [%v]", eth2Client.Mode)
 	}
 
-	var mevBoostString string
+	// Get the MEV-Boost client
+	var mevBoost *results.ServiceClient
+	mevBoostString := "Disabled"
 	if cfg.EnableMevBoost.Value.(bool) {
-		if cfg.MevBoost.Mode.Value.(sharedConfig.Mode) == sharedConfig.Mode_Local {
-			mevBoostString = fmt.Sprintf("Enabled (Local Mode)\n\tImage: %s", cfg.MevBoost.ContainerTag.Value.(string))
+		mevBoost = &results.ServiceClient{
+			Name: "MEV-Boost",
+			Mode: cfg.MevBoost.Mode.Value.(sharedConfig.Mode),
+		}
+		if mevBoost.Mode == sharedConfig.Mode_Local {
+			mevBoost.Image = cfg.MevBoost.ContainerTag.Value.(string)
+			mevBoostString = fmt.Sprintf("Enabled (Local Mode)\n\tImage: %s", mevBoost.Image)
 		} else {
 			mevBoostString = "Enabled (External Mode)"
 		}
-	} else {
-		mevBoostString = "Disabled"
 	}
+	result.ExecutionClient = &eth1Client
+	result.ConsensusClient = &eth2Client
+	result.MevBoost = mevBoost
+	output.SetResult(c, result)
 
 	// Print version info
+	eth1ClientString := "Externally managed"
+	if eth1Client.Mode == cfgtypes.Mode_Local {
+		eth1ClientString = fmt.Sprintf("%s (Locally managed)\n\tImage: %s", eth1Client.Name, eth1Client.Image)
+	}
+	var eth2ClientString string
+	if eth2Client.Mode == cfgtypes.Mode_Local {
+		eth2ClientString = fmt.Sprintf("%s (Locally managed)\n\tImage: %s", eth2Client.Name, eth2Client.Image)
+		if eth2Client.VcImage != "" {
+			eth2ClientString += fmt.Sprintf("\n\tVC image: %s", eth2Client.VcImage)
+		}
+	} else {
+		eth2ClientString = fmt.Sprintf("%s (Externally managed)\n\tVC Image: %s", eth2Client.Name, eth2Client.VcImage)
+	}
 	fmt.Printf("Rocket Pool client version: %s\n", c.App.Version)
 	fmt.Printf("Rocket Pool service version: %s\n", serviceVersion)
 	fmt.Printf("Selected Eth 1.0 client: %s\n", eth1ClientString)
@@ -1904,8 +1931,12 @@ func getPartitionFreeSpace(rp *rocketpool.Client, targetDir string) (uint64, err
 }
 
 // Get the list of features required for modern client containers but not supported by the CPU
-func checkCpuFeatures() error {
+func checkCpuFeatures(c *cli.Context) error {
 	unsupportedFeatures := sys.GetMissingModernCpuFeatures()
+	output.SetResult(c, results.ServiceCpuFeatures{
+		MissingFeatures:      unsupportedFeatures,
+		SupportsModernImages: len(unsupportedFeatures) == 0,
+	})
 	if len(unsupportedFeatures) > 0 {
 		fmt.Println("Your CPU is missing support for the following features:")
 		for _, name := range unsupportedFeatures {
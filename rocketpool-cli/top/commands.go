@@ -0,0 +1,35 @@
+package top
+
+import (
+	"github.com/urfave/cli"
+
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Register commands
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:      name,
+		Aliases:   aliases,
+		Usage:     "Show a live dashboard of the node's clients, minipools, duties, transactions, rewards and alerts",
+		UsageText: "rocketpool top [options]",
+		Flags: []cli.Flag{
+			cli.UintFlag{
+				Name:  "interval, i",
+				Usage: "The number of `seconds` between refreshes of the clients, duties and transactions; minipools, rewards and alerts refresh less often",
+				Value: 12,
+			},
+		},
+		Action: func(c *cli.Context) error {
+
+			// Validate args
+			if err := cliutils.ValidateArgCount(c, 0); err != nil {
+				return err
+			}
+
+			// Run
+			return runTop(c)
+
+		},
+	})
+}
@@ -0,0 +1,383 @@
+package top
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of deferred transactions and alerts listed before the rest are summarized
+const maxListItems int = 5
+
+// The full-screen dashboard
+type dashboard struct {
+	app       *tview.Application
+	updated   string
+	header    *tview.TextView
+	clients   *tview.TextView
+	node      *tview.TextView
+	duties    *tview.TextView
+	minipools *tview.Table
+	txs       *tview.TextView
+	alerts    *tview.TextView
+}
+
+// Create the dashboard's layout and key bindings
+func newDashboard(app *tview.Application, refresh chan<- struct{}) *dashboard {
+	d := &dashboard{
+		app:       app,
+		header:    tview.NewTextView().SetDynamicColors(true),
+		clients:   newPanel("Clients"),
+		node:      newPanel("Node and Rewards"),
+		duties:    newPanel("Upcoming Duties"),
+		minipools: tview.NewTable().SetFixed(1, 0).SetSelectable(true, false),
+		txs:       newPanel("Pending Transactions"),
+		alerts:    newPanel("Alerts"),
+	}
+	d.minipools.SetBorder(true).SetTitle(" Minipools ").SetTitleColor(tcell.ColorOrange)
+
+	top := tview.NewFlex().
+		AddItem(d.clients, 0, 1, false).
+		AddItem(d.node, 0, 1, false).
+		AddItem(d.duties, 0, 1, false)
+	bottom := tview.NewFlex().
+		AddItem(d.txs, 0, 1, false).
+		AddItem(d.alerts, 0, 1, false)
+	layout := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(d.header, 1, 0, false).
+		AddItem(top, 12, 0, false).
+		AddItem(d.minipools, 0, 1, true).
+		AddItem(bottom, 10, 0, false)
+
+	app.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch {
+		case event.Key() == tcell.KeyEscape, event.Rune() == 'q':
+			app.Stop()
+			return nil
+		case event.Rune() == 'r':
+			select {
+			case refresh <- struct{}{}:
+			default:
+			}
+			return nil
+		}
+		return event
+	})
+	app.SetRoot(layout, true)
+
+	d.setHeader("[yellow]loading...[-]")
+	return d
+}
+
+// Create a bordered text panel
+func newPanel(title string) *tview.TextView {
+	panel := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	panel.SetBorder(true).SetTitle(fmt.Sprintf(" %s ", title)).SetTitleColor(tcell.ColorOrange)
+	return panel
+}
+
+// Set the header line, with a note about the refresh status
+func (d *dashboard) setHeader(status string) {
+	d.header.SetText(fmt.Sprintf("[orange]Rocket Pool Smart Node v%s[-]   %s   [gray]r[-] refresh   [gray]q[-] quit", shared.RocketPoolVersion, status))
+}
+
+// Show that the data is being refreshed
+func (d *dashboard) setRefreshing() {
+	d.setHeader(fmt.Sprintf("%s [yellow]refreshing...[-]", d.updated))
+}
+
+// Show the latest data
+func (d *dashboard) render(state *snapshot, interval time.Duration) {
+	d.updated = fmt.Sprintf("updated %s, every %s", state.updated.Format("15:04:05"), interval)
+	d.setHeader(d.updated)
+	d.clients.SetText(renderClients(state))
+	d.node.SetText(renderNode(state))
+	d.duties.SetText(renderDuties(state))
+	d.txs.SetText(renderTxs(state))
+	d.alerts.SetText(renderAlerts(state))
+	d.renderMinipools(state)
+}
+
+// Render the sync and peer status of the clients
+func renderClients(state *snapshot) string {
+	if state.clientsErr != nil {
+		return errorText(state.clientsErr)
+	}
+
+	var text strings.Builder
+	for _, manager := range []struct {
+		name   string
+		status api.ClientManagerStatus
+	}{
+		{"Execution", state.clients.EcManagerStatus},
+		{"Consensus", state.clients.BcManagerStatus},
+	} {
+		fmt.Fprintf(&text, "[::b]%s[::-]\n", manager.name)
+		fmt.Fprintf(&text, "  Primary:  %s\n", clientStatusText(manager.status.PrimaryClientStatus))
+		if manager.status.FallbackEnabled {
+			fmt.Fprintf(&text, "  Fallback: %s\n", clientStatusText(manager.status.FallbackClientStatus))
+		} else {
+			text.WriteString("  Fallback: [gray]not configured[-]\n")
+		}
+	}
+	return text.String()
+}
+
+// Describe a client's status
+func clientStatusText(status api.ClientStatus) string {
+	switch {
+	case status.Error != "":
+		return fmt.Sprintf("[red]unavailable[-] (%s)", tview.Escape(status.Error))
+	case status.IsSynced:
+		return fmt.Sprintf("[green]synced[-], %d peers", status.PeerCount)
+	case status.IsWorking:
+		return fmt.Sprintf("[yellow]syncing %.2f%%[-], %d peers", rocketpool.SyncRatioToPercent(status.SyncProgress), status.PeerCount)
+	default:
+		return "[red]not working[-]"
+	}
+}
+
+// Render the node's stake, smoothing pool status and reward projections
+func renderNode(state *snapshot) string {
+	if state.nodeErr != nil {
+		return errorText(state.nodeErr)
+	}
+	node := state.node
+	if !node.Registered {
+		return fmt.Sprintf("%s\n[yellow]The node is not registered with Rocket Pool.[-]", node.AccountAddress.Hex())
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s\n", node.AccountAddress.Hex())
+	fmt.Fprintf(&text, "Balance:    %s ETH, %s RPL\n", formatEth(node.AccountBalances.ETH), formatEth(node.AccountBalances.RPL))
+	fmt.Fprintf(&text, "RPL staked: %s (%.2f%% of borrowed ETH)\n", formatEth(node.RplStake), node.BorrowedCollateralRatio*100)
+
+	// Smoothing pool
+	switch {
+	case node.FeeRecipientInfo.IsInOptOutCooldown:
+		text.WriteString("Smoothing pool: [yellow]opting out[-]\n")
+	case node.FeeRecipientInfo.IsInSmoothingPool:
+		text.WriteString("Smoothing pool: [green]opted in[-]\n")
+	default:
+		fmt.Fprintf(&text, "Smoothing pool: opted out (distributor holds %s ETH)\n", formatEth(node.FeeDistributorBalance))
+	}
+	if state.networkErr == nil && state.network.SmoothingPoolNodes > 0 {
+		fmt.Fprintf(&text, "  Pool: %.4f ETH across %d nodes (%.4f per node)\n",
+			state.network.SmoothingPoolBalance, state.network.SmoothingPoolNodes, state.network.SmoothingPoolBalance/float64(state.network.SmoothingPoolNodes))
+	}
+
+	// Rewards
+	if state.rewardsErr != nil {
+		text.WriteString(errorText(state.rewardsErr))
+		return text.String()
+	}
+	rewards := state.rewards
+	nextCheckpoint := rewards.LastCheckpoint.Add(rewards.RewardsInterval)
+	fmt.Fprintf(&text, "Next rewards checkpoint: %s\n", formatUntil(nextCheckpoint))
+	fmt.Fprintf(&text, "  Est. RPL this interval: %.4f\n", rewards.EstimatedRewards+rewards.EstimatedTrustedRplRewards)
+	fmt.Fprintf(&text, "  Unclaimed: %.4f RPL, %.4f ETH\n", rewards.UnclaimedRplRewards+rewards.UnclaimedTrustedRplRewards, rewards.UnclaimedEthRewards)
+	return text.String()
+}
+
+// Render the proposals and sync committee duties of the node's validators
+func renderDuties(state *snapshot) string {
+	if state.dutiesErr != nil {
+		return errorText(state.dutiesErr)
+	}
+	duties := state.duties
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "Epoch %d, %d active validators\n", duties.Epoch, duties.ValidatorCount)
+	fmt.Fprintf(&text, "Proposals this epoch:  %s\n", highlightCount(int(duties.ProposalsThisEpoch)))
+	fmt.Fprintf(&text, "Current sync committee: %s\n", highlightCount(duties.CurrentSyncCommitteeCount))
+	nextPeriod := time.Duration(0)
+	if duties.NextSyncCommitteeEpoch > duties.Epoch {
+		nextPeriod = time.Duration((duties.NextSyncCommitteeEpoch-duties.Epoch)*duties.SecondsPerEpoch) * time.Second
+	}
+	fmt.Fprintf(&text, "Next sync committee:    %s (from epoch %d, %s)\n", highlightCount(duties.NextSyncCommitteeCount), duties.NextSyncCommitteeEpoch, formatUntil(time.Now().Add(nextPeriod)))
+
+	for _, validator := range duties.Validators {
+		fmt.Fprintf(&text, "  [::b]%s[::-] %s\n", validator.Index, strings.Join(dutyNames(validator), ", "))
+	}
+	return text.String()
+}
+
+// Render the node's transactions in the mempool and the ones the daemon is waiting to submit
+func renderTxs(state *snapshot) string {
+	var text strings.Builder
+	if state.pendingTxsErr != nil {
+		text.WriteString(errorText(state.pendingTxsErr))
+	} else if state.pendingTxs.PendingCount == 0 {
+		text.WriteString("Mempool: [green]none pending[-]\n")
+	} else {
+		fmt.Fprintf(&text, "Mempool: [yellow]%d pending[-] (nonces %d to %d)\n", state.pendingTxs.PendingCount, state.pendingTxs.LatestNonce, state.pendingTxs.PendingNonce-1)
+	}
+
+	if state.deferredTxsErr != nil {
+		text.WriteString(errorText(state.deferredTxsErr))
+		return text.String()
+	}
+	actions := state.deferredTxs.Actions
+	if len(actions) == 0 {
+		text.WriteString("Waiting for cheaper gas: none\n")
+		return text.String()
+	}
+	fmt.Fprintf(&text, "Waiting for cheaper gas: %d\n", len(actions))
+	for i, action := range actions {
+		if i == maxListItems {
+			fmt.Fprintf(&text, "  ... and %d more\n", len(actions)-maxListItems)
+			break
+		}
+		fmt.Fprintf(&text, "  %s: up to %.2f gwei, deadline %s\n", tview.Escape(action.Description), action.TargetMaxFeeGwei, formatUntil(action.Deadline))
+	}
+	return text.String()
+}
+
+// Render the active alerts from Alertmanager
+func renderAlerts(state *snapshot) string {
+	if state.nodeErr != nil {
+		return errorText(state.nodeErr)
+	}
+
+	var text strings.Builder
+	if state.node.Warning != "" {
+		fmt.Fprintf(&text, "[yellow]%s[-]\n", tview.Escape(state.node.Warning))
+	}
+	alerts := state.node.Alerts
+	if len(alerts) == 0 {
+		text.WriteString("[green]No alerts[-]\n")
+		return text.String()
+	}
+	for i, alert := range alerts {
+		if i == maxListItems {
+			fmt.Fprintf(&text, "... and %d more\n", len(alerts)-maxListItems)
+			break
+		}
+		color := "yellow"
+		if alert.Severity() == "critical" {
+			color = "red"
+		}
+		suppressed := ""
+		if alert.IsSuppressed() {
+			suppressed = " (suppressed)"
+		}
+		fmt.Fprintf(&text, "[%s]%s%s[-] %s: %s\n", color, tview.Escape(alert.Severity()), suppressed, tview.Escape(alert.Summary()), tview.Escape(alert.Description()))
+	}
+	return text.String()
+}
+
+// Render the table of the node's minipools
+func (d *dashboard) renderMinipools(state *snapshot) {
+	d.minipools.Clear()
+	for column, title := range []string{"MINIPOOL", "INDEX", "STATUS", "BOND", "FEE", "BEACON BALANCE", "NODE SHARE", "DUTIES"} {
+		d.minipools.SetCell(0, column, tview.NewTableCell(title).SetTextColor(tcell.ColorOrange).SetSelectable(false).SetExpansion(1))
+	}
+	if state.minipoolsErr != nil {
+		d.minipools.SetCell(1, 0, tview.NewTableCell(state.minipoolsErr.Error()).SetTextColor(tcell.ColorRed))
+		return
+	}
+
+	// Match the duties to the minipools
+	duties := map[types.ValidatorPubkey]api.ValidatorDutyDetails{}
+	for _, validator := range state.duties.Validators {
+		duties[validator.Pubkey] = validator
+	}
+
+	row := 1
+	for _, mp := range state.minipools.Minipools {
+		if mp.Finalised {
+			continue
+		}
+		status := mp.Status.Status.String()
+		statusColor := tcell.ColorWhite
+		switch {
+		case mp.Status.Status == types.Staking && mp.Validator.Active:
+			statusColor = tcell.ColorGreen
+		case mp.Status.Status == types.Dissolved || mp.Penalties > 0:
+			statusColor = tcell.ColorRed
+		case mp.Status.Status != types.Staking:
+			statusColor = tcell.ColorYellow
+		}
+		if mp.Status.IsVacant {
+			status += " (vacant)"
+		}
+
+		index, beaconBalance, nodeShare := "-", "-", "-"
+		if mp.Validator.Exists {
+			index = mp.Validator.Index
+			beaconBalance = formatEth(mp.Validator.Balance)
+			nodeShare = formatEth(mp.Validator.NodeBalance)
+		}
+
+		d.minipools.SetCell(row, 0, tview.NewTableCell(mp.Address.Hex()))
+		d.minipools.SetCell(row, 1, tview.NewTableCell(index))
+		d.minipools.SetCell(row, 2, tview.NewTableCell(status).SetTextColor(statusColor))
+		d.minipools.SetCell(row, 3, tview.NewTableCell(formatEth(mp.Node.DepositBalance)))
+		d.minipools.SetCell(row, 4, tview.NewTableCell(fmt.Sprintf("%.2f%%", mp.Node.Fee*100)))
+		d.minipools.SetCell(row, 5, tview.NewTableCell(beaconBalance))
+		d.minipools.SetCell(row, 6, tview.NewTableCell(nodeShare))
+		d.minipools.SetCell(row, 7, tview.NewTableCell(strings.Join(dutyNames(duties[mp.ValidatorPubkey]), ", ")).SetTextColor(tcell.ColorAqua))
+		row++
+	}
+	if row == 1 {
+		d.minipools.SetCell(1, 0, tview.NewTableCell("The node doesn't have any active minipools.").SetTextColor(tcell.ColorGray))
+	}
+}
+
+// Get the names of a validator's duties
+func dutyNames(validator api.ValidatorDutyDetails) []string {
+	names := []string{}
+	if validator.ProposalsThisEpoch > 0 {
+		names = append(names, "proposing this epoch")
+	}
+	if validator.InCurrentSyncCommittee {
+		names = append(names, "sync committee")
+	}
+	if validator.InNextSyncCommittee {
+		names = append(names, "next sync committee")
+	}
+	return names
+}
+
+// Highlight a count when it's not zero
+func highlightCount(count int) string {
+	if count == 0 {
+		return "0"
+	}
+	return fmt.Sprintf("[aqua]%d[-]", count)
+}
+
+// Format an amount of wei as ETH
+func formatEth(wei *big.Int) string {
+	if wei == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.4f", eth.WeiToEth(wei))
+}
+
+// Format the time until a moment
+func formatUntil(moment time.Time) string {
+	if moment.IsZero() {
+		return "unknown"
+	}
+	remaining := time.Until(moment)
+	if remaining <= 0 {
+		return "now"
+	}
+	return fmt.Sprintf("in %s", remaining.Round(time.Minute))
+}
+
+// Format an error for a panel
+func errorText(err error) string {
+	return fmt.Sprintf("[red]%s[-]\n", tview.Escape(err.Error()))
+}
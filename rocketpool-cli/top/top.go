@@ -0,0 +1,123 @@
+package top
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rivo/tview"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of refreshes between updates of the data that is slow to collect and changes rarely
+const slowRefreshCycles int = 5
+
+// Everything the dashboard shows, as last received from the daemon's API
+type snapshot struct {
+	updated time.Time
+
+	// Refreshed every cycle
+	clients        api.ClientStatusResponse
+	clientsErr     error
+	duties         api.NodeDutiesResponse
+	dutiesErr      error
+	pendingTxs     api.NodePendingTxsResponse
+	pendingTxsErr  error
+	deferredTxs    api.NodeDeferredTxsResponse
+	deferredTxsErr error
+
+	// Refreshed every few cycles
+	slowUpdated  time.Time
+	node         api.NodeStatusResponse
+	nodeErr      error
+	minipools    api.MinipoolStatusResponse
+	minipoolsErr error
+	rewards      api.NodeRewardsResponse
+	rewardsErr   error
+	network      api.NetworkStatsResponse
+	networkErr   error
+}
+
+func runTop(c *cli.Context) error {
+
+	interval := time.Duration(c.Uint("interval")) * time.Second
+	if interval == 0 {
+		return fmt.Errorf("the refresh interval must be at least 1 second")
+	}
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Start the dashboard
+	app := tview.NewApplication()
+	refresh := make(chan struct{}, 1)
+	done := make(chan struct{})
+	dashboard := newDashboard(app, refresh)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		state := &snapshot{}
+		for cycle := 0; ; cycle++ {
+			app.QueueUpdateDraw(func() {
+				dashboard.setRefreshing()
+			})
+			updateSnapshot(rp, state, cycle%slowRefreshCycles == 0)
+			latest := *state
+			app.QueueUpdateDraw(func() {
+				dashboard.render(&latest, interval)
+			})
+
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			case <-refresh:
+				// A manual refresh gets everything
+				cycle = -1
+			}
+		}
+	}()
+
+	err := app.Run()
+	close(done)
+	return err
+
+}
+
+// Update the snapshot from the daemon's API; the calls are made one at a time because the client isn't safe for concurrent use
+func updateSnapshot(rp *rocketpool.Client, state *snapshot, includeSlow bool) {
+
+	// Pick the clients the API should use, the same way the other commands do but without printing anything
+	state.clients, state.clientsErr = rp.GetClientStatus()
+	if state.clientsErr == nil {
+		ec := state.clients.EcManagerStatus
+		bc := state.clients.BcManagerStatus
+		switch {
+		case ec.PrimaryClientStatus.IsSynced && bc.PrimaryClientStatus.IsSynced:
+			rp.SetClientStatusFlags(true, false)
+		case ec.FallbackEnabled && bc.FallbackEnabled && ec.FallbackClientStatus.IsSynced && bc.FallbackClientStatus.IsSynced:
+			rp.SetClientStatusFlags(true, true)
+		default:
+			rp.SetClientStatusFlags(false, false)
+		}
+	}
+
+	state.duties, state.dutiesErr = rp.NodeDuties()
+	state.pendingTxs, state.pendingTxsErr = rp.NodePendingTxs()
+	state.deferredTxs, state.deferredTxsErr = rp.NodeDeferredTxs()
+	state.updated = time.Now()
+	if !includeSlow {
+		return
+	}
+
+	state.node, state.nodeErr = rp.NodeStatus()
+	state.minipools, state.minipoolsErr = rp.MinipoolStatus()
+	state.rewards, state.rewardsErr = rp.NodeRewards()
+	state.network, state.networkErr = rp.NetworkStats()
+	state.slowUpdated = time.Now()
+
+}
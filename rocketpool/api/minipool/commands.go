@@ -34,6 +34,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "scrub-check",
+				Usage:     "Run the Oracle DAO's scrub checks against the node's prelaunch minipools",
+				UsageText: "rocketpool api minipool scrub-check",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getScrubCheck(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-stake",
 				Usage:     "Check whether the minipool is ready to be staked, moving from prelaunch to staking status",
@@ -795,6 +813,32 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "can-set-fee-recipient-override",
+				Usage:     "Check whether a minipool's validator can use a custom fee recipient without being penalized",
+				UsageText: "rocketpool api minipool can-set-fee-recipient-override minipool-address fee-recipient",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					feeRecipient, err := cliutils.ValidateAddress("fee recipient", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(canSetFeeRecipientOverride(c, minipoolAddress, feeRecipient))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,73 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+func canSetFeeRecipientOverride(c *cli.Context, minipoolAddress common.Address, feeRecipient common.Address) (*api.CanSetFeeRecipientOverrideResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CanSetFeeRecipientOverrideResponse{}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Check the fee recipient against the node's smoothing pool status
+	info, err := rputils.GetFeeRecipientInfoWithoutState(rp, bc, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee recipient info: %w", err)
+	}
+	response.SmoothingPoolAddress = info.SmoothingPoolAddress
+	response.FeeDistributorAddress = info.FeeDistributorAddress
+	response.RethAddress = cfg.Smartnode.GetRethAddress()
+	response.IsInSmoothingPool = info.IsInSmoothingPool
+	response.IsInOptOutCooldown = info.IsInOptOutCooldown
+	response.WouldBePenalized = !rputils.IsFeeRecipientAllowed(info, response.RethAddress, feeRecipient)
+	response.CanSet = !response.WouldBePenalized
+
+	// Return response
+	return &response, nil
+
+}
@@ -216,6 +216,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "gas-estimate",
+				Usage:     "Get suggested max fees from the fee history of the Execution Client.",
+				UsageText: "rocketpool api network gas-estimate",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getGasEstimate(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
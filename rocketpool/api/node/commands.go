@@ -34,6 +34,108 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "proposals",
+				Usage:     "Get the blocks proposed by the node's validators and how much they were worth",
+				UsageText: "rocketpool api node proposals limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getProposals(c, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "deferred-txs",
+				Usage:     "Get the automatic transactions the node daemon is waiting for cheaper gas to submit",
+				UsageText: "rocketpool api node deferred-txs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDeferredTxs(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "duties",
+				Usage:     "Get the upcoming block proposals and sync committee duties of the node's validators",
+				UsageText: "rocketpool api node duties",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDuties(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "pending-txs",
+				Usage:     "Get the number of the node's transactions waiting in the mempool",
+				UsageText: "rocketpool api node pending-txs",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getPendingTxs(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "export-ledger",
+				Usage:     "Get the node's rewards claims, distributions, RPL staking and gas between two times (unix seconds, 0 for the node's registration and now)",
+				UsageText: "rocketpool api node export-ledger from-time to-time",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					fromTime, err := cliutils.ValidateUint("from time", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					toTime, err := cliutils.ValidateUint("to time", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportLedger(c, fromTime, toTime))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "sync",
 				Aliases:   []string{"y"},
@@ -0,0 +1,39 @@
+package node
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getDeferredTxs(c *cli.Context) (*api.NodeDeferredTxsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeDeferredTxsResponse{
+		Actions: []*deferred.Action{},
+	}
+
+	// Load the schedule the node daemon has saved
+	schedule, err := deferred.LoadSchedule(cfg.Smartnode.GetDeferredTxSchedulePath())
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return &response, nil
+	}
+	response.HasSchedule = true
+	response.UpdatedTime = schedule.UpdatedTime
+	response.Actions = schedule.Actions
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,128 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getDuties(c *cli.Context) (*api.NodeDutiesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeDutiesResponse{
+		Validators: []api.ValidatorDutyDetails{},
+	}
+
+	// Get the chain state
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting beacon config: %w", err)
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting beacon head: %w", err)
+	}
+	response.Epoch = head.Epoch
+	response.SecondsPerEpoch = eth2Config.SecondsPerEpoch
+	if eth2Config.EpochsPerSyncCommitteePeriod > 0 {
+		response.NextSyncCommitteeEpoch = (head.Epoch/eth2Config.EpochsPerSyncCommitteePeriod + 1) * eth2Config.EpochsPerSyncCommitteePeriod
+	}
+
+	// Get the node's validators
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	indices := []string{}
+	validators := map[string]*api.ValidatorDutyDetails{}
+	for pubkey, status := range statuses {
+		if status.Exists && status.Status != beacon.ValidatorState_PendingInitialized && status.Status != beacon.ValidatorState_PendingQueued {
+			indices = append(indices, status.Index)
+			validators[status.Index] = &api.ValidatorDutyDetails{
+				Pubkey: pubkey,
+				Index:  status.Index,
+			}
+		}
+	}
+	response.ValidatorCount = len(indices)
+	if len(indices) == 0 {
+		return &response, nil
+	}
+
+	// Get the duties; proposer duties can only be known for the current epoch, and sync committees for the current and next periods
+	var currentSyncDuties, nextSyncDuties map[string]bool
+	var proposerDuties map[string]uint64
+	var wg errgroup.Group
+	wg.Go(func() error {
+		var err error
+		proposerDuties, err = bc.GetValidatorProposerDuties(indices, head.Epoch)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		currentSyncDuties, err = bc.GetValidatorSyncDuties(indices, head.Epoch)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		nextSyncDuties, err = bc.GetValidatorSyncDuties(indices, response.NextSyncCommitteeEpoch)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Add the validators that have a duty
+	for _, index := range indices {
+		validator := validators[index]
+		validator.ProposalsThisEpoch = proposerDuties[index]
+		validator.InCurrentSyncCommittee = currentSyncDuties[index]
+		validator.InNextSyncCommittee = nextSyncDuties[index]
+
+		response.ProposalsThisEpoch += validator.ProposalsThisEpoch
+		if validator.InCurrentSyncCommittee {
+			response.CurrentSyncCommitteeCount++
+		}
+		if validator.InNextSyncCommittee {
+			response.NextSyncCommitteeCount++
+		}
+		if validator.ProposalsThisEpoch > 0 || validator.InCurrentSyncCommittee || validator.InNextSyncCommittee {
+			response.Validators = append(response.Validators, *validator)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,766 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	v110_network "github.com/rocket-pool/rocketpool-go/legacy/v1.1.0/network"
+	v120_network "github.com/rocket-pool/rocketpool-go/legacy/v1.2.0/network"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/storage"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+)
+
+// The assets a ledger entry can be in
+const (
+	ledgerAsset_Eth string = "ETH"
+	ledgerAsset_Rpl string = "RPL"
+)
+
+// A contract event that moves the node's ETH or RPL
+type ledgerEvent struct {
+	entryType    api.LedgerEntryType
+	contractName string
+	eventName    string
+	amountArg    string
+	asset        string
+	inflow       bool
+}
+
+// The events that make up the ledger besides rewards claims; argument names are compared in lower case without leading underscores
+var ledgerEvents = []ledgerEvent{
+	{api.LedgerEntry_MinipoolDistribution, "rocketMinipool", "EtherWithdrawalProcessed", "nodeamount", ledgerAsset_Eth, true},
+	{api.LedgerEntry_MinipoolRefund, "rocketMinipool", "EtherWithdrawn", "amount", ledgerAsset_Eth, true},
+	{api.LedgerEntry_FeeDistribution, "rocketNodeDistributorDelegate", "FeesDistributed", "nodeamount", ledgerAsset_Eth, true},
+	{api.LedgerEntry_RplStake, "rocketNodeStaking", "RPLStaked", "amount", ledgerAsset_Rpl, false},
+	{api.LedgerEntry_RplWithdrawal, "rocketNodeStaking", "RPLWithdrawn", "amount", ledgerAsset_Rpl, true},
+	{api.LedgerEntry_RplSlash, "rocketNodeStaking", "RPLSlashed", "amount", ledgerAsset_Rpl, false},
+}
+
+// An event from the ledger with its ABI
+type loadedLedgerEvent struct {
+	ledgerEvent
+	abiEvent abi.Event
+}
+
+// A ledger entry with its position in the chain, for sorting
+type ledgerItem struct {
+	entry    api.LedgerEntry
+	txIndex  uint
+	logIndex uint
+}
+
+// The calls used to find the blocks of the node's transactions
+type ledgerBlockClient interface {
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+}
+
+// The timestamp and RPL price of a block
+type ledgerBlock struct {
+	time     time.Time
+	rplPrice *big.Int
+}
+
+func exportLedger(c *cli.Context, fromTime uint64, toTime uint64) (*api.NodeExportLedgerResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeExportLedgerResponse{
+		Entries:  []api.LedgerEntry{},
+		Warnings: []string{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	nodeAddress := nodeAccount.Address
+	response.NodeAddress = nodeAddress
+
+	// Get the block range; it starts when the node registered unless a start time was provided
+	response.FromTime = time.Unix(int64(fromTime), 0)
+	if fromTime == 0 {
+		response.FromTime, err = node.GetNodeRegistrationTime(rp, nodeAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting node registration time: %w", err)
+		}
+	}
+	fromHeader, err := rprewards.GetELBlockHeaderForTime(response.FromTime, rp)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the block for %s: %w", response.FromTime, err)
+	}
+	response.FromBlock = fromHeader.Number.Uint64()
+	if fromHeader.Time < uint64(response.FromTime.Unix()) {
+		response.FromBlock++
+	}
+	var toHeader *types.Header
+	if toTime == 0 {
+		toHeader, err = rp.Client.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting latest block header: %w", err)
+		}
+		response.ToTime = time.Unix(int64(toHeader.Time), 0)
+	} else {
+		response.ToTime = time.Unix(int64(toTime), 0)
+		toHeader, err = rprewards.GetELBlockHeaderForTime(response.ToTime, rp)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the block for %s: %w", response.ToTime, err)
+		}
+	}
+	response.ToBlock = toHeader.Number.Uint64()
+	if response.FromBlock > response.ToBlock {
+		return nil, fmt.Errorf("there are no blocks between %s and %s", response.FromTime, response.ToTime)
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+	fromBlock := big.NewInt(0).SetUint64(response.FromBlock)
+	toBlock := big.NewInt(0).SetUint64(response.ToBlock)
+
+	// Get the contracts whose events are trusted
+	trusted, err := getLedgerContracts(rp, cfg, nodeAddress, intervalSize, fromBlock)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the events
+	events := map[common.Hash][]loadedLedgerEvent{}
+	for _, event := range ledgerEvents {
+		abiEvent, err := getLedgerAbiEvent(rp, event.contractName, event.eventName)
+		if err != nil {
+			response.Warnings = append(response.Warnings, fmt.Sprintf("%s, so %s entries are missing.", err.Error(), event.entryType))
+			continue
+		}
+		events[abiEvent.ID] = append(events[abiEvent.ID], loadedLedgerEvent{
+			ledgerEvent: event,
+			abiEvent:    abiEvent,
+		})
+	}
+	var claimEvent *abi.Event
+	if abiEvent, err := getLedgerAbiEvent(rp, "rocketMerkleDistributorMainnet", "RewardsClaimed"); err != nil {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("%s, so rewards claims are missing.", err.Error()))
+	} else {
+		claimEvent = &abiEvent
+	}
+
+	// Get the logs of the node's minipools and fee distributor, and the node's logs from the other contracts
+	poolAddresses := []common.Address{}
+	for _, contractName := range []string{"rocketMinipool", "rocketNodeDistributorDelegate"} {
+		for address := range trusted[contractName] {
+			poolAddresses = append(poolAddresses, address)
+		}
+	}
+	logs := []types.Log{}
+	if len(poolAddresses) > 0 {
+		poolLogs, err := eth.GetLogs(rp, poolAddresses, nil, intervalSize, fromBlock, toBlock, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting minipool and fee distributor logs: %w", err)
+		}
+		logs = append(logs, poolLogs...)
+	}
+	nodeContractAddresses := []common.Address{}
+	for _, contractName := range []string{"rocketNodeStaking", "rocketMerkleDistributorMainnet", "rocketNodeManager", "rocketTokenRPL"} {
+		for address := range trusted[contractName] {
+			nodeContractAddresses = append(nodeContractAddresses, address)
+		}
+	}
+	nodeLogs, err := eth.GetLogs(rp, nodeContractAddresses, [][]common.Hash{{}, {common.BytesToHash(nodeAddress.Bytes())}}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node logs: %w", err)
+	}
+	logs = append(logs, nodeLogs...)
+
+	// Stakes made for the node by its withdrawal addresses are logged under the sender rather than the node
+	stakeLogs, err := getLedgerStakesForNode(rp, nodeAddress, trusted["rocketNodeStaking"], intervalSize, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	logs = append(logs, stakeLogs...)
+
+	// Get the node's claimed rewards intervals
+	_, claimedIntervals, err := rprewards.GetClaimStatus(rp, nodeAddress)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+	claimed := map[uint64]bool{}
+	for _, interval := range claimedIntervals {
+		claimed[interval] = true
+	}
+
+	// Build the entries
+	items := []ledgerItem{}
+	logTxs := map[common.Hash]bool{}
+	for _, entry := range logs {
+		if entry.Removed || len(entry.Topics) == 0 {
+			continue
+		}
+		logTxs[entry.TxHash] = true
+
+		// Handle rewards claims
+		if claimEvent != nil && entry.Topics[0] == claimEvent.ID && trusted["rocketMerkleDistributorMainnet"][entry.Address] {
+			claimItems, warnings, err := getLedgerClaimItems(rp, cfg, nodeAddress, *claimEvent, entry, claimed)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, claimItems...)
+			response.Warnings = append(response.Warnings, warnings...)
+			continue
+		}
+
+		// Handle the other events
+		for _, event := range events[entry.Topics[0]] {
+			if !trusted[event.contractName][entry.Address] {
+				continue
+			}
+			values, err := unpackLedgerLog(event.abiEvent, entry)
+			if err != nil {
+				return nil, fmt.Errorf("error decoding %s event in transaction %s: %w", event.eventName, entry.TxHash.Hex(), err)
+			}
+			amount, ok := values[event.amountArg].(*big.Int)
+			if !ok {
+				return nil, fmt.Errorf("%s event in transaction %s doesn't have an amount", event.eventName, entry.TxHash.Hex())
+			}
+			if amount.Sign() == 0 {
+				break
+			}
+			if !event.inflow {
+				amount = big.NewInt(0).Neg(amount)
+			}
+			items = append(items, ledgerItem{
+				entry: api.LedgerEntry{
+					Type:     event.entryType,
+					Block:    entry.BlockNumber,
+					TxHash:   entry.TxHash,
+					Contract: entry.Address,
+					Asset:    event.asset,
+					Amount:   amount,
+				},
+				txIndex:  entry.TxIndex,
+				logIndex: entry.Index,
+			})
+			break
+		}
+	}
+
+	// Get a client with the state of the range, which is the Archive EC if the primary EC doesn't have it
+	startBlock := big.NewInt(0).Sub(fromBlock, big.NewInt(1))
+	if startBlock.Sign() < 0 {
+		startBlock.SetUint64(0)
+	}
+	historicalRp, err := eth1.GetBestApiClient(rp, cfg, func(string) {}, startBlock)
+
+	// Get the transactions the node sent; if they can't be found, fall back to the ones behind the other entries and the node's minipool deposits
+	var sentTxs []*types.Transaction
+	if err == nil {
+		sentTxs, err = getLedgerSentTxs(historicalRp, nodeAddress, startBlock, fromBlock, toBlock)
+	} else {
+		// The prices are still attempted with the primary EC
+		historicalRp = rp
+	}
+	if err != nil {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("The node's transactions couldn't be found (%s); this requires an Execution client with the archived state of the range. Gas and deposits are only included for the node's transactions that produced another ledger entry or created a minipool, so transactions such as votes, settings changes, credit deposits and failed transactions are missing.", err.Error()))
+		sentTxs, err = getLedgerFallbackTxs(rp, nodeAddress, trusted["rocketMinipoolManager"], logTxs, intervalSize, fromBlock, toBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Add the ETH the node deposited for its minipools and credit, and the gas of each transaction it sent
+	for _, tx := range sentTxs {
+		receipt, err := rp.Client.TransactionReceipt(context.Background(), tx.Hash())
+		if err != nil {
+			return nil, fmt.Errorf("error getting receipt for transaction %s: %w", tx.Hash().Hex(), err)
+		}
+		contract := common.Address{}
+		if tx.To() != nil {
+			contract = *tx.To()
+		}
+		if tx.Value().Sign() > 0 && trusted["rocketNodeDeposit"][contract] && receipt.Status == types.ReceiptStatusSuccessful {
+			items = append(items, ledgerItem{
+				entry: api.LedgerEntry{
+					Type:     api.LedgerEntry_NodeDeposit,
+					Block:    receipt.BlockNumber.Uint64(),
+					TxHash:   tx.Hash(),
+					Contract: contract,
+					Asset:    ledgerAsset_Eth,
+					Amount:   big.NewInt(0).Neg(tx.Value()),
+				},
+				txIndex:  receipt.TransactionIndex,
+				logIndex: 0,
+			})
+		}
+		gasPrice := receipt.EffectiveGasPrice
+		if gasPrice == nil {
+			gasPrice = tx.GasPrice()
+		}
+		gasCost := big.NewInt(0).SetUint64(receipt.GasUsed)
+		gasCost.Mul(gasCost, gasPrice)
+		gasCost.Neg(gasCost)
+		items = append(items, ledgerItem{
+			entry: api.LedgerEntry{
+				Type:     api.LedgerEntry_Gas,
+				Block:    receipt.BlockNumber.Uint64(),
+				TxHash:   tx.Hash(),
+				Contract: contract,
+				Asset:    ledgerAsset_Eth,
+				Amount:   gasCost,
+			},
+			txIndex:  receipt.TransactionIndex,
+			logIndex: ^uint(0),
+		})
+	}
+
+	// Sort the entries in chain order, with each transaction's gas after its other entries
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].entry.Block != items[j].entry.Block {
+			return items[i].entry.Block < items[j].entry.Block
+		}
+		if items[i].txIndex != items[j].txIndex {
+			return items[i].txIndex < items[j].txIndex
+		}
+		return items[i].logIndex < items[j].logIndex
+	})
+
+	// Add the time and RPL price of each entry's block
+	blocks := map[uint64]*ledgerBlock{}
+	missingPrices := 0
+	for _, item := range items {
+		block, exists := blocks[item.entry.Block]
+		if !exists {
+			header, err := rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(item.entry.Block))
+			if err != nil {
+				return nil, fmt.Errorf("error getting header for block %d: %w", item.entry.Block, err)
+			}
+			block = &ledgerBlock{
+				time:     time.Unix(int64(header.Time), 0),
+				rplPrice: getLedgerRplPrice(historicalRp, cfg, header.Number),
+			}
+			if block.rplPrice == nil {
+				missingPrices++
+			}
+			blocks[item.entry.Block] = block
+		}
+		item.entry.Time = block.time
+		item.entry.RplPrice = block.rplPrice
+		response.Entries = append(response.Entries, item.entry)
+	}
+	if missingPrices > 0 {
+		response.Warnings = append(response.Warnings, fmt.Sprintf("The RPL price couldn't be retrieved for %d blocks; this requires an Execution client (or an Archive EC) with the archived state of those blocks.", missingPrices))
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the addresses of the contracts that can emit events for the ledger
+func getLedgerContracts(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, intervalSize *big.Int, fromBlock *big.Int) (map[string]map[common.Address]bool, error) {
+	trusted := map[string]map[common.Address]bool{}
+	add := func(contractName string, address common.Address) {
+		if address == (common.Address{}) {
+			return
+		}
+		if trusted[contractName] == nil {
+			trusted[contractName] = map[common.Address]bool{}
+		}
+		trusted[contractName][address] = true
+	}
+
+	// Network contracts, including the upgraded versions the config knows about
+	for _, contractName := range []string{"rocketNodeStaking", "rocketMerkleDistributorMainnet", "rocketNodeManager", "rocketTokenRPL", "rocketMinipoolManager", "rocketNodeDeposit"} {
+		address, err := rp.GetAddress(contractName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting %s address: %w", contractName, err)
+		}
+		add(contractName, *address)
+	}
+	add("rocketNodeStaking", cfg.Smartnode.GetV110NodeStakingAddress())
+	add("rocketMinipoolManager", cfg.Smartnode.GetV100MinipoolManagerAddress())
+	add("rocketNodeDeposit", cfg.Smartnode.GetV110NodeDepositAddress())
+
+	// The node's current minipools
+	minipoolAddresses, err := minipool.GetNodeMinipoolAddresses(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node minipool addresses: %w", err)
+	}
+	for _, address := range minipoolAddresses {
+		add("rocketMinipool", address)
+	}
+
+	// Minipools that were closed since the start of the range are no longer listed, so get them from their destruction events
+	destroyedEvent, err := getLedgerAbiEvent(rp, "rocketMinipoolManager", "MinipoolDestroyed")
+	if err != nil {
+		return nil, err
+	}
+	managerAddresses := []common.Address{}
+	for address := range trusted["rocketMinipoolManager"] {
+		managerAddresses = append(managerAddresses, address)
+	}
+	destroyedLogs, err := eth.GetLogs(rp, managerAddresses, [][]common.Hash{{destroyedEvent.ID}, {}, {common.BytesToHash(nodeAddress.Bytes())}}, intervalSize, fromBlock, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting closed minipools: %w", err)
+	}
+	for _, entry := range destroyedLogs {
+		if !entry.Removed && len(entry.Topics) > 1 {
+			add("rocketMinipool", common.BytesToAddress(entry.Topics[1].Bytes()))
+		}
+	}
+
+	// The fee distributor, if it's been initialized
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor address: %w", err)
+	}
+	code, err := rp.Client.CodeAt(context.Background(), distributorAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee distributor code: %w", err)
+	}
+	if len(code) > 0 {
+		add("rocketNodeDistributorDelegate", distributorAddress)
+	}
+
+	return trusted, nil
+}
+
+// Get the RPLStaked logs of the stakes the node's withdrawal addresses made for it with stakeRPLFor
+func getLedgerStakesForNode(rp *rocketpool.RocketPool, nodeAddress common.Address, stakingAddresses map[common.Address]bool, intervalSize *big.Int, fromBlock *big.Int, toBlock *big.Int) ([]types.Log, error) {
+	stakedEvent, err := getLedgerAbiEvent(rp, "rocketNodeStaking", "RPLStaked")
+	if err != nil {
+		// The missing stake entries are already reported
+		return []types.Log{}, nil
+	}
+	stakingAbi, err := rp.GetABI("rocketNodeStaking", nil)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't load the ABI for rocketNodeStaking: %w", err)
+	}
+	stakeForMethod, exists := stakingAbi.Methods["stakeRPLFor"]
+	if !exists {
+		return []types.Log{}, nil
+	}
+
+	// Get the withdrawal addresses that aren't the node itself
+	primaryWithdrawalAddress, err := storage.GetNodeWithdrawalAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node withdrawal address: %w", err)
+	}
+	rplWithdrawalAddress, err := node.GetNodeRPLWithdrawalAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node RPL withdrawal address: %w", err)
+	}
+	senders := []common.Hash{}
+	for _, address := range []common.Address{primaryWithdrawalAddress, rplWithdrawalAddress} {
+		sender := common.BytesToHash(address.Bytes())
+		if address == nodeAddress || address == (common.Address{}) || (len(senders) > 0 && senders[0] == sender) {
+			continue
+		}
+		senders = append(senders, sender)
+	}
+	if len(senders) == 0 || len(stakingAddresses) == 0 {
+		return []types.Log{}, nil
+	}
+	addresses := []common.Address{}
+	for address := range stakingAddresses {
+		addresses = append(addresses, address)
+	}
+	stakeLogs, err := eth.GetLogs(rp, addresses, [][]common.Hash{{stakedEvent.ID}, senders}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting withdrawal address RPL stakes: %w", err)
+	}
+
+	// Keep the stakes whose transaction staked for this node; the withdrawal addresses may stake for other nodes too
+	logs := []types.Log{}
+	for _, entry := range stakeLogs {
+		if entry.Removed {
+			continue
+		}
+		tx, _, err := rp.Client.TransactionByHash(context.Background(), entry.TxHash)
+		if err != nil {
+			return nil, fmt.Errorf("error getting transaction %s: %w", entry.TxHash.Hex(), err)
+		}
+		data := tx.Data()
+		if tx.To() == nil || !stakingAddresses[*tx.To()] || len(data) < 4 || !bytes.Equal(data[:4], stakeForMethod.ID) {
+			continue
+		}
+		args, err := stakeForMethod.Inputs.Unpack(data[4:])
+		if err != nil {
+			return nil, fmt.Errorf("error decoding stakeRPLFor call in transaction %s: %w", entry.TxHash.Hex(), err)
+		}
+		if stakedFor, ok := args[0].(common.Address); ok && stakedFor == nodeAddress {
+			logs = append(logs, entry)
+		}
+	}
+	return logs, nil
+}
+
+// Get the transactions the node sent in the block range.
+// Each one is found by bisecting the node's nonce over the range, which needs the state of every block in it (starting with the one before the range), so rp must have it.
+func getLedgerSentTxs(rp *rocketpool.RocketPool, nodeAddress common.Address, startBlock *big.Int, fromBlock *big.Int, toBlock *big.Int) ([]*types.Transaction, error) {
+	client, ok := rp.Client.(ledgerBlockClient)
+	if !ok {
+		return nil, fmt.Errorf("the Execution client can't get blocks")
+	}
+	nonceAt := func(blockNumber uint64) (uint64, error) {
+		nonce, err := client.NonceAt(context.Background(), nodeAddress, big.NewInt(0).SetUint64(blockNumber))
+		if err != nil {
+			return 0, fmt.Errorf("error getting the node's nonce at block %d: %w", blockNumber, err)
+		}
+		return nonce, nil
+	}
+
+	// Get the node's nonces at the edges of the range
+	startNonce, err := nonceAt(startBlock.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	endNonce, err := nonceAt(toBlock.Uint64())
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the block of each nonce in turn; a block can hold several of them
+	txs := []*types.Transaction{}
+	nonce := startNonce
+	low := fromBlock.Uint64()
+	for nonce < endNonce {
+		high := toBlock.Uint64()
+		for low < high {
+			mid := low + (high-low)/2
+			midNonce, err := nonceAt(mid)
+			if err != nil {
+				return nil, err
+			}
+			if midNonce > nonce {
+				high = mid
+			} else {
+				low = mid + 1
+			}
+		}
+		blockNonce, err := nonceAt(low)
+		if err != nil {
+			return nil, err
+		}
+		block, err := client.BlockByNumber(context.Background(), big.NewInt(0).SetUint64(low))
+		if err != nil {
+			return nil, fmt.Errorf("error getting block %d: %w", low, err)
+		}
+		for _, tx := range block.Transactions() {
+			sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+			if err == nil && sender == nodeAddress {
+				txs = append(txs, tx)
+			}
+		}
+		nonce = blockNonce
+		low++
+	}
+	return txs, nil
+}
+
+// Get the transactions the node sent out of the ones behind the ledger's logs and the node's minipool deposits, for when the node's transactions can't be found from its nonces
+func getLedgerFallbackTxs(rp *rocketpool.RocketPool, nodeAddress common.Address, managerAddresses map[common.Address]bool, logTxs map[common.Hash]bool, intervalSize *big.Int, fromBlock *big.Int, toBlock *big.Int) ([]*types.Transaction, error) {
+	txHashes := map[common.Hash]bool{}
+	for txHash := range logTxs {
+		txHashes[txHash] = true
+	}
+
+	// Add the deposits that created the node's minipools
+	createdEvent, err := getLedgerAbiEvent(rp, "rocketMinipoolManager", "MinipoolCreated")
+	if err != nil {
+		return nil, err
+	}
+	addresses := []common.Address{}
+	for address := range managerAddresses {
+		addresses = append(addresses, address)
+	}
+	createdLogs, err := eth.GetLogs(rp, addresses, [][]common.Hash{{createdEvent.ID}, {}, {common.BytesToHash(nodeAddress.Bytes())}}, intervalSize, fromBlock, toBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting created minipools: %w", err)
+	}
+	for _, entry := range createdLogs {
+		if !entry.Removed {
+			txHashes[entry.TxHash] = true
+		}
+	}
+
+	// Keep the ones the node sent
+	txs := []*types.Transaction{}
+	for txHash := range txHashes {
+		tx, _, err := rp.Client.TransactionByHash(context.Background(), txHash)
+		if err != nil {
+			return nil, fmt.Errorf("error getting transaction %s: %w", txHash.Hex(), err)
+		}
+		sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the sender of transaction %s: %w", txHash.Hex(), err)
+		}
+		if sender == nodeAddress {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+// Get an event from a contract's ABI
+func getLedgerAbiEvent(rp *rocketpool.RocketPool, contractName string, eventName string) (abi.Event, error) {
+	contractAbi, err := rp.GetABI(contractName, nil)
+	if err != nil {
+		return abi.Event{}, fmt.Errorf("couldn't load the ABI for %s: %w", contractName, err)
+	}
+	event, exists := contractAbi.Events[eventName]
+	if !exists {
+		return abi.Event{}, fmt.Errorf("%s doesn't have a %s event", contractName, eventName)
+	}
+	return event, nil
+}
+
+// Get the entries for the rewards intervals claimed by a RewardsClaimed event, preferring the amounts in the rewards files
+func getLedgerClaimItems(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, event abi.Event, entry types.Log, claimed map[uint64]bool) ([]ledgerItem, []string, error) {
+	values, err := unpackLedgerLog(event, entry)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error decoding rewards claim in transaction %s: %w", entry.TxHash.Hex(), err)
+	}
+	indices, _ := values["rewardindex"].([]*big.Int)
+	amountsRpl, _ := values["amountrpl"].([]*big.Int)
+	amountsEth, _ := values["amounteth"].([]*big.Int)
+	if len(amountsRpl) != len(indices) || len(amountsEth) != len(indices) {
+		return nil, nil, fmt.Errorf("rewards claim in transaction %s doesn't have an amount for each interval", entry.TxHash.Hex())
+	}
+
+	items := []ledgerItem{}
+	warnings := []string{}
+	for i, index := range indices {
+		interval := index.Uint64()
+		if !claimed[interval] {
+			warnings = append(warnings, fmt.Sprintf("Interval %d was claimed in transaction %s but isn't marked as claimed.", interval, entry.TxHash.Hex()))
+		}
+
+		// Get the amounts from the rewards file, or from the event if it isn't available
+		amountRpl := amountsRpl[i]
+		amountEth := amountsEth[i]
+		intervalInfo, err := rprewards.GetIntervalInfo(rp, cfg, nodeAddress, interval, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting info for interval %d: %w", interval, err)
+		}
+		if intervalInfo.TreeFileExists && intervalInfo.MerkleRootValid && intervalInfo.NodeExists {
+			amountRpl = big.NewInt(0).Add(&intervalInfo.CollateralRplAmount.Int, &intervalInfo.ODaoRplAmount.Int)
+			amountEth = &intervalInfo.SmoothingPoolEthAmount.Int
+		} else {
+			warnings = append(warnings, fmt.Sprintf("The rewards file for interval %d isn't available, so its amounts come from the claim transaction.", interval))
+		}
+
+		for _, amount := range []struct {
+			entryType api.LedgerEntryType
+			asset     string
+			value     *big.Int
+		}{
+			{api.LedgerEntry_RplRewards, ledgerAsset_Rpl, amountRpl},
+			{api.LedgerEntry_SmoothingPoolRewards, ledgerAsset_Eth, amountEth},
+		} {
+			if amount.value.Sign() == 0 {
+				continue
+			}
+			items = append(items, ledgerItem{
+				entry: api.LedgerEntry{
+					Type:     amount.entryType,
+					Block:    entry.BlockNumber,
+					TxHash:   entry.TxHash,
+					Contract: entry.Address,
+					Interval: &interval,
+					Asset:    amount.asset,
+					Amount:   big.NewInt(0).Set(amount.value),
+				},
+				txIndex:  entry.TxIndex,
+				logIndex: entry.Index,
+			})
+		}
+	}
+	return items, warnings, nil
+}
+
+// Decode the arguments of a log by name, in lower case without leading underscores
+func unpackLedgerLog(event abi.Event, entry types.Log) (map[string]interface{}, error) {
+	raw := map[string]interface{}{}
+	if err := event.Inputs.UnpackIntoMap(raw, entry.Data); err != nil {
+		return nil, err
+	}
+	indexed := abi.Arguments{}
+	for _, input := range event.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if len(entry.Topics) != len(indexed)+1 {
+		return nil, fmt.Errorf("expected %d topics but the log has %d", len(indexed)+1, len(entry.Topics))
+	}
+	if err := abi.ParseTopicsIntoMap(raw, indexed, entry.Topics[1:]); err != nil {
+		return nil, err
+	}
+
+	values := map[string]interface{}{}
+	for name, value := range raw {
+		values[strings.ToLower(strings.TrimLeft(name, "_"))] = value
+	}
+	return values, nil
+}
+
+// Get the RPL price at a block from whichever network prices contract was active then, or nil if it isn't available
+func getLedgerRplPrice(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, blockNumber *big.Int) *big.Int {
+	opts := &bind.CallOpts{
+		BlockNumber: blockNumber,
+	}
+	price, err := network.GetRPLPrice(rp, opts)
+	if err == nil && price.Sign() > 0 {
+		return price
+	}
+	if address := cfg.Smartnode.GetV120NetworkPricesAddress(); address != (common.Address{}) {
+		price, err = v120_network.GetRPLPrice(rp, opts, &address)
+		if err == nil && price.Sign() > 0 {
+			return price
+		}
+	}
+	if address := cfg.Smartnode.GetV110NetworkPricesAddress(); address != (common.Address{}) {
+		price, err = v110_network.GetRPLPrice(rp, opts, &address)
+		if err == nil && price.Sign() > 0 {
+			return price
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package node
+
+import (
+	"math/big"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/blocks"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getProposals(c *cli.Context, limit uint64) (*api.NodeProposalsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeProposalsResponse{
+		QueryRelayData: cfg.MevBoost.QueryRelayData.Value == true,
+	}
+
+	// Load the history recorded by the node daemon
+	history, err := blocks.LoadProposalHistory(cfg.Smartnode.GetProposalHistoryPath())
+	if err != nil {
+		return nil, err
+	}
+	count := len(history.Proposals)
+	if limit > 0 && limit < uint64(count) {
+		count = int(limit)
+	}
+	response.Proposals = history.GetRecentProposals(count)
+	response.ProposalCount = len(history.Proposals)
+	response.Intervals = history.GetIntervalSummaries()
+
+	// Get the total value of every proposal
+	response.TotalPayloadValue = big.NewInt(0)
+	for _, interval := range response.Intervals {
+		response.TotalPayloadValue.Add(response.TotalPayloadValue, interval.TotalPayloadValue)
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -25,11 +25,15 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/blocks"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
+// The number of recent proposals to include in the status
+const recentProposalCount int = 5
+
 func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 
 	// Get services
@@ -302,6 +306,17 @@ func getStatus(c *cli.Context) (*api.NodeStatusResponse, error) {
 		return nil, err
 	}
 
+	// Get the fee recipient audit history
+	history, err := blocks.LoadProposalHistory(cfg.Smartnode.GetProposalHistoryPath())
+	if err != nil {
+		// The history is informational, so don't fail the whole status if it can't be read
+		response.Warning = fmt.Sprintf("Error loading proposal history: %s", err)
+	} else {
+		response.RecentProposals = history.GetRecentProposals(recentProposalCount)
+		response.ProposalCount = len(history.Proposals)
+		response.IncorrectProposalCount = history.GetIncorrectProposalCount()
+	}
+
 	// Get withdrawal address balances
 	if !bytes.Equal(nodeAccount.Address.Bytes(), response.PrimaryWithdrawalAddress.Bytes()) {
 		withdrawalBalances, err := tokens.GetBalances(rp, response.PrimaryWithdrawalAddress, nil)
@@ -53,6 +53,42 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "participation",
+				Usage:     "Get the duty participation history of the oracle DAO members",
+				UsageText: "rocketpool api odao participation",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getParticipation(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "watchtower-status",
+				Usage:     "Get the status of each of the watchtower's tasks",
+				UsageText: "rocketpool api odao watchtower-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getWatchtowerStatus(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "proposals",
 				Aliases:   []string{"p"},
@@ -0,0 +1,98 @@
+package odao
+
+import (
+	"os"
+	"sort"
+
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/participation"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of latest rounds of each duty to count missed submissions in
+const participationRecentWindow int = 10
+
+// The number of latest rounds to return
+const participationRoundLimit int = 20
+
+func getParticipation(c *cli.Context) (*api.TNDAOParticipationResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOParticipationResponse{
+		RecentWindow: participationRecentWindow,
+		Members:      []api.TNDAOMemberParticipation{},
+		RecentRounds: []participation.DutyRound{},
+	}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the history the watchtower has recorded
+	historyPath := cfg.Smartnode.GetParticipationHistoryPath(true)
+	if _, err := os.Stat(historyPath); os.IsNotExist(err) {
+		return &response, nil
+	}
+	history, err := participation.LoadParticipationHistory(historyPath)
+	if err != nil {
+		return nil, err
+	}
+	response.HasHistory = true
+	response.LastScannedBlock = history.LastScannedBlock
+
+	// Get the member IDs
+	members, err := trustednode.GetMembers(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	memberIds := map[string]string{}
+	for _, member := range members {
+		memberIds[member.Address.Hex()] = member.ID
+	}
+
+	// Get the summaries
+	for _, summary := range history.GetMemberSummaries(participationRecentWindow) {
+		response.Members = append(response.Members, api.TNDAOMemberParticipation{
+			Address:     summary.Address,
+			ID:          memberIds[summary.Address.Hex()],
+			IsLocalNode: summary.Address == nodeAccount.Address,
+			Duties:      summary.Duties,
+		})
+	}
+	sort.SliceStable(response.Members, func(i, j int) bool {
+		return response.Members[i].ID < response.Members[j].ID
+	})
+
+	// Get the latest rounds
+	rounds := history.Rounds
+	if len(rounds) > participationRoundLimit {
+		rounds = rounds[len(rounds)-participationRoundLimit:]
+	}
+	response.RecentRounds = append(response.RecentRounds, rounds...)
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,39 @@
+package odao
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getWatchtowerStatus(c *cli.Context) (*api.TNDAOWatchtowerStatusResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAOWatchtowerStatusResponse{
+		Tasks: []scheduler.TaskStatus{},
+	}
+
+	// Load the task status the watchtower has saved
+	status, err := scheduler.LoadStatus(cfg.Smartnode.GetWatchtowerTaskStatusPath(true))
+	if err != nil {
+		return nil, err
+	}
+	if status == nil {
+		return &response, nil
+	}
+	response.HasStatus = true
+	response.UpdatedTime = status.UpdatedTime
+	response.Tasks = status.Tasks
+
+	// Return response
+	return &response, nil
+
+}
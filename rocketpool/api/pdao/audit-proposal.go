@@ -0,0 +1,184 @@
+package pdao
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func auditProposal(c *cli.Context, proposalId uint64) (*api.PDAOProposalAuditResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOProposalAuditResponse{
+		ProposalID:      proposalId,
+		RootSubmissions: []api.PDAORootSubmissionAudit{},
+		Challenges:      []api.PDAOChallengeAudit{},
+	}
+
+	// Check if the proposal exists
+	proposalCount, err := protocol.GetTotalProposalCount(rp, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.DoesExist = (proposalId != 0 && proposalId <= proposalCount)
+	if !response.DoesExist {
+		return &response, nil
+	}
+
+	// Get the proposal
+	prop, err := protocol.GetProposalDetails(rp, proposalId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting details for proposal %d: %w", proposalId, err)
+	}
+	response.State = prop.State
+	response.Proposer = prop.ProposerAddress
+	response.TargetBlock = prop.TargetBlock
+	response.VotingStartTime = prop.VotingStartTime
+
+	// Rebuild the network tree for the target block
+	propMgr, err := proposals.NewProposalManager(nil, cfg, rp, bc)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := propMgr.GetVotingInfoSnapshot(prop.TargetBlock)
+	if err != nil {
+		return nil, err
+	}
+	response.NodeCount = uint64(len(snapshot.Info))
+	networkTree, err := propMgr.GetNetworkTree(prop.TargetBlock, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	response.LocalRoot = *networkTree.Nodes[0]
+	response.ProposedRoot, err = protocol.GetNode(rp, proposalId, 1, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting root node for proposal %d: %w", proposalId, err)
+	}
+
+	// Get the event window; the target block is picked before the proposal is created, so its root submission and every
+	// challenge and response after it come on or after the target block
+	latestBlock, err := ec.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting latest block number: %w", err)
+	}
+	startBlock := big.NewInt(int64(prop.TargetBlock))
+	endBlock := big.NewInt(0).SetUint64(latestBlock)
+	intervalSize := big.NewInt(int64(cfg.Geth.EventLogInterval))
+	verifierAddresses := cfg.Smartnode.GetPreviousRocketDAOProtocolVerifierAddresses()
+
+	// Get the root submissions and challenges
+	rootSubmissionEvents, err := protocol.GetRootSubmittedEvents(rp, []uint64{proposalId}, intervalSize, startBlock, endBlock, verifierAddresses, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning for RootSubmitted events: %w", err)
+	}
+	challengeEvents, err := protocol.GetChallengeSubmittedEvents(rp, []uint64{proposalId}, intervalSize, startBlock, endBlock, verifierAddresses, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning for ChallengeSubmitted events: %w", err)
+	}
+
+	// Get the state of every challenged index
+	mcAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
+	indices := []uint64{}
+	for _, event := range rootSubmissionEvents {
+		indices = append(indices, event.Index.Uint64())
+	}
+	for _, event := range challengeEvents {
+		indices = append(indices, event.Index.Uint64())
+	}
+	challengeStates := map[uint64]types.ChallengeState{}
+	if len(indices) > 0 {
+		proposalIds := make([]uint64, len(indices))
+		for i := range proposalIds {
+			proposalIds[i] = proposalId
+		}
+		states, err := protocol.GetMultiChallengeStatesFast(rp, mcAddress, proposalIds, indices, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error getting challenge states: %w", err)
+		}
+		for i, index := range indices {
+			challengeStates[index] = states[i]
+		}
+	}
+
+	// Compare every submitted pollard against the local trees
+	for _, event := range rootSubmissionEvents {
+		mismatches, err := propMgr.AuditRootSubmission(event)
+		if err != nil {
+			return nil, fmt.Errorf("error auditing root submission for index %d: %w", event.Index.Uint64(), err)
+		}
+		index := event.Index.Uint64()
+		response.RootSubmissions = append(response.RootSubmissions, api.PDAORootSubmissionAudit{
+			Index:          index,
+			Submitter:      event.Proposer,
+			Timestamp:      event.Timestamp,
+			ChallengeState: challengeStates[index],
+			Mismatches:     mismatches,
+		})
+	}
+	sort.SliceStable(response.RootSubmissions, func(i, j int) bool {
+		return response.RootSubmissions[i].Index < response.RootSubmissions[j].Index
+	})
+
+	// Check whether any unanswered challenge can be used to defeat the proposal
+	now := time.Now()
+	for _, event := range challengeEvents {
+		index := event.Index.Uint64()
+		state := challengeStates[index]
+		canBeDefeated := prop.State == types.ProtocolDaoProposalState_Pending &&
+			state == types.ChallengeState_Challenged &&
+			now.After(event.Timestamp.Add(prop.ChallengeWindow))
+		response.Challenges = append(response.Challenges, api.PDAOChallengeAudit{
+			Index:         index,
+			Challenger:    event.Challenger,
+			Timestamp:     event.Timestamp,
+			State:         state,
+			CanBeDefeated: canBeDefeated,
+		})
+		if canBeDefeated && !response.IsDefeatable {
+			response.IsDefeatable = true
+			response.DefeatIndex = index
+		}
+	}
+	sort.SliceStable(response.Challenges, func(i, j int) bool {
+		return response.Challenges[i].Index < response.Challenges[j].Index
+	})
+
+	// Return response
+	return &response, nil
+
+}
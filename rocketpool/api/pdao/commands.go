@@ -59,6 +59,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "proposal-impact",
+				Usage:     "Simulate the effect of a proposal's setting changes on the current network state",
+				UsageText: "rocketpool api pdao proposal-impact proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					id, err := cliutils.ValidateUint("proposal-id", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getProposalImpact(c, id))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-vote-proposal",
@@ -240,6 +261,26 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "setting-impact",
+				Usage:     "Simulate the effect of changing a PDAO setting on the current network state",
+				UsageText: "rocketpool api pdao setting-impact contract-name setting-name value",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					contractName := c.Args().Get(0)
+					settingName := c.Args().Get(1)
+					value := c.Args().Get(2)
+
+					// Run
+					api.PrintResponse(getSettingImpact(c, contractName, settingName, value))
+					return nil
+
+				},
+			},
 			{
 				Name:      "propose-setting",
 				Usage:     "Propose updating a PDAO setting (use can-propose-setting to get the pollard)",
@@ -311,6 +352,35 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "rewards-percentages-impact",
+				Usage:     "Simulate the effect of new RPL rewards allocation percentages on the current network state",
+				UsageText: "rocketpool api pdao rewards-percentages-impact node odao pdao",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					node, err := cliutils.ValidateBigInt("node", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					odao, err := cliutils.ValidateBigInt("odao", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					pdao, err := cliutils.ValidateBigInt("pdao", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getRewardsPercentagesImpact(c, node, odao, pdao))
+					return nil
+
+				},
+			},
 			{
 				Name:      "propose-rewards-percentages",
 				Usage:     "Propose new RPL rewards allocation percentages for the Oracle DAO, the Protocol DAO, and the node operators",
@@ -879,6 +949,27 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "audit-proposal",
+				Usage:     "Rebuild the voting tree for a proposal and compare it against every submitted root and pollard",
+				UsageText: "rocketpool api pdao audit-proposal proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal-id", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(auditProposal(c, proposalId))
+					return nil
+
+				},
+			},
 
 			{
 				Name:      "can-finalize-proposal",
@@ -1029,6 +1120,53 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "voting-power",
+				Usage:     "Get the node's current voting power, its voting power at each proposal's target block, and an estimate with additional RPL or minipools",
+				UsageText: "rocketpool api pdao voting-power additional-rpl-wei additional-minipools bond-amount-wei",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					additionalRpl, err := cliutils.ValidateBigInt("additional-rpl-wei", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+					additionalMinipools, err := cliutils.ValidateUint("additional-minipools", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+					bondAmount, err := cliutils.ValidateBigInt("bond-amount-wei", c.Args().Get(2))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getVotingPower(c, additionalRpl, additionalMinipools, bondAmount))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "delegates",
+				Usage:     "Get the onchain voting power of each delegate, the node's delegators, and how the node's delegate has voted",
+				UsageText: "rocketpool api pdao delegates",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDelegates(c))
+					return nil
+
+				},
+			},
 			{
 				Name:      "status",
 				Usage:     "get your node's voting power at the latest block",
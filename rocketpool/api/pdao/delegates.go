@@ -0,0 +1,186 @@
+package pdao
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getDelegates(c *cli.Context) (*api.PDAODelegatesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAODelegatesResponse{
+		NodeVotingPower:      big.NewInt(0),
+		TotalVotingPower:     big.NewInt(0),
+		DelegatedVotingPower: big.NewInt(0),
+		Delegates:            []api.PDAODelegateSummary{},
+		Delegators:           []api.PDAODelegator{},
+		DelegateVotes:        []api.PDAODelegateVote{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Sync
+	var wg errgroup.Group
+	var props []protocol.ProtocolDaoProposalDetails
+
+	// Get the node's onchain voting delegate
+	wg.Go(func() error {
+		var err error
+		response.OnchainVotingDelegate, err = network.GetCurrentVotingDelegate(rp, nodeAccount.Address, nil)
+		if err == nil {
+			response.OnchainVotingDelegateFormatted = formatResolvedAddress(c, response.OnchainVotingDelegate)
+		}
+		return err
+	})
+
+	// Get latest block number
+	wg.Go(func() error {
+		blockNumber, err := ec.BlockNumber(context.Background())
+		if err != nil {
+			return fmt.Errorf("Error getting block number: %w", err)
+		}
+		response.BlockNumber = uint32(blockNumber)
+		return nil
+	})
+
+	// Get the proposals
+	wg.Go(func() error {
+		var err error
+		props, err = protocol.GetProposals(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Get the voting info for every node at the latest block
+	propMgr, err := proposals.NewProposalManager(nil, cfg, rp, bc)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := propMgr.GetVotingInfoSnapshot(response.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Total up the voting power by delegate and find the node's delegators
+	delegates := map[common.Address]*api.PDAODelegateSummary{}
+	for _, info := range snapshot.Info {
+		if info.VotingPower == nil {
+			continue
+		}
+		response.TotalVotingPower.Add(response.TotalVotingPower, info.VotingPower)
+
+		summary, exists := delegates[info.Delegate]
+		if !exists {
+			summary = &api.PDAODelegateSummary{
+				Delegate:    info.Delegate,
+				VotingPower: big.NewInt(0),
+			}
+			delegates[info.Delegate] = summary
+		}
+		summary.VotingPower.Add(summary.VotingPower, info.VotingPower)
+		summary.DelegatorCount++
+
+		if info.NodeAddress == nodeAccount.Address {
+			response.NodeVotingPower.Set(info.VotingPower)
+		}
+		if info.Delegate == nodeAccount.Address {
+			response.DelegatedVotingPower.Add(response.DelegatedVotingPower, info.VotingPower)
+			if info.NodeAddress != nodeAccount.Address {
+				response.Delegators = append(response.Delegators, api.PDAODelegator{
+					NodeAddress: info.NodeAddress,
+					VotingPower: info.VotingPower,
+				})
+			}
+		}
+	}
+	for _, summary := range delegates {
+		response.Delegates = append(response.Delegates, *summary)
+	}
+	sort.SliceStable(response.Delegates, func(i, j int) bool {
+		return response.Delegates[i].VotingPower.Cmp(response.Delegates[j].VotingPower) > 0
+	})
+	sort.SliceStable(response.Delegators, func(i, j int) bool {
+		return response.Delegators[i].VotingPower.Cmp(response.Delegators[j].VotingPower) > 0
+	})
+
+	// Get the votes of the node and its delegate on every proposal that has reached voting
+	votableProps := []protocol.ProtocolDaoProposalDetails{}
+	for _, prop := range props {
+		if prop.State != types.ProtocolDaoProposalState_Pending {
+			votableProps = append(votableProps, prop)
+		}
+	}
+	delegateProps, err := getProposalsWithNodeVoteDirection(rp, response.OnchainVotingDelegate, votableProps)
+	if err != nil {
+		return nil, err
+	}
+	nodeProps, err := getProposalsWithNodeVoteDirection(rp, nodeAccount.Address, votableProps)
+	if err != nil {
+		return nil, err
+	}
+	for i, prop := range delegateProps {
+		response.DelegateVotes = append(response.DelegateVotes, api.PDAODelegateVote{
+			ProposalID:            prop.ID,
+			Message:               prop.Message,
+			State:                 prop.State,
+			Phase1EndTime:         prop.Phase1EndTime,
+			DelegateVoteDirection: prop.NodeVoteDirection,
+			NodeVoteDirection:     nodeProps[i].NodeVoteDirection,
+			IsAwaitingDelegate:    prop.State == types.ProtocolDaoProposalState_ActivePhase1 && prop.NodeVoteDirection == types.VoteDirection_NoVote,
+		})
+	}
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,435 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The pseudo-setting used for the rewards claimer percentages, which are always changed together
+const rewardsClaimersSettingPath string = "rewards.claimers"
+
+// A setting change made by a proposal
+type settingChange struct {
+	contract string
+	setting  string
+	value    interface{}
+}
+
+// The new rewards claimer percentages
+type rewardsPercentages struct {
+	odao *big.Int
+	pdao *big.Int
+	node *big.Int
+}
+
+// Get the impact of proposing a setting change
+func getSettingImpact(c *cli.Context, contractName string, settingName string, value string) (*api.PDAOProposalImpactResponse, error) {
+	change := settingChange{
+		contract: contractName,
+		setting:  settingName,
+		value:    value,
+	}
+
+	// Parse the settings the preview knows about; the rest are only reported
+	switch settingName {
+	case psettings.MinimumPerMinipoolStakeSettingPath, psettings.MaximumPerMinipoolStakeSettingPath, psettings.MaximumDepositPoolSizeSettingPath:
+		parsedValue, success := big.NewInt(0).SetString(value, 10)
+		if !success {
+			return nil, fmt.Errorf("invalid value for %s: %s", settingName, value)
+		}
+		change.value = parsedValue
+	case psettings.AssignDepositsEnabledSettingPath:
+		parsedValue, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %s", settingName, value)
+		}
+		change.value = parsedValue
+	}
+	return simulateSettingChanges(c, []settingChange{change})
+}
+
+// Get the impact of proposing new rewards claimer percentages
+func getRewardsPercentagesImpact(c *cli.Context, node *big.Int, odao *big.Int, pdao *big.Int) (*api.PDAOProposalImpactResponse, error) {
+	return simulateSettingChanges(c, []settingChange{{
+		contract: psettings.RewardsSettingsContractName,
+		setting:  rewardsClaimersSettingPath,
+		value: rewardsPercentages{
+			odao: odao,
+			pdao: pdao,
+			node: node,
+		},
+	}})
+}
+
+// Get the impact of an existing proposal
+func getProposalImpact(c *cli.Context, proposalId uint64) (*api.PDAOProposalImpactResponse, error) {
+
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode the proposal's payload
+	payload, err := protocol.GetProposalPayload(rp, proposalId, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting payload for proposal %d: %w", proposalId, err)
+	}
+	changes, isSettingChange, err := decodeProposalPayload(rp, payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding payload for proposal %d: %w", proposalId, err)
+	}
+	if !isSettingChange {
+		return &api.PDAOProposalImpactResponse{}, nil
+	}
+	return simulateSettingChanges(c, changes)
+
+}
+
+// Decode the setting changes from a proposal's payload
+func decodeProposalPayload(rp *rocketpool.RocketPool, payload []byte) ([]settingChange, bool, error) {
+	if len(payload) < 4 {
+		return nil, false, fmt.Errorf("payload is too short")
+	}
+	rocketDAOProtocolProposals, err := rp.GetContract("rocketDAOProtocolProposals", nil)
+	if err != nil {
+		return nil, false, err
+	}
+	method, err := rocketDAOProtocolProposals.ABI.MethodById(payload[:4])
+	if err != nil {
+		return nil, false, err
+	}
+	args, err := method.Inputs.Unpack(payload[4:])
+	if err != nil {
+		return nil, false, fmt.Errorf("error unpacking %s arguments: %w", method.Name, err)
+	}
+
+	switch method.Name {
+	case "proposalSettingUint", "proposalSettingBool", "proposalSettingAddress":
+		return []settingChange{{
+			contract: args[0].(string),
+			setting:  args[1].(string),
+			value:    args[2],
+		}}, true, nil
+
+	case "proposalSettingRewardsClaimers":
+		return []settingChange{{
+			contract: psettings.RewardsSettingsContractName,
+			setting:  rewardsClaimersSettingPath,
+			value: rewardsPercentages{
+				odao: args[0].(*big.Int),
+				pdao: args[1].(*big.Int),
+				node: args[2].(*big.Int),
+			},
+		}}, true, nil
+
+	case "proposalSettingMulti":
+		contracts := args[0].([]string)
+		settings := args[1].([]string)
+		settingTypes := args[2].([]uint8)
+		data := args[3].([][]byte)
+		changes := make([]settingChange, len(contracts))
+		for i := range contracts {
+			change := settingChange{
+				contract: contracts[i],
+				setting:  settings[i],
+			}
+			switch types.ProposalSettingType(settingTypes[i]) {
+			case types.ProposalSettingType_Uint256:
+				change.value = big.NewInt(0).SetBytes(data[i])
+			case types.ProposalSettingType_Bool:
+				change.value = big.NewInt(0).SetBytes(data[i]).Sign() != 0
+			case types.ProposalSettingType_Address:
+				change.value = common.BytesToAddress(data[i])
+			default:
+				return nil, false, fmt.Errorf("unknown setting type %d for %s", settingTypes[i], settings[i])
+			}
+			changes[i] = change
+		}
+		return changes, true, nil
+	}
+
+	// Not a setting change (e.g. a treasury spend or security council update)
+	return nil, false, nil
+}
+
+// Apply setting changes to a copy of the current network state and compare the quantities they affect
+func simulateSettingChanges(c *cli.Context, changes []settingChange) (*api.PDAOProposalImpactResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOProposalImpactResponse{
+		IsSettingChange: true,
+		Changes:         make([]api.PDAOSettingChange, len(changes)),
+		Rows:            []api.PDAOImpactRow{},
+	}
+
+	// Find out which quantities are affected
+	affectsCollateral := false
+	affectsRewards := false
+	affectsDeposits := false
+	for i, change := range changes {
+		response.Changes[i] = api.PDAOSettingChange{
+			Contract: change.contract,
+			Setting:  change.setting,
+			Value:    formatChangeValue(change.value),
+		}
+		switch change.setting {
+		case psettings.MinimumPerMinipoolStakeSettingPath, psettings.MaximumPerMinipoolStakeSettingPath:
+			affectsCollateral = true
+		case rewardsClaimersSettingPath:
+			affectsRewards = true
+		case psettings.MaximumDepositPoolSizeSettingPath, psettings.AssignDepositsEnabledSettingPath:
+			affectsDeposits = true
+		}
+	}
+	if !(affectsCollateral || affectsRewards || affectsDeposits) {
+		return &response, nil
+	}
+	response.HasImpact = true
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the current state; effective stakes need every node, the rest only need the network details
+	m, err := state.NewNetworkStateManager(rp, cfg, ec, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	var before *state.NetworkState
+	if affectsCollateral {
+		before, err = m.GetHeadState()
+	} else {
+		before, _, err = m.GetHeadStateForNode(nodeAccount.Address, false)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting network state: %w", err)
+	}
+	response.ElBlockNumber = before.ElBlockNumber
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(before.ElBlockNumber),
+	}
+
+	// Fork the state and apply the changes to the copy
+	after := *before
+	afterDetails := *before.NetworkDetails
+	after.NetworkDetails = &afterDetails
+	var maxDepositPoolSizeBefore *big.Int
+	var assignDepositsEnabledBefore bool
+	if affectsDeposits {
+		maxDepositPoolSizeBefore, err = psettings.GetMaximumDepositPoolSize(rp, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting maximum deposit pool size: %w", err)
+		}
+		assignDepositsEnabledBefore, err = psettings.GetAssignDepositsEnabled(rp, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting deposit assignment status: %w", err)
+		}
+	}
+	maxDepositPoolSizeAfter := maxDepositPoolSizeBefore
+	assignDepositsEnabledAfter := assignDepositsEnabledBefore
+	for _, change := range changes {
+		switch change.setting {
+		case psettings.MinimumPerMinipoolStakeSettingPath:
+			after.NetworkDetails.MinCollateralFraction = change.value.(*big.Int)
+		case psettings.MaximumPerMinipoolStakeSettingPath:
+			after.NetworkDetails.MaxCollateralFraction = change.value.(*big.Int)
+		case rewardsClaimersSettingPath:
+			percentages := change.value.(rewardsPercentages)
+			after.NetworkDetails.TrustedNodeOperatorRewardsPercent = percentages.odao
+			after.NetworkDetails.ProtocolDaoRewardsPercent = percentages.pdao
+			after.NetworkDetails.NodeOperatorRewardsPercent = percentages.node
+		case psettings.MaximumDepositPoolSizeSettingPath:
+			maxDepositPoolSizeAfter = change.value.(*big.Int)
+		case psettings.AssignDepositsEnabledSettingPath:
+			assignDepositsEnabledAfter = change.value.(bool)
+		}
+	}
+
+	// Collateral requirements and effective stakes
+	nodeDetails, isNodeRegistered := before.NodeDetailsByAddress[nodeAccount.Address]
+	response.IsNodeRegistered = isNodeRegistered
+	if affectsCollateral {
+		response.Rows = append(response.Rows,
+			newImpactRow("Minimum RPL stake (% of borrowed ETH)", api.PDAOImpactUnit_Percent, before.NetworkDetails.MinCollateralFraction, after.NetworkDetails.MinCollateralFraction),
+			newImpactRow("Maximum RPL stake (% of bonded ETH)", api.PDAOImpactUnit_Percent, before.NetworkDetails.MaxCollateralFraction, after.NetworkDetails.MaxCollateralFraction),
+		)
+		if isNodeRegistered {
+			response.Rows = append(response.Rows,
+				newImpactRow("Your node's minimum RPL stake", api.PDAOImpactUnit_Rpl, nodeDetails.MinimumRPLStake, scaleCollateralLimit(nodeDetails.MinimumRPLStake, before.NetworkDetails.MinCollateralFraction, after.NetworkDetails.MinCollateralFraction)),
+				newImpactRow("Your node's maximum RPL stake", api.PDAOImpactUnit_Rpl, nodeDetails.MaximumRPLStake, scaleCollateralLimit(nodeDetails.MaximumRPLStake, before.NetworkDetails.MaxCollateralFraction, after.NetworkDetails.MaxCollateralFraction)),
+			)
+		}
+
+		stakesBefore, totalBefore, err := before.CalculateTrueEffectiveStakes(false, true)
+		if err != nil {
+			return nil, fmt.Errorf("error calculating current effective stakes: %w", err)
+		}
+		stakesAfter, totalAfter, err := after.CalculateTrueEffectiveStakes(false, true)
+		if err != nil {
+			return nil, fmt.Errorf("error calculating simulated effective stakes: %w", err)
+		}
+		if isNodeRegistered {
+			response.Rows = append(response.Rows, newImpactRow("Your node's effective RPL stake", api.PDAOImpactUnit_Rpl, stakesBefore[nodeAccount.Address], stakesAfter[nodeAccount.Address]))
+		}
+		response.Rows = append(response.Rows,
+			newImpactRow("Total effective RPL stake", api.PDAOImpactUnit_Rpl, totalBefore, totalAfter),
+			newImpactRow("Nodes with an effective RPL stake", api.PDAOImpactUnit_Count, countEffectiveStakers(before.NodeDetails, stakesBefore), countEffectiveStakers(after.NodeDetails, stakesAfter)),
+			newImpactRow("Nodes limited by the maximum RPL stake", api.PDAOImpactUnit_Count, countCappedStakers(before.NodeDetails, stakesBefore), countCappedStakers(after.NodeDetails, stakesAfter)),
+		)
+	}
+
+	// Rewards split
+	if affectsRewards {
+		response.Rows = append(response.Rows,
+			newImpactRow("Node operator share of RPL rewards", api.PDAOImpactUnit_Percent, before.NetworkDetails.NodeOperatorRewardsPercent, after.NetworkDetails.NodeOperatorRewardsPercent),
+			newImpactRow("Oracle DAO share of RPL rewards", api.PDAOImpactUnit_Percent, before.NetworkDetails.TrustedNodeOperatorRewardsPercent, after.NetworkDetails.TrustedNodeOperatorRewardsPercent),
+			newImpactRow("Protocol DAO share of RPL rewards", api.PDAOImpactUnit_Percent, before.NetworkDetails.ProtocolDaoRewardsPercent, after.NetworkDetails.ProtocolDaoRewardsPercent),
+		)
+	}
+
+	// Deposit pool limits
+	if affectsDeposits {
+		capacityBefore := getDepositCapacity(before.NetworkDetails, maxDepositPoolSizeBefore, assignDepositsEnabledBefore)
+		capacityAfter := getDepositCapacity(after.NetworkDetails, maxDepositPoolSizeAfter, assignDepositsEnabledAfter)
+		response.Rows = append(response.Rows,
+			newImpactRow("Maximum deposit pool size", api.PDAOImpactUnit_Eth, maxDepositPoolSizeBefore, maxDepositPoolSizeAfter),
+			newImpactRow("Deposit assignments enabled", api.PDAOImpactUnit_Bool, boolToBig(assignDepositsEnabledBefore), boolToBig(assignDepositsEnabledAfter)),
+			newImpactRow("Total deposit capacity (including the minipool queue)", api.PDAOImpactUnit_Eth, capacityBefore, capacityAfter),
+			newImpactRow("Remaining space for new deposits", api.PDAOImpactUnit_Eth, getRemainingDepositSpace(before.NetworkDetails, capacityBefore), getRemainingDepositSpace(after.NetworkDetails, capacityAfter)),
+		)
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Create a row for the impact table
+func newImpactRow(name string, unit api.PDAOImpactUnit, before *big.Int, after *big.Int) api.PDAOImpactRow {
+	return api.PDAOImpactRow{
+		Name:   name,
+		Unit:   unit,
+		Before: before,
+		After:  after,
+	}
+}
+
+// Format a setting value for display
+func formatChangeValue(value interface{}) string {
+	switch value := value.(type) {
+	case common.Address:
+		return value.Hex()
+	case rewardsPercentages:
+		return fmt.Sprintf("node operators: %s, oDAO: %s, pDAO: %s", value.node.String(), value.odao.String(), value.pdao.String())
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// Node collateral limits are linear in the per-minipool stake settings, so rescale the current one to the new fraction
+func scaleCollateralLimit(limit *big.Int, fractionBefore *big.Int, fractionAfter *big.Int) *big.Int {
+	if limit == nil || fractionBefore.Sign() == 0 {
+		return nil
+	}
+	scaled := big.NewInt(0).Mul(limit, fractionAfter)
+	return scaled.Div(scaled, fractionBefore)
+}
+
+// Count the nodes that have a non-zero effective stake
+func countEffectiveStakers(nodes []rpstate.NativeNodeDetails, stakes map[common.Address]*big.Int) *big.Int {
+	count := int64(0)
+	for _, node := range nodes {
+		if stake, exists := stakes[node.NodeAddress]; exists && stake.Sign() > 0 {
+			count++
+		}
+	}
+	return big.NewInt(count)
+}
+
+// Count the nodes whose effective stake is less than their actual stake because of the maximum
+func countCappedStakers(nodes []rpstate.NativeNodeDetails, stakes map[common.Address]*big.Int) *big.Int {
+	count := int64(0)
+	for _, node := range nodes {
+		if stake, exists := stakes[node.NodeAddress]; exists && stake.Sign() > 0 && stake.Cmp(node.RplStake) < 0 {
+			count++
+		}
+	}
+	return big.NewInt(count)
+}
+
+// Get the amount of ETH the deposit pool can hold, including what can be assigned straight to the minipool queue
+func getDepositCapacity(details *rpstate.NetworkDetails, maxDepositPoolSize *big.Int, assignDepositsEnabled bool) *big.Int {
+	capacity := big.NewInt(0).Set(maxDepositPoolSize)
+	if assignDepositsEnabled && details.QueueCapacity.Effective != nil {
+		capacity.Add(capacity, details.QueueCapacity.Effective)
+	}
+	return capacity
+}
+
+// Get how much more ETH can be deposited before the capacity is reached
+func getRemainingDepositSpace(details *rpstate.NetworkDetails, capacity *big.Int) *big.Int {
+	remaining := big.NewInt(0).Sub(capacity, details.DepositPoolBalance)
+	if remaining.Sign() < 0 {
+		remaining.SetUint64(0)
+	}
+	return remaining
+}
+
+// Convert a bool to a number for the impact table
+func boolToBig(value bool) *big.Int {
+	if value {
+		return big.NewInt(1)
+	}
+	return big.NewInt(0)
+}
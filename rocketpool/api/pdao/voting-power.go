@@ -0,0 +1,222 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/node"
+	protocolsettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The amount of ETH it takes to launch a minipool
+var minipoolLaunchAmount = eth.EthToWei(32)
+
+func getVotingPower(c *cli.Context, additionalRpl *big.Int, additionalMinipools uint64, bondAmount *big.Int) (*api.PDAOVotingPowerResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOVotingPowerResponse{
+		DelegatedVotingPower: big.NewInt(0),
+		TotalVotingPower:     big.NewInt(0),
+		History:              []api.PDAOVotingPowerHistoryEntry{},
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.AccountAddress = nodeAccount.Address
+
+	// Get the latest finalized block
+	m, err := state.NewNetworkStateManager(rp, cfg, ec, bc, nil)
+	if err != nil {
+		return nil, err
+	}
+	block, err := m.GetLatestFinalizedBeaconBlock()
+	if err != nil {
+		return nil, fmt.Errorf("error determining latest finalized block: %w", err)
+	}
+	response.BlockNumber = uint32(block.ExecutionBlockNumber)
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(block.ExecutionBlockNumber),
+	}
+
+	// Sync
+	var wg errgroup.Group
+	var props []protocol.ProtocolDaoProposalDetails
+	var activeMinipools uint64
+	var ethMatched *big.Int
+
+	// Get the node's voting power
+	wg.Go(func() error {
+		var err error
+		response.VotingPower, err = network.GetVotingPower(rp, nodeAccount.Address, response.BlockNumber, opts)
+		return err
+	})
+
+	// Get the inputs to the voting power formula
+	wg.Go(func() error {
+		var err error
+		response.Current.RplStake, err = node.GetNodeRPLStake(rp, nodeAccount.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		activeMinipools, err = minipool.GetNodeActiveMinipoolCount(rp, nodeAccount.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		ethMatched, err = node.GetNodeEthMatched(rp, nodeAccount.Address, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.RplPrice, err = network.GetRPLPrice(rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		response.MaxStakeFraction, err = protocolsettings.GetMaximumPerMinipoolStakeRaw(rp, opts)
+		return err
+	})
+
+	// Get the proposals
+	wg.Go(func() error {
+		var err error
+		props, err = protocol.GetProposals(rp, nil)
+		return err
+	})
+
+	// Wait for data
+	if err := wg.Wait(); err != nil {
+		return nil, err
+	}
+
+	// Estimate the current voting power from its inputs
+	bondedEth := big.NewInt(0).Mul(big.NewInt(int64(activeMinipools)), minipoolLaunchAmount)
+	bondedEth.Sub(bondedEth, ethMatched)
+	response.Current = getVotingPowerEstimate(response.Current.RplStake, bondedEth, response.RplPrice, response.MaxStakeFraction)
+
+	// Estimate the voting power with the extra RPL and minipools
+	if additionalRpl.Sign() > 0 || additionalMinipools > 0 {
+		whatIfRplStake := big.NewInt(0).Add(response.Current.RplStake, additionalRpl)
+		whatIfBondedEth := big.NewInt(0).Mul(big.NewInt(int64(additionalMinipools)), bondAmount)
+		whatIfBondedEth.Add(whatIfBondedEth, bondedEth)
+		whatIf := getVotingPowerEstimate(whatIfRplStake, whatIfBondedEth, response.RplPrice, response.MaxStakeFraction)
+		response.WhatIf = &whatIf
+	}
+
+	// Get the voting power delegated to the node
+	propMgr, err := proposals.NewProposalManager(nil, cfg, rp, bc)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := propMgr.GetVotingInfoSnapshot(response.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	addSnapshotVotingPower(response.AccountAddress, snapshot, nil, response.DelegatedVotingPower, response.TotalVotingPower)
+
+	// Get the voting power at each proposal's target block, using the cached snapshots where they exist
+	for _, prop := range props {
+		entry := api.PDAOVotingPowerHistoryEntry{
+			ProposalID:           prop.ID,
+			Message:              prop.Message,
+			CreatedTime:          prop.CreatedTime,
+			TargetBlock:          prop.TargetBlock,
+			DelegatedVotingPower: big.NewInt(0),
+			TotalVotingPower:     big.NewInt(0),
+		}
+		snapshot, err := propMgr.GetCachedVotingInfoSnapshot(prop.TargetBlock)
+		if err != nil {
+			return nil, err
+		}
+		if snapshot != nil {
+			entry.IsSnapshotCached = true
+			entry.VotingPower = big.NewInt(0)
+			addSnapshotVotingPower(response.AccountAddress, snapshot, entry.VotingPower, entry.DelegatedVotingPower, entry.TotalVotingPower)
+		} else {
+			entry.VotingPower, err = network.GetVotingPower(rp, nodeAccount.Address, prop.TargetBlock, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		response.History = append(response.History, entry)
+	}
+	sort.SliceStable(response.History, func(i, j int) bool {
+		return response.History[i].TargetBlock < response.History[j].TargetBlock
+	})
+
+	// Return response
+	return &response, nil
+
+}
+
+// Get the voting power the formula produces for the provided inputs
+func getVotingPowerEstimate(rplStake *big.Int, bondedEth *big.Int, rplPrice *big.Int, maxStakeFraction *big.Int) api.PDAOVotingPowerEstimate {
+	return api.PDAOVotingPowerEstimate{
+		RplStake:       rplStake,
+		BondedEth:      bondedEth,
+		MaxVotingStake: proposals.GetMaximumVotingStake(bondedEth, rplPrice, maxStakeFraction),
+		VotingPower:    proposals.CalculateVotingPower(rplStake, bondedEth, rplPrice, maxStakeFraction),
+	}
+}
+
+// Add up the node's own voting power, the voting power delegated to it, and the network's total voting power in a snapshot
+func addSnapshotVotingPower(nodeAddress common.Address, snapshot *proposals.VotingInfoSnapshot, votingPower *big.Int, delegatedVotingPower *big.Int, totalVotingPower *big.Int) {
+	for _, info := range snapshot.Info {
+		if info.VotingPower == nil {
+			continue
+		}
+		totalVotingPower.Add(totalVotingPower, info.VotingPower)
+		if info.Delegate == nodeAddress {
+			delegatedVotingPower.Add(delegatedVotingPower, info.VotingPower)
+		}
+		if votingPower != nil && info.NodeAddress == nodeAddress {
+			votingPower.Set(info.VotingPower)
+		}
+	}
+}
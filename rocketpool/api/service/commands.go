@@ -69,6 +69,66 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "create-migration-bundle",
+				Usage:     "Creates an encrypted bundle of the node's wallet, validator keys, slashing protection data, rolling records and settings for migrating to a new machine",
+				UsageText: "rocketpool api service create-migration-bundle passphrase",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					passphrase, err := cliutils.ValidateNodePassword("passphrase", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(createMigrationBundle(c, passphrase))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "restore-migration-bundle",
+				Usage:     "Restores the node's wallet, validator keys, slashing protection data and rolling records from a migration bundle in the migration folder",
+				UsageText: "rocketpool api service restore-migration-bundle filename passphrase",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					filename := c.Args().Get(0)
+					passphrase := c.Args().Get(1)
+
+					// Run
+					api.PrintResponse(restoreMigrationBundle(c, filename, passphrase))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "get-validator-liveness",
+				Usage:     "Checks whether any of the node's validators performed duties during the last completed epoch",
+				UsageText: "rocketpool api service get-validator-liveness",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getValidatorLiveness(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/bundle"
+)
+
+// Bundle entry names
+const (
+	walletEntry             string = "wallet"
+	passwordEntry           string = "password"
+	validatorsEntry         string = "validators"
+	customKeysEntry         string = "custom-keys"
+	customKeyPasswordsEntry string = "custom-key-passwords"
+	recordsEntry            string = "records"
+	settingsEntry           string = "settings"
+)
+
+// Packages the wallet, password, validator keys (including the slashing protection databases that live alongside them),
+// rolling records, and user settings into an encrypted bundle in the migration folder.
+// The validator client must already be stopped so the slashing protection data is final.
+func createMigrationBundle(c *cli.Context, passphrase string) (*api.CreateMigrationBundleResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CreateMigrationBundleResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	response.NodeAddress = nodeAccount.Address
+
+	// Get the node's validators
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	response.ValidatorCount = len(pubkeys)
+
+	// Record the epoch the validator went offline so the target knows where to start watching from
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting beacon head: %w", err)
+	}
+	response.StopEpoch = head.Epoch
+
+	// Create the bundle
+	manifest := bundle.Manifest{
+		SmartnodeVersion: shared.RocketPoolVersion,
+		Network:          string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		NodeAddress:      nodeAccount.Address,
+		CreatedAt:        time.Now().UTC(),
+		StopEpoch:        head.Epoch,
+		ValidatorPubkeys: pubkeys,
+	}
+	sources := []bundle.Source{
+		{Name: walletEntry, Path: os.ExpandEnv(cfg.Smartnode.GetWalletPath())},
+		{Name: passwordEntry, Path: os.ExpandEnv(cfg.Smartnode.GetPasswordPath())},
+		{Name: validatorsEntry, Path: os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())},
+		{Name: customKeysEntry, Path: os.ExpandEnv(cfg.Smartnode.GetCustomKeyPath())},
+		{Name: customKeyPasswordsEntry, Path: os.ExpandEnv(cfg.Smartnode.GetCustomKeyPasswordFilePath())},
+		{Name: recordsEntry, Path: os.ExpandEnv(cfg.Smartnode.GetRecordsPath())},
+		{Name: settingsEntry, Path: os.ExpandEnv(c.GlobalString("settings"))},
+	}
+
+	migrationFolder := cfg.Smartnode.GetMigrationFolder(true)
+	if err := os.MkdirAll(migrationFolder, 0755); err != nil {
+		return nil, fmt.Errorf("error creating migration folder: %w", err)
+	}
+	response.BundleFile = fmt.Sprintf("%s-%s%s", nodeAccount.Address.Hex(), manifest.CreatedAt.Format("20060102-150405"), bundle.FileExtension)
+	bundlePath := filepath.Join(migrationFolder, response.BundleFile)
+
+	// Write to a temp file first so a partial bundle is never left behind under the real name.
+	// The bundle is encrypted, so it's made readable by the host user to let the CLI copy it out.
+	tempFile, err := os.CreateTemp(migrationFolder, ".tmp-*"+bundle.FileExtension)
+	if err != nil {
+		return nil, fmt.Errorf("error creating bundle file: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	err = bundle.Write(tempFile, passphrase, manifest, sources)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error writing bundle: %w", err)
+	}
+	if err := os.Chmod(tempFile.Name(), 0644); err != nil {
+		return nil, fmt.Errorf("error setting bundle permissions: %w", err)
+	}
+	if err := os.Rename(tempFile.Name(), bundlePath); err != nil {
+		return nil, fmt.Errorf("error saving bundle: %w", err)
+	}
+
+	// Read back the entries that were actually included
+	bundleFile, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening bundle for verification: %w", err)
+	}
+	defer bundleFile.Close()
+	verified, err := bundle.Read(bundleFile, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("error verifying bundle: %w", err)
+	}
+	response.Entries = verified.Manifest.Entries
+
+	// Return response
+	return &response, nil
+
+}
+
+// Restores the wallet, password, validator keys, and rolling records from a migration bundle in the migration folder.
+// The bundled user settings are returned to the caller rather than applied, since they live on the host.
+func restoreMigrationBundle(c *cli.Context, filename string, passphrase string) (*api.RestoreMigrationBundleResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RestoreMigrationBundleResponse{}
+
+	// Never overwrite an existing wallet
+	if w.IsInitialized() {
+		return nil, errors.New("the node wallet is already initialized; a migration bundle can only be restored onto a node without a wallet")
+	}
+
+	// Load the bundle
+	bundlePath := filepath.Join(cfg.Smartnode.GetMigrationFolder(true), filepath.Base(filename))
+	bundleFile, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("error opening bundle: %w", err)
+	}
+	defer bundleFile.Close()
+	b, err := bundle.Read(bundleFile, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	// Make sure it belongs to this network
+	network := string(cfg.Smartnode.Network.Value.(cfgtypes.Network))
+	if b.Manifest.Network != network {
+		return nil, fmt.Errorf("the bundle was created on the %s network but this node is configured for %s", b.Manifest.Network, network)
+	}
+	if !b.HasEntry(walletEntry) || !b.HasEntry(passwordEntry) {
+		return nil, errors.New("the bundle does not contain a node wallet and password")
+	}
+
+	// Extract everything
+	targets := []bundle.Source{
+		{Name: passwordEntry, Path: os.ExpandEnv(cfg.Smartnode.GetPasswordPath())},
+		{Name: walletEntry, Path: os.ExpandEnv(cfg.Smartnode.GetWalletPath())},
+		{Name: validatorsEntry, Path: os.ExpandEnv(cfg.Smartnode.GetValidatorKeychainPath())},
+		{Name: customKeysEntry, Path: os.ExpandEnv(cfg.Smartnode.GetCustomKeyPath())},
+		{Name: customKeyPasswordsEntry, Path: os.ExpandEnv(cfg.Smartnode.GetCustomKeyPasswordFilePath())},
+		{Name: recordsEntry, Path: os.ExpandEnv(cfg.Smartnode.GetRecordsPath())},
+	}
+	for _, target := range targets {
+		if !b.HasEntry(target.Name) {
+			continue
+		}
+		if err := b.Extract(target.Name, target.Path); err != nil {
+			return nil, fmt.Errorf("error restoring %s: %w", target.Name, err)
+		}
+	}
+	if b.HasEntry(settingsEntry) {
+		settings, err := b.ReadFile(settingsEntry)
+		if err != nil {
+			return nil, err
+		}
+		response.Settings = string(settings)
+	}
+
+	response.NodeAddress = b.Manifest.NodeAddress
+	response.Network = b.Manifest.Network
+	response.CreatedAt = b.Manifest.CreatedAt
+	response.StopEpoch = b.Manifest.StopEpoch
+	response.ValidatorCount = len(b.Manifest.ValidatorPubkeys)
+	response.Entries = b.Manifest.Entries
+
+	// Return response
+	return &response, nil
+
+}
+
+// Checks whether any of the node's validators were seen performing duties during the last completed epoch
+func getValidatorLiveness(c *cli.Context) (*api.ValidatorLivenessResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ValidatorLivenessResponse{
+		LiveValidators: []string{},
+	}
+
+	// Get the node's validator indices
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	statuses, err := bc.GetValidatorStatuses(pubkeys, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator statuses: %w", err)
+	}
+	indices := []string{}
+	for _, status := range statuses {
+		if status.Exists && status.Status != beacon.ValidatorState_PendingInitialized && status.Status != beacon.ValidatorState_PendingQueued {
+			indices = append(indices, status.Index)
+		}
+	}
+	response.ValidatorCount = len(indices)
+
+	// Get the chain state
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting beacon config: %w", err)
+	}
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting beacon head: %w", err)
+	}
+	response.SecondsPerEpoch = eth2Config.SecondsPerEpoch
+	response.FinalizedEpoch = head.FinalizedEpoch
+
+	// Check the last completed epoch
+	if head.Epoch > 0 {
+		response.Epoch = head.Epoch - 1
+	}
+	if len(indices) == 0 {
+		return &response, nil
+	}
+	liveness, err := bc.GetValidatorLiveness(indices, response.Epoch)
+	if err != nil {
+		return nil, err
+	}
+	for _, index := range indices {
+		if liveness[index] {
+			response.LiveValidators = append(response.LiveValidators, index)
+		}
+	}
+
+	// Return response
+	return &response, nil
+
+}
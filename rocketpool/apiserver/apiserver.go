@@ -0,0 +1,301 @@
+package apiserver
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	ApiServerColor = color.FgHiBlue
+
+	maxRequestSize     int64 = 1024 * 1024
+	readHeaderTimeout        = 10 * time.Second
+	minimumTokenLength int   = 16
+)
+
+// The global daemon flags a request may set before the api command, and whether each one takes a value
+var allowedFlags = map[string]bool{
+	"--ignore-sync-check": false,
+	"--force-fallbacks":   false,
+	"--maxFee":            true,
+	"--maxPrioFee":        true,
+	"--gasLimit":          true,
+	"--nonce":             true,
+}
+
+// The API commands a remote CLI can run: the reads that fleet and context commands need, and the routine reward and distribution transactions
+var allowedCommands = map[string]bool{
+	// Reads
+	"node status":           true,
+	"node sync":             true,
+	"node rewards":          true,
+	"node get-rewards-info": true,
+	"minipool status":       true,
+	"minipool get-distribute-balance-details": true,
+	"network node-fee":                        true,
+	"network rpl-price":                       true,
+	"network stats":                           true,
+	"network gas-estimate":                    true,
+	"network is-houston-deployed":             true,
+	"service get-client-status":               true,
+	"wallet status":                           true,
+
+	// Transactions
+	"node can-claim-rewards":           true,
+	"node claim-rewards":               true,
+	"node can-claim-and-stake-rewards": true,
+	"node claim-and-stake-rewards":     true,
+	"node can-distribute":              true,
+	"node distribute":                  true,
+	"minipool distribute-balance":      true,
+	"wait":                             true,
+}
+
+// Commands that can expose or replace the node's keys; these are never run, even if they're added to the allowlist by mistake
+var deniedCommands = map[string]bool{
+	"wallet export":                  true,
+	"wallet init":                    true,
+	"wallet recover":                 true,
+	"wallet search-and-recover":      true,
+	"wallet test-recovery":           true,
+	"wallet test-search-and-recover": true,
+	"wallet rebuild":                 true,
+	"wallet set-password":            true,
+}
+
+// API server
+type apiServer struct {
+	log          log.ColorLogger
+	token        []byte
+	executable   string
+	settingsPath string
+}
+
+// Register API server command
+func RegisterCommands(app *cli.App, name string, aliases []string) {
+	app.Commands = append(app.Commands, cli.Command{
+		Name:    name,
+		Aliases: aliases,
+		Usage:   "Serve the Rocket Pool API over HTTP, so a CLI on another machine can use this node as a context",
+		Flags: []cli.Flag{
+			cli.StringFlag{
+				Name:  "address, a",
+				Usage: "The address to listen on; only expose it beyond localhost through a TLS proxy or a private network",
+				Value: "127.0.0.1",
+			},
+			cli.UintFlag{
+				Name:  "port, p",
+				Usage: "The port to listen on",
+				Value: 8280,
+			},
+			cli.StringFlag{
+				Name:  "token-file, t",
+				Usage: "The `path` of a file with the secret token that clients must send",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			return run(c)
+		},
+	})
+}
+
+// Run the API server
+func run(c *cli.Context) error {
+
+	// Load the token
+	tokenFile := c.String("token-file")
+	if tokenFile == "" {
+		return fmt.Errorf("the API server needs a token file")
+	}
+	tokenBytes, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return fmt.Errorf("error reading token file: %w", err)
+	}
+	token := strings.TrimSpace(string(tokenBytes))
+	if len(token) < minimumTokenLength {
+		return fmt.Errorf("the token must be at least %d characters long", minimumTokenLength)
+	}
+
+	// Get this binary so API commands can be run the same way the CLI runs them
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("error getting the daemon executable: %w", err)
+	}
+
+	server := &apiServer{
+		log:          log.NewColorLogger(ApiServerColor),
+		token:        []byte(token),
+		executable:   executable,
+		settingsPath: c.GlobalString("settings"),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(api.ApiServerCommandPath, server.authorize(http.MethodPost, server.handleCommand))
+	mux.HandleFunc(api.ApiServerConfigPath, server.authorize(http.MethodGet, server.handleConfig))
+	mux.HandleFunc(api.ApiServerVersionPath, server.authorize(http.MethodGet, server.handleVersion))
+
+	address := fmt.Sprintf("%s:%d", c.String("address"), c.Uint("port"))
+	server.log.Printlnf("Starting API server on %s.", address)
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+	if err := httpServer.ListenAndServe(); err != nil {
+		return fmt.Errorf("error running API server: %w", err)
+	}
+	return nil
+
+}
+
+// Only pass requests with the right method and token on to a handler
+func (s *apiServer) authorize(method string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), s.token) != 1 {
+			s.log.Printlnf("Rejected unauthorized request from %s.", r.RemoteAddr)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		handler(w, r)
+	}
+}
+
+// Run an API command and return its response
+func (s *apiServer) handleCommand(w http.ResponseWriter, r *http.Request) {
+
+	// Read the request
+	var request api.ApiServerRequest
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading request: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		http.Error(w, fmt.Sprintf("error decoding request: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+	command, err := validateArgs(request.Args)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Run the command; arguments can hold secrets, so only the command name is logged
+	s.log.Printlnf("Running API command [%s] for %s.", command, r.RemoteAddr)
+	args := append([]string{"--settings", s.settingsPath}, request.Args...)
+	cmd := exec.CommandContext(r.Context(), s.executable, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil && len(output) == 0 {
+		http.Error(w, fmt.Sprintf("error running API command: %s %s", err.Error(), strings.TrimSpace(stderr.String())), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(output)
+
+}
+
+// Return the parts of the daemon's settings a remote CLI needs to load its config.
+// Only the root and Smart Node sections are sent, without the URLs that can hold API keys; the client settings, credentials, and overrides stay on this machine.
+func (s *apiServer) handleConfig(w http.ResponseWriter, r *http.Request) {
+	cfg, err := config.LoadFromFile(s.settingsPath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error loading settings file: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/yaml")
+	if cfg == nil {
+		// The node hasn't been configured yet
+		return
+	}
+
+	serialized := cfg.Serialize()
+	settings := map[string]map[string]string{
+		"root":      serialized["root"],
+		"smartnode": serialized["smartnode"],
+	}
+	delete(settings["smartnode"], cfg.Smartnode.ArchiveECUrl.ID)
+	delete(settings["smartnode"], cfg.Smartnode.RplPriceSources.ID)
+	settingsBytes, err := yaml.Marshal(settings)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error serializing settings: %s", err.Error()), http.StatusInternalServerError)
+		return
+	}
+	w.Write(settingsBytes)
+}
+
+// Return the daemon's version
+func (s *apiServer) handleVersion(w http.ResponseWriter, r *http.Request) {
+	response, err := json.Marshal(api.ApiServerVersionResponse{
+		Status:  "success",
+		Version: shared.RocketPoolVersion,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(response)
+}
+
+// Make sure the arguments only set allowed global flags before running an allowed api command, so requests can't start other daemon commands
+// Returns the name of the API command
+func validateArgs(args []string) (string, error) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "api" {
+			if i+1 >= len(args) {
+				return "", fmt.Errorf("no API command given")
+			}
+			command := args[i+1]
+			if i+2 < len(args) {
+				command += " " + args[i+2]
+			}
+			if deniedCommands[command] {
+				return "", fmt.Errorf("API command [%s] can't be run remotely", command)
+			}
+			if allowedCommands[command] {
+				return command, nil
+			}
+
+			// Commands like wait take a positional argument instead of a subcommand
+			if allowedCommands[args[i+1]] {
+				return args[i+1], nil
+			}
+			return "", fmt.Errorf("API command [%s] isn't allowed remotely", command)
+		}
+		takesValue, allowed := allowedFlags[arg]
+		if !allowed {
+			return "", fmt.Errorf("argument [%s] is not allowed", arg)
+		}
+		if takesValue {
+			i++
+		}
+	}
+	return "", fmt.Errorf("only api commands can be run")
+}
@@ -0,0 +1,49 @@
+package apiserver
+
+import "testing"
+
+func TestValidateArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		command string
+		wantErr bool
+	}{
+		{"read", []string{"api", "node", "status"}, "node status", false},
+		{"read with arguments", []string{"api", "minipool", "get-distribute-balance-details", "0x01"}, "minipool get-distribute-balance-details", false},
+		{"transaction", []string{"api", "node", "claim-rewards", "1,2", "0"}, "node claim-rewards", false},
+		{"global flags", []string{"--ignore-sync-check", "--force-fallbacks", "api", "node", "sync"}, "node sync", false},
+		{"flags with values", []string{"--maxFee", "20", "--maxPrioFee", "2", "--gasLimit", "0", "--nonce", "5", "api", "node", "distribute"}, "node distribute", false},
+		{"flag value that looks like api", []string{"--nonce", "api", "node", "status"}, "", true},
+		{"wait with a hash", []string{"api", "wait", "0x1234"}, "wait", false},
+		{"wait without a hash", []string{"api", "wait"}, "wait", false},
+		{"denied command", []string{"api", "wallet", "export"}, "", true},
+		{"denied command with a flag", []string{"--maxFee", "20", "api", "wallet", "recover", "--mnemonic", "abc"}, "", true},
+		{"command that isn't allowed", []string{"api", "node", "send", "1", "eth", "0x01"}, "", true},
+		{"group without a command", []string{"api", "node"}, "", true},
+		{"unknown global flag", []string{"--settings", "/tmp", "api", "node", "status"}, "", true},
+		{"unknown flag with a value", []string{"--config-path=/tmp", "api", "node", "status"}, "", true},
+		{"missing command after api", []string{"api"}, "", true},
+		{"flag after api", []string{"--maxFee", "20", "api"}, "", true},
+		{"no api", []string{"service", "version"}, "", true},
+		{"no arguments", []string{}, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			command, err := validateArgs(test.args)
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error for %v, got command [%s]", test.args, command)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error for %v: %s", test.args, err.Error())
+			}
+			if command != test.command {
+				t.Fatalf("expected command [%s] for %v, got [%s]", test.command, test.args, command)
+			}
+		})
+	}
+}
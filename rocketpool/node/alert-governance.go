@@ -0,0 +1,307 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/dao/security"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/urfave/cli"
+)
+
+// How long before voting ends to warn about proposals the node hasn't voted on
+const governanceVoteWarningWindow time.Duration = 24 * time.Hour
+
+// The permissions of the saved governance alert state
+const governanceAlertStateFileMode os.FileMode = 0644
+
+// The last seen state of each proposal, saved so restarting the daemon doesn't repeat or miss alerts
+type governanceAlertState struct {
+	PdaoStates      map[uint64]types.ProtocolDaoProposalState `json:"pdaoStates"`
+	DaoStates       map[string]types.ProposalState            `json:"daoStates"`
+	WarnedProposals map[string]bool                           `json:"warnedProposals"`
+}
+
+// Governance alerts task
+type alertGovernance struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	rp          *rocketpool.RocketPool
+	nodeAddress common.Address
+
+	// The last seen state of each proposal; if there's no saved state, proposals seen on the first run are recorded without alerting
+	statePath     string
+	isInitialized bool
+	state         governanceAlertState
+}
+
+// Create governance alerts task
+func newAlertGovernance(c *cli.Context, logger log.ColorLogger) (*alertGovernance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node account
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node account: %w", err)
+	}
+
+	// Load the saved proposal states
+	statePath := cfg.Smartnode.GetGovernanceAlertStatePath()
+	savedState, err := loadGovernanceAlertState(statePath)
+	if err != nil {
+		return nil, err
+	}
+	isInitialized := savedState != nil
+	if savedState == nil {
+		savedState = &governanceAlertState{}
+	}
+	if savedState.PdaoStates == nil {
+		savedState.PdaoStates = map[uint64]types.ProtocolDaoProposalState{}
+	}
+	if savedState.DaoStates == nil {
+		savedState.DaoStates = map[string]types.ProposalState{}
+	}
+	if savedState.WarnedProposals == nil {
+		savedState.WarnedProposals = map[string]bool{}
+	}
+
+	// Return task
+	return &alertGovernance{
+		c:             c,
+		log:           logger,
+		cfg:           cfg,
+		rp:            rp,
+		nodeAddress:   account.Address,
+		statePath:     statePath,
+		isInitialized: isInitialized,
+		state:         *savedState,
+	}, nil
+
+}
+
+// Check for governance events to alert on.
+// Proposal states are recorded even when their alerts are disabled, so enabling them later doesn't alert on everything that happened in the meantime.
+func (t *alertGovernance) run(state *state.NetworkState) error {
+
+	// Get the alerts to send
+	alertingEnabled := t.cfg.Alertmanager.EnableAlerting.Value == true
+	pdaoEnabled := alertingEnabled && t.cfg.Alertmanager.AlertEnabled_PDAOProposals.Value == true
+	odaoEnabled := alertingEnabled && t.cfg.Alertmanager.AlertEnabled_ODAOProposals.Value == true
+	securityEnabled := alertingEnabled && t.cfg.Alertmanager.AlertEnabled_SecurityCouncilProposals.Value == true
+
+	// Log
+	if pdaoEnabled || odaoEnabled || securityEnabled {
+		t.log.Println("Checking for governance proposal updates...")
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	now := time.Unix(int64(state.BeaconConfig.GenesisTime+state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot), 0)
+
+	// Protocol DAO proposals
+	if state.IsHoustonDeployed {
+		if err := t.checkPdaoProposals(state, now, opts, pdaoEnabled); err != nil {
+			return fmt.Errorf("error checking Protocol DAO proposals: %w", err)
+		}
+	}
+
+	// Oracle DAO proposals
+	isMember, err := trustednode.GetMemberExists(t.rp, t.nodeAddress, opts)
+	if err != nil {
+		return fmt.Errorf("error checking Oracle DAO membership: %w", err)
+	}
+	if isMember {
+		if err := t.checkDaoProposals(alerting.GovernanceDao_Oracle, "rocketDAONodeTrustedProposals", now, opts, odaoEnabled); err != nil {
+			return fmt.Errorf("error checking Oracle DAO proposals: %w", err)
+		}
+	}
+
+	// Security council proposals
+	if state.IsHoustonDeployed {
+		isMember, err := security.GetMemberExists(t.rp, t.nodeAddress, opts)
+		if err != nil {
+			return fmt.Errorf("error checking security council membership: %w", err)
+		}
+		if isMember {
+			if err := t.checkDaoProposals(alerting.GovernanceDao_SecurityCouncil, "rocketDAOSecurityProposals", now, opts, securityEnabled); err != nil {
+				return fmt.Errorf("error checking security council proposals: %w", err)
+			}
+		}
+	}
+
+	t.isInitialized = true
+	if err := saveGovernanceAlertState(t.statePath, &t.state); err != nil {
+		return err
+	}
+	return nil
+
+}
+
+// Alert on new Protocol DAO proposals, phase changes, and proposals the node hasn't voted on; if sendAlerts is false, the proposal states are only recorded
+func (t *alertGovernance) checkPdaoProposals(state *state.NetworkState, now time.Time, opts *bind.CallOpts, sendAlerts bool) error {
+	var delegate *common.Address
+	for _, prop := range state.ProtocolDaoProposalDetails {
+		lastState, exists := t.state.PdaoStates[prop.ID]
+		t.state.PdaoStates[prop.ID] = prop.State
+
+		if !sendAlerts {
+			continue
+		}
+		if !exists {
+			if t.isInitialized {
+				t.log.Printlnf("Protocol DAO proposal %d was created.", prop.ID)
+				alerting.AlertGovernanceProposalCreated(t.cfg, alerting.GovernanceDao_Protocol, prop.ID, prop.Message, prop.ProposerAddress)
+			}
+		} else if lastState != prop.State {
+			switch prop.State {
+			case types.ProtocolDaoProposalState_ActivePhase1:
+				t.log.Printlnf("Protocol DAO proposal %d entered voting phase 1.", prop.ID)
+				alerting.AlertGovernanceProposalPhaseChanged(t.cfg, alerting.GovernanceDao_Protocol, prop.ID, prop.Message, "in voting phase 1", prop.Phase1EndTime)
+			case types.ProtocolDaoProposalState_ActivePhase2:
+				t.log.Printlnf("Protocol DAO proposal %d entered voting phase 2.", prop.ID)
+				alerting.AlertGovernanceProposalPhaseChanged(t.cfg, alerting.GovernanceDao_Protocol, prop.ID, prop.Message, "in voting phase 2", prop.Phase2EndTime)
+			}
+		}
+
+		// Warn if voting is about to end and neither the node nor its delegate has voted
+		if prop.State != types.ProtocolDaoProposalState_ActivePhase2 || prop.Phase2EndTime.Sub(now) > governanceVoteWarningWindow {
+			continue
+		}
+		warningKey := fmt.Sprintf("pdao-%d", prop.ID)
+		if t.state.WarnedProposals[warningKey] {
+			continue
+		}
+		voteDirection, err := protocol.GetAddressVoteDirection(t.rp, prop.ID, t.nodeAddress, opts)
+		if err != nil {
+			return fmt.Errorf("error getting the node's vote on proposal %d: %w", prop.ID, err)
+		}
+		if voteDirection == types.VoteDirection_NoVote {
+			if delegate == nil {
+				currentDelegate, err := network.GetCurrentVotingDelegate(t.rp, t.nodeAddress, opts)
+				if err != nil {
+					return fmt.Errorf("error getting the node's voting delegate: %w", err)
+				}
+				delegate = &currentDelegate
+			}
+			if *delegate != t.nodeAddress {
+				voteDirection, err = protocol.GetAddressVoteDirection(t.rp, prop.ID, *delegate, opts)
+				if err != nil {
+					return fmt.Errorf("error getting the delegate's vote on proposal %d: %w", prop.ID, err)
+				}
+			}
+		}
+		if voteDirection == types.VoteDirection_NoVote {
+			t.log.Printlnf("Voting on Protocol DAO proposal %d ends soon and the node hasn't voted.", prop.ID)
+			alerting.AlertGovernanceProposalExpiringWithoutVote(t.cfg, alerting.GovernanceDao_Protocol, prop.ID, prop.Message, prop.Phase2EndTime)
+		}
+		t.state.WarnedProposals[warningKey] = true
+	}
+	return nil
+}
+
+// Alert on new Oracle DAO or security council proposals, the start of voting, and proposals the node hasn't voted on; if sendAlerts is false, the proposal states are only recorded
+func (t *alertGovernance) checkDaoProposals(daoType alerting.GovernanceDao, daoName string, now time.Time, opts *bind.CallOpts, sendAlerts bool) error {
+	props, err := dao.GetDAOProposalsWithMember(t.rp, daoName, t.nodeAddress, opts)
+	if err != nil {
+		return err
+	}
+	for _, prop := range props {
+		// The Oracle DAO and security council number their proposals separately
+		stateKey := fmt.Sprintf("%s-%d", daoName, prop.ID)
+		lastState, exists := t.state.DaoStates[stateKey]
+		t.state.DaoStates[stateKey] = prop.State
+
+		if !sendAlerts {
+			continue
+		}
+		if !exists {
+			if t.isInitialized {
+				t.log.Printlnf("%s proposal %d was created.", daoType, prop.ID)
+				alerting.AlertGovernanceProposalCreated(t.cfg, daoType, prop.ID, prop.Message, prop.ProposerAddress)
+			}
+		} else if lastState != prop.State && prop.State == types.Active {
+			t.log.Printlnf("Voting on %s proposal %d has started.", daoType, prop.ID)
+			alerting.AlertGovernanceProposalPhaseChanged(t.cfg, daoType, prop.ID, prop.Message, "open for voting", time.Unix(int64(prop.EndTime), 0))
+		}
+
+		// Warn if voting is about to end and the node hasn't voted
+		endTime := time.Unix(int64(prop.EndTime), 0)
+		if prop.State != types.Active || prop.MemberVoted || endTime.Sub(now) > governanceVoteWarningWindow {
+			continue
+		}
+		if t.state.WarnedProposals[stateKey] {
+			continue
+		}
+		t.log.Printlnf("Voting on %s proposal %d ends soon and the node hasn't voted.", daoType, prop.ID)
+		alerting.AlertGovernanceProposalExpiringWithoutVote(t.cfg, daoType, prop.ID, prop.Message, endTime)
+		t.state.WarnedProposals[stateKey] = true
+	}
+	return nil
+}
+
+// Load the saved governance alert state, returning nil if it hasn't been saved yet
+func loadGovernanceAlertState(path string) (*governanceAlertState, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading governance alert state: %w", err)
+	}
+	alertState := &governanceAlertState{}
+	if err := json.Unmarshal(bytes, alertState); err != nil {
+		return nil, fmt.Errorf("error deserializing governance alert state: %w", err)
+	}
+	return alertState, nil
+}
+
+// Save the governance alert state
+func saveGovernanceAlertState(path string, alertState *governanceAlertState) error {
+	bytes, err := json.Marshal(alertState)
+	if err != nil {
+		return fmt.Errorf("error serializing governance alert state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating governance alert state folder: %w", err)
+	}
+
+	// Write to a temp file first so the state is never left half-written
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, governanceAlertStateFileMode); err != nil {
+		return fmt.Errorf("error writing governance alert state: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving governance alert state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/blocks"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Settings
+const maxAuditSlotsPerRun uint64 = 320
+
+// A validator that belongs to one of the node's minipools
+type auditedValidator struct {
+	pubkey          types.ValidatorPubkey
+	minipoolAddress common.Address
+}
+
+// Audit fee recipients task
+type auditFeeRecipients struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	rp  *rocketpool.RocketPool
+	ec  *services.ExecutionClientManager
+	bc  beacon.Client
+
+	// The node to audit, and where to keep its proposal history
+	nodeAddress common.Address
+	historyPath string
+}
+
+// Create audit fee recipients task
+func newAuditFeeRecipients(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address, historyPath string) (*auditFeeRecipients, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &auditFeeRecipients{
+		c:   c,
+		log: logger,
+		cfg: cfg,
+		rp:  rp,
+		ec:  ec,
+		bc:  bc,
+
+		nodeAddress: nodeAddress,
+		historyPath: historyPath,
+	}, nil
+
+}
+
+// Scan the blocks since the last run for proposals by the node's validators, record how much each one was worth, and check that it used a fee recipient that won't get the node penalized
+func (a *auditFeeRecipients) run(state *state.NetworkState) error {
+
+	// Load the history
+	historyPath := a.historyPath
+	history, err := blocks.LoadProposalHistory(historyPath)
+	if err != nil {
+		return err
+	}
+
+	// Get the slots to scan; on the first run, start from the current slot instead of scanning the whole chain
+	endSlot := state.BeaconSlotNumber
+	if history.LastScannedSlot == 0 || history.LastScannedSlot > endSlot {
+		history.LastScannedSlot = endSlot
+		return history.Save(historyPath)
+	}
+	if history.LastScannedSlot == endSlot {
+		return nil
+	}
+	startSlot := history.LastScannedSlot + 1
+	if endSlot-startSlot+1 > maxAuditSlotsPerRun {
+		endSlot = startSlot + maxAuditSlotsPerRun - 1
+	}
+
+	// Map the node's validator indices to their minipools
+	validators := map[string]auditedValidator{}
+	for _, mpd := range state.MinipoolDetailsByNode[a.nodeAddress] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		validators[status.Index] = auditedValidator{
+			pubkey:          mpd.Pubkey,
+			minipoolAddress: mpd.MinipoolAddress,
+		}
+	}
+
+	// Log
+	a.log.Printlnf("Auditing fee recipients of blocks in slots %d to %d...", startSlot, endSlot)
+
+	// Scan the blocks
+	for slot := startSlot; slot <= endSlot; slot++ {
+		if len(validators) > 0 {
+			block, exists, err := a.bc.GetBeaconBlock(fmt.Sprint(slot))
+			if err != nil {
+				return fmt.Errorf("error getting block for slot %d: %w", slot, err)
+			}
+			validator, isNodeValidator := validators[block.ProposerIndex]
+			if exists && isNodeValidator && block.HasExecutionPayload {
+				record, err := a.auditBlock(a.nodeAddress, &block, validator, state)
+				if err != nil {
+					return fmt.Errorf("error auditing block for slot %d: %w", slot, err)
+				}
+				history.Proposals = append(history.Proposals, record)
+				feeRecipient := record.FeeRecipient
+				if record.IsMevBlock {
+					feeRecipient = record.MevRecipient
+				}
+				if record.IsCorrect {
+					a.log.Printlnf("Minipool %s proposed the block in slot %d with a correct fee recipient (value: %.6f ETH).", record.MinipoolAddress.Hex(), slot, eth.WeiToEth(record.PayloadValue))
+				} else {
+					a.log.Println("=== INCORRECT FEE RECIPIENT DETECTED ===")
+					a.log.Printlnf("Beacon Block:  %d", slot)
+					a.log.Printlnf("Minipool:      %s", record.MinipoolAddress.Hex())
+					a.log.Printlnf("Expected:      %s", record.ExpectedFeeRecipient.Hex())
+					a.log.Printlnf("FEE RECIPIENT: %s", feeRecipient.Hex())
+					a.log.Println("========================================")
+					alerting.AlertFeeRecipientMismatch(a.cfg, a.nodeAddress, record.MinipoolAddress, slot, feeRecipient, record.ExpectedFeeRecipient)
+				}
+			}
+		}
+		history.LastScannedSlot = slot
+	}
+
+	// Record the Smoothing Pool's progress in the current interval so proposals can be compared against it
+	spNodeCount, err := node.GetSmoothingPoolRegisteredNodeCount(a.rp, nil)
+	if err != nil {
+		a.log.Printlnf("WARNING: couldn't get the number of nodes in the Smoothing Pool: %s", err.Error())
+	} else {
+		history.UpdateInterval(blocks.IntervalSnapshot{
+			Index:                  state.NetworkDetails.RewardIndex,
+			Start:                  state.NetworkDetails.IntervalStart,
+			SmoothingPoolBalance:   state.NetworkDetails.SmoothingPoolBalance,
+			SmoothingPoolNodeCount: spNodeCount,
+		})
+	}
+
+	// Save the history
+	return history.Save(historyPath)
+
+}
+
+// Check a block proposed by one of the node's validators against the node's smoothing pool status at the time
+func (a *auditFeeRecipients) auditBlock(nodeAddress common.Address, block *beacon.BeaconBlock, validator auditedValidator, state *state.NetworkState) (blocks.ProposalRecord, error) {
+
+	beaconConfig := state.BeaconConfig
+	record := blocks.ProposalRecord{
+		Slot:            block.Slot,
+		Time:            time.Unix(int64(beaconConfig.GenesisTime+block.Slot*beaconConfig.SecondsPerSlot), 0),
+		BlockNumber:     block.ExecutionBlockNumber,
+		ValidatorIndex:  block.ProposerIndex,
+		ValidatorPubkey: validator.pubkey,
+		MinipoolAddress: validator.minipoolAddress,
+		FeeRecipient:    block.FeeRecipient,
+		MevReward:       big.NewInt(0),
+		PayloadValue:    big.NewInt(0),
+		RewardsInterval: state.NetworkDetails.RewardIndex,
+	}
+	if record.Time.Before(state.NetworkDetails.IntervalStart) && record.RewardsInterval > 0 {
+		record.RewardsInterval--
+	}
+
+	// Get the fee recipient info as of the block
+	blockNumber := big.NewInt(0).SetUint64(block.ExecutionBlockNumber)
+	opts := &bind.CallOpts{
+		BlockNumber: blockNumber,
+	}
+	info, err := rputils.GetFeeRecipientInfoAtEpoch(a.rp, a.bc, nodeAddress, opts, block.Slot/beaconConfig.SlotsPerEpoch)
+	if err != nil {
+		return blocks.ProposalRecord{}, fmt.Errorf("error getting fee recipient info: %w", err)
+	}
+	record.IsInSmoothingPool = info.IsInSmoothingPool
+	if info.IsInSmoothingPool || info.IsInOptOutCooldown {
+		record.ExpectedFeeRecipient = info.SmoothingPoolAddress
+	} else {
+		record.ExpectedFeeRecipient = info.FeeDistributorAddress
+	}
+	rethAddress := a.cfg.Smartnode.GetRethAddress()
+
+	// Blocks from builders pay the proposer with a transfer at the end of the block, so check that as well
+	executionBlock, err := a.ec.BlockByNumber(context.Background(), blockNumber)
+	if err != nil {
+		return blocks.ProposalRecord{}, fmt.Errorf("error getting execution block %d: %w", block.ExecutionBlockNumber, err)
+	}
+	txs := executionBlock.Transactions()
+	if len(txs) > 0 {
+		lastTx := txs[len(txs)-1]
+		if lastTx.To() != nil && *lastTx.To() != block.FeeRecipient && lastTx.Value().Sign() > 0 {
+			sender, err := gethtypes.Sender(gethtypes.LatestSignerForChainID(lastTx.ChainId()), lastTx)
+			if err == nil && sender == block.FeeRecipient {
+				record.IsMevBlock = true
+				record.MevRecipient = *lastTx.To()
+				record.MevReward = lastTx.Value()
+			}
+		}
+	}
+
+	// Get the value of the payload; builders pay the proposer directly, otherwise the fee recipient collects the priority fees
+	if record.IsMevBlock {
+		record.IsFromRelay = true
+		record.PayloadValue = record.MevReward
+	} else {
+		previousBlockNumber := big.NewInt(0).Sub(blockNumber, big.NewInt(1))
+		previousBalance, err := a.ec.BalanceAt(context.Background(), block.FeeRecipient, previousBlockNumber)
+		if err != nil {
+			return blocks.ProposalRecord{}, fmt.Errorf("error getting fee recipient balance at block %d: %w", previousBlockNumber.Uint64(), err)
+		}
+		balance, err := a.ec.BalanceAt(context.Background(), block.FeeRecipient, blockNumber)
+		if err != nil {
+			return blocks.ProposalRecord{}, fmt.Errorf("error getting fee recipient balance at block %d: %w", block.ExecutionBlockNumber, err)
+		}
+		delta := big.NewInt(0).Sub(balance, previousBalance)
+		if delta.Sign() > 0 {
+			record.PayloadValue = delta
+		}
+	}
+
+	// Ask the relays which one delivered the payload, if enabled
+	if a.cfg.EnableMevBoost.Value == true && a.cfg.MevBoost.QueryRelayData.Value == true {
+		a.queryRelays(&record)
+	}
+
+	// Check the fee recipient
+	if record.IsMevBlock {
+		record.IsCorrect = rputils.IsFeeRecipientAllowed(info, rethAddress, record.MevRecipient)
+	} else {
+		record.IsCorrect = rputils.IsFeeRecipientAllowed(info, rethAddress, record.FeeRecipient)
+	}
+	return record, nil
+
+}
+
+// Look up a proposal in the relays' data APIs, recording which relay delivered it and the value it reported
+func (a *auditFeeRecipients) queryRelays(record *blocks.ProposalRecord) {
+
+	// Only the enabled relays can have delivered the payload when MEV-Boost is managed by the Smartnode
+	var relays []cfgtypes.MevRelay
+	if a.cfg.MevBoost.Mode.Value == cfgtypes.Mode_Local {
+		relays = a.cfg.MevBoost.GetEnabledMevRelays()
+	} else {
+		relays = a.cfg.MevBoost.GetAvailableRelays()
+	}
+
+	network := a.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	for _, relay := range relays {
+		relayUrl := relay.Urls[network]
+		if relayUrl == "" {
+			continue
+		}
+		payload, delivered, err := blocks.GetDeliveredPayload(relayUrl, record.Slot)
+		if err != nil {
+			a.log.Printlnf("WARNING: couldn't check relay %s for slot %d: %s", relay.Name, record.Slot, err.Error())
+			continue
+		}
+		if !delivered || payload.BlockNumber != record.BlockNumber {
+			continue
+		}
+		record.IsFromRelay = true
+		record.RelayName = relay.Name
+		if !record.IsMevBlock {
+			record.PayloadValue = payload.Value
+		}
+		return
+	}
+
+}
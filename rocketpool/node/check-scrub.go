@@ -0,0 +1,113 @@
+package node
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/scrub"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Check scrub task
+type checkScrub struct {
+	c   *cli.Context
+	log log.ColorLogger
+	cfg *config.RocketPoolConfig
+	rp  *rocketpool.RocketPool
+
+	// The node whose minipools are checked
+	nodeAddress common.Address
+
+	// Minipools that have passed the check don't need to be checked again
+	passedMinipools map[common.Address]bool
+
+	// Minipools that have already been alerted on
+	failedMinipools map[common.Address]bool
+}
+
+// Create check scrub task
+func newCheckScrub(c *cli.Context, logger log.ColorLogger, nodeAddress common.Address) (*checkScrub, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkScrub{
+		c:               c,
+		log:             logger,
+		cfg:             cfg,
+		rp:              rp,
+		nodeAddress:     nodeAddress,
+		passedMinipools: map[common.Address]bool{},
+		failedMinipools: map[common.Address]bool{},
+	}, nil
+
+}
+
+// Run the Oracle DAO's scrub checks against the node's prelaunch minipools, and alert if any of them fail
+func (t *checkScrub) run(state *state.NetworkState) error {
+
+	// Get the prelaunch minipools that still need to be checked
+	minipools := []*rpstate.NativeMinipoolDetails{}
+	for _, mpd := range state.MinipoolDetailsByNode[t.nodeAddress] {
+		if mpd.Status != types.Prelaunch || mpd.IsVacant {
+			continue
+		}
+		if t.passedMinipools[mpd.MinipoolAddress] || t.failedMinipools[mpd.MinipoolAddress] {
+			continue
+		}
+		minipools = append(minipools, mpd)
+	}
+	if len(minipools) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Running the scrub check on %d prelaunch minipool(s)...", len(minipools))
+
+	// Run the checks
+	checks, err := scrub.CheckMinipools(t.rp, t.cfg, state, minipools)
+	if err != nil {
+		return err
+	}
+	for _, check := range checks {
+		for _, note := range check.Notes {
+			t.log.Printlnf("NOTE: minipool %s: %s.", check.Address.Hex(), note)
+		}
+		switch {
+		case check.IsFailed():
+			t.log.Printlnf("WARNING: minipool %s failed the scrub check: %s.", check.Address.Hex(), check.Reason)
+			if check.OffendingDeposit != nil {
+				t.log.Printlnf("\tOffending deposit: TX %s (block %d)", check.OffendingDeposit.TxHash.Hex(), check.OffendingDeposit.BlockNumber)
+			}
+			t.failedMinipools[check.Address] = true
+			alerting.AlertMinipoolScrubCheckFailed(t.cfg, t.nodeAddress, check.Address, check.Reason)
+		case check.Result == scrub.CheckResult_Passed:
+			t.log.Printlnf("Minipool %s passed the scrub check.", check.Address.Hex())
+			t.passedMinipools[check.Address] = true
+		case check.Result == scrub.CheckResult_Error:
+			// Not marked either way, so it's checked again next time
+			t.log.Printlnf("WARNING: couldn't check minipool %s: %s.", check.Address.Hex(), check.Reason)
+		default:
+			t.log.Printlnf("Minipool %s: %s.", check.Address.Hex(), check.Reason)
+		}
+	}
+
+	// Return
+	return nil
+
+}
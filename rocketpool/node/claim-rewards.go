@@ -0,0 +1,234 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Claim rewards task
+type claimRewards struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	deferredTxs    *deferred.Scheduler
+	disabled       bool
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+}
+
+// Create claim rewards task
+func newClaimRewards(c *cli.Context, logger log.ColorLogger, deferredTxs *deferred.Scheduler) (*claimRewards, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-claiming is disabled
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	disabled := !cfg.Smartnode.AutoClaimRewards.Value.(bool)
+	if !disabled && gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling auto-claim.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &claimRewards{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		deferredTxs:    deferredTxs,
+		disabled:       disabled,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+	}, nil
+
+}
+
+// Claim rewards
+func (t *claimRewards) run(state *state.NetworkState) error {
+
+	// Check if auto-claim is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for rewards to claim...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the unclaimed intervals
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	// Get the rewards for each interval that has a valid tree
+	indices := []*big.Int{}
+	indexStrings := []string{}
+	amountRPL := []*big.Int{}
+	amountETH := []*big.Int{}
+	merkleProofs := [][]common.Hash{}
+	for _, interval := range unclaimed {
+		intervalInfo, err := rprewards.GetIntervalInfo(t.rp, t.cfg, nodeAccount.Address, interval, nil)
+		if err != nil {
+			return fmt.Errorf("error getting info for interval %d: %w", interval, err)
+		}
+		if !intervalInfo.TreeFileExists || !intervalInfo.MerkleRootValid {
+			t.log.Printlnf("The rewards tree for interval %d isn't available yet, so it can't be claimed.", interval)
+			continue
+		}
+		if !intervalInfo.NodeExists {
+			continue
+		}
+
+		rplForInterval := big.NewInt(0)
+		rplForInterval.Add(rplForInterval, &intervalInfo.CollateralRplAmount.Int)
+		rplForInterval.Add(rplForInterval, &intervalInfo.ODaoRplAmount.Int)
+		ethForInterval := big.NewInt(0)
+		ethForInterval.Add(ethForInterval, &intervalInfo.SmoothingPoolEthAmount.Int)
+
+		indices = append(indices, big.NewInt(0).SetUint64(interval))
+		indexStrings = append(indexStrings, fmt.Sprint(interval))
+		amountRPL = append(amountRPL, rplForInterval)
+		amountETH = append(amountETH, ethForInterval)
+		merkleProofs = append(merkleProofs, intervalInfo.MerkleProof)
+	}
+
+	// Forget deferred claims for intervals that have been claimed already
+	key := strings.Join(indexStrings, ",")
+	keys := []string{}
+	if len(indices) > 0 {
+		keys = append(keys, key)
+	}
+	if err := t.deferredTxs.Retain(deferred.ActionType_ClaimRewards, keys); err != nil {
+		return err
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Claiming rewards for interval(s) %s...", key)
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := rewards.EstimateClaimGas(t.rp, nodeAccount.Address, indices, amountRPL, amountETH, merkleProofs, opts)
+	if err != nil {
+		return fmt.Errorf("could not estimate the gas required to claim rewards: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check if the claim should be deferred
+	decision, err := t.deferredTxs.Check(deferred.Request{
+		Type:        deferred.ActionType_ClaimRewards,
+		Key:         key,
+		Description: fmt.Sprintf("Claim rewards for interval(s) %s", key),
+		GasLimit:    gas.Uint64(),
+		MaxFeeWei:   maxFee,
+	}, &t.log)
+	if err != nil {
+		return err
+	}
+	if !decision.Submit {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Claim rewards
+	hash, err := rewards.Claim(t.rp, nodeAccount.Address, indices, amountRPL, amountETH, merkleProofs, opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully claimed rewards for interval(s) %s.", key)
+
+	// Return
+	return nil
+
+}
@@ -46,24 +46,24 @@ type BeaconCollector struct {
 }
 
 // Create a new BeaconCollector instance
-func NewBeaconCollector(rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpool.ExecutionClient, nodeAddress common.Address, stateLocker *StateLocker) *BeaconCollector {
+func NewBeaconCollector(rp *rocketpool.RocketPool, bc beacon.Client, ec rocketpool.ExecutionClient, nodeAddress common.Address, stateLocker *StateLocker, constLabels prometheus.Labels) *BeaconCollector {
 	subsystem := "beacon"
 	return &BeaconCollector{
 		activeSyncCommittee: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active_sync_committee"),
 			"The number of validators on a current sync committee",
-			nil, nil,
+			nil, constLabels,
 		),
 		upcomingSyncCommittee: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "upcoming_sync_committee"),
 			"The number of validators on the next sync committee",
-			nil, nil,
+			nil, constLabels,
 		),
 		upcomingProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "upcoming_proposals"),
 			"The number of proposals assigned to validators in this epoch and the next",
-			nil, nil,
+			nil, constLabels,
 		),
 		recentProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "recent_proposals"),
 			"The number of block proposals made by validators in the most recent finalized epoch",
-			nil, nil,
+			nil, constLabels,
 		),
 		rp:          rp,
 		bc:          bc,
@@ -0,0 +1,81 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Represents the collector for the requests the daemon has sent to the Beacon node
+type BeaconRequestCollector struct {
+	// The number of requests sent to the Beacon node, by route
+	requests *prometheus.Desc
+
+	// The number of requests that failed, by route
+	failures *prometheus.Desc
+
+	// The number of responses that were SSZ-encoded instead of JSON, by route
+	sszResponses *prometheus.Desc
+
+	// The number of bytes downloaded from the Beacon node, by route
+	bytesReceived *prometheus.Desc
+
+	// The total time spent on requests, by route
+	duration *prometheus.Desc
+
+	// The Beacon client
+	bc beacon.Client
+}
+
+// Create a new BeaconRequestCollector instance
+func NewBeaconRequestCollector(bc beacon.Client) *BeaconRequestCollector {
+	subsystem := "beacon_requests"
+	return &BeaconRequestCollector{
+		requests: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "total"),
+			"The number of requests sent to the Beacon node",
+			[]string{"route"}, nil,
+		),
+		failures: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures_total"),
+			"The number of requests to the Beacon node that failed",
+			[]string{"route"}, nil,
+		),
+		sszResponses: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "ssz_responses_total"),
+			"The number of responses from the Beacon node that were SSZ-encoded",
+			[]string{"route"}, nil,
+		),
+		bytesReceived: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "received_bytes_total"),
+			"The number of bytes downloaded from the Beacon node",
+			[]string{"route"}, nil,
+		),
+		duration: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "duration_seconds_total"),
+			"The total time spent on requests to the Beacon node, in seconds",
+			[]string{"route"}, nil,
+		),
+		bc: bc,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *BeaconRequestCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.requests
+	channel <- collector.failures
+	channel <- collector.sszResponses
+	channel <- collector.bytesReceived
+	channel <- collector.duration
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *BeaconRequestCollector) Collect(channel chan<- prometheus.Metric) {
+	for route, metrics := range collector.bc.GetRequestMetrics() {
+		channel <- prometheus.MustNewConstMetric(
+			collector.requests, prometheus.CounterValue, float64(metrics.Requests), route)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failures, prometheus.CounterValue, float64(metrics.Failures), route)
+		channel <- prometheus.MustNewConstMetric(
+			collector.sszResponses, prometheus.CounterValue, float64(metrics.SszResponses), route)
+		channel <- prometheus.MustNewConstMetric(
+			collector.bytesReceived, prometheus.CounterValue, float64(metrics.BytesReceived), route)
+		channel <- prometheus.MustNewConstMetric(
+			collector.duration, prometheus.CounterValue, metrics.TotalDuration.Seconds(), route)
+	}
+}
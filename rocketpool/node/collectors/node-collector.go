@@ -121,7 +121,7 @@ type NodeCollector struct {
 }
 
 // Create a new NodeCollector instance
-func NewNodeCollector(rp *rocketpool.RocketPool, bc *services.BeaconClientManager, ec *services.ExecutionClientManager, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker) *NodeCollector {
+func NewNodeCollector(rp *rocketpool.RocketPool, bc *services.BeaconClientManager, ec *services.ExecutionClientManager, nodeAddress common.Address, cfg *config.RocketPoolConfig, stateLocker *StateLocker, constLabels prometheus.Labels) *NodeCollector {
 
 	// Get the event log interval
 	eventLogInterval, err := cfg.GetEventLogInterval()
@@ -134,83 +134,83 @@ func NewNodeCollector(rp *rocketpool.RocketPool, bc *services.BeaconClientManage
 	return &NodeCollector{
 		totalStakedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "total_staked_rpl"),
 			"The total amount of RPL staked on the node",
-			nil, nil,
+			nil, constLabels,
 		),
 		effectiveStakedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "effective_staked_rpl"),
 			"The effective amount of RPL staked on the node (honoring the 150% collateral cap)",
-			nil, nil,
+			nil, constLabels,
 		),
 		rewardableStakedRpl: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rewardable_staked_rpl"),
 			"The amount of staked RPL that will be eligible for rewards (including Beacon Chain data and accounding for pending bond reductions)",
-			nil, nil,
+			nil, constLabels,
 		),
 		cumulativeRplRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "cumulative_rpl_rewards"),
 			"The cumulative RPL rewards earned by the node",
-			nil, nil,
+			nil, constLabels,
 		),
 		expectedRplRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "expected_rpl_rewards"),
 			"The expected RPL rewards for the node at the next rewards checkpoint",
-			nil, nil,
+			nil, constLabels,
 		),
 		rplApr: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rpl_apr"),
 			"The estimated APR of RPL for the node from the next rewards checkpoint",
-			nil, nil,
+			nil, constLabels,
 		),
 		balances: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "balance"),
 			"How much ETH is in this node wallet",
-			[]string{"Token"}, nil,
+			[]string{"Token"}, constLabels,
 		),
 		activeMinipoolCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "active_minipool_count"),
 			"The number of active minipools owned by the node",
-			nil, nil,
+			nil, constLabels,
 		),
 		depositedEth: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "deposited_eth"),
 			"The amount of ETH this node deposited into minipools",
-			nil, nil,
+			nil, constLabels,
 		),
 		beaconShare: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "beacon_share"),
 			"The node's total share of its minipool's beacon chain balances",
-			nil, nil,
+			nil, constLabels,
 		),
 		beaconBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "beacon_balance"),
 			"The total balances of all this node's validators on the beacon chain",
-			nil, nil,
+			nil, constLabels,
 		),
 		clientSyncProgress: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "sync_progress"),
 			"The sync progress of the beacon and execution clients",
-			[]string{"client"}, nil,
+			[]string{"client"}, constLabels,
 		),
 		minipoolBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_balance"),
 			"The total EL balance of all minipools belonging to this node",
-			nil, nil,
+			nil, constLabels,
 		),
 		minipoolShare: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "minipool_share"),
 			"The node's share of the total minipool EL balance",
-			nil, nil,
+			nil, constLabels,
 		),
 		refundBalance: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "refund_balance"),
 			"The amount of ETH waiting to be refunded for all minipools",
-			nil, nil,
+			nil, constLabels,
 		),
 		unclaimedRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unclaimed_rewards"),
 			"The RPL rewards from the last period that have not been claimed yet",
-			nil, nil,
+			nil, constLabels,
 		),
 		claimedEthRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claimed_eth_rewards"),
 			"The claimed ETH rewards from the smoothing pool",
-			nil, nil,
+			nil, constLabels,
 		),
 		unclaimedEthRewards: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "unclaimed_eth_rewards"),
 			"The unclaimed ETH rewards from the smoothing pool",
-			nil, nil,
+			nil, constLabels,
 		),
 		borrowedCollateralRatio: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "borrowed_collateral_ratio"),
 			"The collateral ratio with respect to the amount of borrowed ETH",
-			nil, nil,
+			nil, constLabels,
 		),
 		bondedCollateralRatio: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bonded_collateral_ratio"),
 			"The collateral ratio with respect to the amount of bonded ETH",
-			nil, nil,
+			nil, constLabels,
 		),
 		rp:               rp,
 		bc:               bc,
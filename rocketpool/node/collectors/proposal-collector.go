@@ -0,0 +1,161 @@
+package collectors
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/blocks"
+)
+
+// Represents the collector for the value of the node's block proposals
+type ProposalCollector struct {
+	// The number of proposals recorded for this node's validators, by source
+	proposalCount *prometheus.Desc
+
+	// The total value of the proposals recorded for this node's validators, by source
+	proposalValue *prometheus.Desc
+
+	// The value of the most recent proposal
+	lastProposalValue *prometheus.Desc
+
+	// The slot of the most recent proposal
+	lastProposalSlot *prometheus.Desc
+
+	// The number of proposals that used an incorrect fee recipient
+	incorrectProposals *prometheus.Desc
+
+	// The total value of this node's proposals in the current rewards interval
+	intervalProposalValue *prometheus.Desc
+
+	// The Smoothing Pool balance per opted-in node in the current rewards interval
+	intervalSmoothingPoolNodeAvg *prometheus.Desc
+
+	// The path of the proposal history file written by the fee recipient audit task
+	historyPath string
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new ProposalCollector instance
+func NewProposalCollector(historyPath string, constLabels prometheus.Labels) *ProposalCollector {
+	subsystem := "proposals"
+	return &ProposalCollector{
+		proposalCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "count"),
+			"The number of block proposals recorded for this node's validators",
+			[]string{"source"}, constLabels,
+		),
+		proposalValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "value_eth"),
+			"The total value of the block proposals recorded for this node's validators, in ETH",
+			[]string{"source"}, constLabels,
+		),
+		lastProposalValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_value_eth"),
+			"The value of the most recent block proposal by this node's validators, in ETH",
+			nil, constLabels,
+		),
+		lastProposalSlot: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_slot"),
+			"The slot of the most recent block proposal by this node's validators",
+			nil, constLabels,
+		),
+		incorrectProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "incorrect_fee_recipient"),
+			"The number of block proposals by this node's validators that used an incorrect fee recipient",
+			nil, constLabels,
+		),
+		intervalProposalValue: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "interval_value_eth"),
+			"The total value of the block proposals by this node's validators in the current rewards interval, in ETH",
+			nil, constLabels,
+		),
+		intervalSmoothingPoolNodeAvg: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "interval_smoothing_pool_node_avg_eth"),
+			"The Smoothing Pool balance divided by the number of opted-in nodes in the current rewards interval, in ETH",
+			nil, constLabels,
+		),
+		historyPath: historyPath,
+		logPrefix:   "Proposal Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ProposalCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.proposalCount
+	channel <- collector.proposalValue
+	channel <- collector.lastProposalValue
+	channel <- collector.lastProposalSlot
+	channel <- collector.incorrectProposals
+	channel <- collector.intervalProposalValue
+	channel <- collector.intervalSmoothingPoolNodeAvg
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ProposalCollector) Collect(channel chan<- prometheus.Metric) {
+	// Load the history recorded by the fee recipient audit task
+	history, err := blocks.LoadProposalHistory(collector.historyPath)
+	if err != nil {
+		collector.logError(err)
+		return
+	}
+
+	// Total up the proposals by source
+	counts := map[string]float64{
+		"local": 0,
+		"relay": 0,
+	}
+	values := map[string]float64{
+		"local": 0,
+		"relay": 0,
+	}
+	for _, proposal := range history.Proposals {
+		source := "local"
+		if proposal.IsFromRelay {
+			source = "relay"
+		}
+		counts[source]++
+		if proposal.PayloadValue != nil {
+			values[source] += eth.WeiToEth(proposal.PayloadValue)
+		}
+	}
+	for source, count := range counts {
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposalCount, prometheus.GaugeValue, count, source)
+		channel <- prometheus.MustNewConstMetric(
+			collector.proposalValue, prometheus.GaugeValue, values[source], source)
+	}
+
+	// Get the most recent proposal
+	lastProposalValue := float64(0)
+	lastProposalSlot := float64(0)
+	if len(history.Proposals) > 0 {
+		lastProposal := history.Proposals[len(history.Proposals)-1]
+		lastProposalSlot = float64(lastProposal.Slot)
+		if lastProposal.PayloadValue != nil {
+			lastProposalValue = eth.WeiToEth(lastProposal.PayloadValue)
+		}
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastProposalValue, prometheus.GaugeValue, lastProposalValue)
+	channel <- prometheus.MustNewConstMetric(
+		collector.lastProposalSlot, prometheus.GaugeValue, lastProposalSlot)
+	channel <- prometheus.MustNewConstMetric(
+		collector.incorrectProposals, prometheus.GaugeValue, float64(history.GetIncorrectProposalCount()))
+
+	// Compare the current interval against the Smoothing Pool
+	intervals := history.GetIntervalSummaries()
+	intervalValue := float64(0)
+	intervalSmoothingPoolNodeAvg := float64(0)
+	if len(intervals) > 0 {
+		intervalValue = eth.WeiToEth(intervals[0].TotalPayloadValue)
+		if intervals[0].HasSmoothingPoolStats {
+			intervalSmoothingPoolNodeAvg = eth.WeiToEth(intervals[0].SmoothingPoolNodeAvg)
+		}
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.intervalProposalValue, prometheus.GaugeValue, intervalValue)
+	channel <- prometheus.MustNewConstMetric(
+		collector.intervalSmoothingPoolNodeAvg, prometheus.GaugeValue, intervalSmoothingPoolNodeAvg)
+}
+
+// Log error messages
+func (collector *ProposalCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+}
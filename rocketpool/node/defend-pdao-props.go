@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
@@ -141,6 +142,7 @@ func (t *defendPdaoProps) run(state *state.NetworkState) error {
 	// Defend props
 	for _, prop := range defendableProps {
 		err := t.defendProposal(prop)
+		alerting.AlertPdaoChallengeDefended(t.cfg, prop.proposal.ID, prop.challengeEvent.Index.Uint64(), err == nil)
 		if err != nil {
 			return fmt.Errorf("error submitting response for proposal %d, challenged index %d: %w", prop.proposal.ID, prop.challengeEvent.Index.Uint64(), err)
 		}
@@ -218,6 +220,7 @@ func (t *defendPdaoProps) getDefendableProposals(state *state.NetworkState, opts
 		}
 		if state == types.ChallengeState_Challenged {
 			t.log.Printlnf("Proposal %d, index %d has been challenged by %s.", propID, index, event.Challenger.Hex())
+			alerting.AlertPdaoProposalChallenged(t.cfg, propID, index, event.Challenger)
 			defendableProposals = append(defendableProposals, defendableProposal{
 				challengeEvent: &event,
 				proposal:       propMap[propID],
@@ -256,7 +259,7 @@ func (t *defendPdaoProps) defendProposal(prop defendableProposal) error {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return err
 		}
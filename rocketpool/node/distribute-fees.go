@@ -0,0 +1,207 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Distribute fees task
+type distributeFees struct {
+	c                   *cli.Context
+	log                 log.ColorLogger
+	cfg                 *config.RocketPoolConfig
+	w                   *wallet.Wallet
+	rp                  *rocketpool.RocketPool
+	deferredTxs         *deferred.Scheduler
+	distributeThreshold *big.Int
+	disabled            bool
+	maxFee              *big.Int
+	maxPriorityFee      *big.Int
+	gasLimit            uint64
+}
+
+// Create distribute fees task
+func newDistributeFees(c *cli.Context, logger log.ColorLogger, deferredTxs *deferred.Scheduler) (*distributeFees, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-distributing is disabled
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	distributeThreshold := cfg.Smartnode.AutoDistributeFeesThreshold.Value.(float64)
+	disabled := false
+	if distributeThreshold == 0 {
+		disabled = true
+	} else if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling auto-distribute for the fee distributor.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Return task
+	return &distributeFees{
+		c:                   c,
+		log:                 logger,
+		cfg:                 cfg,
+		w:                   w,
+		rp:                  rp,
+		deferredTxs:         deferredTxs,
+		distributeThreshold: eth.EthToWei(distributeThreshold),
+		disabled:            disabled,
+		maxFee:              maxFee,
+		maxPriorityFee:      priorityFee,
+		gasLimit:            0,
+	}, nil
+
+}
+
+// Distribute the fee distributor's balance
+func (t *distributeFees) run(state *state.NetworkState) error {
+
+	// Check if auto-distribute is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking the fee distributor balance...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the fee distributor details
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists {
+		return fmt.Errorf("node %s was not found in the network state", nodeAccount.Address.Hex())
+	}
+	if !nodeDetails.FeeDistributorInitialised {
+		return nil
+	}
+	distributorAddress := nodeDetails.FeeDistributorAddress
+	balance := nodeDetails.DistributorBalance
+	if balance.Sign() == 0 {
+		return t.deferredTxs.Remove(deferred.ActionType_DistributeFees, distributorAddress.Hex())
+	}
+	if balance.Cmp(t.distributeThreshold) < 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Distributing fee distributor %s (total balance of %.6f ETH)...", distributorAddress.Hex(), eth.WeiToEth(balance))
+
+	distributor, err := node.NewDistributor(t.rp, distributorAddress, nil)
+	if err != nil {
+		return fmt.Errorf("cannot create binding for fee distributor %s: %w", distributorAddress.Hex(), err)
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := distributor.EstimateDistributeGas(opts)
+	if err != nil {
+		return fmt.Errorf("could not estimate the gas required to distribute fee distributor %s: %w", distributorAddress.Hex(), err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Check if the distribution should be deferred
+	decision, err := t.deferredTxs.Check(deferred.Request{
+		Type:        deferred.ActionType_DistributeFees,
+		Key:         distributorAddress.Hex(),
+		Description: fmt.Sprintf("Distribute the balance of fee distributor %s", distributorAddress.Hex()),
+		GasLimit:    gas.Uint64(),
+		MaxFeeWei:   maxFee,
+	}, &t.log)
+	if err != nil {
+		return err
+	}
+	if !decision.Submit {
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Distribute
+	hash, err := distributor.Distribute(opts)
+	if err != nil {
+		return err
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully distributed the balance of fee distributor %s.", distributorAddress.Hex())
+
+	// Return
+	return nil
+
+}
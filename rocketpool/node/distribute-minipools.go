@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -34,7 +35,7 @@ type distributeMinipools struct {
 	rp                  *rocketpool.RocketPool
 	bc                  beacon.Client
 	d                   *client.Client
-	gasThreshold        float64
+	deferredTxs         *deferred.Scheduler
 	distributeThreshold *big.Int
 	disabled            bool
 	eight               *big.Int
@@ -44,7 +45,7 @@ type distributeMinipools struct {
 }
 
 // Create distribute minipools task
-func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distributeMinipools, error) {
+func newDistributeMinipools(c *cli.Context, logger log.ColorLogger, deferredTxs *deferred.Scheduler) (*distributeMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -114,7 +115,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		rp:                  rp,
 		bc:                  bc,
 		d:                   d,
-		gasThreshold:        gasThreshold,
+		deferredTxs:         deferredTxs,
 		distributeThreshold: eth.EthToWei(distributeThreshold),
 		disabled:            disabled,
 		eight:               eth.EthToWei(8),
@@ -152,6 +153,15 @@ func (t *distributeMinipools) run(state *state.NetworkState) error {
 	if err != nil {
 		return err
 	}
+
+	// Forget deferred distributions for minipools that can't be distributed any more
+	keys := make([]string, len(minipools))
+	for i, mpd := range minipools {
+		keys[i] = mpd.MinipoolAddress.Hex()
+	}
+	if err := t.deferredTxs.Retain(deferred.ActionType_DistributeMinipool, keys); err != nil {
+		return err
+	}
 	if len(minipools) == 0 {
 		return nil
 	}
@@ -242,14 +252,24 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	// Check if the distribution should be deferred
+	decision, err := t.deferredTxs.Check(deferred.Request{
+		Type:        deferred.ActionType_DistributeMinipool,
+		Key:         mpd.MinipoolAddress.Hex(),
+		Description: fmt.Sprintf("Distribute the balance of minipool %s", mpd.MinipoolAddress.Hex()),
+		GasLimit:    gas.Uint64(),
+		MaxFeeWei:   maxFee,
+	}, &t.log)
+	if err != nil {
+		return false, err
+	}
+	if !decision.Submit {
 		return false, nil
 	}
 
@@ -2,6 +2,7 @@ package node
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/common"
@@ -106,32 +107,59 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 		return fmt.Errorf("error validating fee recipient files: %w", err)
 	}
 
+	restartVc := true
 	if !fileExists {
 		m.log.Println("Fee recipient files don't all exist, regenerating...")
 	} else if !correctAddress {
 		m.log.Printlnf("WARNING: Fee recipient files did not contain the correct fee recipient of %s, regenerating...", correctFeeRecipient.Hex())
 	} else {
-		// Files are all correct, return.
-		return nil
+		// Files are all correct
+		restartVc = false
+	}
+
+	if restartVc {
+		// Regenerate the fee recipient files
+		err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
+		alerting.AlertFeeRecipientChanged(m.cfg, correctFeeRecipient, err == nil)
+		if err != nil {
+			m.log.Println("***ERROR***")
+			m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
+			return m.stopValidator()
+		}
+		m.log.Println("Fee recipient files updated successfully!")
 	}
 
-	// Regenerate the fee recipient files
-	err = rpsvc.UpdateFeeRecipientFile(correctFeeRecipient, m.cfg)
-	alerting.AlertFeeRecipientChanged(m.cfg, correctFeeRecipient, err == nil)
+	// Get the per-validator proposal settings
+	settings, err := m.getProposerSettings(nodeAccount.Address, feeRecipientInfo, state)
+	if err != nil {
+		return fmt.Errorf("error getting per-validator proposer settings: %w", err)
+	}
+
+	// Render them into the VC's proposer config files
+	configChanged, err := rpsvc.UpdateProposerConfigFiles(correctFeeRecipient, settings, m.cfg)
 	if err != nil {
 		m.log.Println("***ERROR***")
-		m.log.Printlnf("Error updating fee recipient files: %s", err.Error())
-		m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
+		m.log.Printlnf("Error updating proposer config files: %s", err.Error())
+		return m.stopValidator()
+	}
+	if configChanged {
+		m.log.Println("Per-validator proposer config files updated successfully!")
+		restartVc = true
+	}
 
-		err = validator.StopValidator(m.cfg, m.bc, &m.log, m.d)
-		if err != nil {
-			return fmt.Errorf("error stopping validator client: %w", err)
-		}
+	// Push them to the VC's keymanager API if it doesn't use config files
+	err = rpsvc.ApplyKeymanagerProposerSettings(settings, m.cfg)
+	if err != nil {
+		m.log.Printlnf("WARNING: Couldn't apply per-validator proposer settings through the Validator Client's keymanager API: %s", err.Error())
+		m.log.Println("Your validators will use the default graffiti and fee recipient until this succeeds; it will be retried automatically.")
+	}
+
+	if !restartVc {
 		return nil
 	}
 
 	// Restart the VC
-	m.log.Println("Fee recipient files updated successfully! Restarting validator client...")
+	m.log.Println("Restarting validator client...")
 	err = validator.RestartValidator(m.cfg, m.bc, &m.log, m.d)
 	if err != nil {
 		return fmt.Errorf("error restarting validator client: %w", err)
@@ -142,3 +170,52 @@ func (m *manageFeeRecipient) run(state *state.NetworkState) error {
 	return nil
 
 }
+
+// Stop the VC after failing to write its fee recipient settings
+func (m *manageFeeRecipient) stopValidator() error {
+	m.log.Println("Shutting down the validator client for safety to prevent you from being penalized...")
+	err := validator.StopValidator(m.cfg, m.bc, &m.log, m.d)
+	if err != nil {
+		return fmt.Errorf("error stopping validator client: %w", err)
+	}
+	return nil
+}
+
+// Get the proposal settings for each of the node's validators from the per-minipool overrides in the user settings.
+// Fee recipient overrides that would get the node penalized are ignored so the validator falls back to the default.
+func (m *manageFeeRecipient) getProposerSettings(nodeAddress common.Address, feeRecipientInfo *rputils.FeeRecipientInfo, state *state.NetworkState) ([]rpsvc.ValidatorProposerSettings, error) {
+
+	// Reload the settings file since the overrides can be changed without restarting the daemon
+	settingsFile := os.ExpandEnv(m.c.GlobalString("settings"))
+	cfg, err := rputils.LoadConfigFromFile(settingsFile)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("settings file [%s] not found", settingsFile)
+	}
+
+	rethAddress := m.cfg.Smartnode.GetRethAddress()
+	settings := []rpsvc.ValidatorProposerSettings{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		if mpd.Finalised {
+			continue
+		}
+		validatorSettings := rpsvc.ValidatorProposerSettings{
+			Pubkey: mpd.Pubkey,
+		}
+		if graffiti, exists := cfg.GetGraffitiOverride(mpd.MinipoolAddress); exists {
+			validatorSettings.Graffiti = graffiti
+		}
+		if feeRecipient, exists := cfg.GetFeeRecipientOverride(mpd.MinipoolAddress); exists {
+			if rputils.IsFeeRecipientAllowed(feeRecipientInfo, rethAddress, feeRecipient) {
+				validatorSettings.FeeRecipient = &feeRecipient
+			} else {
+				m.log.Printlnf("WARNING: Minipool %s has a fee recipient override of %s, which would get it penalized with your current smoothing pool status. Using the default fee recipient instead.", mpd.MinipoolAddress.Hex(), feeRecipient.Hex())
+			}
+		}
+		settings = append(settings, validatorSettings)
+	}
+	return settings, nil
+
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
@@ -14,17 +15,13 @@ import (
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, watchOnlyNodes []common.Address) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return err
 	}
-	w, err := services.GetWallet(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -37,10 +34,6 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	if err != nil {
 		return err
 	}
-	s, err := services.GetSnapshotDelegation(c)
-	if err != nil {
-		return err
-	}
 
 	// Return if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
@@ -51,21 +44,14 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 		}
 	}
 
-	nodeAccount, err := w.GetNodeAccount()
-	if err != nil {
-		return fmt.Errorf("Error getting node account: %w", err)
-	}
-
-	// Create the collectors
+	// Create the network collectors
 	demandCollector := collectors.NewDemandCollector(rp, stateLocker)
 	performanceCollector := collectors.NewPerformanceCollector(rp, stateLocker)
 	supplyCollector := collectors.NewSupplyCollector(rp, stateLocker)
 	rplCollector := collectors.NewRplCollector(rp, cfg, stateLocker)
 	odaoCollector := collectors.NewOdaoCollector(rp, stateLocker)
-	nodeCollector := collectors.NewNodeCollector(rp, bc, ec, nodeAccount.Address, cfg, stateLocker)
-	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
-	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
 	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	beaconRequestCollector := collectors.NewBeaconRequestCollector(bc)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -74,10 +60,43 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	registry.MustRegister(supplyCollector)
 	registry.MustRegister(rplCollector)
 	registry.MustRegister(odaoCollector)
+	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(beaconRequestCollector)
+
+	// In watch-only mode, collect the node metrics for each watched node, labelled by its address
+	if len(watchOnlyNodes) > 0 {
+		for _, nodeAddress := range watchOnlyNodes {
+			labels := prometheus.Labels{"node": nodeAddress.Hex()}
+			registry.MustRegister(collectors.NewNodeCollector(rp, bc, ec, nodeAddress, cfg, stateLocker, labels))
+			registry.MustRegister(collectors.NewBeaconCollector(rp, bc, ec, nodeAddress, stateLocker, labels))
+			registry.MustRegister(collectors.NewProposalCollector(cfg.Smartnode.GetWatchOnlyProposalHistoryPath(nodeAddress), labels))
+		}
+		return serveMetrics(c, logger, registry)
+	}
+
+	// Get the node account
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return err
+	}
+	s, err := services.GetSnapshotDelegation(c)
+	if err != nil {
+		return err
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return fmt.Errorf("Error getting node account: %w", err)
+	}
+
+	// Create the node collectors
+	nodeCollector := collectors.NewNodeCollector(rp, bc, ec, nodeAccount.Address, cfg, stateLocker, nil)
+	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
+	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker, nil)
+	proposalCollector := collectors.NewProposalCollector(cfg.Smartnode.GetProposalHistoryPath(), nil)
 	registry.MustRegister(nodeCollector)
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
-	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(proposalCollector)
 
 	// Set up snapshot checking if enabled
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
@@ -90,6 +109,13 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 		registry.MustRegister(snapshotCollector)
 	}
 
+	return serveMetrics(c, logger, registry)
+
+}
+
+// Serve the metrics in the registry over HTTP
+func serveMetrics(c *cli.Context, logger log.ColorLogger, registry *prometheus.Registry) error {
+
 	// Start the HTTP server
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 	metricsAddress := c.GlobalString("metricsAddress")
@@ -107,7 +133,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
             </html>`,
 		))
 	})
-	err = http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
+	err := http.ListenAndServe(fmt.Sprintf("%s:%d", metricsAddress, metricsPort), nil)
 	if err != nil {
 		return fmt.Errorf("Error running HTTP server: %w", err)
 	}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 	"net/http"
@@ -16,6 +17,12 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
@@ -27,7 +34,10 @@ import (
 // Config
 var tasksInterval, _ = time.ParseDuration("5m")
 var taskCooldown, _ = time.ParseDuration("10s")
+var minStateUpdateInterval, _ = time.ParseDuration("1m")
+var maxNetworkStateAge, _ = time.ParseDuration("8m")
 var totalEffectiveStakeCooldown, _ = time.ParseDuration("1h")
+var scrubPeriodEndMargin, _ = time.ParseDuration("30s")
 
 const (
 	MaxConcurrentEth1Requests = 200
@@ -36,11 +46,17 @@ const (
 	DownloadRewardsTreesColor    = color.FgGreen
 	MetricsColor                 = color.FgHiYellow
 	ManageFeeRecipientColor      = color.FgHiCyan
+	AuditFeeRecipientsColor      = color.FgCyan
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
 	DefendPdaoPropsColor         = color.FgYellow
 	VerifyPdaoPropsColor         = color.FgYellow
+	AlertGovernanceColor         = color.FgHiMagenta
 	DistributeMinipoolsColor     = color.FgHiGreen
+	CheckScrubColor              = color.FgHiRed
+	DistributeFeesColor          = color.FgGreen
+	ClaimRewardsColor            = color.FgHiCyan
+	EventsColor                  = color.FgHiBlack
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -61,12 +77,34 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 // Run daemon
 func run(c *cli.Context) error {
 
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+
+	// Only monitor the configured nodes if watch-only mode is enabled
+	watchOnlyNodes, err := cfg.Smartnode.GetWatchOnlyNodeAddresses()
+	if err != nil {
+		return err
+	}
+	if len(watchOnlyNodes) > 0 {
+		configureHTTP()
+		return runWatchOnly(c, watchOnlyNodes)
+	}
+
 	// Handle the initial fee recipient file deployment
-	err := deployDefaultFeeRecipientFile(c)
+	err = deployDefaultFeeRecipientFile(c)
 	if err != nil {
 		return err
 	}
 
+	// Make sure the proposer settings the VC is pointed at exist
+	err = rpsvc.DeployDefaultProposerSettings(cfg.Smartnode.GetRethAddress(), cfg)
+	if err != nil {
+		return fmt.Errorf("error deploying default proposer settings: %w", err)
+	}
+
 	// Clean up old fee recipient files
 	err = removeLegacyFeeRecipientFiles(c)
 	if err != nil {
@@ -82,10 +120,6 @@ func run(c *cli.Context) error {
 	}
 
 	// Get services
-	cfg, err := services.GetConfig(c)
-	if err != nil {
-		return err
-	}
 	rp, err := services.GetRocketPool(c)
 	if err != nil {
 		return err
@@ -122,20 +156,44 @@ func run(c *cli.Context) error {
 	}
 	stateLocker := collectors.NewStateLocker()
 
+	// Create the scheduler for deferred transactions
+	var feeClient feehistory.Client
+	if client, ok := rp.Client.(feehistory.Client); ok {
+		feeClient = client
+	}
+	deferredTxs, err := deferred.NewScheduler(cfg, feeClient)
+	if err != nil {
+		return err
+	}
+
+	// Watch for chain events so tasks can run as soon as they have something to do
+	eventLog := log.NewColorLogger(EventsColor)
+	bus := events.NewBus()
+	go events.WatchBeaconEvents(bc, bus, &eventLog)
+	go events.WatchLogs(rp, bus, &eventLog)
+
 	// Initialize tasks
 	manageFeeRecipient, err := newManageFeeRecipient(c, log.NewColorLogger(ManageFeeRecipientColor))
 	if err != nil {
 		return err
 	}
-	distributeMinipools, err := newDistributeMinipools(c, log.NewColorLogger(DistributeMinipoolsColor))
+	auditFeeRecipients, err := newAuditFeeRecipients(c, log.NewColorLogger(AuditFeeRecipientsColor), nodeAccount.Address, cfg.Smartnode.GetProposalHistoryPath())
+	if err != nil {
+		return err
+	}
+	distributeMinipools, err := newDistributeMinipools(c, log.NewColorLogger(DistributeMinipoolsColor), deferredTxs)
+	if err != nil {
+		return err
+	}
+	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor), deferredTxs, bus)
 	if err != nil {
 		return err
 	}
-	stakePrelaunchMinipools, err := newStakePrelaunchMinipools(c, log.NewColorLogger(StakePrelaunchMinipoolsColor))
+	checkScrub, err := newCheckScrub(c, log.NewColorLogger(CheckScrubColor), nodeAccount.Address)
 	if err != nil {
 		return err
 	}
-	promoteMinipools, err := newPromoteMinipools(c, log.NewColorLogger(PromoteMinipoolsColor))
+	promoteMinipools, err := newPromoteMinipools(c, log.NewColorLogger(PromoteMinipoolsColor), deferredTxs, bus)
 	if err != nil {
 		return err
 	}
@@ -143,7 +201,15 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
+	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor), deferredTxs)
+	if err != nil {
+		return err
+	}
+	distributeFees, err := newDistributeFees(c, log.NewColorLogger(DistributeFeesColor), deferredTxs)
+	if err != nil {
+		return err
+	}
+	claimRewards, err := newClaimRewards(c, log.NewColorLogger(ClaimRewardsColor), deferredTxs)
 	if err != nil {
 		return err
 	}
@@ -151,6 +217,10 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	alertGovernance, err := newAlertGovernance(c, log.NewColorLogger(AlertGovernanceColor))
+	if err != nil {
+		return err
+	}
 	var verifyPdaoProps *verifyPdaoProps
 	// Make sure the user opted into this duty
 	verifyEnabled := cfg.Smartnode.VerifyProposals.Value.(bool)
@@ -161,49 +231,37 @@ func run(c *cli.Context) error {
 		}
 	}
 
-	// Wait group to handle the various threads
-	wg := new(sync.WaitGroup)
-	wg.Add(2)
-
-	// Timestamp for caching total effective RPL stake
-	lastTotalEffectiveStakeTime := time.Unix(0, 0)
+	// Create the scheduler; each task runs on its own interval so a slow task doesn't hold up the others
+	taskScheduler := scheduler.NewScheduler(&errorLog, "")
+	networkState := &sharedNetworkState{
+		lock: &sync.RWMutex{},
+	}
 
-	// Run task loop
+	// Keep the network state up to date for the other tasks; tasks that react to a trigger run after the update that follows it.
+	// Network-wide events like prestakes can come in bursts, so the state isn't rebuilt more than once per minimum interval.
 	isHoustonDeployedMasterFlag := false
-	go func() {
-		// we assume clients are synced on startup so that we don't send unnecessary alerts
-		wasExecutionClientSynced := true
-		wasBeaconClientSynced := true
-		for {
-			// Check the EC status
-			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+	lastTotalEffectiveStakeTime := time.Unix(0, 0) // Timestamp for caching total effective RPL stake
+	syncTracker := newClientSyncTracker()
+	taskScheduler.AddTask(scheduler.Task{
+		Name:        "update-network-state",
+		Interval:    tasksInterval,
+		MinInterval: minStateUpdateInterval,
+		MaxBackoff:  tasksInterval,
+		Wake: bus.Subscribe(
+			events.Trigger_FinalizedEpoch,
+			events.Trigger_RewardSnapshot,
+			events.Trigger_PdaoRootSubmitted,
+			events.Trigger_PdaoChallengeSubmitted,
+			events.Trigger_MinipoolPrestaked,
+			events.Trigger_ScrubPeriodEnded,
+			events.Trigger_ChainReorg,
+		),
+		Run: func(ctx context.Context) error {
+			// Make sure the clients are synced
+			err := syncTracker.check(c, cfg, &updateLog)
 			if err != nil {
-				wasExecutionClientSynced = false
-				errorLog.Printlnf("Execution client not synced: %s. Waiting for sync...", err.Error())
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			if !wasExecutionClientSynced {
-				updateLog.Println("Execution client is now synced.")
-				wasExecutionClientSynced = true
-				alerting.AlertExecutionClientSyncComplete(cfg)
-			}
-
-			// Check the BC status
-			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
-			if err != nil {
-				// NOTE: if not synced, it returns an error - so there isn't necessarily an underlying issue
-				wasBeaconClientSynced = false
-				errorLog.Printlnf("Beacon client not synced: %s. Waiting for sync...", err.Error())
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			if !wasBeaconClientSynced {
-				updateLog.Println("Beacon client is now synced.")
-				wasBeaconClientSynced = true
-				alerting.AlertBeaconClientSyncComplete(cfg)
+				networkState.setUnsynced()
+				return err
 			}
 
 			// Update the network state
@@ -214,87 +272,263 @@ func run(c *cli.Context) error {
 			}
 			state, totalEffectiveStake, err := updateNetworkState(m, &updateLog, nodeAccount.Address, updateTotalEffectiveStake)
 			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
+				return err
 			}
 			stateLocker.UpdateState(state, totalEffectiveStake)
+			networkState.set(state)
 
 			// Check for Houston
 			if !isHoustonDeployedMasterFlag && state.IsHoustonDeployed {
 				printHoustonMessage(&updateLog)
 				isHoustonDeployedMasterFlag = true
 			}
+			bus.Publish(events.Trigger_NetworkStateUpdated)
+			return nil
+		},
+	})
 
-			// Manage the fee recipient for the node
-			if err := manageFeeRecipient.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+	// Manage the fee recipient for the node
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "manage-fee-recipient",
+		Interval: tasksInterval,
+		Wake:     wakeAfterStateUpdate(bus),
+		Run:      networkState.runTask(manageFeeRecipient.run),
+	})
 
-			// Run the rewards download check
-			if err := downloadRewardsTrees.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
-
-			if state.IsHoustonDeployed {
-				// Run the pDAO proposal defender
-				if err := defendPdaoProps.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Run the pDAO proposal verifier
-				if verifyPdaoProps != nil {
-					if err := verifyPdaoProps.run(state); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-				}
-			}
+	// Audit the fee recipients of the node's proposals once their epoch is finalized
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "audit-fee-recipients",
+		Interval: tasksInterval,
+		Wake:     wakeAfterStateUpdate(bus, events.Trigger_FinalizedEpoch),
+		Run:      networkState.runTask(auditFeeRecipients.run),
+	})
 
-			// Run the minipool stake check
-			if err := stakePrelaunchMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+	// Run the rewards download check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "download-rewards-trees",
+		Interval: tasksInterval,
+		Wake:     wakeAfterStateUpdate(bus, events.Trigger_RewardSnapshot),
+		Run:      networkState.runTask(downloadRewardsTrees.run),
+	})
 
-			// Run the balance distribution check
-			if err := distributeMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+	// Send alerts for governance proposal updates
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "alert-governance",
+		Interval: tasksInterval,
+		Wake:     wakeAfterStateUpdate(bus, events.Trigger_PdaoRootSubmitted, events.Trigger_PdaoChallengeSubmitted),
+		Run:      networkState.runTask(alertGovernance.run),
+	})
 
-			// Run the reduce bond check
-			if err := reduceBonds.run(state); err != nil {
-				errorLog.Println(err)
-			}
-			time.Sleep(taskCooldown)
+	// Run the pDAO proposal defender
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "defend-pdao-props",
+		Interval:          tasksInterval,
+		Wake:              wakeAfterStateUpdate(bus, events.Trigger_PdaoRootSubmitted, events.Trigger_PdaoChallengeSubmitted),
+		SendsTransactions: true,
+		Run:               networkState.runTask(onlyAfterHouston(defendPdaoProps.run)),
+	})
 
-			// Run the minipool promotion check
-			if err := promoteMinipools.run(state); err != nil {
-				errorLog.Println(err)
-			}
+	// Run the pDAO proposal verifier
+	if verifyPdaoProps != nil {
+		taskScheduler.AddTask(scheduler.Task{
+			Name:              "verify-pdao-props",
+			Interval:          tasksInterval,
+			Wake:              wakeAfterStateUpdate(bus, events.Trigger_PdaoRootSubmitted, events.Trigger_PdaoChallengeSubmitted),
+			SendsTransactions: true,
+			Run:               networkState.runTask(onlyAfterHouston(verifyPdaoProps.run)),
+		})
+	}
 
-			time.Sleep(tasksInterval)
-		}
-		wg.Done()
-	}()
+	// Run the minipool scrub check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "check-scrub",
+		Interval: tasksInterval,
+		Wake:     wakeAfterStateUpdate(bus, events.Trigger_MinipoolPrestaked),
+		Run:      networkState.runTask(checkScrub.run),
+	})
+
+	// Run the minipool stake check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "stake-prelaunch-minipools",
+		Interval:          tasksInterval,
+		Wake:              wakeAfterStateUpdate(bus, events.Trigger_ScrubPeriodEnded),
+		SendsTransactions: true,
+		Run:               networkState.runTask(stakePrelaunchMinipools.run),
+	})
+
+	// Run the balance distribution check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "distribute-minipools",
+		Interval:          tasksInterval,
+		Wake:              wakeAfterStateUpdate(bus),
+		SendsTransactions: true,
+		Run:               networkState.runTask(distributeMinipools.run),
+	})
+
+	// Run the reduce bond check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "reduce-bonds",
+		Interval:          tasksInterval,
+		Wake:              wakeAfterStateUpdate(bus),
+		SendsTransactions: true,
+		Run:               networkState.runTask(reduceBonds.run),
+	})
+
+	// Run the minipool promotion check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "promote-minipools",
+		Interval:          tasksInterval,
+		Wake:              wakeAfterStateUpdate(bus, events.Trigger_ScrubPeriodEnded),
+		SendsTransactions: true,
+		Run:               networkState.runTask(promoteMinipools.run),
+	})
+
+	// Run the fee distributor distribution check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "distribute-fees",
+		Interval:          tasksInterval,
+		Wake:              wakeAfterStateUpdate(bus),
+		SendsTransactions: true,
+		Run:               networkState.runTask(distributeFees.run),
+	})
+
+	// Run the rewards claim check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "claim-rewards",
+		Interval:          tasksInterval,
+		Wake:              wakeAfterStateUpdate(bus, events.Trigger_RewardSnapshot),
+		SendsTransactions: true,
+		Run:               networkState.runTask(claimRewards.run),
+	})
+
+	// Run the tasks; they run until the daemon exits
+	taskScheduler.Start(context.Background(), taskCooldown)
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker, nil)
 		if err != nil {
 			errorLog.Println(err)
 		}
-		wg.Done()
 	}()
 
-	// Wait for both threads to stop
-	wg.Wait()
+	// Block forever since the tasks never stop
+	select {}
+
+}
+
+// The latest network state, shared between the node daemon's tasks
+type sharedNetworkState struct {
+	lock        *sync.RWMutex
+	state       *state.NetworkState
+	updatedTime time.Time
+	isUnsynced  bool
+}
+
+// Replace the shared network state
+func (s *sharedNetworkState) set(state *state.NetworkState) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.state = state
+	s.updatedTime = time.Now()
+	s.isUnsynced = false
+}
+
+// Record that the clients aren't synced, so tasks stop running until the next successful update
+func (s *sharedNetworkState) setUnsynced() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.isUnsynced = true
+}
+
+// Wrap a task so it only runs when the clients are synced and a recent network state is available
+func (s *sharedNetworkState) runTask(run func(state *state.NetworkState) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		s.lock.RLock()
+		state := s.state
+		isUsable := !s.isUnsynced && time.Since(s.updatedTime) < maxNetworkStateAge
+		s.lock.RUnlock()
+		if state == nil || !isUsable {
+			return nil
+		}
+		return run(state)
+	}
+}
+
+// Tracks whether the clients were synced, so an alert can be sent once they finish syncing
+type clientSyncTracker struct {
+	wasExecutionClientSynced bool
+	wasBeaconClientSynced    bool
+}
+
+// Create a new client sync tracker; clients are assumed to be synced on startup so that we don't send unnecessary alerts
+func newClientSyncTracker() *clientSyncTracker {
+	return &clientSyncTracker{
+		wasExecutionClientSynced: true,
+		wasBeaconClientSynced:    true,
+	}
+}
+
+// Check that the clients are synced, sending an alert for any client that has finished syncing since the last check
+func (t *clientSyncTracker) check(c *cli.Context, cfg *config.RocketPoolConfig, updateLog *log.ColorLogger) error {
+	// Check the EC status
+	err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
+	if err != nil {
+		t.wasExecutionClientSynced = false
+		return fmt.Errorf("Execution client not synced: %w. Waiting for sync...", err)
+	}
+
+	if !t.wasExecutionClientSynced {
+		updateLog.Println("Execution client is now synced.")
+		t.wasExecutionClientSynced = true
+		alerting.AlertExecutionClientSyncComplete(cfg)
+	}
+
+	// Check the BC status
+	err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
+	if err != nil {
+		// NOTE: if not synced, it returns an error - so there isn't necessarily an underlying issue
+		t.wasBeaconClientSynced = false
+		return fmt.Errorf("Beacon client not synced: %w. Waiting for sync...", err)
+	}
+
+	if !t.wasBeaconClientSynced {
+		updateLog.Println("Beacon client is now synced.")
+		t.wasBeaconClientSynced = true
+		alerting.AlertBeaconClientSyncComplete(cfg)
+	}
 	return nil
+}
+
+// Wrap a task so it only runs once Houston has been deployed
+func onlyAfterHouston(run func(state *state.NetworkState) error) func(state *state.NetworkState) error {
+	return func(state *state.NetworkState) error {
+		if !state.IsHoustonDeployed {
+			return nil
+		}
+		return run(state)
+	}
+}
 
+// Get a channel that wakes a task once the network state has been updated after one of the triggers.
+// Every task is woken after a deep reorg, since any state it acted on may have been wrong.
+func wakeAfterStateUpdate(bus *events.Bus, triggers ...events.Trigger) <-chan struct{} {
+	triggered := bus.Subscribe(append(triggers, events.Trigger_ChainReorg)...)
+	updated := bus.Subscribe(events.Trigger_NetworkStateUpdated)
+	wake := make(chan struct{}, 1)
+	go func() {
+		for range triggered {
+			// Ignore updates that were published before the trigger
+			events.IsTriggered(updated)
+			<-updated
+			select {
+			case wake <- struct{}{}:
+			default:
+				// The task already has a pending wake-up
+			}
+		}
+	}()
+	return wake
 }
 
 // Configure HTTP transport settings
@@ -19,6 +19,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
+	"github.com/rocket-pool/smartnode/shared/services/events"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -34,14 +36,15 @@ type promoteMinipools struct {
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
 	d              *client.Client
-	gasThreshold   float64
+	deferredTxs    *deferred.Scheduler
+	bus            *events.Bus
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
 }
 
 // Create promote minipools task
-func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipools, error) {
+func newPromoteMinipools(c *cli.Context, logger log.ColorLogger, deferredTxs *deferred.Scheduler, bus *events.Bus) (*promoteMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -61,8 +64,6 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		return nil, err
 	}
 
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
-
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
 	var maxFee *big.Int
@@ -90,7 +91,8 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		w:              w,
 		rp:             rp,
 		d:              d,
-		gasThreshold:   gasThreshold,
+		deferredTxs:    deferredTxs,
+		bus:            bus,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
@@ -120,6 +122,15 @@ func (t *promoteMinipools) run(state *state.NetworkState) error {
 	if err != nil {
 		return err
 	}
+
+	// Forget deferred promotions for minipools that aren't waiting any more
+	keys := make([]string, len(minipools))
+	for i, mpd := range minipools {
+		keys[i] = mpd.MinipoolAddress.Hex()
+	}
+	if err := t.deferredTxs.Retain(deferred.ActionType_Promote, keys); err != nil {
+		return err
+	}
 	if len(minipools) == 0 {
 		return nil
 	}
@@ -167,6 +178,7 @@ func (t *promoteMinipools) getVacantMinipools(nodeAddress common.Address, state
 				vacantMinipools = append(vacantMinipools, mpd)
 			} else {
 				t.log.Printlnf("Minipool %s has %s left until it can be promoted.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.bus.PublishAt(events.Trigger_ScrubPeriodEnded, creationTime.Add(scrubPeriod).Add(scrubPeriodEndMargin))
 			}
 		}
 	}
@@ -213,26 +225,38 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
-		// Check for the timeout buffer
-		creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
-		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, creationTime)
-		if err != nil {
-			t.log.Printlnf("Error checking if minipool is due: %s\nPromoting now for safety...", err.Error())
-		}
-		if !isDue {
-			t.log.Printlnf("Time until promoting will be forced for safety: %s", timeUntilDue)
-			return false, nil
-		}
+	// Get the deadline for promoting
+	var deadline time.Time
+	creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
+	_, timeUntilDue, err := api.IsTransactionDue(t.rp, creationTime)
+	if err != nil {
+		// Without a deadline the promotion can't be deferred safely
+		t.log.Printlnf("Error checking if minipool is due: %s\nPromoting now for safety...", err.Error())
+		deadline = time.Now()
+	} else {
+		deadline = time.Now().Add(timeUntilDue)
+	}
 
-		t.log.Println("NOTICE: The minipool has exceeded half of the timeout period, so it will be force-promoted at the current gas price.")
+	// Check if the promotion should be deferred
+	decision, err := t.deferredTxs.Check(deferred.Request{
+		Type:        deferred.ActionType_Promote,
+		Key:         mpd.MinipoolAddress.Hex(),
+		Description: fmt.Sprintf("Promote minipool %s", mpd.MinipoolAddress.Hex()),
+		Deadline:    deadline,
+		GasLimit:    gas.Uint64(),
+		MaxFeeWei:   maxFee,
+	}, &t.log)
+	if err != nil {
+		return false, err
+	}
+	if !decision.Submit {
+		return false, nil
 	}
 
 	opts.GasFeeCap = maxFee
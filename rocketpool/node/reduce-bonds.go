@@ -21,6 +21,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -36,7 +37,7 @@ type reduceBonds struct {
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
 	d              *client.Client
-	gasThreshold   float64
+	deferredTxs    *deferred.Scheduler
 	disabled       bool
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -53,7 +54,7 @@ type minipoolBondReductionDetails struct {
 }
 
 // Create reduce bonds task
-func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error) {
+func newReduceBonds(c *cli.Context, logger log.ColorLogger, deferredTxs *deferred.Scheduler) (*reduceBonds, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -108,7 +109,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		w:              w,
 		rp:             rp,
 		d:              d,
-		gasThreshold:   gasThreshold,
+		deferredTxs:    deferredTxs,
 		disabled:       disabled,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
@@ -151,10 +152,19 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
 	// Get reduceable minipools
-	minipools, err := t.getReduceableMinipools(nodeAccount.Address, windowStart, windowLength, latestBlockTime, state, opts)
+	minipools, deadlines, err := t.getReduceableMinipools(nodeAccount.Address, windowStart, windowLength, latestBlockTime, state, opts)
 	if err != nil {
 		return err
 	}
+
+	// Forget deferred bond reductions for minipools that can't be reduced any more
+	keys := make([]string, len(minipools))
+	for i, mpd := range minipools {
+		keys[i] = mpd.MinipoolAddress.Hex()
+	}
+	if err := t.deferredTxs.Retain(deferred.ActionType_ReduceBond, keys); err != nil {
+		return err
+	}
 	if len(minipools) == 0 {
 		return nil
 	}
@@ -162,8 +172,14 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	// Log
 	t.log.Printlnf("%d minipool(s) are ready for bond reduction...", len(minipools))
 
-	// Workaround for the fee distribution issue
-	success, err := t.forceFeeDistribution()
+	// Workaround for the fee distribution issue; it has to happen before the earliest bond reduction deadline
+	var earliestDeadline time.Time
+	for _, deadline := range deadlines {
+		if earliestDeadline.IsZero() || deadline.Before(earliestDeadline) {
+			earliestDeadline = deadline
+		}
+	}
+	success, err := t.forceFeeDistribution(earliestDeadline)
 	if err != nil {
 		return err
 	}
@@ -174,7 +190,7 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	// Reduce bonds
 	successCount := 0
 	for _, mp := range minipools {
-		success, err := t.reduceBond(mp, windowStart, windowLength, latestBlockTime, opts)
+		success, err := t.reduceBond(mp, deadlines[mp.MinipoolAddress], opts)
 		alerting.AlertMinipoolBondReduced(t.cfg, mp.MinipoolAddress, err == nil)
 		if err != nil {
 			t.log.Println(fmt.Errorf("could not reduce bond for minipool %s: %w", mp.MinipoolAddress.Hex(), err))
@@ -191,7 +207,7 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 }
 
 // Temp mitigation for the
-func (t *reduceBonds) forceFeeDistribution() (bool, error) {
+func (t *reduceBonds) forceFeeDistribution(deadline time.Time) (bool, error) {
 
 	// Get node account
 	nodeAccount, err := t.w.GetNodeAccount()
@@ -239,7 +255,7 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	balance := eth.WeiToEth(balanceRaw)
 	if balance == 0 {
 		t.log.Println("Your fee distributor does not have any ETH and does not need to be distributed.")
-		return true, nil
+		return true, t.deferredTxs.Remove(deferred.ActionType_DistributeFees, distributorAddress.Hex())
 	}
 	t.log.Println("NOTE: prior to bond reduction, you must distribute the funds in your fee distributor.")
 
@@ -269,14 +285,25 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	// Check if the distribution should be deferred
+	decision, err := t.deferredTxs.Check(deferred.Request{
+		Type:        deferred.ActionType_DistributeFees,
+		Key:         distributorAddress.Hex(),
+		Description: fmt.Sprintf("Distribute the balance of fee distributor %s", distributorAddress.Hex()),
+		Deadline:    deadline,
+		GasLimit:    gas.Uint64(),
+		MaxFeeWei:   maxFee,
+	}, &t.log)
+	if err != nil {
+		return false, err
+	}
+	if !decision.Submit {
 		return false, nil
 	}
 
@@ -303,20 +330,21 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 }
 
 // Get reduceable minipools
-func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, state *state.NetworkState, opts *bind.CallOpts) ([]*rpstate.NativeMinipoolDetails, error) {
+func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, state *state.NetworkState, opts *bind.CallOpts) ([]*rpstate.NativeMinipoolDetails, map[common.Address]time.Time, error) {
 
 	// Filter minipools
 	reduceableMinipools := []*rpstate.NativeMinipoolDetails{}
+	deadlines := map[common.Address]time.Time{}
 	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
 
 		// TEMP
 		reduceBondTime, err := minipool.GetReduceBondTime(t.rp, mpd.MinipoolAddress, opts)
 		if err != nil {
-			return nil, fmt.Errorf("error getting reduce bond time for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+			return nil, nil, fmt.Errorf("error getting reduce bond time for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
 		}
 		reduceBondCancelled, err := minipool.GetReduceBondCancelled(t.rp, mpd.MinipoolAddress, opts)
 		if err != nil {
-			return nil, fmt.Errorf("error getting reduce bond cancelled for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+			return nil, nil, fmt.Errorf("error getting reduce bond cancelled for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
 		}
 
 		depositBalance := eth.WeiToEth(mpd.NodeDepositBalance)
@@ -328,6 +356,7 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 			mpd.Status == types.Staking {
 			if timeSinceReductionStart > windowStart {
 				reduceableMinipools = append(reduceableMinipools, mpd)
+				deadlines[mpd.MinipoolAddress] = reduceBondTime.Add(windowStart + windowLength)
 			} else {
 				remainingTime := windowStart - timeSinceReductionStart
 				t.log.Printlnf("Minipool %s has %s left until it can have its bond reduced.", mpd.MinipoolAddress.Hex(), remainingTime)
@@ -336,12 +365,12 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 	}
 
 	// Return
-	return reduceableMinipools, nil
+	return reduceableMinipools, deadlines, nil
 
 }
 
 // Reduce a minipool's bond
-func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, callOpts *bind.CallOpts) (bool, error) {
+func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, deadline time.Time, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
 	t.log.Printlnf("Reducing bond for minipool %s...", mpd.MinipoolAddress.Hex())
@@ -379,23 +408,25 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	// TEMP
-	reduceBondTime, err := minipool.GetReduceBondTime(t.rp, mpd.MinipoolAddress, callOpts)
+	// Check if the bond reduction should be deferred
+	decision, err := t.deferredTxs.Check(deferred.Request{
+		Type:        deferred.ActionType_ReduceBond,
+		Key:         mpd.MinipoolAddress.Hex(),
+		Description: fmt.Sprintf("Reduce the bond of minipool %s", mpd.MinipoolAddress.Hex()),
+		Deadline:    deadline,
+		GasLimit:    gas.Uint64(),
+		MaxFeeWei:   maxFee,
+	}, &t.log)
 	if err != nil {
-		return false, fmt.Errorf("error getting reduce bond time for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+		return false, err
 	}
-
-	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
-		timeSinceReductionStart := latestBlockTime.Sub(reduceBondTime)
-		remainingTime := (windowStart + windowLength) - timeSinceReductionStart
-		t.log.Printlnf("Time until bond reduction times out: %s", remainingTime)
+	if !decision.Submit {
 		return false, nil
 	}
 
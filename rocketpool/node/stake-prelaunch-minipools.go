@@ -20,6 +20,8 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
+	"github.com/rocket-pool/smartnode/shared/services/events"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -37,14 +39,15 @@ type stakePrelaunchMinipools struct {
 	rp             *rocketpool.RocketPool
 	bc             beacon.Client
 	d              *client.Client
-	gasThreshold   float64
+	deferredTxs    *deferred.Scheduler
+	bus            *events.Bus
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
 }
 
 // Create stake prelaunch minipools task
-func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakePrelaunchMinipools, error) {
+func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger, deferredTxs *deferred.Scheduler, bus *events.Bus) (*stakePrelaunchMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -68,8 +71,6 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		return nil, err
 	}
 
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
-
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
 	var maxFee *big.Int
@@ -98,7 +99,8 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		rp:             rp,
 		bc:             bc,
 		d:              d,
-		gasThreshold:   gasThreshold,
+		deferredTxs:    deferredTxs,
+		bus:            bus,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
@@ -133,6 +135,15 @@ func (t *stakePrelaunchMinipools) run(state *state.NetworkState) error {
 	if err != nil {
 		return err
 	}
+
+	// Forget deferred stakes for minipools that aren't waiting any more
+	keys := make([]string, len(minipools))
+	for i, mpd := range minipools {
+		keys[i] = mpd.MinipoolAddress.Hex()
+	}
+	if err := t.deferredTxs.Retain(deferred.ActionType_Stake, keys); err != nil {
+		return err
+	}
 	if len(minipools) == 0 {
 		return nil
 	}
@@ -193,6 +204,7 @@ func (t *stakePrelaunchMinipools) getPrelaunchMinipools(nodeAddress common.Addre
 				prelaunchMinipools = append(prelaunchMinipools, mpd)
 			} else {
 				t.log.Printlnf("Minipool %s has %s left until it can be staked.", mpd.MinipoolAddress.Hex(), remainingTime)
+				t.bus.PublishAt(events.Trigger_ScrubPeriodEnded, creationTime.Add(scrubPeriod).Add(scrubPeriodEndMargin))
 			}
 		}
 	}
@@ -264,26 +276,38 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return false, err
 		}
 	}
 
-	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
-		// Check for the timeout buffer
-		prelaunchTime := time.Unix(mpd.StatusTime.Int64(), 0)
-		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, prelaunchTime)
-		if err != nil {
-			t.log.Printlnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
-		}
-		if !isDue {
-			t.log.Printlnf("Time until staking will be forced for safety: %s", timeUntilDue)
-			return false, nil
-		}
+	// Get the deadline for staking
+	var deadline time.Time
+	prelaunchTime := time.Unix(mpd.StatusTime.Int64(), 0)
+	_, timeUntilDue, err := api.IsTransactionDue(t.rp, prelaunchTime)
+	if err != nil {
+		// Without a deadline the stake can't be deferred safely
+		t.log.Printlnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
+		deadline = time.Now()
+	} else {
+		deadline = time.Now().Add(timeUntilDue)
+	}
 
-		t.log.Println("NOTICE: The minipool has exceeded half of the timeout period, so it will be force-staked at the current gas price.")
+	// Check if the stake should be deferred
+	decision, err := t.deferredTxs.Check(deferred.Request{
+		Type:        deferred.ActionType_Stake,
+		Key:         mpd.MinipoolAddress.Hex(),
+		Description: fmt.Sprintf("Stake minipool %s", mpd.MinipoolAddress.Hex()),
+		Deadline:    deadline,
+		GasLimit:    gas.Uint64(),
+		MaxFeeWei:   maxFee,
+	}, &t.log)
+	if err != nil {
+		return false, err
+	}
+	if !decision.Submit {
+		return false, nil
 	}
 
 	opts.GasFeeCap = maxFee
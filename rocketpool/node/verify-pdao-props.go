@@ -12,6 +12,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
@@ -157,6 +158,7 @@ func (t *verifyPdaoProps) run(state *state.NetworkState) error {
 	// Submit defeats
 	for _, defeat := range defeats {
 		err := t.submitDefeat(defeat)
+		alerting.AlertPdaoProposalDefeated(t.cfg, defeat.proposalID, defeat.challengedIndex, err == nil)
 		if err != nil {
 			return fmt.Errorf("error submitting defeat of proposal %d, index %d: %w", defeat.proposalID, defeat.challengedIndex, err)
 		}
@@ -371,7 +373,7 @@ func (t *verifyPdaoProps) submitChallenge(challenge challenge) error {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return err
 		}
@@ -427,7 +429,7 @@ func (t *verifyPdaoProps) submitDefeat(defeat defeat) error {
 	// Get the max fee
 	maxFee := t.maxFee
 	if maxFee == nil || maxFee.Uint64() == 0 {
-		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei(t.cfg, t.rp.Client)
 		if err != nil {
 			return err
 		}
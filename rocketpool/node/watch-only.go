@@ -0,0 +1,185 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The tasks that run for each node monitored in watch-only mode
+type watchedNode struct {
+	address            common.Address
+	auditFeeRecipients *auditFeeRecipients
+	checkScrub         *checkScrub
+}
+
+// Run the daemon in watch-only mode; it monitors the given nodes without loading a wallet, so it never sends transactions
+func runWatchOnly(c *cli.Context, nodeAddresses []common.Address) error {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
+
+	// Print the current mode
+	if cfg.IsNativeMode {
+		fmt.Printf("Starting node daemon in Native Mode, watching %d node(s).\n", len(nodeAddresses))
+	} else {
+		fmt.Printf("Starting node daemon in Docker Mode, watching %d node(s).\n", len(nodeAddresses))
+	}
+	fmt.Println("Watch-only mode is enabled: no wallet will be loaded and no transactions will be sent.")
+
+	// Initialize loggers
+	errorLog := log.NewColorLogger(ErrorColor)
+	updateLog := log.NewColorLogger(UpdateColor)
+
+	// Create the state manager
+	m, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, &updateLog)
+	if err != nil {
+		return err
+	}
+	stateLocker := collectors.NewStateLocker()
+
+	// Watch for chain events so tasks can run as soon as they have something to do
+	eventLog := log.NewColorLogger(EventsColor)
+	bus := events.NewBus()
+	go events.WatchBeaconEvents(bc, bus, &eventLog)
+	go events.WatchLogs(rp, bus, &eventLog)
+
+	// Initialize the tasks for each node
+	nodes := make([]watchedNode, len(nodeAddresses))
+	for i, address := range nodeAddresses {
+		nodes[i].address = address
+		nodes[i].auditFeeRecipients, err = newAuditFeeRecipients(c, log.NewColorLogger(AuditFeeRecipientsColor), address, cfg.Smartnode.GetWatchOnlyProposalHistoryPath(address))
+		if err != nil {
+			return err
+		}
+		nodes[i].checkScrub, err = newCheckScrub(c, log.NewColorLogger(CheckScrubColor), address)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Create the scheduler; each task runs on its own interval so a slow task doesn't hold up the others
+	taskScheduler := scheduler.NewScheduler(&errorLog, "")
+	networkState := &sharedNetworkState{
+		lock: &sync.RWMutex{},
+	}
+
+	// Keep the network state up to date for the other tasks; tasks that react to a trigger run after the update that follows it
+	syncTracker := newClientSyncTracker()
+	taskScheduler.AddTask(scheduler.Task{
+		Name:        "update-network-state",
+		Interval:    tasksInterval,
+		MinInterval: minStateUpdateInterval,
+		MaxBackoff:  tasksInterval,
+		Wake:        bus.Subscribe(events.Trigger_FinalizedEpoch, events.Trigger_MinipoolPrestaked, events.Trigger_ChainReorg),
+		Run: func(ctx context.Context) error {
+			// Make sure the clients are synced
+			err := syncTracker.check(c, cfg, &updateLog)
+			if err != nil {
+				networkState.setUnsynced()
+				return err
+			}
+
+			// Update the network state; every watched node needs its details, so this uses the full state
+			state, err := m.GetHeadState()
+			if err != nil {
+				return fmt.Errorf("error updating network state: %w", err)
+			}
+			stateLocker.UpdateState(state, getTotalEffectiveStake(state))
+			networkState.set(state)
+			bus.Publish(events.Trigger_NetworkStateUpdated)
+			return nil
+		},
+	})
+
+	// Audit the fee recipients of each node's proposals once their epoch is finalized
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "audit-fee-recipients",
+		Interval: tasksInterval,
+		Wake:     wakeAfterStateUpdate(bus, events.Trigger_FinalizedEpoch),
+		Run: networkState.runTask(func(state *state.NetworkState) error {
+			for _, node := range getRegisteredNodes(nodes, state, &errorLog) {
+				if err := node.auditFeeRecipients.run(state); err != nil {
+					errorLog.Printlnf("Error auditing fee recipients for node %s: %s", node.address.Hex(), err.Error())
+				}
+			}
+			return nil
+		}),
+	})
+
+	// Run the minipool scrub check for each node
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "check-scrub",
+		Interval: tasksInterval,
+		Wake:     wakeAfterStateUpdate(bus, events.Trigger_MinipoolPrestaked),
+		Run: networkState.runTask(func(state *state.NetworkState) error {
+			for _, node := range getRegisteredNodes(nodes, state, nil) {
+				if err := node.checkScrub.run(state); err != nil {
+					errorLog.Printlnf("Error running the scrub check for node %s: %s", node.address.Hex(), err.Error())
+				}
+			}
+			return nil
+		}),
+	})
+
+	// Run the tasks; they run until the daemon exits
+	taskScheduler.Start(context.Background(), taskCooldown)
+
+	// Run metrics loop
+	go func() {
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker, nodeAddresses)
+		if err != nil {
+			errorLog.Println(err)
+		}
+	}()
+
+	// Block forever since the tasks never stop
+	select {}
+
+}
+
+// Get the watched nodes that are registered with Rocket Pool, logging the ones that aren't if a logger is provided
+func getRegisteredNodes(nodes []watchedNode, state *state.NetworkState, errorLog *log.ColorLogger) []watchedNode {
+	registeredNodes := make([]watchedNode, 0, len(nodes))
+	for _, node := range nodes {
+		if _, exists := state.NodeDetailsByAddress[node.address]; !exists {
+			if errorLog != nil {
+				errorLog.Printlnf("Watched node %s is not registered with Rocket Pool, skipping it.", node.address.Hex())
+			}
+			continue
+		}
+		registeredNodes = append(registeredNodes, node)
+	}
+	return registeredNodes
+}
+
+// Get the network's total effective RPL stake from the details of every node
+func getTotalEffectiveStake(state *state.NetworkState) *big.Int {
+	totalEffectiveStake := big.NewInt(0)
+	for _, node := range state.NodeDetails {
+		totalEffectiveStake.Add(totalEffectiveStake, node.EffectiveRPLStake)
+	}
+	return totalEffectiveStake
+}
@@ -3,7 +3,6 @@ package watchtower
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
@@ -37,8 +36,6 @@ type cancelBondReductions struct {
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	coll             *collectors.BondReductionCollector
-	lock             *sync.Mutex
-	isRunning        bool
 	generationPrefix string
 }
 
@@ -64,7 +61,6 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &cancelBondReductions{
 		c:                c,
 		log:              logger,
@@ -74,8 +70,6 @@ func newCancelBondReductions(c *cli.Context, logger log.ColorLogger, errorLogger
 		rp:               rp,
 		ec:               ec,
 		coll:             coll,
-		lock:             lock,
-		isRunning:        false,
 		generationPrefix: "[Bond Reduction]",
 	}, nil
 
@@ -95,32 +89,10 @@ func (t *cancelBondReductions) run(state *state.NetworkState) error {
 	// Log
 	t.log.Println("Checking for bond reductions to cancel...")
 
-	// Check if the check is already running
-	t.lock.Lock()
-	if t.isRunning {
-		t.log.Println("Bond reduction cancel check is already running in the background.")
-		t.lock.Unlock()
-		return nil
+	// Run the check; the scheduler holds the transaction lock until it's done, so its votes don't race other tasks' transactions
+	if err := t.checkBondReductions(state); err != nil {
+		return fmt.Errorf("%s %w", t.generationPrefix, err)
 	}
-	t.lock.Unlock()
-
-	// Run the check
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		t.printMessage("Starting bond reduction cancel check in a separate thread.")
-
-		err := t.checkBondReductions(state)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", t.generationPrefix, err))
-			return
-		}
-
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
 
 	// Return
 	return nil
@@ -263,14 +235,6 @@ func (t *cancelBondReductions) cancelBondReduction(address common.Address, reaso
 
 }
 
-func (t *cancelBondReductions) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Bond reduction cancel check failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
-}
-
 // Print a message from the tree generation goroutine
 func (t *cancelBondReductions) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.generationPrefix, message)
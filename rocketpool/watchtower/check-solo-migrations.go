@@ -3,7 +3,6 @@ package watchtower
 import (
 	"fmt"
 	"math/big"
-	"sync"
 	"time"
 
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
@@ -41,8 +40,6 @@ type checkSoloMigrations struct {
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
 	coll             *collectors.SoloMigrationCollector
-	lock             *sync.Mutex
-	isRunning        bool
 	generationPrefix string
 }
 
@@ -72,7 +69,6 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &checkSoloMigrations{
 		c:                c,
 		log:              logger,
@@ -83,8 +79,6 @@ func newCheckSoloMigrations(c *cli.Context, logger log.ColorLogger, errorLogger
 		ec:               ec,
 		bc:               bc,
 		coll:             coll,
-		lock:             lock,
-		isRunning:        false,
 		generationPrefix: "[Solo Migration]",
 	}, nil
 
@@ -104,32 +98,10 @@ func (t *checkSoloMigrations) run(state *state.NetworkState) error {
 	// Log
 	t.log.Println("Checking for solo migrations...")
 
-	// Check if the check is already running
-	t.lock.Lock()
-	if t.isRunning {
-		t.log.Println("Solo migration check is already running in the background.")
-		t.lock.Unlock()
-		return nil
+	// Run the check; the scheduler holds the transaction lock until it's done, so its votes don't race other tasks' transactions
+	if err := t.checkSoloMigrations(state); err != nil {
+		return fmt.Errorf("%s %w", t.generationPrefix, err)
 	}
-	t.lock.Unlock()
-
-	// Run the check
-	go func() {
-		t.lock.Lock()
-		t.isRunning = true
-		t.lock.Unlock()
-		t.printMessage("Starting solo migration check in a separate thread.")
-
-		err := t.checkSoloMigrations(state)
-		if err != nil {
-			t.handleError(fmt.Errorf("%s %w", t.generationPrefix, err))
-			return
-		}
-
-		t.lock.Lock()
-		t.isRunning = false
-		t.lock.Unlock()
-	}()
 
 	// Return
 	return nil
@@ -315,14 +287,6 @@ func (t *checkSoloMigrations) scrubVacantMinipool(address common.Address, reason
 
 }
 
-func (t *checkSoloMigrations) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Solo migration check failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
-}
-
 // Print a message from the tree generation goroutine
 func (t *checkSoloMigrations) printMessage(message string) {
 	t.log.Printlnf("%s %s", t.generationPrefix, message)
@@ -0,0 +1,100 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The metrics for a single L2 price messenger
+type L2PriceMessengerMetrics struct {
+	RateStale          float64
+	Submissions        float64
+	Failures           float64
+	LastSubmissionTime float64
+}
+
+// Represents the collector for the L2 price submission metrics
+type L2PriceCollector struct {
+
+	// Whether the messenger's rate was stale at the last check
+	rateStaleDesc *prometheus.Desc
+
+	// The number of successful submissions to the messenger
+	submissionsDesc *prometheus.Desc
+
+	// The number of failed submissions to the messenger
+	failuresDesc *prometheus.Desc
+
+	// The time of the last successful submission to the messenger
+	lastSubmissionTimeDesc *prometheus.Desc
+
+	// The metrics for each messenger, by name
+	Messengers map[string]*L2PriceMessengerMetrics
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new L2PriceCollector instance
+func NewL2PriceCollector() *L2PriceCollector {
+	subsystem := "l2_price"
+	return &L2PriceCollector{
+		rateStaleDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rate_stale"),
+			"Whether the messenger's rate was stale at the last check",
+			[]string{"messenger"}, nil,
+		),
+		submissionsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "submissions"),
+			"The number of successful submissions to the messenger",
+			[]string{"messenger"}, nil,
+		),
+		failuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures"),
+			"The number of failed submissions to the messenger",
+			[]string{"messenger"}, nil,
+		),
+		lastSubmissionTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_submission_time"),
+			"The time of the last successful submission to the messenger",
+			[]string{"messenger"}, nil,
+		),
+		Messengers: map[string]*L2PriceMessengerMetrics{},
+		UpdateLock: &sync.Mutex{},
+	}
+}
+
+// Get the metrics for a messenger, creating them if they don't exist yet; the caller must hold the update lock
+func (collector *L2PriceCollector) GetMessengerMetrics(name string) *L2PriceMessengerMetrics {
+	metrics, exists := collector.Messengers[name]
+	if !exists {
+		metrics = &L2PriceMessengerMetrics{}
+		collector.Messengers[name] = metrics
+	}
+	return metrics
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *L2PriceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.rateStaleDesc
+	channel <- collector.submissionsDesc
+	channel <- collector.failuresDesc
+	channel <- collector.lastSubmissionTimeDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *L2PriceCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	for name, metrics := range collector.Messengers {
+		channel <- prometheus.MustNewConstMetric(
+			collector.rateStaleDesc, prometheus.GaugeValue, metrics.RateStale, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.submissionsDesc, prometheus.CounterValue, metrics.Submissions, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failuresDesc, prometheus.CounterValue, metrics.Failures, name)
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastSubmissionTimeDesc, prometheus.GaugeValue, metrics.LastSubmissionTime, name)
+	}
+}
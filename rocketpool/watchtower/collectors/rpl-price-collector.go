@@ -0,0 +1,96 @@
+package collectors
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Represents the collector for the RPL price source metrics
+type RplPriceCollector struct {
+
+	// The RPL price of each source at the last price report
+	sourcePriceDesc *prometheus.Desc
+
+	// The combined RPL price at the last price report
+	aggregatePriceDesc *prometheus.Desc
+
+	// The largest difference (in percent) between a source and the combined price at the last price report
+	maxDivergenceDesc *prometheus.Desc
+
+	// Whether the last price report was blocked because the sources diverged
+	submissionBlockedDesc *prometheus.Desc
+
+	// The block of the last price report
+	blockNumberDesc *prometheus.Desc
+
+	// Counters
+	SourcePrices      map[string]float64
+	AggregatePrice    float64
+	MaxDivergence     float64
+	SubmissionBlocked float64
+	BlockNumber       float64
+
+	// Mutex
+	UpdateLock *sync.Mutex
+}
+
+// Create a new RplPriceCollector instance
+func NewRplPriceCollector() *RplPriceCollector {
+	subsystem := "rpl_price"
+	return &RplPriceCollector{
+		sourcePriceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "source_price"),
+			"The RPL price of each source at the last price report",
+			[]string{"source"}, nil,
+		),
+		aggregatePriceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "aggregate_price"),
+			"The combined RPL price at the last price report",
+			nil, nil,
+		),
+		maxDivergenceDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "max_divergence"),
+			"The largest difference (in percent) between a source and the combined price at the last price report",
+			nil, nil,
+		),
+		submissionBlockedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "submission_blocked"),
+			"Whether the last price report was blocked because the sources diverged",
+			nil, nil,
+		),
+		blockNumberDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "block_number"),
+			"The block of the last price report",
+			nil, nil,
+		),
+		SourcePrices: map[string]float64{},
+		UpdateLock:   &sync.Mutex{},
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *RplPriceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.sourcePriceDesc
+	channel <- collector.aggregatePriceDesc
+	channel <- collector.maxDivergenceDesc
+	channel <- collector.submissionBlockedDesc
+	channel <- collector.blockNumberDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *RplPriceCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Sync
+	collector.UpdateLock.Lock()
+	defer collector.UpdateLock.Unlock()
+
+	// Update all of the metrics
+	for source, price := range collector.SourcePrices {
+		channel <- prometheus.MustNewConstMetric(
+			collector.sourcePriceDesc, prometheus.GaugeValue, price, source)
+	}
+	channel <- prometheus.MustNewConstMetric(
+		collector.aggregatePriceDesc, prometheus.GaugeValue, collector.AggregatePrice)
+	channel <- prometheus.MustNewConstMetric(
+		collector.maxDivergenceDesc, prometheus.GaugeValue, collector.MaxDivergence)
+	channel <- prometheus.MustNewConstMetric(
+		collector.submissionBlockedDesc, prometheus.GaugeValue, collector.SubmissionBlocked)
+	channel <- prometheus.MustNewConstMetric(
+		collector.blockNumberDesc, prometheus.GaugeValue, collector.BlockNumber)
+}
@@ -0,0 +1,852 @@
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
+	"github.com/rocket-pool/smartnode/rocketpool/watchtower/utils"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Messenger ABIs
+const (
+	OptimismMessengerAbi string = `[
+		{
+		"inputs": [],
+		"name": "rateStale",
+		"outputs": [
+			{
+			"internalType": "bool",
+			"name": "",
+			"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [],
+		"name": "submitRate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+		}
+	]`
+
+	PolygonMessengerAbi string = `[
+		{
+		"inputs": [],
+		"name": "rateStale",
+		"outputs": [
+			{
+			"internalType": "bool",
+			"name": "",
+			"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [],
+		"name": "submitRate",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+		}
+	]`
+
+	ArbitrumMessengerAbi string = `[
+		{
+		"inputs": [],
+		"name": "rateStale",
+		"outputs": [
+			{
+			"internalType": "bool",
+			"name": "",
+			"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [
+			{
+			"internalType": "uint256",
+			"name": "_maxSubmissionCost",
+			"type": "uint256"
+			},
+			{
+			"internalType": "uint256",
+			"name": "_gasLimit",
+			"type": "uint256"
+			},
+			{
+			"internalType": "uint256",
+			"name": "_gasPriceBid",
+			"type": "uint256"
+			}
+		],
+		"name": "submitRate",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+		}
+	]`
+
+	zkSyncEraMessengerAbi string = `[
+		{
+			"inputs": [],
+			"name": "rateStale",
+			"outputs": [
+			{
+				"internalType": "bool",
+				"name": "",
+				"type": "bool"
+			}
+			],
+			"stateMutability": "view",
+			"type": "function"
+		},
+		{
+			"inputs": [
+			{
+				"internalType": "uint256",
+				"name": "_l2GasLimit",
+				"type": "uint256"
+			},
+			{
+				"internalType": "uint256",
+				"name": "_l2GasPerPubdataByteLimit",
+				"type": "uint256"
+			}
+			],
+			"name": "submitRate",
+			"outputs": [],
+			"stateMutability": "payable",
+			"type": "function"
+		}
+	]`
+
+	ScrollMessengerAbi string = `[
+		{
+		"inputs": [],
+		"name": "rateStale",
+		"outputs": [
+			{
+			"internalType": "bool",
+			"name": "",
+			"type": "bool"
+			}
+		],
+		"stateMutability": "view",
+		"type": "function"
+		},
+		{
+		"inputs": [
+			{
+			"internalType": "uint256",
+			"name": "_l2GasLimit",
+			"type": "uint256"
+			}
+		],
+		"name": "submitRate",
+		"outputs": [],
+		"stateMutability": "payable",
+		"type": "function"
+		}
+	]`
+
+	ScrollFeeEstimatorAbi string = `[
+		{
+			"inputs": [
+				{
+				"internalType": "uint256",
+				"name": "_l2GasLimit",
+				"type": "uint256"
+				}
+			],
+			"name": "estimateCrossDomainMessageFee",
+			"outputs": [ 
+				{
+				"internalType":"uint256","name":"","type":"uint256"
+				}
+			]
+			,"stateMutability":"view",
+			"type": "function"
+		}
+	]`
+)
+
+// Settings
+const (
+	l2SubmissionAttempts   int           = 3
+	l2SubmissionRetryDelay time.Duration = 15 * time.Second
+)
+
+// An L1 contract that relays the RPL price to an L2 when the L2's rate is stale
+type L2PriceMessenger interface {
+	// Get the name of the L2
+	GetName() string
+
+	// Get the messenger contract
+	GetContract() *rocketpool.Contract
+
+	// Get the arguments for submitRate and the ETH to send with it to pay for the L2 message
+	GetSubmitRateArgs(maxFee *big.Int) ([]interface{}, *big.Int, error)
+}
+
+// The gas parameters of the L2 message a messenger sends; each messenger only uses the ones its bridge needs
+type l2GasParameters struct {
+	// The gas limit of the L2 transaction
+	l2GasLimit *big.Int
+
+	// The max fee per gas of the L2 transaction (Arbitrum)
+	l2MaxFeePerGas *big.Int
+
+	// The L2 gas price floor (zkSync Era)
+	fairL2GasPrice *big.Int
+
+	// The L1 gas per byte of pubdata, and the L2 gas per byte of pubdata limit (zkSync Era)
+	l1GasPerPubdataByte *big.Int
+	gasPerPubdataByte   *big.Int
+}
+
+// The registration of an L2 price messenger
+type l2PriceMessengerRegistration struct {
+	name string
+	abi  string
+
+	// The name of the L2 in the L2 price submission overrides; messengers for the same L2 share it
+	id string
+
+	// Whether the watchtower submits to the messenger unless it's overridden
+	enabled bool
+
+	// The max fee (in gwei) of submissions to the messenger unless it's overridden, or 0 to use the watchtower's max fee
+	maxFee float64
+
+	// Get the address of the messenger on the current network, or an empty string if it isn't deployed there
+	getAddress func(cfg *config.SmartnodeConfig) string
+
+	// The gas parameters of the messenger's L2 messages on each network
+	gas map[cfgtypes.Network]l2GasParameters
+
+	// Create the messenger
+	create func(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, base l2PriceMessengerBase, gas l2GasParameters) (L2PriceMessenger, error)
+}
+
+// An override of a messenger's registered settings from the L2 price submission overrides
+type l2PriceSubmissionOverride struct {
+	enabled bool
+	maxFee  float64
+}
+
+// The L2 price messengers, in submission order. Adding an L2 only requires a new entry here, and a new L2PriceMessenger if its bridge takes arguments.
+// Messengers whose bridge takes gas arguments need gas parameters for every network they're deployed on.
+var l2PriceMessengers = []l2PriceMessengerRegistration{
+	{
+		name:    "Optimism",
+		id:      "optimism",
+		enabled: true,
+		abi:     OptimismMessengerAbi,
+		getAddress: func(cfg *config.SmartnodeConfig) string {
+			return cfg.GetOptimismMessengerAddress()
+		},
+		create: newSimpleL2PriceMessenger,
+	},
+	{
+		name:    "Polygon",
+		id:      "polygon",
+		enabled: true,
+		abi:     PolygonMessengerAbi,
+		getAddress: func(cfg *config.SmartnodeConfig) string {
+			return cfg.GetPolygonMessengerAddress()
+		},
+		create: newSimpleL2PriceMessenger,
+	},
+	{
+		// This messenger will be deprecated soon; submit to both Arbitrum messengers until it sunsets
+		name:    "Arbitrum",
+		id:      "arbitrum",
+		enabled: true,
+		abi:     ArbitrumMessengerAbi,
+		getAddress: func(cfg *config.SmartnodeConfig) string {
+			return cfg.GetArbitrumMessengerAddress()
+		},
+		gas: map[cfgtypes.Network]l2GasParameters{
+			cfgtypes.Network_Mainnet: {
+				l2GasLimit:     big.NewInt(40000),
+				l2MaxFeePerGas: eth.GweiToWei(0.1),
+			},
+		},
+		create: newArbitrumPriceMessenger,
+	},
+	{
+		name:    "Arbitrum v2",
+		id:      "arbitrum",
+		enabled: true,
+		abi:     ArbitrumMessengerAbi,
+		getAddress: func(cfg *config.SmartnodeConfig) string {
+			return cfg.GetArbitrumMessengerAddressV2()
+		},
+		gas: map[cfgtypes.Network]l2GasParameters{
+			cfgtypes.Network_Mainnet: {
+				l2GasLimit:     big.NewInt(40000),
+				l2MaxFeePerGas: eth.GweiToWei(0.1),
+			},
+		},
+		create: newArbitrumPriceMessenger,
+	},
+	{
+		name:    "zkSync Era",
+		id:      "zksync-era",
+		enabled: true,
+		abi:     zkSyncEraMessengerAbi,
+		getAddress: func(cfg *config.SmartnodeConfig) string {
+			return cfg.GetZkSyncEraMessengerAddress()
+		},
+		gas: map[cfgtypes.Network]l2GasParameters{
+			cfgtypes.Network_Mainnet: {
+				l2GasLimit:          big.NewInt(750000),
+				fairL2GasPrice:      eth.GweiToWei(0.5),
+				l1GasPerPubdataByte: big.NewInt(17),
+				gasPerPubdataByte:   big.NewInt(800),
+			},
+		},
+		create: newZkSyncEraPriceMessenger,
+	},
+	{
+		// Base uses the same messenger as Optimism
+		name:    "Base",
+		id:      "base",
+		enabled: true,
+		abi:     OptimismMessengerAbi,
+		getAddress: func(cfg *config.SmartnodeConfig) string {
+			return cfg.GetBaseMessengerAddress()
+		},
+		create: newSimpleL2PriceMessenger,
+	},
+	{
+		name:    "Scroll",
+		id:      "scroll",
+		enabled: true,
+		abi:     ScrollMessengerAbi,
+		getAddress: func(cfg *config.SmartnodeConfig) string {
+			return cfg.GetScrollMessengerAddress()
+		},
+		gas: map[cfgtypes.Network]l2GasParameters{
+			cfgtypes.Network_Mainnet: {
+				// A bit above the estimated 85,283
+				l2GasLimit: big.NewInt(90000),
+			},
+		},
+		create: newScrollPriceMessenger,
+	},
+}
+
+// Get the L2 price submission overrides by L2 name
+func getL2PriceSubmissionOverrides(cfg *config.RocketPoolConfig) (map[string]l2PriceSubmissionOverride, error) {
+	overrides := map[string]l2PriceSubmissionOverride{}
+	setting := strings.TrimSpace(cfg.Smartnode.L2PriceSubmissionOverrides.Value.(string))
+	if setting == "" {
+		return overrides, nil
+	}
+	for _, entry := range strings.Split(setting, ",") {
+		id, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid L2 price submission override [%s]", entry)
+		}
+		id = strings.ToLower(strings.TrimSpace(id))
+		value = strings.TrimSpace(value)
+		if value == "off" {
+			overrides[id] = l2PriceSubmissionOverride{enabled: false}
+			continue
+		}
+		maxFee, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max fee in L2 price submission override [%s]: %w", entry, err)
+		}
+		overrides[id] = l2PriceSubmissionOverride{enabled: true, maxFee: maxFee}
+	}
+	return overrides, nil
+}
+
+// Get the addresses of the L2 price messengers deployed on the current network, by name
+func getL2PriceMessengerAddresses(cfg *config.RocketPoolConfig) map[string]common.Address {
+	addresses := map[string]common.Address{}
+	for _, registration := range l2PriceMessengers {
+		address := registration.getAddress(cfg.Smartnode)
+		if address != "" {
+			addresses[registration.name] = common.HexToAddress(address)
+		}
+	}
+	return addresses
+}
+
+// Create a bound contract for a messenger or one of its helpers
+func newL2Contract(ec rocketpool.ExecutionClient, address common.Address, abiString string) (*rocketpool.Contract, error) {
+	parsed, err := abi.JSON(strings.NewReader(abiString))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding ABI: %w", err)
+	}
+	return &rocketpool.Contract{
+		Contract: bind.NewBoundContract(address, parsed, ec, ec, ec),
+		Address:  &address,
+		ABI:      &parsed,
+		Client:   ec,
+	}, nil
+}
+
+// The name and contract every messenger has
+type l2PriceMessengerBase struct {
+	name     string
+	contract *rocketpool.Contract
+}
+
+func (m *l2PriceMessengerBase) GetName() string {
+	return m.name
+}
+
+func (m *l2PriceMessengerBase) GetContract() *rocketpool.Contract {
+	return m.contract
+}
+
+// A messenger whose submitRate takes no arguments, such as Optimism, Polygon and Base
+type simpleL2PriceMessenger struct {
+	l2PriceMessengerBase
+}
+
+func newSimpleL2PriceMessenger(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, base l2PriceMessengerBase, gas l2GasParameters) (L2PriceMessenger, error) {
+	return &simpleL2PriceMessenger{
+		l2PriceMessengerBase: base,
+	}, nil
+}
+
+func (m *simpleL2PriceMessenger) GetSubmitRateArgs(maxFee *big.Int) ([]interface{}, *big.Int, error) {
+	return []interface{}{}, nil, nil
+}
+
+// A messenger that pays for an Arbitrum retryable ticket
+type arbitrumPriceMessenger struct {
+	l2PriceMessengerBase
+	gas l2GasParameters
+	cfg *config.RocketPoolConfig
+	ec  rocketpool.ExecutionClient
+}
+
+func newArbitrumPriceMessenger(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, base l2PriceMessengerBase, gas l2GasParameters) (L2PriceMessenger, error) {
+	return &arbitrumPriceMessenger{
+		l2PriceMessengerBase: base,
+		gas:                  gas,
+		cfg:                  cfg,
+		ec:                   ec,
+	}, nil
+}
+
+func (m *arbitrumPriceMessenger) GetSubmitRateArgs(maxFee *big.Int) ([]interface{}, *big.Int, error) {
+	// Get the current network recommended max fee
+	suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei(m.cfg, m.ec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting recommended base fee from the network: %w", err)
+	}
+
+	// Constants for Arbitrum
+	bufferMultiplier := big.NewInt(4)
+	dataLength := big.NewInt(36)
+
+	// Gas limit calculation on Arbitrum
+	maxSubmissionCost := big.NewInt(6)
+	maxSubmissionCost.Mul(maxSubmissionCost, dataLength)
+	maxSubmissionCost.Add(maxSubmissionCost, big.NewInt(1400))
+	maxSubmissionCost.Mul(maxSubmissionCost, suggestedMaxFee)  // (1400 + 6 * dataLength) * baseFee
+	maxSubmissionCost.Mul(maxSubmissionCost, bufferMultiplier) // Multiply by the buffer constant for safety
+
+	// Provide enough ETH for the L2 and roundtrip TX's
+	value := big.NewInt(0)
+	value.Mul(m.gas.l2GasLimit, m.gas.l2MaxFeePerGas)
+	value.Add(value, maxSubmissionCost)
+	return []interface{}{maxSubmissionCost, m.gas.l2GasLimit, m.gas.l2MaxFeePerGas}, value, nil
+}
+
+// A messenger that pays for a zkSync Era L1 -> L2 transaction
+type zkSyncEraPriceMessenger struct {
+	l2PriceMessengerBase
+	gas l2GasParameters
+}
+
+func newZkSyncEraPriceMessenger(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, base l2PriceMessengerBase, gas l2GasParameters) (L2PriceMessenger, error) {
+	return &zkSyncEraPriceMessenger{
+		l2PriceMessengerBase: base,
+		gas:                  gas,
+	}, nil
+}
+
+func (m *zkSyncEraPriceMessenger) GetSubmitRateArgs(maxFee *big.Int) ([]interface{}, *big.Int, error) {
+	// Value calculation on zkSync Era
+	pubdataPrice := big.NewInt(0).Mul(m.gas.l1GasPerPubdataByte, maxFee)
+	minL2GasPrice := big.NewInt(0).Add(pubdataPrice, m.gas.gasPerPubdataByte)
+	minL2GasPrice.Sub(minL2GasPrice, big.NewInt(1))
+	minL2GasPrice.Div(minL2GasPrice, m.gas.gasPerPubdataByte)
+	gasPrice := big.NewInt(0).Set(m.gas.fairL2GasPrice)
+	if minL2GasPrice.Cmp(gasPrice) > 0 {
+		gasPrice.Set(minL2GasPrice)
+	}
+	value := big.NewInt(0).Mul(m.gas.l2GasLimit, gasPrice)
+	return []interface{}{m.gas.l2GasLimit, m.gas.gasPerPubdataByte}, value, nil
+}
+
+// A messenger that pays the Scroll cross domain message fee
+type scrollPriceMessenger struct {
+	l2PriceMessengerBase
+	gas          l2GasParameters
+	feeEstimator *rocketpool.Contract
+}
+
+func newScrollPriceMessenger(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, base l2PriceMessengerBase, gas l2GasParameters) (L2PriceMessenger, error) {
+	feeEstimator, err := newL2Contract(ec, common.HexToAddress(cfg.Smartnode.GetScrollFeeEstimatorAddress()), ScrollFeeEstimatorAbi)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Scroll fee estimator: %w", err)
+	}
+	return &scrollPriceMessenger{
+		l2PriceMessengerBase: base,
+		gas:                  gas,
+		feeEstimator:         feeEstimator,
+	}, nil
+}
+
+func (m *scrollPriceMessenger) GetSubmitRateArgs(maxFee *big.Int) ([]interface{}, *big.Int, error) {
+	// Query the L2 message fee
+	var messageFee *big.Int
+	err := m.feeEstimator.Call(nil, &messageFee, "estimateCrossDomainMessageFee", m.gas.l2GasLimit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting cross domain message fee: %w", err)
+	}
+	return []interface{}{m.gas.l2GasLimit}, messageFee, nil
+}
+
+// Submits the RPL price to the L2 price messengers when their rates are stale and it's this node's turn
+type l2PriceSubmitter struct {
+	log       *log.ColorLogger
+	cfg       *config.RocketPoolConfig
+	ec        rocketpool.ExecutionClient
+	w         *wallet.Wallet
+	rp        *rocketpool.RocketPool
+	collector *collectors.L2PriceCollector
+
+	// The turn each messenger was last submitted to; a messenger is only submitted to once per turn
+	lastSubmittedTurns map[string]uint64
+}
+
+// Create an L2 price submitter
+func newL2PriceSubmitter(logger *log.ColorLogger, cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient, w *wallet.Wallet, rp *rocketpool.RocketPool, collector *collectors.L2PriceCollector) *l2PriceSubmitter {
+	return &l2PriceSubmitter{
+		log:                logger,
+		cfg:                cfg,
+		ec:                 ec,
+		w:                  w,
+		rp:                 rp,
+		collector:          collector,
+		lastSubmittedTurns: map[string]uint64{},
+	}
+}
+
+// Check every enabled messenger and submit to the ones that are stale if it's this node's turn
+func (s *l2PriceSubmitter) run() {
+	overrides, err := getL2PriceSubmissionOverrides(s.cfg)
+	if err != nil {
+		s.log.Printlnf("Error reading the L2 price submission overrides: %s", err.Error())
+		return
+	}
+	network := s.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+
+	var isOurTurn *bool
+	blockNumber := uint64(0)
+	for _, registration := range l2PriceMessengers {
+		address := registration.getAddress(s.cfg.Smartnode)
+		if address == "" {
+			// No price messenger deployed on the current network
+			continue
+		}
+		enabled := registration.enabled
+		maxFeeCeiling := registration.maxFee
+		if override, exists := overrides[registration.id]; exists {
+			enabled = override.enabled
+			maxFeeCeiling = override.maxFee
+		}
+		if !enabled {
+			continue
+		}
+		gas, exists := registration.gas[network]
+		if registration.gas != nil && !exists {
+			s.log.Printlnf("The %s price messenger has no gas parameters for %s, skipping it.", registration.name, network)
+			continue
+		}
+
+		// Create the messenger
+		contract, err := newL2Contract(s.ec, common.HexToAddress(address), registration.abi)
+		if err != nil {
+			s.log.Printlnf("Error creating %s price messenger: %s", registration.name, err.Error())
+			continue
+		}
+		base := l2PriceMessengerBase{
+			name:     registration.name,
+			contract: contract,
+		}
+		messenger, err := registration.create(s.cfg, s.ec, base, gas)
+		if err != nil {
+			s.log.Printlnf("Error creating %s price messenger: %s", registration.name, err.Error())
+			continue
+		}
+
+		// Check if the rate is stale
+		rateStale, err := s.isRateStale(messenger)
+		if err != nil {
+			s.log.Printlnf("Error checking %s rate: %s", registration.name, err.Error())
+			continue
+		}
+		if !rateStale {
+			// Nothing to do
+			continue
+		}
+
+		// Check whose turn it is; this is the same for every messenger, so only do it once
+		if isOurTurn == nil {
+			turn, number, err := s.isOurTurn()
+			if err != nil {
+				s.log.Printlnf("Error checking whose turn it is to submit L2 prices: %s", err.Error())
+				return
+			}
+			isOurTurn = &turn
+			blockNumber = number
+		}
+		if !*isOurTurn {
+			continue
+		}
+
+		// Only submit to each messenger once per turn, so a rate that stays stale doesn't keep costing gas
+		turn := blockNumber / BlocksPerTurn
+		if lastTurn, exists := s.lastSubmittedTurns[registration.name]; exists && lastTurn == turn {
+			s.log.Printlnf("Already submitted to the %s price messenger during turn %d, skipping it until the next turn.", registration.name, turn)
+			continue
+		}
+
+		// Submit the rate
+		err = s.submitWithRetry(messenger, maxFeeCeiling, blockNumber)
+		s.lastSubmittedTurns[registration.name] = turn
+		if err != nil {
+			// Error is not fatal for this task so print and continue
+			s.log.Printlnf("Error submitting %s price: %s", registration.name, err.Error())
+			s.updateMetrics(registration.name, func(metrics *collectors.L2PriceMessengerMetrics) {
+				metrics.Failures++
+			})
+		}
+	}
+}
+
+// Check if a messenger's rate is stale and record it in the metrics
+func (s *l2PriceSubmitter) isRateStale(messenger L2PriceMessenger) (bool, error) {
+	var out []interface{}
+	err := messenger.GetContract().Contract.Call(nil, &out, "rateStale")
+	if err != nil {
+		return false, fmt.Errorf("error querying rate staleness: %w", err)
+	}
+	rateStale := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	s.updateMetrics(messenger.GetName(), func(metrics *collectors.L2PriceMessengerMetrics) {
+		metrics.RateStale = 0
+		if rateStale {
+			metrics.RateStale = 1
+		}
+	})
+	return rateStale, nil
+}
+
+// Check if it's this node's turn to submit L2 prices, returning the block number the turn was calculated for
+func (s *l2PriceSubmitter) isOurTurn() (bool, uint64, error) {
+	nodeAccount, err := s.w.GetNodeAccount()
+	if err != nil {
+		return false, 0, fmt.Errorf("error getting node account: %w", err)
+	}
+
+	// Get total number of ODAO members
+	count, err := trustednode.GetMemberCount(s.rp, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("error getting member count: %w", err)
+	}
+	if count == 0 {
+		return false, 0, nil
+	}
+
+	// Get current block number
+	blockNumber, err := s.ec.BlockNumber(context.Background())
+	if err != nil {
+		return false, 0, fmt.Errorf("error getting block number: %w", err)
+	}
+
+	// Calculate whose turn it is to submit
+	indexToSubmit := (blockNumber / BlocksPerTurn) % count
+	addr, err := trustednode.GetMemberAt(s.rp, indexToSubmit, nil)
+	if err != nil {
+		return false, 0, fmt.Errorf("error getting member at %d: %w", indexToSubmit, err)
+	}
+	return bytes.Equal(addr.Bytes(), nodeAccount.Address.Bytes()), blockNumber, nil
+}
+
+// Submit a messenger's rate, retrying if it fails and the rate is still stale
+func (s *l2PriceSubmitter) submitWithRetry(messenger L2PriceMessenger, maxFeeCeiling float64, blockNumber uint64) error {
+	// Get the max fee; with a ceiling, use the network's recommended fee up to the ceiling, and skip the turn if the base fee is already above it
+	maxFee := eth.GweiToWei(utils.GetWatchtowerMaxFee(s.cfg))
+	if maxFeeCeiling > 0 {
+		ceiling := eth.GweiToWei(maxFeeCeiling)
+		header, err := s.ec.HeaderByNumber(context.Background(), nil)
+		if err != nil {
+			return fmt.Errorf("error getting the latest block header: %w", err)
+		}
+		if header.BaseFee != nil && header.BaseFee.Cmp(ceiling) > 0 {
+			s.log.Printlnf("The base fee of %.2f gwei is above the %s max fee of %.2f gwei, skipping this turn.", eth.WeiToGwei(header.BaseFee), messenger.GetName(), maxFeeCeiling)
+			return nil
+		}
+		suggestedMaxFee, err := rpgas.GetHeadlessMaxFeeWei(s.cfg, s.ec)
+		if err != nil {
+			return fmt.Errorf("error getting recommended max fee from the network: %w", err)
+		}
+		maxFee = suggestedMaxFee
+		if maxFee.Cmp(ceiling) > 0 {
+			maxFee = ceiling
+		}
+	}
+
+	var err error
+	for attempt := 1; attempt <= l2SubmissionAttempts; attempt++ {
+		if attempt > 1 {
+			s.log.Printlnf("Submitting %s price failed (%s), retrying in %s...", messenger.GetName(), err.Error(), l2SubmissionRetryDelay)
+			time.Sleep(l2SubmissionRetryDelay)
+		}
+
+		// Another member may have submitted since the rate was last checked
+		rateStale, staleErr := s.isRateStale(messenger)
+		if staleErr != nil {
+			return staleErr
+		}
+		if !rateStale {
+			return nil
+		}
+
+		var submitted bool
+		submitted, err = s.submitRate(messenger, maxFee)
+		if err == nil {
+			if submitted {
+				s.log.Printlnf("Successfully submitted %s price for block %d.", messenger.GetName(), blockNumber)
+				s.updateMetrics(messenger.GetName(), func(metrics *collectors.L2PriceMessengerMetrics) {
+					metrics.Submissions++
+					metrics.LastSubmissionTime = float64(time.Now().Unix())
+					metrics.RateStale = 0
+				})
+			}
+			return nil
+		}
+	}
+	return err
+}
+
+// Call submitRate on a messenger, returning whether the transaction was sent
+func (s *l2PriceSubmitter) submitRate(messenger L2PriceMessenger, maxFee *big.Int) (bool, error) {
+	contract := messenger.GetContract()
+
+	// Get transactor
+	opts, err := s.w.GetNodeAccountTransactor()
+	if err != nil {
+		return false, fmt.Errorf("error getting transactor: %w", err)
+	}
+
+	// Get the submitRate arguments
+	args, value, err := messenger.GetSubmitRateArgs(maxFee)
+	if err != nil {
+		return false, err
+	}
+	opts.Value = value
+
+	// Temporary gas calculations until this gets put into a binding
+	input, err := contract.ABI.Pack("submitRate", args...)
+	if err != nil {
+		return false, fmt.Errorf("could not encode input data: %w", err)
+	}
+
+	// Estimate gas limit
+	gasLimit, err := s.rp.Client.EstimateGas(context.Background(), ethereum.CallMsg{
+		From:     opts.From,
+		To:       contract.Address,
+		GasPrice: big.NewInt(0), // use 0 gwei for simulation
+		Value:    opts.Value,
+		Data:     input,
+	})
+	if err != nil {
+		return false, fmt.Errorf("error estimating gas limit: %w", err)
+	}
+
+	// Get the safe gas limit
+	safeGasLimit := uint64(float64(gasLimit) * rocketpool.GasLimitMultiplier)
+	if gasLimit > rocketpool.MaxGasLimit {
+		gasLimit = rocketpool.MaxGasLimit
+	}
+	if safeGasLimit > rocketpool.MaxGasLimit {
+		safeGasLimit = rocketpool.MaxGasLimit
+	}
+	gasInfo := rocketpool.GasInfo{
+		EstGasLimit:  gasLimit,
+		SafeGasLimit: safeGasLimit,
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, false, 0, s.log, maxFee, 0) {
+		return false, nil
+	}
+
+	// Set the gas settings
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = eth.GweiToWei(utils.GetWatchtowerPrioFee(s.cfg))
+	opts.GasLimit = gasInfo.SafeGasLimit
+
+	s.log.Printlnf("Submitting rate to %s...", messenger.GetName())
+
+	// Submit rates
+	tx, err := contract.Transact(opts, "submitRate", args...)
+	if err != nil {
+		return false, fmt.Errorf("error submitting rate: %w", err)
+	}
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(s.cfg, tx.Hash(), s.rp.Client, s.log)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Update a messenger's metrics under the collector's lock
+func (s *l2PriceSubmitter) updateMetrics(name string, update func(metrics *collectors.L2PriceMessengerMetrics)) {
+	s.collector.UpdateLock.Lock()
+	defer s.collector.UpdateLock.Unlock()
+	update(s.collector.GetMessengerMetrics(name))
+}
@@ -8,19 +8,25 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	nodecollectors "github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, taskScheduler *scheduler.Scheduler, scrubCollector *collectors.ScrubCollector, bondReductionCollector *collectors.BondReductionCollector, soloMigrationCollector *collectors.SoloMigrationCollector, l2PriceCollector *collectors.L2PriceCollector, rplPriceCollector *collectors.RplPriceCollector) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
 		return err
 	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return err
+	}
 
 	// Return if metrics are disabled
 	if cfg.EnableMetrics.Value == false {
@@ -36,6 +42,9 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	registry.MustRegister(scrubCollector)
 	registry.MustRegister(bondReductionCollector)
 	registry.MustRegister(soloMigrationCollector)
+	registry.MustRegister(l2PriceCollector)
+	registry.MustRegister(rplPriceCollector)
+	registry.MustRegister(nodecollectors.NewBeaconRequestCollector(bc))
 	handler := promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
 
 	// Start the HTTP server
@@ -43,13 +52,16 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, scrubCollector *co
 	metricsPort := c.GlobalUint("metricsPort")
 	logger.Printlnf("Starting metrics exporter on %s:%d.", metricsAddress, metricsPort)
 	metricsPath := "/metrics"
+	statusPath := "/status"
 	http.Handle(metricsPath, handler)
+	http.Handle(statusPath, taskScheduler)
 	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`<html>
             <head><title>Rocket Pool Watchtower Metrics Exporter</title></head>
             <body>
             <h1>Rocket Pool Watchtower Metrics Exporter</h1>
             <p><a href='` + metricsPath + `'>Metrics</a></p>
+            <p><a href='` + statusPath + `'>Task Status</a></p>
             </body>
             </html>`,
 		))
@@ -0,0 +1,295 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/eth1"
+	mathutils "github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+// The name of the default RPL TWAP pool in logs and metrics
+const defaultRplPriceSourceName string = "default"
+
+// A Uniswap v3-style pool to read the RPL price from
+type rplPriceSource struct {
+	name    string
+	address common.Address
+}
+
+// The RPL price of a single source over the TWAP window
+type rplPriceSample struct {
+	source rplPriceSource
+	price  *big.Int
+
+	// The harmonic mean of the pool's in-range liquidity over the TWAP window
+	liquidity *big.Int
+}
+
+// Get the RPL price at a block from every configured source, refusing to return one if the sources diverge too much
+func (t *submitRplPrice) getRplPrice(blockNumber uint64) (*big.Int, error) {
+
+	// Initialize call options
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(int64(blockNumber)),
+	}
+
+	// Get the sources
+	sources, err := t.getRplPriceSources()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get a client with the block number available
+	client, err := eth1.GetBestApiClient(t.rp, t.cfg, t.printMessage, opts.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	rplAddress, err := client.GetAddress("rocketTokenRPL", opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting RPL token address: %w", err)
+	}
+
+	// Get the price of each source
+	samples := make([]rplPriceSample, 0, len(sources))
+	for _, source := range sources {
+		sample, err := t.getRplPoolPrice(client, source, *rplAddress, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting RPL price from source %s (%s): %w", source.name, source.address.Hex(), err)
+		}
+		t.log.Printlnf("RPL price from source %s (%s): %.6f ETH", source.name, source.address.Hex(), mathutils.RoundDown(eth.WeiToEth(sample.price), 6))
+		samples = append(samples, sample)
+	}
+
+	// Combine them
+	aggregation := t.cfg.Smartnode.RplPriceAggregation.Value.(cfgtypes.RplPriceAggregation)
+	rplPrice, err := aggregateRplPrices(samples, aggregation)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check how far each source is from the combined price
+	maxDivergence := float64(0)
+	for _, sample := range samples {
+		divergence := getRplPriceDivergence(sample.price, rplPrice)
+		if divergence > maxDivergence {
+			maxDivergence = divergence
+		}
+	}
+	allowedDivergence := t.cfg.Smartnode.RplPriceMaxDivergence.Value.(float64)
+	isBlocked := maxDivergence > allowedDivergence
+
+	// Record the prices for auditing
+	t.rplPriceCollector.UpdateLock.Lock()
+	t.rplPriceCollector.SourcePrices = map[string]float64{}
+	for _, sample := range samples {
+		t.rplPriceCollector.SourcePrices[sample.source.name] = eth.WeiToEth(sample.price)
+	}
+	t.rplPriceCollector.AggregatePrice = eth.WeiToEth(rplPrice)
+	t.rplPriceCollector.MaxDivergence = maxDivergence
+	t.rplPriceCollector.SubmissionBlocked = 0
+	if isBlocked {
+		t.rplPriceCollector.SubmissionBlocked = 1
+	}
+	t.rplPriceCollector.BlockNumber = float64(blockNumber)
+	t.rplPriceCollector.UpdateLock.Unlock()
+
+	if len(samples) > 1 {
+		t.log.Printlnf("Combined RPL price (%s of %d sources): %.6f ETH, largest divergence %.2f%%", aggregation, len(samples), mathutils.RoundDown(eth.WeiToEth(rplPrice), 6), maxDivergence)
+
+		// The other Oracle DAO members submit the default pool's price, so a different price here won't match theirs
+		if samples[0].price.Cmp(rplPrice) != 0 {
+			t.log.Printlnf("WARNING: the extra RPL price sources changed the price from the default pool's %.6f ETH to %.6f ETH. Oracle DAO members that don't use the same sources will submit a different price, so this submission may not count towards consensus.", mathutils.RoundDown(eth.WeiToEth(samples[0].price), 6), mathutils.RoundDown(eth.WeiToEth(rplPrice), 6))
+		}
+	}
+	if isBlocked {
+		alerting.AlertRplPriceDivergence(t.cfg, blockNumber, maxDivergence, allowedDivergence)
+		return nil, fmt.Errorf("RPL price sources diverge by up to %.2f%% for block %d, which is more than the allowed %.2f%%; refusing to submit", maxDivergence, blockNumber, allowedDivergence)
+	}
+
+	// Return
+	return rplPrice, nil
+
+}
+
+// Get the default RPL TWAP pool and any extra pools from the config
+func (t *submitRplPrice) getRplPriceSources() ([]rplPriceSource, error) {
+	poolAddress := t.cfg.Smartnode.GetRplTwapPoolAddress()
+	if poolAddress == "" {
+		return nil, fmt.Errorf("RPL TWAP pool contract not deployed on this network")
+	}
+	sources := []rplPriceSource{
+		{
+			name:    defaultRplPriceSourceName,
+			address: common.HexToAddress(poolAddress),
+		},
+	}
+
+	seen := map[common.Address]bool{
+		sources[0].address: true,
+	}
+	for _, entry := range strings.Split(t.cfg.Smartnode.RplPriceSources.Value.(string), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("RPL price source [%s] is not a valid address", entry)
+		}
+		address := common.HexToAddress(entry)
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		sources = append(sources, rplPriceSource{
+			name:    address.Hex(),
+			address: address,
+		})
+	}
+	return sources, nil
+}
+
+// Get the RPL price of a Uniswap v3-style RPL / ETH pool over the TWAP window
+func (t *submitRplPrice) getRplPoolPrice(client *rocketpool.RocketPool, source rplPriceSource, rplAddress common.Address, opts *bind.CallOpts) (rplPriceSample, error) {
+
+	// Construct the pool contract instance
+	parsed, err := abi.JSON(strings.NewReader(RplTwapPoolAbi))
+	if err != nil {
+		return rplPriceSample{}, fmt.Errorf("error decoding RPL TWAP pool ABI: %w", err)
+	}
+	addr := source.address
+	poolContract := bind.NewBoundContract(addr, parsed, client.Client, client.Client, client.Client)
+	pool := rocketpool.Contract{
+		Contract: poolContract,
+		Address:  &addr,
+		ABI:      &parsed,
+		Client:   client.Client,
+	}
+
+	// Check which side of the pool RPL is on
+	token0 := new(common.Address)
+	if err := pool.Call(opts, token0, "token0"); err != nil {
+		return rplPriceSample{}, fmt.Errorf("could not get pool tokens: %w", err)
+	}
+	isRplToken0 := *token0 == rplAddress
+
+	// Get the cumulative ticks and liquidity
+	response := poolObserveResponse{}
+	interval := twapNumberOfSeconds
+	args := []uint32{interval, 0}
+
+	err = pool.Call(opts, &response, "observe", args)
+	if err != nil {
+		return rplPriceSample{}, fmt.Errorf("could not get RPL price at block %s: %w", opts.BlockNumber.String(), err)
+	}
+	if len(response.TickCumulatives) < 2 || len(response.SecondsPerLiquidityCumulativeX128s) < 2 {
+		return rplPriceSample{}, fmt.Errorf("TWAP contract didn't have enough tick cumulatives for block %s (raw: %v)", opts.BlockNumber.String(), response.TickCumulatives)
+	}
+
+	tick := big.NewInt(0).Sub(response.TickCumulatives[1], response.TickCumulatives[0])
+	tick.Div(tick, big.NewInt(int64(interval))) // tick = (cumulative[1] - cumulative[0]) / interval
+
+	// The tick is the price of token0 in token1, so RPL's price is its inverse when RPL is token1
+	isInverse := !isRplToken0
+	if tick.Sign() < 0 {
+		tick.Neg(tick)
+		isInverse = !isInverse
+	}
+	ratio := getTickRatio(tick)
+	rplPrice := ratio
+	if isInverse {
+		one := eth.EthToWei(1)
+		numerator := big.NewInt(0).Mul(one, one)
+		rplPrice = big.NewInt(0).Div(numerator, ratio) // 1e18 ^ 2 / (1.0001e18^tick * 1e18 / 1e18^tick)
+	}
+
+	// Get the harmonic mean liquidity, the same way Uniswap's OracleLibrary does
+	liquidity := big.NewInt(0)
+	secondsPerLiquidity := big.NewInt(0).Sub(response.SecondsPerLiquidityCumulativeX128s[1], response.SecondsPerLiquidityCumulativeX128s[0])
+	secondsPerLiquidity.Mod(secondsPerLiquidity, big.NewInt(0).Lsh(big.NewInt(1), 160)) // The cumulative is a wrapping uint160
+	if secondsPerLiquidity.Sign() > 0 {
+		liquidity.Lsh(big.NewInt(int64(interval)), 128)
+		liquidity.Div(liquidity, secondsPerLiquidity)
+	}
+
+	return rplPriceSample{
+		source:    source,
+		price:     rplPrice,
+		liquidity: liquidity,
+	}, nil
+
+}
+
+// Get 1.0001 ^ tick as an 18-decimal fixed point number, for a non-negative tick
+func getTickRatio(tick *big.Int) *big.Int {
+	base := eth.EthToWei(1.0001) // 1.0001e18
+	one := eth.EthToWei(1)       // 1e18
+
+	numerator := big.NewInt(0).Exp(base, tick, nil) // 1.0001e18 ^ tick
+	numerator.Mul(numerator, one)
+
+	denominator := big.NewInt(0).Exp(one, tick, nil) // 1e18 ^ tick
+	return denominator.Div(numerator, denominator)   // 1.0001e18^tick * 1e18 / 1e18^tick
+}
+
+// Combine the prices of the sources; a single source's price is used as-is
+func aggregateRplPrices(samples []rplPriceSample, aggregation cfgtypes.RplPriceAggregation) (*big.Int, error) {
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("no RPL price sources")
+	}
+	if len(samples) == 1 {
+		return big.NewInt(0).Set(samples[0].price), nil
+	}
+
+	switch aggregation {
+	case cfgtypes.RplPriceAggregation_Median:
+		prices := make([]*big.Int, len(samples))
+		for i, sample := range samples {
+			prices[i] = sample.price
+		}
+		sort.Slice(prices, func(i, j int) bool {
+			return prices[i].Cmp(prices[j]) < 0
+		})
+		middle := len(prices) / 2
+		if len(prices)%2 == 1 {
+			return big.NewInt(0).Set(prices[middle]), nil
+		}
+		median := big.NewInt(0).Add(prices[middle-1], prices[middle])
+		return median.Div(median, big.NewInt(2)), nil
+
+	case cfgtypes.RplPriceAggregation_LiquidityWeighted:
+		weightedSum := big.NewInt(0)
+		totalLiquidity := big.NewInt(0)
+		for _, sample := range samples {
+			weightedSum.Add(weightedSum, big.NewInt(0).Mul(sample.price, sample.liquidity))
+			totalLiquidity.Add(totalLiquidity, sample.liquidity)
+		}
+		if totalLiquidity.Sign() == 0 {
+			return nil, fmt.Errorf("none of the RPL price sources had any liquidity")
+		}
+		return weightedSum.Div(weightedSum, totalLiquidity), nil
+	}
+
+	return nil, fmt.Errorf("unknown RPL price aggregation [%s]", aggregation)
+}
+
+// Get the difference between a source's price and the combined price, in percent of the combined price
+func getRplPriceDivergence(price *big.Int, aggregatePrice *big.Int) float64 {
+	if aggregatePrice.Sign() == 0 {
+		return 0
+	}
+	difference := big.NewInt(0).Sub(price, aggregatePrice)
+	difference.Abs(difference)
+	divergence, _ := new(big.Float).Quo(new(big.Float).SetInt(difference), new(big.Float).SetInt(aggregatePrice)).Float64()
+	return divergence * 100
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -38,16 +37,14 @@ const (
 
 // Submit network balances task
 type submitNetworkBalances struct {
-	c         *cli.Context
-	log       *log.ColorLogger
-	errLog    *log.ColorLogger
-	cfg       *config.RocketPoolConfig
-	w         *wallet.Wallet
-	ec        rocketpool.ExecutionClient
-	rp        *rocketpool.RocketPool
-	bc        beacon.Client
-	lock      *sync.Mutex
-	isRunning bool
+	c      *cli.Context
+	log    *log.ColorLogger
+	errLog *log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	ec     rocketpool.ExecutionClient
+	rp     *rocketpool.RocketPool
+	bc     beacon.Client
 }
 
 // Network balance info
@@ -94,18 +91,15 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 	}
 
 	// Return task
-	lock := &sync.Mutex{}
 	return &submitNetworkBalances{
-		c:         c,
-		log:       &logger,
-		errLog:    &errorLogger,
-		cfg:       cfg,
-		w:         w,
-		ec:        ec,
-		rp:        rp,
-		bc:        bc,
-		lock:      lock,
-		isRunning: false,
+		c:      c,
+		log:    &logger,
+		errLog: &errorLogger,
+		cfg:    cfg,
+		w:      w,
+		ec:     ec,
+		rp:     rp,
+		bc:     bc,
 	}, nil
 
 }
@@ -196,84 +190,61 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			return nil
 		}
 
-		// Check if the process is already running
-		t.lock.Lock()
-		if t.isRunning {
-			t.log.Println("Balance report is already running in the background.")
-			t.lock.Unlock()
-			return nil
-		}
-		t.lock.Unlock()
+		logPrefix := "[Balance Report]"
+		t.log.Printlnf("%s Starting balance report.", logPrefix)
 
-		go func() {
-			t.lock.Lock()
-			t.isRunning = true
-			t.lock.Unlock()
-			logPrefix := "[Balance Report]"
-			t.log.Printlnf("%s Starting balance report in a separate thread.", logPrefix)
-
-			// Log
-			t.log.Printlnf("Calculating network balances for block %d...", targetBlockNumber)
+		// Log
+		t.log.Printlnf("Calculating network balances for block %d...", targetBlockNumber)
 
-			// Get network balances at block
-			balances, err := t.getNetworkBalances(targetBlockHeader, big.NewInt(int64(targetBlockNumber)), slotNumber, time.Unix(int64(targetBlockHeader.Time), 0))
-			if err != nil {
-				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-				return
-			}
+		// Get network balances at block
+		balances, err := t.getNetworkBalances(targetBlockHeader, big.NewInt(int64(targetBlockNumber)), slotNumber, time.Unix(int64(targetBlockHeader.Time), 0))
+		if err != nil {
+			return fmt.Errorf("%s %w", logPrefix, err)
+		}
 
-			// Log
-			t.log.Printlnf("Deposit pool balance: %s wei", balances.DepositPool.String())
-			t.log.Printlnf("Node credit balance: %s wei", balances.NodeCreditBalance.String())
-			t.log.Printlnf("Total minipool user balance: %s wei", balances.MinipoolsTotal.String())
-			t.log.Printlnf("Staking minipool user balance: %s wei", balances.MinipoolsStaking.String())
-			t.log.Printlnf("Fee distributor user balance: %s wei", balances.DistributorShareTotal.String())
-			t.log.Printlnf("Smoothing pool user balance: %s wei", balances.SmoothingPoolShare.String())
-			t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
-			t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
-
-			// Check if we have reported these specific values before
-			balances.SlotTimestamp = uint64(nextSubmissionTime.Unix())
-			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, targetBlockNumber, balances)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-				return
-			}
-			if hasSubmittedSpecific {
-				t.lock.Lock()
-				t.isRunning = false
-				t.lock.Unlock()
-				return
-			}
+		// Log
+		t.log.Printlnf("Deposit pool balance: %s wei", balances.DepositPool.String())
+		t.log.Printlnf("Node credit balance: %s wei", balances.NodeCreditBalance.String())
+		t.log.Printlnf("Total minipool user balance: %s wei", balances.MinipoolsTotal.String())
+		t.log.Printlnf("Staking minipool user balance: %s wei", balances.MinipoolsStaking.String())
+		t.log.Printlnf("Fee distributor user balance: %s wei", balances.DistributorShareTotal.String())
+		t.log.Printlnf("Smoothing pool user balance: %s wei", balances.SmoothingPoolShare.String())
+		t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
+		t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
+
+		// Check if we have reported these specific values before
+		balances.SlotTimestamp = uint64(nextSubmissionTime.Unix())
+		hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, targetBlockNumber, balances)
+		if err != nil {
+			return fmt.Errorf("%s %w", logPrefix, err)
+		}
+		if hasSubmittedSpecific {
+			return nil
+		}
 
-			// We haven't submitted these values, check if we've submitted any for this block so we can log it
-			hasSubmitted, err := t.hasSubmittedBlockBalances(nodeAccount.Address, targetBlockNumber)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-				return
-			}
-			if hasSubmitted {
-				t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", targetBlockNumber)
-			}
+		// We haven't submitted these values, check if we've submitted any for this block so we can log it
+		hasSubmitted, err := t.hasSubmittedBlockBalances(nodeAccount.Address, targetBlockNumber)
+		if err != nil {
+			return fmt.Errorf("%s %w", logPrefix, err)
+		}
+		if hasSubmitted {
+			t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", targetBlockNumber)
+		}
 
-			// Log
-			t.log.Println("Submitting balances...")
+		// Log
+		t.log.Println("Submitting balances...")
 
-			// Set the reference timestamp
-			balances.SlotTimestamp = uint64(nextSubmissionTime.Unix())
+		// Set the reference timestamp
+		balances.SlotTimestamp = uint64(nextSubmissionTime.Unix())
 
-			// Submit balances
-			if err := t.submitBalances(balances, true); err != nil {
-				t.handleError(fmt.Errorf("%s could not submit network balances: %w", logPrefix, err))
-				return
-			}
+		// Submit balances
+		if err := t.submitBalances(balances, true); err != nil {
+			return fmt.Errorf("%s could not submit network balances: %w", logPrefix, err)
+		}
 
-			// Log and return
-			t.log.Printlnf("%s Balance report complete.", logPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-		}()
+		// Log and return
+		t.log.Printlnf("%s Balance report complete.", logPrefix)
+		return nil
 	} else { // Houston still not deployed, using legacy submission
 		// Get block to submit balances for
 		blockNumber := state.NetworkDetails.LatestReportableBalancesBlock
@@ -309,92 +280,59 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 			return nil
 		}
 
-		// Check if the process is already running
-		t.lock.Lock()
-		if t.isRunning {
-			t.log.Println("Balance report is already running in the background.")
-			t.lock.Unlock()
-			return nil
-		}
-		t.lock.Unlock()
-
-		go func() {
-			t.lock.Lock()
-			t.isRunning = true
-			t.lock.Unlock()
-			logPrefix := "[Balance Report]"
-			t.log.Printlnf("%s Starting balance report in a separate thread.", logPrefix)
+		logPrefix := "[Balance Report]"
+		t.log.Printlnf("%s Starting balance report.", logPrefix)
 
-			// Log
-			t.log.Printlnf("Calculating network balances for block %d...", blockNumber)
+		// Log
+		t.log.Printlnf("Calculating network balances for block %d...", blockNumber)
 
-			// Get network balances at block
-			balances, err := t.getNetworkBalances(header, blockNumberBig, slotNumber, blockTime)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-				return
-			}
+		// Get network balances at block
+		balances, err := t.getNetworkBalances(header, blockNumberBig, slotNumber, blockTime)
+		if err != nil {
+			return fmt.Errorf("%s %w", logPrefix, err)
+		}
 
-			// Log
-			t.log.Printlnf("Deposit pool balance: %s wei", balances.DepositPool.String())
-			t.log.Printlnf("Node credit balance: %s wei", balances.NodeCreditBalance.String())
-			t.log.Printlnf("Total minipool user balance: %s wei", balances.MinipoolsTotal.String())
-			t.log.Printlnf("Staking minipool user balance: %s wei", balances.MinipoolsStaking.String())
-			t.log.Printlnf("Fee distributor user balance: %s wei", balances.DistributorShareTotal.String())
-			t.log.Printlnf("Smoothing pool user balance: %s wei", balances.SmoothingPoolShare.String())
-			t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
-			t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
-
-			// Check if we have reported these specific values before
-			hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, blockNumber, balances)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-				return
-			}
-			if hasSubmittedSpecific {
-				t.lock.Lock()
-				t.isRunning = false
-				t.lock.Unlock()
-				return
-			}
+		// Log
+		t.log.Printlnf("Deposit pool balance: %s wei", balances.DepositPool.String())
+		t.log.Printlnf("Node credit balance: %s wei", balances.NodeCreditBalance.String())
+		t.log.Printlnf("Total minipool user balance: %s wei", balances.MinipoolsTotal.String())
+		t.log.Printlnf("Staking minipool user balance: %s wei", balances.MinipoolsStaking.String())
+		t.log.Printlnf("Fee distributor user balance: %s wei", balances.DistributorShareTotal.String())
+		t.log.Printlnf("Smoothing pool user balance: %s wei", balances.SmoothingPoolShare.String())
+		t.log.Printlnf("rETH contract balance: %s wei", balances.RETHContract.String())
+		t.log.Printlnf("rETH token supply: %s wei", balances.RETHSupply.String())
+
+		// Check if we have reported these specific values before
+		hasSubmittedSpecific, err := t.hasSubmittedSpecificBlockBalances(nodeAccount.Address, blockNumber, balances)
+		if err != nil {
+			return fmt.Errorf("%s %w", logPrefix, err)
+		}
+		if hasSubmittedSpecific {
+			return nil
+		}
 
-			// We haven't submitted these values, check if we've submitted any for this block so we can log it
-			hasSubmitted, err := t.hasSubmittedBlockBalances(nodeAccount.Address, blockNumber)
-			if err != nil {
-				t.handleError(fmt.Errorf("%s %w", logPrefix, err))
-				return
-			}
-			if hasSubmitted {
-				t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", blockNumber)
-			}
+		// We haven't submitted these values, check if we've submitted any for this block so we can log it
+		hasSubmitted, err := t.hasSubmittedBlockBalances(nodeAccount.Address, blockNumber)
+		if err != nil {
+			return fmt.Errorf("%s %w", logPrefix, err)
+		}
+		if hasSubmitted {
+			t.log.Printlnf("Have previously submitted out-of-date balances for block %d, trying again...", blockNumber)
+		}
 
-			// Log
-			t.log.Println("Submitting balances...")
+		// Log
+		t.log.Println("Submitting balances...")
 
-			// Submit balances
-			if err := t.submitBalances(balances, false); err != nil {
-				t.handleError(fmt.Errorf("%s could not submit network balances: %w", logPrefix, err))
-				return
-			}
+		// Submit balances
+		if err := t.submitBalances(balances, false); err != nil {
+			return fmt.Errorf("%s could not submit network balances: %w", logPrefix, err)
+		}
 
-			// Log and return
-			t.log.Printlnf("%s Balance report complete.", logPrefix)
-			t.lock.Lock()
-			t.isRunning = false
-			t.lock.Unlock()
-		}()
+		// Log and return
+		t.log.Printlnf("%s Balance report complete.", logPrefix)
+		return nil
 	}
-	// Return
-	return nil
-
-}
 
-func (t *submitNetworkBalances) handleError(err error) {
-	t.errLog.Println(err)
-	t.errLog.Println("*** Balance report failed. ***")
-	t.lock.Lock()
-	t.isRunning = false
-	t.lock.Unlock()
 }
 
 // Check whether balances for a block has already been submitted by the node
@@ -23,6 +23,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -45,6 +46,7 @@ type submitRewardsTree_Rolling struct {
 	genesisTime time.Time
 	recordMgr   *rprewards.RollingRecordManager
 	stateMgr    *state.NetworkStateManager
+	txLock      *scheduler.TransactionLock
 	logPrefix   string
 
 	lock      *sync.Mutex
@@ -52,7 +54,7 @@ type submitRewardsTree_Rolling struct {
 }
 
 // Create submit rewards tree with rolling record support
-func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager) (*submitRewardsTree_Rolling, error) {
+func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager, txLock *scheduler.TransactionLock) (*submitRewardsTree_Rolling, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -126,6 +128,7 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 		rp:          rp,
 		bc:          bc,
 		stateMgr:    stateMgr,
+		txLock:      txLock,
 		genesisTime: genesisTime,
 		logPrefix:   logPrefix,
 		lock:        lock,
@@ -631,6 +634,12 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 		network++
 	}
 
+	// Wait for the other tasks to finish sending their transactions
+	if err := t.txLock.Lock(context.Background()); err != nil {
+		return err
+	}
+	defer t.txLock.Unlock()
+
 	// Get transactor
 	opts, err := t.w.GetNodeAccountTransactor()
 	if err != nil {
@@ -0,0 +1,455 @@
+package watchtower
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/participation"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// The event a minipool emits when an Oracle DAO member votes to scrub it
+var scrubVotedEventId = crypto.Keccak256Hash([]byte("ScrubVoted(address,uint256)"))
+
+// The event a minipool emits when enough members voted to scrub it
+var minipoolScrubbedEventId = crypto.Keccak256Hash([]byte("MinipoolScrubbed(uint256)"))
+
+const (
+	// How long after a balances or prices round is due before a missing submission from this node is alerted on
+	consensusDutyGracePeriod time.Duration = 2 * time.Hour
+
+	// How long after a rewards interval ends before a missing rewards tree submission from this node is alerted on
+	rewardsTreeDutyGracePeriod time.Duration = 6 * time.Hour
+)
+
+// The last observed state of an L2 price messenger
+type l2MessengerTurn struct {
+	turn        uint64
+	responsible common.Address
+	isStale     bool
+}
+
+// Track Oracle DAO participation task
+type trackOdaoParticipation struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	ec          rocketpool.ExecutionClient
+	rp          *rocketpool.RocketPool
+	nodeAddress common.Address
+	historyPath string
+
+	// The last observed turn of each L2 price messenger
+	l2Turns map[string]l2MessengerTurn
+
+	// The overdue rounds that have already been alerted on
+	missedRounds map[string]bool
+}
+
+// Create track Oracle DAO participation task
+func newTrackOdaoParticipation(c *cli.Context, logger log.ColorLogger) (*trackOdaoParticipation, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node account
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node account: %w", err)
+	}
+
+	// Return task
+	return &trackOdaoParticipation{
+		c:            c,
+		log:          logger,
+		cfg:          cfg,
+		ec:           ec,
+		rp:           rp,
+		nodeAddress:  account.Address,
+		historyPath:  cfg.Smartnode.GetParticipationHistoryPath(true),
+		l2Turns:      map[string]l2MessengerTurn{},
+		missedRounds: map[string]bool{},
+	}, nil
+
+}
+
+// Record which members submitted for every duty round that has finished since the last run
+func (t *trackOdaoParticipation) run(state *state.NetworkState) error {
+
+	// Log
+	t.log.Println("Checking Oracle DAO duty participation...")
+
+	// Load the history
+	history, err := participation.LoadParticipationHistory(t.historyPath)
+	if err != nil {
+		return err
+	}
+
+	// Get the current members
+	members := []common.Address{}
+	for _, member := range state.OracleDaoMemberDetails {
+		if member.Exists {
+			members = append(members, member.Address)
+		}
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	now := time.Unix(int64(state.BeaconConfig.GenesisTime+state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot), 0)
+
+	isMember := false
+	for _, member := range members {
+		if member == t.nodeAddress {
+			isMember = true
+			break
+		}
+	}
+
+	// Network balances
+	if state.NetworkDetails.BalancesBlock != nil {
+		balancesBlock := state.NetworkDetails.BalancesBlock.Uint64()
+		if err := t.recordConsensusRound(history, participation.Duty_Balances, balancesBlock, members, now, network.GetBalancesSubmissions); err != nil {
+			return fmt.Errorf("error recording balances participation: %w", err)
+		}
+		if isMember && state.NetworkDetails.SubmitBalancesEnabled {
+			if err := t.checkConsensusRoundOverdue(participation.Duty_Balances, balancesBlock, state.NetworkDetails.BalancesSubmissionFrequency, now, network.GetBalancesSubmissions); err != nil {
+				return fmt.Errorf("error checking for overdue balances submissions: %w", err)
+			}
+		}
+	}
+
+	// RPL prices
+	if err := t.recordConsensusRound(history, participation.Duty_Prices, state.NetworkDetails.PricesBlock, members, now, network.GetPricesSubmissions); err != nil {
+		return fmt.Errorf("error recording prices participation: %w", err)
+	}
+	if isMember && state.NetworkDetails.SubmitPricesEnabled {
+		if err := t.checkConsensusRoundOverdue(participation.Duty_Prices, state.NetworkDetails.PricesBlock, state.NetworkDetails.PricesSubmissionFrequency, now, network.GetPricesSubmissions); err != nil {
+			return fmt.Errorf("error checking for overdue prices submissions: %w", err)
+		}
+	}
+
+	// Rewards trees
+	if err := t.recordRewardsTreeRound(history, state.NetworkDetails.RewardIndex, members, now, opts); err != nil {
+		return fmt.Errorf("error recording rewards tree participation: %w", err)
+	}
+	if isMember {
+		if err := t.checkRewardsTreeOverdue(state, now, opts); err != nil {
+			return fmt.Errorf("error checking for an overdue rewards tree submission: %w", err)
+		}
+	}
+
+	// Scrub votes
+	if err := t.recordScrubVotes(history, state, members, now); err != nil {
+		return fmt.Errorf("error recording scrub vote participation: %w", err)
+	}
+
+	// L2 price messengers
+	if state.NetworkDetails.SubmitPricesEnabled {
+		for name, address := range getL2PriceMessengerAddresses(t.cfg) {
+			if err := t.recordL2Turn(history, name, address, state.ElBlockNumber, members, now, opts); err != nil {
+				t.log.Printlnf("WARNING: error recording %s price messenger participation: %s", name, err.Error())
+			}
+		}
+	}
+
+	// Save the history
+	history.LastScannedBlock = state.ElBlockNumber
+	return history.Save(t.historyPath)
+
+}
+
+// Record a round of a duty that reaches consensus on a block number, if it hasn't been recorded yet
+func (t *trackOdaoParticipation) recordConsensusRound(history *participation.ParticipationHistory, duty participation.Duty, consensusBlock uint64, members []common.Address, now time.Time, getSubmissions func(*rocketpool.RocketPool, common.Address, uint64, *big.Int, *bind.CallOpts) (*[]uint64, error)) error {
+	if consensusBlock == 0 || history.GetRound(duty, "", consensusBlock) != nil {
+		return nil
+	}
+	isFirstRound := history.GetLatestRound(duty, "") == nil
+
+	// Find the members that submitted for the block; submissions can only come after it
+	intervalSize := big.NewInt(int64(t.cfg.Geth.EventLogInterval))
+	round := participation.DutyRound{
+		Duty:             duty,
+		Round:            consensusBlock,
+		Time:             now,
+		ConsensusReached: true,
+		Members:          members,
+		Submitters:       []common.Address{},
+	}
+	for _, member := range members {
+		blocks, err := getSubmissions(t.rp, member, consensusBlock, intervalSize, nil)
+		if err != nil {
+			return fmt.Errorf("error getting submissions for member %s: %w", member.Hex(), err)
+		}
+		for _, block := range *blocks {
+			if block == consensusBlock {
+				round.Submitters = append(round.Submitters, member)
+				break
+			}
+		}
+	}
+	history.UpdateRound(round)
+	t.log.Printlnf("%d of %d members submitted %s for block %d.", len(round.Submitters), len(members), duty, consensusBlock)
+
+	// Alert if consensus was reached without this node; skip the first round, since it may have happened before the node joined
+	if !isFirstRound {
+		t.checkConsensusWithoutNode(&round)
+	}
+	return nil
+}
+
+// Record the rewards tree submissions for the last interval that reached consensus, if it hasn't been recorded yet
+func (t *trackOdaoParticipation) recordRewardsTreeRound(history *participation.ParticipationHistory, rewardIndex uint64, members []common.Address, now time.Time, opts *bind.CallOpts) error {
+	if rewardIndex == 0 {
+		return nil
+	}
+	interval := rewardIndex - 1
+	if history.GetRound(participation.Duty_RewardsTree, "", interval) != nil {
+		return nil
+	}
+	isFirstRound := history.GetLatestRound(participation.Duty_RewardsTree, "") == nil
+
+	round := participation.DutyRound{
+		Duty:             participation.Duty_RewardsTree,
+		Round:            interval,
+		Time:             now,
+		ConsensusReached: true,
+		Members:          members,
+		Submitters:       []common.Address{},
+	}
+	for _, member := range members {
+		submitted, err := rewards.GetTrustedNodeSubmitted(t.rp, member, interval, opts)
+		if err != nil {
+			return fmt.Errorf("error checking if member %s submitted interval %d: %w", member.Hex(), interval, err)
+		}
+		if submitted {
+			round.Submitters = append(round.Submitters, member)
+		}
+	}
+	history.UpdateRound(round)
+	t.log.Printlnf("%d of %d members submitted the rewards tree for interval %d.", len(round.Submitters), len(members), interval)
+
+	if !isFirstRound {
+		t.checkConsensusWithoutNode(&round)
+	}
+	return nil
+}
+
+// Record the scrub votes cast since the last run, and whether each voted minipool has been scrubbed
+func (t *trackOdaoParticipation) recordScrubVotes(history *participation.ParticipationHistory, state *state.NetworkState, members []common.Address, now time.Time) error {
+	// Start from the current block on the first run instead of scanning the whole chain
+	if history.LastScannedBlock == 0 {
+		return nil
+	}
+	startBlock := history.LastScannedBlock + 1
+	if startBlock > state.ElBlockNumber {
+		return nil
+	}
+
+	// Get the new votes and scrubs
+	intervalSize := big.NewInt(int64(t.cfg.Geth.EventLogInterval))
+	logs, err := eth.GetLogs(t.rp, nil, [][]common.Hash{{scrubVotedEventId, minipoolScrubbedEventId}}, intervalSize, big.NewInt(int64(startBlock)), big.NewInt(int64(state.ElBlockNumber)), nil)
+	if err != nil {
+		return err
+	}
+	for _, log := range logs {
+		target := log.Address.Hex()
+		round := history.GetLatestRound(participation.Duty_ScrubVote, target)
+
+		// The vote that reaches the quorum scrubs the minipool in the same transaction, so its vote has already been recorded
+		if log.Topics[0] == minipoolScrubbedEventId {
+			if round == nil || round.ConsensusReached {
+				continue
+			}
+			round.ConsensusReached = true
+			t.log.Printlnf("Minipool %s was scrubbed with votes from %d of %d members.", round.Target, len(round.Submitters), len(round.Members))
+			t.checkConsensusWithoutNode(round)
+			continue
+		}
+
+		if len(log.Topics) < 2 {
+			continue
+		}
+		member := common.BytesToAddress(log.Topics[1].Bytes())
+		if round == nil {
+			history.UpdateRound(participation.DutyRound{
+				Duty:       participation.Duty_ScrubVote,
+				Target:     target,
+				Round:      log.BlockNumber,
+				Time:       now,
+				Members:    members,
+				Submitters: []common.Address{member},
+			})
+			continue
+		}
+		if !round.HasSubmitted(member) {
+			round.Submitters = append(round.Submitters, member)
+		}
+	}
+	return nil
+}
+
+// Alert if the next round of a balances or prices duty is overdue and this node hasn't submitted for it
+func (t *trackOdaoParticipation) checkConsensusRoundOverdue(duty participation.Duty, consensusBlock uint64, frequency uint64, now time.Time, getSubmissions func(*rocketpool.RocketPool, common.Address, uint64, *big.Int, *bind.CallOpts) (*[]uint64, error)) error {
+	// The submission frequency is only available after Houston
+	if consensusBlock == 0 || frequency == 0 {
+		return nil
+	}
+	roundKey := fmt.Sprintf("%s-%d", duty, consensusBlock)
+	if t.missedRounds[roundKey] {
+		return nil
+	}
+
+	// The next round is due one submission period after the last one that reached consensus
+	header, err := t.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(consensusBlock))
+	if err != nil {
+		return fmt.Errorf("error getting header for block %d: %w", consensusBlock, err)
+	}
+	dueTime := time.Unix(int64(header.Time), 0).Add(time.Duration(frequency) * time.Second)
+	if now.Before(dueTime.Add(consensusDutyGracePeriod)) {
+		return nil
+	}
+
+	// Check if the node has submitted for any later block
+	intervalSize := big.NewInt(int64(t.cfg.Geth.EventLogInterval))
+	blocks, err := getSubmissions(t.rp, t.nodeAddress, consensusBlock+1, intervalSize, nil)
+	if err != nil {
+		return fmt.Errorf("error getting this node's submissions: %w", err)
+	}
+	if len(*blocks) > 0 {
+		return nil
+	}
+
+	t.log.Printlnf("WARNING: the %s round after block %d was due at %s and this node hasn't submitted for it.", duty, consensusBlock, dueTime.UTC().Format(time.RFC822))
+	alerting.AlertOracleDaoDutyMissed(t.cfg, string(duty), "", consensusBlock)
+	t.missedRounds[roundKey] = true
+	return nil
+}
+
+// Alert if the current rewards interval ended a while ago and this node hasn't submitted its tree
+func (t *trackOdaoParticipation) checkRewardsTreeOverdue(state *state.NetworkState, now time.Time, opts *bind.CallOpts) error {
+	interval := state.NetworkDetails.RewardIndex
+	roundKey := fmt.Sprintf("%s-%d", participation.Duty_RewardsTree, interval)
+	if t.missedRounds[roundKey] {
+		return nil
+	}
+	intervalEnd := state.NetworkDetails.IntervalStart.Add(state.NetworkDetails.IntervalDuration)
+	if now.Before(intervalEnd.Add(rewardsTreeDutyGracePeriod)) {
+		return nil
+	}
+
+	submitted, err := rewards.GetTrustedNodeSubmitted(t.rp, t.nodeAddress, interval, opts)
+	if err != nil {
+		return fmt.Errorf("error checking if this node submitted interval %d: %w", interval, err)
+	}
+	if submitted {
+		return nil
+	}
+
+	t.log.Printlnf("WARNING: interval %d ended at %s and this node hasn't submitted its rewards tree.", interval, intervalEnd.UTC().Format(time.RFC822))
+	alerting.AlertOracleDaoDutyMissed(t.cfg, string(participation.Duty_RewardsTree), "", interval)
+	t.missedRounds[roundKey] = true
+	return nil
+}
+
+// Record the turns of an L2 price messenger; members take turns updating a stale rate, so a turn that ends with the rate still stale was missed
+func (t *trackOdaoParticipation) recordL2Turn(history *participation.ParticipationHistory, name string, address common.Address, blockNumber uint64, members []common.Address, now time.Time, opts *bind.CallOpts) error {
+	// Check if the rate is stale
+	parsed, err := abi.JSON(strings.NewReader(OptimismMessengerAbi))
+	if err != nil {
+		return err
+	}
+	messenger := bind.NewBoundContract(address, parsed, t.ec, t.ec, t.ec)
+	var out []interface{}
+	if err := messenger.Call(nil, &out, "rateStale"); err != nil {
+		return fmt.Errorf("error checking rate staleness: %w", err)
+	}
+	isStale := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	// Get whose turn it is, the same way the price submission task does
+	count := uint64(len(members))
+	if count == 0 {
+		return nil
+	}
+	turn := blockNumber / BlocksPerTurn
+	responsible, err := trustednode.GetMemberAt(t.rp, turn%count, opts)
+	if err != nil {
+		return fmt.Errorf("error getting member for turn %d: %w", turn, err)
+	}
+	current := l2MessengerTurn{
+		turn:        turn,
+		responsible: responsible,
+		isStale:     isStale,
+	}
+	previous, exists := t.l2Turns[name]
+	t.l2Turns[name] = current
+	if !exists || !previous.isStale {
+		return nil
+	}
+
+	// A stale rate was updated during the same turn, or the turn ended; the rate is only checked once per run, so updates are credited to the member whose turn was last seen
+	if previous.turn == current.turn && isStale {
+		return nil
+	}
+	round := participation.DutyRound{
+		Duty:              participation.Duty_L2Prices,
+		Target:            name,
+		Round:             previous.turn,
+		Time:              now,
+		ConsensusReached:  !isStale,
+		Members:           members,
+		Submitters:        []common.Address{},
+		ResponsibleMember: &previous.responsible,
+	}
+	if !isStale {
+		round.Submitters = append(round.Submitters, previous.responsible)
+	}
+	history.UpdateRound(round)
+
+	if isStale && previous.responsible == t.nodeAddress {
+		t.log.Printlnf("WARNING: the %s rate was still stale at the end of this node's turn %d.", name, previous.turn)
+		alerting.AlertOracleDaoDutyMissed(t.cfg, string(participation.Duty_L2Prices), name, previous.turn)
+	}
+	return nil
+}
+
+// Alert if a round reached consensus without this node submitting for it
+func (t *trackOdaoParticipation) checkConsensusWithoutNode(round *participation.DutyRound) {
+	if !round.IsExpected(t.nodeAddress) || round.HasSubmitted(t.nodeAddress) {
+		return
+	}
+	t.log.Printlnf("WARNING: consensus was reached for %s round %d without a submission from this node.", round.Duty, round.Round)
+	alerting.AlertOracleDaoConsensusWithoutNode(t.cfg, string(round.Duty), round.Target, round.Round)
+}
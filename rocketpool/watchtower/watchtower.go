@@ -1,9 +1,9 @@
 package watchtower
 
 import (
+	"context"
 	"fmt"
 	"math/big"
-	"math/rand"
 	"net/http"
 	"sync"
 	"time"
@@ -18,13 +18,19 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/events"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
 
 // Config
-var minTasksInterval, _ = time.ParseDuration("4m")
-var maxTasksInterval, _ = time.ParseDuration("6m")
+var stateUpdateInterval, _ = time.ParseDuration("4m")
+var stateUpdateJitter, _ = time.ParseDuration("2m")
+var minStateUpdateInterval, _ = time.ParseDuration("1m")
+var defaultTaskInterval, _ = time.ParseDuration("5m")
+var maxNetworkStateAge, _ = time.ParseDuration("8m")
+var rewardsTreeTaskTimeout, _ = time.ParseDuration("2h")
 var taskCooldown, _ = time.ParseDuration("5s")
 
 const (
@@ -40,10 +46,12 @@ const (
 	MetricsColor                   = color.FgHiYellow
 	SubmitRewardsTreeColor         = color.FgHiCyan
 	WarningColor                   = color.FgYellow
+	EventsColor                    = color.FgHiBlack
 	ProcessPenaltiesColor          = color.FgHiMagenta
 	CancelBondsColor               = color.FgGreen
 	CheckSoloMigrationsColor       = color.FgCyan
 	FinalizeProposalsColor         = color.FgMagenta
+	TrackParticipationColor        = color.FgHiBlue
 	UpdateColor                    = color.FgHiWhite
 )
 
@@ -105,6 +113,8 @@ func run(c *cli.Context) error {
 	scrubCollector := collectors.NewScrubCollector()
 	bondReductionCollector := collectors.NewBondReductionCollector()
 	soloMigrationCollector := collectors.NewSoloMigrationCollector()
+	l2PriceCollector := collectors.NewL2PriceCollector()
+	rplPriceCollector := collectors.NewRplPriceCollector()
 
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
@@ -122,12 +132,15 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("error getting node account: %w", err)
 	}
 
+	// Create the scheduler; each task runs on its own interval so a slow task doesn't hold up the others
+	taskScheduler := scheduler.NewScheduler(&errorLog, cfg.Smartnode.GetWatchtowerTaskStatusPath(true))
+
 	// Initialize tasks
 	respondChallenges, err := newRespondChallenges(c, log.NewColorLogger(RespondChallengesColor), m)
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog, l2PriceCollector, rplPriceCollector)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
@@ -139,19 +152,19 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector, taskScheduler.TransactionLock())
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
 	var submitRewardsTree_Stateless *submitRewardsTree_Stateless
 	var submitRewardsTree_Rolling *submitRewardsTree_Rolling
 	if !useRollingRecords {
-		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, taskScheduler.TransactionLock())
 		if err != nil {
 			return fmt.Errorf("error during stateless rewards tree check: %w", err)
 		}
 	} else {
-		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, taskScheduler.TransactionLock())
 		if err != nil {
 			return fmt.Errorf("error during rolling rewards tree check: %w", err)
 		}
@@ -176,74 +189,65 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error creating finalize-pdao-proposals task: %w", err)
 	}
+	trackOdaoParticipation, err := newTrackOdaoParticipation(c, log.NewColorLogger(TrackParticipationColor))
+	if err != nil {
+		return fmt.Errorf("error creating track-odao-participation task: %w", err)
+	}
 
-	intervalDelta := maxTasksInterval - minTasksInterval
-	secondsDelta := intervalDelta.Seconds()
+	// Watch for chain events so tasks can run as soon as they have something to do; their intervals remain as a fallback
+	eventLog := log.NewColorLogger(EventsColor)
+	bus := events.NewBus()
+	go events.WatchBeaconEvents(bc, bus, &eventLog)
+	go events.WatchLogs(rp, bus, &eventLog)
 
-	// Wait group to handle the various threads
-	wg := new(sync.WaitGroup)
-	wg.Add(2)
+	// Share the latest network state between the tasks
+	networkState := &sharedNetworkState{
+		lock: &sync.RWMutex{},
+	}
 
-	// Run task loop
+	// Keep the network state up to date for the other tasks
 	isHoustonDeployedMasterFlag := false
-	go func() {
-		for {
-			// Randomize the next interval
-			randomSeconds := rand.Intn(int(secondsDelta))
-			interval := time.Duration(randomSeconds)*time.Second + minTasksInterval
-
+	taskScheduler.AddTask(scheduler.Task{
+		Name:        "update-network-state",
+		Interval:    stateUpdateInterval,
+		Jitter:      stateUpdateJitter,
+		MinInterval: minStateUpdateInterval,
+		MaxBackoff:  stateUpdateInterval,
+		Wake:        bus.Subscribe(events.Trigger_FinalizedEpoch, events.Trigger_ChainReorg, events.Trigger_MinipoolPrestaked, events.Trigger_RewardSnapshot),
+		Run: func(ctx context.Context) error {
 			// Check the EC status
 			err := services.WaitEthClientSynced(c, false) // Force refresh the primary / fallback EC status
 			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
+				networkState.setUnsynced()
+				return err
 			}
 
 			// Check the BC status
 			err = services.WaitBeaconClientSynced(c, false) // Force refresh the primary / fallback BC status
 			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
+				networkState.setUnsynced()
+				return err
 			}
 
 			// Get the Beacon block
 			//latestBlock, err := m.GetLatestFinalizedBeaconBlock()
 			latestBlock, err := m.GetLatestBeaconBlock()
 			if err != nil {
-				errorLog.Println(fmt.Errorf("error getting latest Beacon block: %w", err))
-				time.Sleep(taskCooldown)
-				continue
+				return fmt.Errorf("error getting latest Beacon block: %w", err)
 			}
 
 			// Check if on the Oracle DAO
 			isOnOdao, err := isOnOracleDAO(rp, nodeAccount.Address, latestBlock)
 			if err != nil {
-				errorLog.Println(err)
-				time.Sleep(taskCooldown)
-				continue
-			}
-
-			// Run the manual rewards tree generation
-			if err := generateRewardsTree.run(); err != nil {
-				errorLog.Println(err)
+				return err
 			}
-			time.Sleep(taskCooldown)
 
+			// Update the network state
+			var state *state.NetworkState
 			if isOnOdao {
-				// Run the challenge check
-				if err := respondChallenges.run(); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				// Update the network state
-				state, err := updateNetworkState(m, &updateLog, latestBlock)
+				state, err = updateNetworkState(m, &updateLog, latestBlock)
 				if err != nil {
-					errorLog.Println(err)
-					time.Sleep(taskCooldown)
-					continue
+					return err
 				}
 
 				// Check for Houston
@@ -251,103 +255,212 @@ func run(c *cli.Context) error {
 					printHoustonMessage(&updateLog)
 					isHoustonDeployedMasterFlag = true
 				}
+			}
+			networkState.set(state, latestBlock, isOnOdao)
+			bus.Publish(events.Trigger_NetworkStateUpdated)
+			return nil
+		},
+	})
 
-				// Run the network balance submission check
-				if err := submitNetworkBalances.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				if !useRollingRecords {
-					// Run the rewards tree submission check
-					if err := submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-				} else {
-					// Run the network balance and rewards tree submission check
-					if err := submitRewardsTree_Rolling.run(state); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-				}
+	// Run the manual rewards tree generation
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "generate-rewards-tree",
+		Interval: defaultTaskInterval,
+		Timeout:  rewardsTreeTaskTimeout,
+		Run: func(ctx context.Context) error {
+			if !networkState.isSynced() {
+				return nil
+			}
+			return generateRewardsTree.run()
+		},
+	})
 
-				// Run the price submission check
-				if err := submitRplPrice.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+	// Run the challenge check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "respond-challenges",
+		Interval:          defaultTaskInterval,
+		Wake:              bus.Subscribe(events.Trigger_OdaoChallengeMade),
+		SendsTransactions: true,
+		Run: func(ctx context.Context) error {
+			if _, _, isOnOdao, isReady := networkState.get(); !isReady || !isOnOdao || !networkState.isSynced() {
+				return nil
+			}
+			return respondChallenges.run()
+		},
+	})
 
-				// Run the minipool dissolve check
-				if err := dissolveTimedOutMinipools.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
-
-				if state.IsHoustonDeployed {
-					// Run the finalize proposals check
-					if err := finalizePdaoProposals.run(state); err != nil {
-						errorLog.Println(err)
-					}
-					time.Sleep(taskCooldown)
-				}
+	// Run the network balance submission check; it runs on each state update so it never works from an old state
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "submit-network-balances",
+		Interval:          defaultTaskInterval,
+		Wake:              bus.Subscribe(events.Trigger_NetworkStateUpdated),
+		SendsTransactions: true,
+		Run:               networkState.runOdaoTask(submitNetworkBalances.run),
+	})
 
-				// Run the minipool scrub check
-				if err := submitScrubMinipools.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+	// Run the rewards tree submission check; this runs off the oDAO too so the tree can be generated for the node's own records.
+	// The tree is submitted in the background once it's generated, so the submission takes the transaction lock itself.
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "submit-rewards-tree",
+		Interval: defaultTaskInterval,
+		Timeout:  rewardsTreeTaskTimeout,
+		Wake:     bus.Subscribe(events.Trigger_NetworkStateUpdated),
+		Run: func(ctx context.Context) error {
+			state, latestBlock, isOnOdao, isReady := networkState.get()
+			if !isReady || !networkState.isSynced() {
+				return nil
+			}
+			if !useRollingRecords {
+				return submitRewardsTree_Stateless.Run(isOnOdao, state, latestBlock.Slot)
+			}
+			return submitRewardsTree_Rolling.run(state)
+		},
+	})
 
-				// Run the bond cancel check
-				if err := cancelBondReductions.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				time.Sleep(taskCooldown)
+	// Run the price submission check; it runs on each state update so it never works from an old state
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "submit-rpl-price",
+		Interval:          defaultTaskInterval,
+		Wake:              bus.Subscribe(events.Trigger_NetworkStateUpdated),
+		SendsTransactions: true,
+		Run:               networkState.runOdaoTask(submitRplPrice.run),
+	})
 
-				// Run the solo migration check
-				if err := checkSoloMigrations.run(state); err != nil {
-					errorLog.Println(err)
-				}
-				/*time.Sleep(taskCooldown)
-
-				// Run the fee recipient penalty check
-				if err := processPenalties.run(); err != nil {
-					errorLog.Println(err)
-				}*/
-				// DISABLED until MEV-Boost can support it
-			} else {
-				/*
-				 */
-				if !useRollingRecords {
-					// Run the rewards tree submission check
-					if err := submitRewardsTree_Stateless.Run(isOnOdao, nil, latestBlock.Slot); err != nil {
-						errorLog.Println(err)
-					}
-				} else {
-					// Run the network balance and rewards tree submission check
-					if err := submitRewardsTree_Rolling.run(nil); err != nil {
-						errorLog.Println(err)
-					}
-				}
+	// Run the minipool dissolve check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "dissolve-timed-out-minipools",
+		Interval:          defaultTaskInterval,
+		SendsTransactions: true,
+		Run:               networkState.runOdaoTask(dissolveTimedOutMinipools.run),
+	})
+
+	// Run the finalize proposals check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "finalize-pdao-proposals",
+		Interval:          defaultTaskInterval,
+		SendsTransactions: true,
+		Run: networkState.runOdaoTask(func(state *state.NetworkState) error {
+			if !state.IsHoustonDeployed {
+				return nil
 			}
+			return finalizePdaoProposals.run(state)
+		}),
+	})
 
-			time.Sleep(interval)
-		}
-		wg.Done()
-	}()
+	// Run the minipool scrub check; the check runs in the background, so its votes take the transaction lock themselves
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "submit-scrub-minipools",
+		Interval: defaultTaskInterval,
+		Wake:     bus.Subscribe(events.Trigger_NetworkStateUpdated),
+		Run:      networkState.runOdaoTask(submitScrubMinipools.run),
+	})
+
+	// Run the duty participation check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:     "track-odao-participation",
+		Interval: defaultTaskInterval,
+		Run:      networkState.runOdaoTask(trackOdaoParticipation.run),
+	})
+
+	// Run the bond cancel check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "cancel-bond-reductions",
+		Interval:          defaultTaskInterval,
+		SendsTransactions: true,
+		Run:               networkState.runOdaoTask(cancelBondReductions.run),
+	})
+
+	// Run the solo migration check
+	taskScheduler.AddTask(scheduler.Task{
+		Name:              "check-solo-migrations",
+		Interval:          defaultTaskInterval,
+		SendsTransactions: true,
+		Run:               networkState.runOdaoTask(checkSoloMigrations.run),
+	})
+
+	// Run the fee recipient penalty check
+	// DISABLED until MEV-Boost can support it
+	/*taskScheduler.AddTask(scheduler.Task{
+		Name:              "process-penalties",
+		Interval:          defaultTaskInterval,
+		SendsTransactions: true,
+		Run: func(ctx context.Context) error {
+			return processPenalties.run()
+		},
+	})*/
+
+	// Run the tasks; they run until the daemon exits
+	taskScheduler.Start(context.Background(), taskCooldown)
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), taskScheduler, scrubCollector, bondReductionCollector, soloMigrationCollector, l2PriceCollector, rplPriceCollector)
 		if err != nil {
 			errorLog.Println(err)
 		}
-		wg.Done()
 	}()
 
-	// Wait for both threads to stop
-	wg.Wait()
-	return nil
+	// Block forever since the tasks never stop
+	select {}
+}
+
+// The latest network state, shared between the watchtower's tasks
+type sharedNetworkState struct {
+	lock        *sync.RWMutex
+	state       *state.NetworkState
+	latestBlock beacon.BeaconBlock
+	updatedTime time.Time
+	isOnOdao    bool
+	isReady     bool
+	isUnsynced  bool
+}
+
+// Replace the shared network state
+func (s *sharedNetworkState) set(state *state.NetworkState, latestBlock beacon.BeaconBlock, isOnOdao bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.state = state
+	s.latestBlock = latestBlock
+	s.updatedTime = time.Now()
+	s.isOnOdao = isOnOdao
+	s.isReady = true
+	s.isUnsynced = false
+}
+
+// Record that the clients aren't synced, so tasks stop running until the next successful update
+func (s *sharedNetworkState) setUnsynced() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.isUnsynced = true
+}
+
+// Get the shared network state; it isn't ready until the first update has finished
+func (s *sharedNetworkState) get() (*state.NetworkState, beacon.BeaconBlock, bool, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.state, s.latestBlock, s.isOnOdao, s.isReady
+}
+
+// Check if the clients were synced at the last update
+func (s *sharedNetworkState) isSynced() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.isReady && !s.isUnsynced
+}
+
+// Wrap a task that should only run when the node is on the Oracle DAO and a recent network state is available
+func (s *sharedNetworkState) runOdaoTask(run func(state *state.NetworkState) error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		s.lock.RLock()
+		state := s.state
+		isOnOdao := s.isOnOdao
+		isUsable := s.isReady && !s.isUnsynced && time.Since(s.updatedTime) < maxNetworkStateAge
+		s.lock.RUnlock()
+		if !isOnOdao || state == nil || !isUsable {
+			return nil
+		}
+		return run(state)
+	}
 }
 
 // Configure HTTP transport settings
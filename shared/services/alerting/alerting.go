@@ -15,6 +15,7 @@ import (
 
 const (
 	DefaultEndsAtDurationForSeverityInfo     = time.Minute * 5
+	DefaultEndsAtDurationForSeverityWarning  = time.Minute * 30
 	DefaultEndsAtDurationForSeverityCritical = time.Minute * 60
 )
 
@@ -177,6 +178,314 @@ func AlertMinipoolStaked(cfg *config.RocketPoolConfig, minipoolAddress common.Ad
 	return sendAlert(alert, cfg)
 }
 
+// Sends an alert when one of the node's validators proposed a block with a fee recipient that could get the node penalized.
+// The node is included in the alert's labels, since a watch-only daemon can monitor several nodes.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertFeeRecipientMismatch(cfg *config.RocketPoolConfig, nodeAddress common.Address, minipoolAddress common.Address, slot uint64, feeRecipient common.Address, expectedFeeRecipient common.Address) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertFeeRecipientMismatch.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_FeeRecipientMismatch.Value != true {
+		logMessage("alert for FeeRecipientMismatch is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("FeeRecipientMismatch-%d-%s", slot, minipoolAddress.Hex()),
+		fmt.Sprintf("Minipool %s proposed with the wrong fee recipient", minipoolAddress.Hex()),
+		fmt.Sprintf("The validator for minipool %s of node %s proposed the block in slot %d with fee recipient %s, but it should have been %s. Check your Validator Client's fee recipient settings immediately to avoid being penalized.", minipoolAddress.Hex(), nodeAddress.Hex(), slot, feeRecipient.Hex(), expectedFeeRecipient.Hex()),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"node":     nodeAddress.Hex(),
+			"minipool": minipoolAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// The DAO a governance alert is about
+type GovernanceDao string
+
+const (
+	GovernanceDao_Protocol        GovernanceDao = "Protocol DAO"
+	GovernanceDao_Oracle          GovernanceDao = "Oracle DAO"
+	GovernanceDao_SecurityCouncil GovernanceDao = "Security Council"
+)
+
+// Sends an alert when a new proposal was raised in one of the DAOs the node participates in.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertGovernanceProposalCreated(cfg *config.RocketPoolConfig, dao GovernanceDao, proposalID uint64, message string, proposer common.Address) error {
+	if !isGovernanceAlertEnabled(cfg, dao, "AlertGovernanceProposalCreated") {
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("GovernanceProposalCreated-%s-%d", getGovernanceDaoLabel(dao), proposalID),
+		fmt.Sprintf("New %s proposal %d", dao, proposalID),
+		fmt.Sprintf("%s proposal %d was raised by %s: %s", dao, proposalID, proposer.Hex(), message),
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{
+			"dao": getGovernanceDaoLabel(dao),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when a proposal moved into a new voting phase.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertGovernanceProposalPhaseChanged(cfg *config.RocketPoolConfig, dao GovernanceDao, proposalID uint64, message string, phase string, phaseEnd time.Time) error {
+	if !isGovernanceAlertEnabled(cfg, dao, "AlertGovernanceProposalPhaseChanged") {
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("GovernanceProposalPhaseChanged-%s-%d-%s", getGovernanceDaoLabel(dao), proposalID, phase),
+		fmt.Sprintf("%s proposal %d is now %s", dao, proposalID, phase),
+		fmt.Sprintf("%s proposal %d (%s) is now %s until %s.", dao, proposalID, message, phase, phaseEnd.UTC().Format(time.RFC822)),
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{
+			"dao": getGovernanceDaoLabel(dao),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when voting on a proposal is about to end and the node hasn't voted on it.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertGovernanceProposalExpiringWithoutVote(cfg *config.RocketPoolConfig, dao GovernanceDao, proposalID uint64, message string, votingEnd time.Time) error {
+	if !isGovernanceAlertEnabled(cfg, dao, "AlertGovernanceProposalExpiringWithoutVote") {
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("GovernanceProposalExpiringWithoutVote-%s-%d", getGovernanceDaoLabel(dao), proposalID),
+		fmt.Sprintf("You haven't voted on %s proposal %d", dao, proposalID),
+		fmt.Sprintf("Voting on %s proposal %d (%s) ends at %s and your node hasn't voted on it yet.", dao, proposalID, message, votingEnd.UTC().Format(time.RFC822)),
+		SeverityWarning,
+		strfmt.DateTime(votingEnd),
+		map[string]string{
+			"dao": getGovernanceDaoLabel(dao),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when one of the node's Protocol DAO proposals was challenged.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertPdaoProposalChallenged(cfg *config.RocketPoolConfig, proposalID uint64, challengedIndex uint64, challenger common.Address) error {
+	if !isGovernanceAlertEnabled(cfg, GovernanceDao_Protocol, "AlertPdaoProposalChallenged") {
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("PdaoProposalChallenged-%d-%d", proposalID, challengedIndex),
+		fmt.Sprintf("Protocol DAO proposal %d was challenged", proposalID),
+		fmt.Sprintf("Your Protocol DAO proposal %d was challenged at index %d by %s. The node daemon will respond to the challenge automatically; make sure it stays online until the challenge period is over.", proposalID, challengedIndex, challenger.Hex()),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityWarning)),
+		map[string]string{
+			"dao": getGovernanceDaoLabel(GovernanceDao_Protocol),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node responded to a challenge against one of its proposals or attempted to (success or failure).
+// If alerting/metrics are disabled, this function does nothing.
+func AlertPdaoChallengeDefended(cfg *config.RocketPoolConfig, proposalID uint64, challengedIndex uint64, succeeded bool) error {
+	if !isGovernanceAlertEnabled(cfg, GovernanceDao_Protocol, "AlertPdaoChallengeDefended") {
+		return nil
+	}
+
+	endsAt, severity, succeededOrFailedText := getAlertSettingsForEvent(succeeded)
+	alert := createAlert(
+		fmt.Sprintf("PdaoChallengeDefended-%s-%d-%d", succeededOrFailedText, proposalID, challengedIndex),
+		fmt.Sprintf("Challenge Response %s", succeededOrFailedText),
+		fmt.Sprintf("The response to the challenge against Protocol DAO proposal %d at index %d %s.", proposalID, challengedIndex, succeededOrFailedText),
+		severity,
+		endsAt,
+		map[string]string{
+			"dao": getGovernanceDaoLabel(GovernanceDao_Protocol),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node defeated an invalid proposal or attempted to (success or failure).
+// If alerting/metrics are disabled, this function does nothing.
+func AlertPdaoProposalDefeated(cfg *config.RocketPoolConfig, proposalID uint64, challengedIndex uint64, succeeded bool) error {
+	if !isGovernanceAlertEnabled(cfg, GovernanceDao_Protocol, "AlertPdaoProposalDefeated") {
+		return nil
+	}
+
+	endsAt, severity, succeededOrFailedText := getAlertSettingsForEvent(succeeded)
+	alert := createAlert(
+		fmt.Sprintf("PdaoProposalDefeated-%s-%d-%d", succeededOrFailedText, proposalID, challengedIndex),
+		fmt.Sprintf("Proposal Defeat %s", succeededOrFailedText),
+		fmt.Sprintf("Defeating Protocol DAO proposal %d with the unanswered challenge at index %d %s.", proposalID, challengedIndex, succeededOrFailedText),
+		severity,
+		endsAt,
+		map[string]string{
+			"dao": getGovernanceDaoLabel(GovernanceDao_Protocol),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node's watchtower missed its turn to submit for an Oracle DAO duty, or its submission for a round is overdue.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertOracleDaoDutyMissed(cfg *config.RocketPoolConfig, duty string, target string, round uint64) error {
+	if !isOracleDaoDutyAlertEnabled(cfg, "AlertOracleDaoDutyMissed") {
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("OracleDaoDutyMissed-%s-%s-%d", duty, target, round),
+		fmt.Sprintf("Watchtower missed a %s submission", getOracleDaoDutyLabel(duty, target)),
+		fmt.Sprintf("Your watchtower has not submitted for %s round %d and its submission is overdue. Check the watchtower logs for errors and make sure the node wallet has enough ETH for gas.", getOracleDaoDutyLabel(duty, target), round),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityWarning)),
+		map[string]string{
+			"duty": duty,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Oracle DAO reached consensus on a duty without a submission from the node's watchtower.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertOracleDaoConsensusWithoutNode(cfg *config.RocketPoolConfig, duty string, target string, round uint64) error {
+	if !isOracleDaoDutyAlertEnabled(cfg, "AlertOracleDaoConsensusWithoutNode") {
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("OracleDaoConsensusWithoutNode-%s-%s-%d", duty, target, round),
+		fmt.Sprintf("Oracle DAO reached %s consensus without your watchtower", getOracleDaoDutyLabel(duty, target)),
+		fmt.Sprintf("The Oracle DAO reached consensus for %s round %d, but your watchtower never submitted for it. Check the watchtower logs for errors and make sure your clients are synced.", getOracleDaoDutyLabel(duty, target), round),
+		SeverityWarning,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityWarning)),
+		map[string]string{
+			"duty": duty,
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the watchtower's RPL price sources disagree too much for it to submit the RPL price.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertRplPriceDivergence(cfg *config.RocketPoolConfig, blockNumber uint64, divergence float64, maxDivergence float64) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRplPriceDivergence.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RplPriceDivergence.Value != true {
+		logMessage("alert for RplPriceDivergence is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("RplPriceDivergence-%d", blockNumber),
+		"RPL price sources diverge",
+		fmt.Sprintf("The RPL price sources for block %d differ by up to %.2f%% from the combined price, which is more than the allowed %.2f%%. Your watchtower will not submit the RPL price for this block. Check the watchtower logs for the price of each source.", blockNumber, divergence, maxDivergence),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when one of the node's prelaunch minipools fails a scrub check, so it can be dealt with before the Oracle DAO scrubs it.
+// The node is included in the alert's labels, since a watch-only daemon can monitor several nodes.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertMinipoolScrubCheckFailed(cfg *config.RocketPoolConfig, nodeAddress common.Address, minipoolAddress common.Address, reason string) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertMinipoolScrubCheckFailed.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_MinipoolScrubCheckFailed.Value != true {
+		logMessage("alert for MinipoolScrubCheckFailed is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("MinipoolScrubCheckFailed-%s", minipoolAddress.Hex()),
+		fmt.Sprintf("Minipool %s failed the scrub check", minipoolAddress.Hex()),
+		fmt.Sprintf("The prelaunch minipool %s of node %s failed the scrub check: %s. The Oracle DAO will scrub it before it can be staked. Run `rocketpool minipool scrub-check` for the details.", minipoolAddress.Hex(), nodeAddress.Hex(), reason),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"node":     nodeAddress.Hex(),
+			"minipool": minipoolAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Checks whether Oracle DAO duty alerts are enabled, logging the reason if they aren't
+func isOracleDaoDutyAlertEnabled(cfg *config.RocketPoolConfig, alertName string) bool {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending %s.", alertName)
+		return false
+	}
+	if cfg.Alertmanager.AlertEnabled_OracleDaoDutyMissed.Value != true {
+		logMessage("alert for OracleDaoDutyMissed is disabled, not sending %s.", alertName)
+		return false
+	}
+	return true
+}
+
+// Gets a readable name for an Oracle DAO duty
+func getOracleDaoDutyLabel(duty string, target string) string {
+	if target == "" {
+		return duty
+	}
+	return fmt.Sprintf("%s (%s)", duty, target)
+}
+
+// Checks whether alerts are enabled for the given DAO, logging the reason if they aren't
+func isGovernanceAlertEnabled(cfg *config.RocketPoolConfig, dao GovernanceDao, alertName string) bool {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending %s.", alertName)
+		return false
+	}
+
+	var enabled interface{}
+	switch dao {
+	case GovernanceDao_Protocol:
+		enabled = cfg.Alertmanager.AlertEnabled_PDAOProposals.Value
+	case GovernanceDao_Oracle:
+		enabled = cfg.Alertmanager.AlertEnabled_ODAOProposals.Value
+	case GovernanceDao_SecurityCouncil:
+		enabled = cfg.Alertmanager.AlertEnabled_SecurityCouncilProposals.Value
+	}
+	if enabled != true {
+		logMessage("alerts for %s proposals are disabled, not sending %s.", dao, alertName)
+		return false
+	}
+	return true
+}
+
+// Gets the value of the dao label for a governance alert
+func getGovernanceDaoLabel(dao GovernanceDao) string {
+	switch dao {
+	case GovernanceDao_Protocol:
+		return "pdao"
+	case GovernanceDao_Oracle:
+		return "odao"
+	case GovernanceDao_SecurityCouncil:
+		return "security"
+	}
+	return string(dao)
+}
+
 // Gets various settings for an alert based on whether a process succeeded or failed.
 func getAlertSettingsForEvent(succeeded bool) (strfmt.DateTime, Severity, string) {
 	endsAt := strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo))
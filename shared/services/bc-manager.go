@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -76,9 +77,11 @@ func NewBeaconClientManager(cfg *config.RocketPoolConfig) (*BeaconClientManager,
 
 	var primaryBc beacon.Client
 	var fallbackBc beacon.Client
-	primaryBc = client.NewStandardHttpClient(primaryProvider)
+	concurrency := int(cfg.Smartnode.BeaconRequestConcurrency.Value.(uint64))
+	useSsz := cfg.Smartnode.BeaconUseSsz.Value.(bool)
+	primaryBc = client.NewStandardHttpClient(primaryProvider, concurrency, useSsz)
 	if fallbackProvider != "" {
-		fallbackBc = client.NewStandardHttpClient(fallbackProvider)
+		fallbackBc = client.NewStandardHttpClient(fallbackProvider, concurrency, useSsz)
 	}
 
 	return &BeaconClientManager{
@@ -117,6 +120,17 @@ func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 	return result.(beacon.SyncStatus), nil
 }
 
+// Get the number of peers the client is connected to
+func (m *BeaconClientManager) GetPeerCount() (uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetPeerCount()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -248,6 +262,17 @@ func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch
 	return result.(map[string]uint64), nil
 }
 
+// Get whether validators were live (performed duties) during an epoch
+func (m *BeaconClientManager) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorLiveness(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string]bool), nil
+}
+
 // Get the Beacon chain's domain data
 func (m *BeaconClientManager) GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -308,6 +333,34 @@ func (m *BeaconClientManager) ChangeWithdrawalCredentials(validatorIndex string,
 	return nil
 }
 
+// Stream events from the Beacon node until the context is cancelled or the stream fails
+func (m *BeaconClientManager) SubscribeEvents(ctx context.Context, topics []beacon.EventTopic, events chan<- beacon.Event) error {
+	err := m.runFunction0(func(client beacon.Client) error {
+		return client.SubscribeEvents(ctx, topics, events)
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Get the request metrics of the primary and fallback clients, combined by route
+func (m *BeaconClientManager) GetRequestMetrics() map[string]beacon.RequestMetrics {
+	metrics := m.primaryBc.GetRequestMetrics()
+	if m.fallbackBc != nil {
+		for route, fallbackMetrics := range m.fallbackBc.GetRequestMetrics() {
+			routeMetrics := metrics[route]
+			routeMetrics.Requests += fallbackMetrics.Requests
+			routeMetrics.Failures += fallbackMetrics.Failures
+			routeMetrics.SszResponses += fallbackMetrics.SszResponses
+			routeMetrics.BytesReceived += fallbackMetrics.BytesReceived
+			routeMetrics.TotalDuration += fallbackMetrics.TotalDuration
+			metrics[route] = routeMetrics
+		}
+	}
+	return metrics
+}
+
 /// ==================
 /// Internal Functions
 /// ==================
@@ -369,6 +422,11 @@ func checkBcStatus(client beacon.Client) api.ClientStatus {
 		status.IsSynced = false
 		status.SyncProgress = syncStatus.Progress
 	}
+
+	// Get the peer count; not every client supports it, so it isn't required for the client to be working
+	if peerCount, err := client.GetPeerCount(); err == nil {
+		status.PeerCount = peerCount
+	}
 	return status
 
 }
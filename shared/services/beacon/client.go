@@ -1,6 +1,9 @@
 package beacon
 
 import (
+	"context"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/rocket-pool/rocketpool-go/types"
@@ -68,6 +71,13 @@ type BeaconBlockHeader struct {
 	Slot          uint64
 	ProposerIndex string
 }
+type Event struct {
+	Topic EventTopic
+	Slot  uint64      // The slot of a head event or chain reorg
+	Epoch uint64      // The epoch of a finalized checkpoint
+	Block common.Hash // The new head block, or the finalized checkpoint's block
+	Depth uint64      // The depth of a chain reorg
+}
 
 // Committees is an interface as an optimization- since committees responses
 // are quite large, there's a decent cpu/memory improvement to removing the
@@ -130,10 +140,29 @@ const (
 	ValidatorState_WithdrawalDone     ValidatorState = "withdrawal_done"
 )
 
+// Beacon node event topics
+type EventTopic string
+
+const (
+	EventTopic_Head                EventTopic = "head"
+	EventTopic_FinalizedCheckpoint EventTopic = "finalized_checkpoint"
+	EventTopic_ChainReorg          EventTopic = "chain_reorg"
+)
+
+// Statistics about the requests made to a Beacon node for one API route
+type RequestMetrics struct {
+	Requests      uint64
+	Failures      uint64
+	SszResponses  uint64
+	BytesReceived uint64
+	TotalDuration time.Duration
+}
+
 // Beacon client interface
 type Client interface {
 	GetClientType() (BeaconClientType, error)
 	GetSyncStatus() (SyncStatus, error)
+	GetPeerCount() (uint64, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetAttestations(blockId string) ([]AttestationInfo, bool, error)
@@ -146,10 +175,13 @@ type Client interface {
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error)
 	GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error)
 	GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error)
+	GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
 	ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error
 	Close() error
 	GetEth1DataForEth2Block(blockId string) (Eth1Data, bool, error)
 	GetCommitteesForEpoch(epoch *uint64) (Committees, error)
 	ChangeWithdrawalCredentials(validatorIndex string, fromBlsPubkey types.ValidatorPubkey, toExecutionAddress common.Address, signature types.ValidatorSignature) error
+	SubscribeEvents(ctx context.Context, topics []EventTopic, events chan<- Event) error
+	GetRequestMetrics() map[string]RequestMetrics
 }
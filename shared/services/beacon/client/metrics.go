@@ -0,0 +1,110 @@
+package client
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Path segments that identify a state, block, or epoch rather than a route
+var routeIdSegments = map[string]bool{
+	"head":      true,
+	"genesis":   true,
+	"finalized": true,
+	"justified": true,
+}
+
+// Tracks the requests a client has sent to its Beacon node, grouped by route
+type requestMetrics struct {
+	lock   *sync.Mutex
+	routes map[string]*beacon.RequestMetrics
+}
+
+// Create a new request metrics tracker
+func newRequestMetrics() *requestMetrics {
+	return &requestMetrics{
+		lock:   &sync.Mutex{},
+		routes: map[string]*beacon.RequestMetrics{},
+	}
+}
+
+// Get a copy of the metrics for every route
+func (m *requestMetrics) get() map[string]beacon.RequestMetrics {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	metrics := make(map[string]beacon.RequestMetrics, len(m.routes))
+	for route, routeMetrics := range m.routes {
+		metrics[route] = *routeMetrics
+	}
+	return metrics
+}
+
+// Update the metrics for a route
+func (m *requestMetrics) update(route string, updater func(metrics *beacon.RequestMetrics)) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	routeMetrics, exists := m.routes[route]
+	if !exists {
+		routeMetrics = &beacon.RequestMetrics{}
+		m.routes[route] = routeMetrics
+	}
+	updater(routeMetrics)
+}
+
+// A response body that records how long the request took and how much it downloaded once it's closed
+type meteredBody struct {
+	body      io.ReadCloser
+	metrics   *requestMetrics
+	route     string
+	start     time.Time
+	bytesRead uint64
+	closed    bool
+}
+
+func (b *meteredBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	b.bytesRead += uint64(n)
+	return n, err
+}
+
+func (b *meteredBody) Close() error {
+	if !b.closed {
+		b.closed = true
+		duration := time.Since(b.start)
+		b.metrics.update(b.route, func(metrics *beacon.RequestMetrics) {
+			metrics.BytesReceived += b.bytesRead
+			metrics.TotalDuration += duration
+		})
+	}
+	return b.body.Close()
+}
+
+// Get the route of a request path, replacing state IDs, block IDs, and epochs with placeholders so requests can be grouped
+func getRoute(requestPath string) string {
+	path, _, _ := strings.Cut(requestPath, "?")
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if routeIdSegments[segment] || strings.HasPrefix(segment, "0x") || isNumeric(segment) {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// Check if a path segment is a number
+func isNumeric(segment string) bool {
+	if segment == "" {
+		return false
+	}
+	for _, char := range segment {
+		if char < '0' || char > '9' {
+			return false
+		}
+	}
+	return true
+}
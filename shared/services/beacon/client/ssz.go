@@ -0,0 +1,259 @@
+package client
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	RequestSszContentType      = "application/octet-stream"
+	RequestSszOrJsonAccept     = "application/octet-stream;q=1.0,application/json;q=0.9"
+	ConsensusVersionHeader     = "Eth-Consensus-Version"
+	consensusVersion_Phase0    = "phase0"
+	consensusVersion_Altair    = "altair"
+	consensusVersion_Bellatrix = "bellatrix"
+	consensusVersion_Capella   = "capella"
+	consensusVersion_Deneb     = "deneb"
+	consensusVersion_Electra   = "electra"
+	consensusVersion_Fulu      = "fulu"
+)
+
+// Returned when an SSZ response is for a fork that can't be decoded yet, so the request should be retried as JSON
+var errUnsupportedSszVersion = errors.New("unsupported SSZ consensus version")
+
+// The parts of an execution payload that are used by the Smartnode; every fork's payload has them
+type sszExecutionPayload interface {
+	GetFeeRecipient() []byte
+	GetBlockNumber() uint64
+}
+
+// Decode an SSZ-encoded signed beacon block into the same response type as the JSON route
+func decodeSszBeaconBlock(version string, data []byte) (BeaconBlockResponse, error) {
+	switch version {
+	case consensusVersion_Phase0:
+		block := &ethpb.SignedBeaconBlock{}
+		if err := block.UnmarshalSSZ(data); err != nil {
+			return BeaconBlockResponse{}, err
+		}
+		message := block.GetBlock()
+		return newBeaconBlockResponse(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetEth1Data(), message.GetBody().GetAttestations(), nil), nil
+
+	case consensusVersion_Altair:
+		block := &ethpb.SignedBeaconBlockAltair{}
+		if err := block.UnmarshalSSZ(data); err != nil {
+			return BeaconBlockResponse{}, err
+		}
+		message := block.GetBlock()
+		return newBeaconBlockResponse(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetEth1Data(), message.GetBody().GetAttestations(), nil), nil
+
+	case consensusVersion_Bellatrix:
+		block := &ethpb.SignedBeaconBlockBellatrix{}
+		if err := block.UnmarshalSSZ(data); err != nil {
+			return BeaconBlockResponse{}, err
+		}
+		message := block.GetBlock()
+		return newBeaconBlockResponse(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetEth1Data(), message.GetBody().GetAttestations(), message.GetBody().GetExecutionPayload()), nil
+
+	case consensusVersion_Capella:
+		block := &ethpb.SignedBeaconBlockCapella{}
+		if err := block.UnmarshalSSZ(data); err != nil {
+			return BeaconBlockResponse{}, err
+		}
+		message := block.GetBlock()
+		return newBeaconBlockResponse(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetEth1Data(), message.GetBody().GetAttestations(), message.GetBody().GetExecutionPayload()), nil
+
+	case consensusVersion_Deneb:
+		block := &ethpb.SignedBeaconBlockDeneb{}
+		if err := block.UnmarshalSSZ(data); err != nil {
+			return BeaconBlockResponse{}, err
+		}
+		message := block.GetBlock()
+		return newBeaconBlockResponse(uint64(message.GetSlot()), uint64(message.GetProposerIndex()), message.GetBody().GetEth1Data(), message.GetBody().GetAttestations(), message.GetBody().GetExecutionPayload()), nil
+
+	case consensusVersion_Electra, consensusVersion_Fulu:
+		// Prysm v5 doesn't have these forks' types yet, so the fields the Smartnode uses are read directly; Fulu blocks have the same layout as Electra
+		return decodeSszElectraBeaconBlock(data)
+
+	default:
+		return BeaconBlockResponse{}, fmt.Errorf("%w: '%s'", errUnsupportedSszVersion, version)
+	}
+}
+
+// Build a beacon block response from the fields of a decoded block
+func newBeaconBlockResponse(slot uint64, proposerIndex uint64, eth1Data *ethpb.Eth1Data, attestations []*ethpb.Attestation, payload sszExecutionPayload) BeaconBlockResponse {
+	var block BeaconBlockResponse
+	message := &block.Data.Message
+	message.Slot = uinteger(slot)
+	message.ProposerIndex = strconv.FormatUint(proposerIndex, 10)
+	message.Body.Eth1Data.DepositRoot = eth1Data.GetDepositRoot()
+	message.Body.Eth1Data.DepositCount = uinteger(eth1Data.GetDepositCount())
+	message.Body.Eth1Data.BlockHash = eth1Data.GetBlockHash()
+
+	message.Body.Attestations = make([]Attestation, len(attestations))
+	for i, attestation := range attestations {
+		message.Body.Attestations[i].AggregationBits = hexutil.AddPrefix(hex.EncodeToString(attestation.GetAggregationBits()))
+		message.Body.Attestations[i].Data.Slot = uinteger(attestation.GetData().GetSlot())
+		message.Body.Attestations[i].Data.Index = uinteger(attestation.GetData().GetCommitteeIndex())
+	}
+
+	if payload != nil {
+		message.Body.ExecutionPayload = &ExecutionPayload{
+			FeeRecipient: payload.GetFeeRecipient(),
+			BlockNumber:  uinteger(payload.GetBlockNumber()),
+		}
+	}
+	return block
+}
+
+// Sizes and positions of the SSZ fields read from Electra blocks
+const (
+	sszOffsetSize               = 4
+	sszSignatureSize            = 96
+	sszBeaconBlockFixedSize     = 8 + 8 + 32 + 32 + sszOffsetSize
+	sszElectraBodyFixedSize     = 96 + 72 + 32 + 5*sszOffsetSize + 64 + sszSignatureSize + 4*sszOffsetSize
+	sszElectraEth1DataStart     = 96
+	sszElectraAttestationsStart = 96 + 72 + 32 + 2*sszOffsetSize
+	sszElectraPayloadStart      = 96 + 72 + 32 + 5*sszOffsetSize + 64 + sszSignatureSize
+	sszElectraAttestationSize   = sszOffsetSize + 128 + sszSignatureSize + 8
+	sszPayloadFeeRecipientStart = 32
+	sszPayloadBlockNumberStart  = 32 + 20 + 32 + 32 + 256 + 32
+	sszPayloadMinSize           = sszPayloadBlockNumberStart + 8
+)
+
+// Decode the fields the Smartnode uses from an SSZ-encoded Electra or Fulu signed beacon block
+func decodeSszElectraBeaconBlock(data []byte) (BeaconBlockResponse, error) {
+	// SignedBeaconBlock: message offset, signature
+	message, err := readSszOffsetField(data, 0, sszOffsetSize+sszSignatureSize, len(data))
+	if err != nil {
+		return BeaconBlockResponse{}, fmt.Errorf("error reading block message: %w", err)
+	}
+
+	// BeaconBlock: slot, proposer index, parent root, state root, body offset
+	if len(message) < sszBeaconBlockFixedSize {
+		return BeaconBlockResponse{}, fmt.Errorf("block message is too short (%d bytes)", len(message))
+	}
+	slot := binary.LittleEndian.Uint64(message[0:8])
+	proposerIndex := binary.LittleEndian.Uint64(message[8:16])
+	body, err := readSszOffsetField(message, 8+8+32+32, sszBeaconBlockFixedSize, len(message))
+	if err != nil {
+		return BeaconBlockResponse{}, fmt.Errorf("error reading block body: %w", err)
+	}
+	if len(body) < sszElectraBodyFixedSize {
+		return BeaconBlockResponse{}, fmt.Errorf("block body is too short (%d bytes)", len(body))
+	}
+
+	// Eth1 data: deposit root, deposit count, block hash
+	eth1Data := &ethpb.Eth1Data{
+		DepositRoot:  body[sszElectraEth1DataStart : sszElectraEth1DataStart+32],
+		DepositCount: binary.LittleEndian.Uint64(body[sszElectraEth1DataStart+32 : sszElectraEth1DataStart+40]),
+		BlockHash:    body[sszElectraEth1DataStart+40 : sszElectraEth1DataStart+72],
+	}
+
+	// The variable-size fields run from their offset to the next field's offset
+	attestationsStart, err := readSszOffset(body, sszElectraAttestationsStart, sszElectraBodyFixedSize)
+	if err != nil {
+		return BeaconBlockResponse{}, fmt.Errorf("error reading attestations offset: %w", err)
+	}
+	depositsStart, err := readSszOffset(body, sszElectraAttestationsStart+sszOffsetSize, attestationsStart)
+	if err != nil {
+		return BeaconBlockResponse{}, fmt.Errorf("error reading deposits offset: %w", err)
+	}
+	payloadStart, err := readSszOffset(body, sszElectraPayloadStart, depositsStart)
+	if err != nil {
+		return BeaconBlockResponse{}, fmt.Errorf("error reading execution payload offset: %w", err)
+	}
+	blsChangesStart, err := readSszOffset(body, sszElectraPayloadStart+sszOffsetSize, payloadStart)
+	if err != nil {
+		return BeaconBlockResponse{}, fmt.Errorf("error reading BLS to execution changes offset: %w", err)
+	}
+	attestations, err := decodeSszElectraAttestations(body[attestationsStart:depositsStart])
+	if err != nil {
+		return BeaconBlockResponse{}, err
+	}
+	payload := body[payloadStart:blsChangesStart]
+	if len(payload) < sszPayloadMinSize {
+		return BeaconBlockResponse{}, fmt.Errorf("execution payload is too short (%d bytes)", len(payload))
+	}
+
+	block := newBeaconBlockResponse(slot, proposerIndex, eth1Data, nil, nil)
+	block.Data.Message.Body.Attestations = attestations
+	block.Data.Message.Body.ExecutionPayload = &ExecutionPayload{
+		FeeRecipient: payload[sszPayloadFeeRecipientStart : sszPayloadFeeRecipientStart+20],
+		BlockNumber:  uinteger(binary.LittleEndian.Uint64(payload[sszPayloadBlockNumberStart : sszPayloadBlockNumberStart+8])),
+	}
+	return block, nil
+}
+
+// Decode a list of SSZ-encoded Electra attestations
+func decodeSszElectraAttestations(data []byte) ([]Attestation, error) {
+	if len(data) == 0 {
+		return []Attestation{}, nil
+	}
+
+	// A list of variable-size items starts with an offset to each one, so the first offset gives the count
+	firstOffset, err := readSszOffset(data, 0, sszOffsetSize)
+	if err != nil || firstOffset%sszOffsetSize != 0 {
+		return nil, fmt.Errorf("invalid attestations offset")
+	}
+	count := firstOffset / sszOffsetSize
+	attestations := make([]Attestation, count)
+	for i := 0; i < count; i++ {
+		end := len(data)
+		start, err := readSszOffset(data, i*sszOffsetSize, firstOffset)
+		if err != nil {
+			return nil, fmt.Errorf("error reading offset of attestation %d: %w", i, err)
+		}
+		if i+1 < count {
+			end, err = readSszOffset(data, (i+1)*sszOffsetSize, start)
+			if err != nil {
+				return nil, fmt.Errorf("error reading offset of attestation %d: %w", i+1, err)
+			}
+		}
+		attestation := data[start:end]
+
+		// Attestation: aggregation bits offset, data (slot, index, block root, source, target), signature, committee bits
+		if len(attestation) < sszElectraAttestationSize {
+			return nil, fmt.Errorf("attestation %d is too short (%d bytes)", i, len(attestation))
+		}
+		aggregationBits, err := readSszOffsetField(attestation, 0, sszElectraAttestationSize, len(attestation))
+		if err != nil {
+			return nil, fmt.Errorf("error reading aggregation bits of attestation %d: %w", i, err)
+		}
+		attestations[i].AggregationBits = hexutil.AddPrefix(hex.EncodeToString(aggregationBits))
+		attestations[i].Data.Slot = uinteger(binary.LittleEndian.Uint64(attestation[sszOffsetSize : sszOffsetSize+8]))
+		attestations[i].Data.Index = uinteger(binary.LittleEndian.Uint64(attestation[sszOffsetSize+8 : sszOffsetSize+16]))
+	}
+	return attestations, nil
+}
+
+// Read an SSZ offset, making sure it's between the provided minimum and the end of the data
+func readSszOffset(data []byte, position int, min int) (int, error) {
+	if position+sszOffsetSize > len(data) {
+		return 0, fmt.Errorf("offset at %d is past the end of the data", position)
+	}
+	offset := int(binary.LittleEndian.Uint32(data[position : position+sszOffsetSize]))
+	if offset < min || offset > len(data) {
+		return 0, fmt.Errorf("offset %d at %d is out of range", offset, position)
+	}
+	return offset, nil
+}
+
+// Read the single variable-size field of an SSZ container, which runs from its offset to the provided end
+func readSszOffsetField(data []byte, position int, min int, end int) ([]byte, error) {
+	start, err := readSszOffset(data, position, min)
+	if err != nil {
+		return nil, err
+	}
+	if start > end {
+		return nil, fmt.Errorf("field at %d starts after it ends", position)
+	}
+	return data[start:end], nil
+}
@@ -0,0 +1,127 @@
+package client
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// Append a little-endian uint64
+func appendUint64(data []byte, value uint64) []byte {
+	return binary.LittleEndian.AppendUint64(data, value)
+}
+
+// Append a little-endian SSZ offset
+func appendOffset(data []byte, offset int) []byte {
+	return binary.LittleEndian.AppendUint32(data, uint32(offset))
+}
+
+// Build an SSZ-encoded Electra attestation
+func buildElectraAttestation(slot uint64, index uint64, aggregationBits []byte) []byte {
+	attestation := appendOffset(nil, sszElectraAttestationSize)
+	attestation = appendUint64(attestation, slot)
+	attestation = appendUint64(attestation, index)
+	attestation = append(attestation, make([]byte, 32+40+40)...) // Block root, source, target
+	attestation = append(attestation, make([]byte, sszSignatureSize)...)
+	attestation = append(attestation, make([]byte, 8)...) // Committee bits
+	return append(attestation, aggregationBits...)
+}
+
+// Build an SSZ-encoded Electra signed beacon block with the provided attestations and execution payload fields
+func buildElectraBlock(slot uint64, proposerIndex uint64, attestations [][]byte, feeRecipient []byte, blockNumber uint64) []byte {
+	// Attestation list: an offset for each attestation, then the attestations
+	attestationList := []byte{}
+	offset := len(attestations) * sszOffsetSize
+	for _, attestation := range attestations {
+		attestationList = appendOffset(attestationList, offset)
+		offset += len(attestation)
+	}
+	for _, attestation := range attestations {
+		attestationList = append(attestationList, attestation...)
+	}
+
+	// Execution payload, padded past the block number with some of the variable fields
+	payload := make([]byte, sszPayloadFeeRecipientStart)
+	payload = append(payload, feeRecipient...)
+	payload = append(payload, make([]byte, sszPayloadBlockNumberStart-len(payload))...)
+	payload = appendUint64(payload, blockNumber)
+	payload = append(payload, make([]byte, 64)...)
+
+	// Body
+	body := make([]byte, sszElectraEth1DataStart)
+	body = append(body, bytes.Repeat([]byte{0x11}, 32)...) // Deposit root
+	body = appendUint64(body, 1234)
+	body = append(body, bytes.Repeat([]byte{0x22}, 32)...) // Block hash
+	body = append(body, make([]byte, 32)...)               // Graffiti
+	attestationsStart := sszElectraBodyFixedSize
+	payloadStart := attestationsStart + len(attestationList)
+	end := payloadStart + len(payload)
+	body = appendOffset(body, attestationsStart) // Proposer slashings
+	body = appendOffset(body, attestationsStart) // Attester slashings
+	body = appendOffset(body, attestationsStart)
+	body = appendOffset(body, payloadStart) // Deposits
+	body = appendOffset(body, payloadStart) // Voluntary exits
+	body = append(body, make([]byte, 64+sszSignatureSize)...)
+	body = appendOffset(body, payloadStart)
+	body = appendOffset(body, end) // BLS to execution changes
+	body = appendOffset(body, end) // Blob KZG commitments
+	body = appendOffset(body, end) // Execution requests
+	body = append(body, attestationList...)
+	body = append(body, payload...)
+
+	// Block
+	message := appendUint64(nil, slot)
+	message = appendUint64(message, proposerIndex)
+	message = append(message, make([]byte, 64)...)
+	message = appendOffset(message, sszBeaconBlockFixedSize)
+	message = append(message, body...)
+
+	// Signed block
+	block := appendOffset(nil, sszOffsetSize+sszSignatureSize)
+	block = append(block, make([]byte, sszSignatureSize)...)
+	return append(block, message...)
+}
+
+func TestDecodeSszElectraBeaconBlock(t *testing.T) {
+	feeRecipient := bytes.Repeat([]byte{0xab}, 20)
+	data := buildElectraBlock(100, 7, [][]byte{
+		buildElectraAttestation(99, 0, []byte{0x0f}),
+		buildElectraAttestation(98, 0, []byte{0x01, 0x02}),
+	}, feeRecipient, 555)
+
+	for _, version := range []string{consensusVersion_Electra, consensusVersion_Fulu} {
+		block, err := decodeSszBeaconBlock(version, data)
+		if err != nil {
+			t.Fatalf("error decoding %s block: %s", version, err.Error())
+		}
+		message := block.Data.Message
+		if message.Slot != 100 || message.ProposerIndex != "7" {
+			t.Errorf("got slot %d and proposer %s, expected 100 and 7", message.Slot, message.ProposerIndex)
+		}
+		if message.Body.Eth1Data.DepositCount != 1234 || !bytes.Equal(message.Body.Eth1Data.BlockHash, bytes.Repeat([]byte{0x22}, 32)) {
+			t.Error("eth1 data wasn't decoded correctly")
+		}
+		if len(message.Body.Attestations) != 2 {
+			t.Fatalf("got %d attestations, expected 2", len(message.Body.Attestations))
+		}
+		if message.Body.Attestations[0].Data.Slot != 99 || message.Body.Attestations[0].AggregationBits != "0x0f" {
+			t.Errorf("first attestation was decoded as %+v", message.Body.Attestations[0])
+		}
+		if message.Body.Attestations[1].Data.Slot != 98 || message.Body.Attestations[1].AggregationBits != "0x0102" {
+			t.Errorf("second attestation was decoded as %+v", message.Body.Attestations[1])
+		}
+		payload := message.Body.ExecutionPayload
+		if payload == nil || !bytes.Equal(payload.FeeRecipient, feeRecipient) || payload.BlockNumber != 555 {
+			t.Errorf("execution payload was decoded as %+v", payload)
+		}
+	}
+}
+
+func TestDecodeSszElectraBeaconBlockRejectsTruncatedData(t *testing.T) {
+	data := buildElectraBlock(100, 7, [][]byte{buildElectraAttestation(99, 0, []byte{0x0f})}, make([]byte, 20), 555)
+	for _, length := range []int{0, 50, 200, len(data) / 2, len(data) - 1} {
+		if _, err := decodeSszBeaconBlock(consensusVersion_Electra, data[:length]); err == nil {
+			t.Errorf("decoding a block truncated to %d bytes didn't fail", length)
+		}
+	}
+}
@@ -1,14 +1,18 @@
 package client
 
 import (
+	"bufio"
 	"bytes"
+	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -29,6 +33,7 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath                  = "/eth/v1/node/syncing"
+	RequestPeerCountPath                   = "/eth/v1/node/peer_count"
 	RequestEth2ConfigPath                  = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath                     = "/eth/v1/beacon/genesis"
@@ -42,21 +47,42 @@ const (
 	RequestBeaconBlockHeaderPath           = "/eth/v1/beacon/headers/%s"
 	RequestValidatorSyncDuties             = "/eth/v1/validator/duties/sync/%s"
 	RequestValidatorProposerDuties         = "/eth/v1/validator/duties/proposer/%s"
+	RequestValidatorLivenessPath           = "/eth/v1/validator/liveness/%s"
 	RequestWithdrawalCredentialsChangePath = "/eth/v1/beacon/pool/bls_to_execution_changes"
+	RequestEventsPath                      = "/eth/v1/events?topics=%s"
+	RequestEventStreamContentType          = "text/event-stream"
 
 	MaxRequestValidatorsCount     = 600
-	threadLimit               int = 12
+	MaxPostValidatorsCount        = 10000
+	defaultThreadLimit        int = 12
+	maxEventSize              int = 1024 * 1024
 )
 
 // Beacon client using the standard Beacon HTTP REST API (https://ethereum.github.io/beacon-APIs/)
 type StandardHttpClient struct {
 	providerAddress string
+	threadLimit     int
+	useSsz          bool
+	metrics         *requestMetrics
+
+	// Set once the Beacon node rejects a POST request for validators, so later requests go straight to GET
+	postValidatorsUnsupported atomic.Bool
+
+	// One more than the earliest slot of a block whose fork can't be decoded from SSZ, or 0 if there hasn't been one.
+	// Blocks from that slot on are requested as JSON straight away.
+	sszUnsupportedSlot atomic.Uint64
 }
 
-// Create a new client instance
-func NewStandardHttpClient(providerAddress string) *StandardHttpClient {
+// Create a new client instance. The thread limit is the number of requests sent at once for large queries; if it's 0, a default is used.
+func NewStandardHttpClient(providerAddress string, threadLimit int, useSsz bool) *StandardHttpClient {
+	if threadLimit <= 0 {
+		threadLimit = defaultThreadLimit
+	}
 	return &StandardHttpClient{
 		providerAddress: providerAddress,
+		threadLimit:     threadLimit,
+		useSsz:          useSsz,
+		metrics:         newRequestMetrics(),
 	}
 }
 
@@ -90,6 +116,15 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 
 }
 
+// Get the number of peers the node is connected to
+func (c *StandardHttpClient) GetPeerCount() (uint64, error) {
+	peerCount, err := c.getPeerCount()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(peerCount.Data.Connected), nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -379,6 +414,35 @@ func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch
 	return proposerMap, nil
 }
 
+// Get whether validators were seen performing any duties (attestations or proposals) during the given epoch
+func (c *StandardHttpClient) GetValidatorLiveness(indices []string, epoch uint64) (map[string]bool, error) {
+
+	// Perform the post request
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorLivenessPath, strconv.FormatUint(epoch, 10)), indices)
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator liveness: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator liveness: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response ValidatorLivenessResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator liveness data: %w", err)
+	}
+
+	// Map the results
+	livenessMap := make(map[string]bool, len(indices))
+	for _, index := range indices {
+		livenessMap[index] = false
+	}
+	for _, liveness := range response.Data {
+		livenessMap[liveness.Index] = liveness.IsLive
+	}
+
+	return livenessMap, nil
+}
+
 // Get a validator's index
 func (c *StandardHttpClient) GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error) {
 
@@ -576,6 +640,76 @@ func (c *StandardHttpClient) ChangeWithdrawalCredentials(validatorIndex string,
 	})
 }
 
+// Stream events from the Beacon node until the context is cancelled or the stream fails.
+// The stream has no read deadline, so callers should cancel the context if it stops delivering events.
+func (c *StandardHttpClient) SubscribeEvents(ctx context.Context, topics []beacon.EventTopic, events chan<- beacon.Event) error {
+
+	// Open the event stream
+	topicNames := make([]string, len(topics))
+	for i, topic := range topics {
+		topicNames[i] = string(topic)
+	}
+	requestPath := fmt.Sprintf(RequestEventsPath, strings.Join(topicNames, ","))
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), nil)
+	if err != nil {
+		return fmt.Errorf("Could not create event stream request: %w", err)
+	}
+	request.Header.Set("Accept", RequestEventStreamContentType)
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("Could not subscribe to events: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("Could not subscribe to events: HTTP status %d; response body: '%s'", response.StatusCode, string(body))
+	}
+
+	// Read events until the stream ends; each one is an event line and a data line followed by a blank line
+	scanner := bufio.NewScanner(response.Body)
+	scanner.Buffer(make([]byte, 0, 4096), maxEventSize)
+	var eventName string
+	var data strings.Builder
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if eventName != "" {
+				event, err := parseEvent(eventName, data.String())
+				if err != nil {
+					return err
+				}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+			eventName = ""
+			data.Reset()
+		case strings.HasPrefix(line, "event:"):
+			eventName = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimSpace(strings.TrimPrefix(line, "data:")))
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("Could not read event stream: %w", err)
+	}
+	return fmt.Errorf("Event stream was closed by the Beacon node")
+
+}
+
+// Get statistics about the requests sent to the Beacon node, by route
+func (c *StandardHttpClient) GetRequestMetrics() map[string]beacon.RequestMetrics {
+	return c.metrics.get()
+}
+
 // Get sync status
 func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	responseBody, status, err := c.getRequest(RequestSyncStatusPath)
@@ -592,6 +726,22 @@ func (c *StandardHttpClient) getSyncStatus() (SyncStatusResponse, error) {
 	return syncStatus, nil
 }
 
+// Get peer count
+func (c *StandardHttpClient) getPeerCount() (PeerCountResponse, error) {
+	responseBody, status, err := c.getRequest(RequestPeerCountPath)
+	if err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: %w", err)
+	}
+	if status != http.StatusOK {
+		return PeerCountResponse{}, fmt.Errorf("Could not get node peer count: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var peerCount PeerCountResponse
+	if err := json.Unmarshal(responseBody, &peerCount); err != nil {
+		return PeerCountResponse{}, fmt.Errorf("Could not decode node peer count: %w", err)
+	}
+	return peerCount, nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) getEth2Config() (Eth2ConfigResponse, error) {
 	responseBody, status, err := c.getRequest(RequestEth2ConfigPath)
@@ -672,6 +822,48 @@ func (c *StandardHttpClient) getFork(stateId string) (ForkResponse, error) {
 	return fork, nil
 }
 
+// Get validators with a single POST request, which can hold many more IDs than a GET request's query string.
+// Returns false if the Beacon node doesn't support POST requests for validators; some clients reject them as bad requests.
+func (c *StandardHttpClient) postValidators(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, bool, error) {
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorsPath, stateId), ValidatorsRequest{
+		Ids: pubkeysOrIndices,
+	})
+	if err != nil {
+		return ValidatorsResponse{}, true, fmt.Errorf("Could not get validators: %w", err)
+	}
+	switch status {
+	case http.StatusOK:
+	case http.StatusBadRequest, http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusUnsupportedMediaType, http.StatusNotImplemented:
+		return ValidatorsResponse{}, false, nil
+	default:
+		return ValidatorsResponse{}, true, fmt.Errorf("Could not get validators: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var validators ValidatorsResponse
+	if err := json.Unmarshal(responseBody, &validators); err != nil {
+		return ValidatorsResponse{}, true, fmt.Errorf("Could not decode validators: %w", err)
+	}
+	return validators, true, nil
+}
+
+// Get validators with as many GET requests as it takes to fit their IDs into the query strings
+func (c *StandardHttpClient) getValidatorsInChunks(stateId string, pubkeysOrIndices []string) (ValidatorsResponse, error) {
+	validators := ValidatorsResponse{
+		Data: make([]Validator, 0, len(pubkeysOrIndices)),
+	}
+	for i := 0; i < len(pubkeysOrIndices); i += MaxRequestValidatorsCount {
+		max := i + MaxRequestValidatorsCount
+		if max > len(pubkeysOrIndices) {
+			max = len(pubkeysOrIndices)
+		}
+		chunk, err := c.getValidators(stateId, pubkeysOrIndices[i:max])
+		if err != nil {
+			return ValidatorsResponse{}, err
+		}
+		validators.Data = append(validators.Data, chunk.Data...)
+	}
+	return validators, nil
+}
+
 // Get validators
 func (c *StandardHttpClient) getValidators(stateId string, pubkeys []string) (ValidatorsResponse, error) {
 	var query string
@@ -717,14 +909,21 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		return ValidatorsResponse{}, fmt.Errorf("must specify a slot or epoch when calling getValidatorsByOpts")
 	}
 
+	// Use large POST batches unless the Beacon node has already rejected them
+	usePost := !c.postValidatorsUnsupported.Load()
+	batchSize := MaxRequestValidatorsCount
+	if usePost {
+		batchSize = MaxPostValidatorsCount
+	}
+
 	count := len(pubkeysOrIndices)
 	data := make([]Validator, count)
 	validFlags := make([]bool, count)
 	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
-	for i := 0; i < count; i += MaxRequestValidatorsCount {
+	wg.SetLimit(c.threadLimit)
+	for i := 0; i < count; i += batchSize {
 		i := i
-		max := i + MaxRequestValidatorsCount
+		max := i + batchSize
 		if max > count {
 			max = count
 		}
@@ -732,7 +931,21 @@ func (c *StandardHttpClient) getValidatorsByOpts(pubkeysOrIndices []string, opts
 		wg.Go(func() error {
 			// Get & add validators
 			batch := pubkeysOrIndices[i:max]
-			validators, err := c.getValidators(stateId, batch)
+			var validators ValidatorsResponse
+			var err error
+			if usePost {
+				var isSupported bool
+				validators, isSupported, err = c.postValidators(stateId, batch)
+				if err == nil && !isSupported {
+					// Only stop using POST if GET works, since a bad request may have been a problem with the IDs rather than the method
+					validators, err = c.getValidatorsInChunks(stateId, batch)
+					if err == nil {
+						c.postValidatorsUnsupported.Store(true)
+					}
+				}
+			} else {
+				validators, err = c.getValidators(stateId, batch)
+			}
 			if err != nil {
 				return fmt.Errorf("error getting validator statuses: %w", err)
 			}
@@ -792,7 +1005,17 @@ func (c *StandardHttpClient) getAttestations(blockId string) (AttestationsRespon
 
 // Get the target beacon block
 func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse, bool, error) {
-	responseBody, status, err := c.getRequest(fmt.Sprintf(RequestBeaconBlockPath, blockId))
+	requestPath := fmt.Sprintf(RequestBeaconBlockPath, blockId)
+	isSszUnsupported := false
+	if c.useSsz && c.canRequestSszBlock(blockId) {
+		beaconBlock, exists, err := c.getSszBeaconBlock(requestPath)
+		if !errors.Is(err, errUnsupportedSszVersion) {
+			return beaconBlock, exists, err
+		}
+		isSszUnsupported = true
+	}
+
+	responseBody, status, err := c.getRequest(requestPath)
 	if err != nil {
 		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
 	}
@@ -806,6 +1029,74 @@ func (c *StandardHttpClient) getBeaconBlock(blockId string) (BeaconBlockResponse
 	if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
 		return BeaconBlockResponse{}, false, fmt.Errorf("Could not decode beacon block data: %w", err)
 	}
+	if isSszUnsupported {
+		c.setSszUnsupportedSlot(uint64(beaconBlock.Data.Message.Slot))
+	}
+	return beaconBlock, true, nil
+}
+
+// Check if a block might be decodable from SSZ; blocks after one that wasn't are assumed to be from the same or a later fork
+func (c *StandardHttpClient) canRequestSszBlock(blockId string) bool {
+	unsupportedSlot := c.sszUnsupportedSlot.Load()
+	if unsupportedSlot == 0 {
+		return true
+	}
+	slot, err := strconv.ParseUint(blockId, 10, 64)
+	if err != nil {
+		// Roots and named blocks like "head" can't be placed before that slot, so assume they're in an unsupported fork too
+		return false
+	}
+	return slot+1 < unsupportedSlot
+}
+
+// Record a slot whose block couldn't be decoded from SSZ, keeping the earliest one
+func (c *StandardHttpClient) setSszUnsupportedSlot(slot uint64) {
+	for {
+		current := c.sszUnsupportedSlot.Load()
+		if current != 0 && current <= slot+1 {
+			return
+		}
+		if c.sszUnsupportedSlot.CompareAndSwap(current, slot+1) {
+			return
+		}
+	}
+}
+
+// Get the target beacon block as SSZ if the Beacon node supports it, or as JSON if it doesn't
+func (c *StandardHttpClient) getSszBeaconBlock(requestPath string) (BeaconBlockResponse, bool, error) {
+	response, err := c.sendRequest(http.MethodGet, requestPath, RequestSszOrJsonAccept, nil)
+	if err != nil {
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: %w", err)
+	}
+	if response.StatusCode == http.StatusNotFound {
+		return BeaconBlockResponse{}, false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not get beacon block data: HTTP status %d; response body: '%s'", response.StatusCode, string(responseBody))
+	}
+
+	// Some Beacon nodes ignore the SSZ preference and respond with JSON anyway
+	var beaconBlock BeaconBlockResponse
+	if !isSszResponse(response) {
+		if err := json.Unmarshal(responseBody, &beaconBlock); err != nil {
+			return BeaconBlockResponse{}, false, fmt.Errorf("Could not decode beacon block data: %w", err)
+		}
+		return beaconBlock, true, nil
+	}
+	beaconBlock, err = decodeSszBeaconBlock(response.Header.Get(ConsensusVersionHeader), responseBody)
+	if err != nil {
+		if errors.Is(err, errUnsupportedSszVersion) {
+			return BeaconBlockResponse{}, false, err
+		}
+		return BeaconBlockResponse{}, false, fmt.Errorf("Could not decode SSZ beacon block data: %w", err)
+	}
 	return beaconBlock, true, nil
 }
 
@@ -868,7 +1159,8 @@ func (c *StandardHttpClient) getCommittees(stateId string, epoch *uint64) (Commi
 		query = fmt.Sprintf("?epoch=%d", *epoch)
 	}
 
-	// Committees responses are large, so let the json decoder read it in a buffered fashion
+	// Committees responses are large, so let the json decoder read it in a buffered fashion.
+	// The Beacon API only serves committees as JSON; getting them from an SSZ state would mean downloading the whole state instead.
 	reader, status, err := c.getRequestReader(fmt.Sprintf(RequestCommitteePath, stateId) + query)
 	if err != nil {
 		return CommitteesResponse{}, fmt.Errorf("Could not get committees: %w", err)
@@ -911,11 +1203,61 @@ func (c *StandardHttpClient) postWithdrawalCredentialsChange(request BLSToExecut
 	return nil
 }
 
+// Send a request to the beacon node and record it in the request metrics; the caller must close the response body
+func (c *StandardHttpClient) sendRequest(method string, requestPath string, accept string, requestBody []byte) (*http.Response, error) {
+
+	// Build the request
+	var bodyReader io.Reader
+	if requestBody != nil {
+		bodyReader = bytes.NewReader(requestBody)
+	}
+	request, err := http.NewRequest(method, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), bodyReader)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Accept", accept)
+	if requestBody != nil {
+		request.Header.Set("Content-Type", RequestContentType)
+	}
+
+	// Send request
+	route := getRoute(requestPath)
+	start := time.Now()
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		c.metrics.update(route, func(metrics *beacon.RequestMetrics) {
+			metrics.Requests++
+			metrics.Failures++
+			metrics.TotalDuration += time.Since(start)
+		})
+		return nil, err
+	}
+	c.metrics.update(route, func(metrics *beacon.RequestMetrics) {
+		metrics.Requests++
+		if response.StatusCode >= http.StatusBadRequest && response.StatusCode != http.StatusNotFound {
+			metrics.Failures++
+		}
+		if isSszResponse(response) {
+			metrics.SszResponses++
+		}
+	})
+
+	// Record the download size and total time once the body is closed
+	response.Body = &meteredBody{
+		body:    response.Body,
+		metrics: c.metrics,
+		route:   route,
+		start:   start,
+	}
+	return response, nil
+
+}
+
 // Make a GET request but do not read its body yet (allows buffered decoding)
 func (c *StandardHttpClient) getRequestReader(requestPath string) (io.ReadCloser, int, error) {
 
 	// Send request
-	response, err := http.Get(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath))
+	response, err := c.sendRequest(http.MethodGet, requestPath, RequestContentType, nil)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -953,10 +1295,9 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	if err != nil {
 		return []byte{}, 0, err
 	}
-	requestBodyReader := bytes.NewReader(requestBodyBytes)
 
 	// Send request
-	response, err := http.Post(fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), RequestContentType, requestBodyReader)
+	response, err := c.sendRequest(http.MethodPost, requestPath, RequestContentType, requestBodyBytes)
 	if err != nil {
 		return []byte{}, 0, err
 	}
@@ -974,3 +1315,40 @@ func (c *StandardHttpClient) postRequest(requestPath string, requestBody interfa
 	return body, response.StatusCode, nil
 
 }
+
+// Check if a response is SSZ-encoded
+func isSszResponse(response *http.Response) bool {
+	return strings.HasPrefix(response.Header.Get("Content-Type"), RequestSszContentType)
+}
+
+// Parse an event from the Beacon node's event stream
+func parseEvent(eventName string, data string) (beacon.Event, error) {
+	event := beacon.Event{
+		Topic: beacon.EventTopic(eventName),
+	}
+	switch event.Topic {
+	case beacon.EventTopic_Head:
+		var head HeadEventResponse
+		if err := json.Unmarshal([]byte(data), &head); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode head event: %w", err)
+		}
+		event.Slot = uint64(head.Slot)
+		event.Block = common.HexToHash(head.Block)
+	case beacon.EventTopic_FinalizedCheckpoint:
+		var checkpoint FinalizedCheckpointEventResponse
+		if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode finalized checkpoint event: %w", err)
+		}
+		event.Epoch = uint64(checkpoint.Epoch)
+		event.Block = common.HexToHash(checkpoint.Block)
+	case beacon.EventTopic_ChainReorg:
+		var reorg ChainReorgEventResponse
+		if err := json.Unmarshal([]byte(data), &reorg); err != nil {
+			return beacon.Event{}, fmt.Errorf("Could not decode chain reorg event: %w", err)
+		}
+		event.Slot = uint64(reorg.Slot)
+		event.Depth = uint64(reorg.Depth)
+		event.Block = common.HexToHash(reorg.NewHeadBlock)
+	}
+	return event, nil
+}
@@ -28,6 +28,9 @@ type BLSToExecutionChangeRequest struct {
 	Message   BLSToExecutionChangeMessage `json:"message"`
 	Signature byteArray                   `json:"signature"`
 }
+type ValidatorsRequest struct {
+	Ids []string `json:"ids"`
+}
 
 // Response types
 type SyncStatusResponse struct {
@@ -37,6 +40,11 @@ type SyncStatusResponse struct {
 		SyncDistance uinteger `json:"sync_distance"`
 	} `json:"data"`
 }
+type PeerCountResponse struct {
+	Data struct {
+		Connected uinteger `json:"connected"`
+	} `json:"data"`
+}
 type Eth2ConfigResponse struct {
 	Data struct {
 		SecondsPerSlot               uinteger  `json:"SECONDS_PER_SLOT"`
@@ -92,15 +100,16 @@ type BeaconBlockResponse struct {
 					DepositCount uinteger  `json:"deposit_count"`
 					BlockHash    byteArray `json:"block_hash"`
 				} `json:"eth1_data"`
-				Attestations     []Attestation `json:"attestations"`
-				ExecutionPayload *struct {
-					FeeRecipient byteArray `json:"fee_recipient"`
-					BlockNumber  uinteger  `json:"block_number"`
-				} `json:"execution_payload"`
+				Attestations     []Attestation     `json:"attestations"`
+				ExecutionPayload *ExecutionPayload `json:"execution_payload"`
 			} `json:"body"`
 		} `json:"message"`
 	} `json:"data"`
 }
+type ExecutionPayload struct {
+	FeeRecipient byteArray `json:"fee_recipient"`
+	BlockNumber  uinteger  `json:"block_number"`
+}
 type BeaconBlockHeaderResponse struct {
 	Finalized bool `json:"finalized"`
 	Data      struct {
@@ -146,6 +155,27 @@ type ProposerDutiesResponse struct {
 type ProposerDuty struct {
 	ValidatorIndex string `json:"validator_index"`
 }
+type ValidatorLivenessResponse struct {
+	Data []ValidatorLiveness `json:"data"`
+}
+type ValidatorLiveness struct {
+	Index  string `json:"index"`
+	IsLive bool   `json:"is_live"`
+}
+
+type HeadEventResponse struct {
+	Slot  uinteger `json:"slot"`
+	Block string   `json:"block"`
+}
+type FinalizedCheckpointEventResponse struct {
+	Block string   `json:"block"`
+	Epoch uinteger `json:"epoch"`
+}
+type ChainReorgEventResponse struct {
+	Slot         uinteger `json:"slot"`
+	Depth        uinteger `json:"depth"`
+	NewHeadBlock string   `json:"new_head_block"`
+}
 
 type CommitteesResponse struct {
 	Data []Committee `json:"data"`
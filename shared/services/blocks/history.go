@@ -0,0 +1,195 @@
+package blocks
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Config
+const (
+	HistoryVersion    uint64      = 1
+	MaxHistoryRecords int         = 1000
+	historyFileMode   os.FileMode = 0644
+)
+
+// A block proposed by one of the node's validators, and whether its fee recipient was correct
+type ProposalRecord struct {
+	Slot                 uint64                `json:"slot"`
+	Time                 time.Time             `json:"time"`
+	BlockNumber          uint64                `json:"blockNumber"`
+	ValidatorIndex       string                `json:"validatorIndex"`
+	ValidatorPubkey      types.ValidatorPubkey `json:"validatorPubkey"`
+	MinipoolAddress      common.Address        `json:"minipoolAddress"`
+	FeeRecipient         common.Address        `json:"feeRecipient"`
+	ExpectedFeeRecipient common.Address        `json:"expectedFeeRecipient"`
+	IsInSmoothingPool    bool                  `json:"isInSmoothingPool"`
+	IsMevBlock           bool                  `json:"isMevBlock"`
+	MevRecipient         common.Address        `json:"mevRecipient"`
+	MevReward            *big.Int              `json:"mevReward"`
+	IsCorrect            bool                  `json:"isCorrect"`
+	RewardsInterval      uint64                `json:"rewardsInterval"`
+	PayloadValue         *big.Int              `json:"payloadValue"`
+	IsFromRelay          bool                  `json:"isFromRelay"`
+	RelayName            string                `json:"relayName,omitempty"`
+}
+
+// The Smoothing Pool's state during a rewards interval, as last seen by the node
+type IntervalSnapshot struct {
+	Index                  uint64    `json:"index"`
+	Start                  time.Time `json:"start"`
+	SmoothingPoolBalance   *big.Int  `json:"smoothingPoolBalance"`
+	SmoothingPoolNodeCount uint64    `json:"smoothingPoolNodeCount"`
+}
+
+// The total value of the node's proposals during a rewards interval, compared to the Smoothing Pool
+type IntervalSummary struct {
+	IntervalSnapshot
+	ProposalCount         int      `json:"proposalCount"`
+	RelayProposalCount    int      `json:"relayProposalCount"`
+	TotalPayloadValue     *big.Int `json:"totalPayloadValue"`
+	SmoothingPoolNodeAvg  *big.Int `json:"smoothingPoolNodeAvg"`
+	HasSmoothingPoolStats bool     `json:"hasSmoothingPoolStats"`
+}
+
+// The history of blocks proposed by the node's validators
+type ProposalHistory struct {
+	Version         uint64             `json:"version"`
+	LastScannedSlot uint64             `json:"lastScannedSlot"`
+	Proposals       []ProposalRecord   `json:"proposals"`
+	Intervals       []IntervalSnapshot `json:"intervals"`
+}
+
+// Loads the proposal history from disk, returning an empty history if it doesn't exist yet
+func LoadProposalHistory(path string) (*ProposalHistory, error) {
+	history := &ProposalHistory{
+		Version:   HistoryVersion,
+		Proposals: []ProposalRecord{},
+		Intervals: []IntervalSnapshot{},
+	}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading proposal history: %w", err)
+	}
+	if err := json.Unmarshal(bytes, history); err != nil {
+		return nil, fmt.Errorf("error deserializing proposal history: %w", err)
+	}
+	if history.Version != HistoryVersion {
+		return nil, fmt.Errorf("proposal history has unsupported version %d (expected %d)", history.Version, HistoryVersion)
+	}
+	return history, nil
+}
+
+// Saves the proposal history to disk, trimming the oldest records if there are too many
+func (h *ProposalHistory) Save(path string) error {
+	if len(h.Proposals) > MaxHistoryRecords {
+		h.Proposals = h.Proposals[len(h.Proposals)-MaxHistoryRecords:]
+	}
+	if len(h.Intervals) > MaxHistoryRecords {
+		h.Intervals = h.Intervals[len(h.Intervals)-MaxHistoryRecords:]
+	}
+	bytes, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("error serializing proposal history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating proposal history folder: %w", err)
+	}
+
+	// Write to a temp file first so the history is never left half-written
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, historyFileMode); err != nil {
+		return fmt.Errorf("error writing proposal history: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving proposal history: %w", err)
+	}
+	return nil
+}
+
+// Gets the most recent proposals, newest first
+func (h *ProposalHistory) GetRecentProposals(count int) []ProposalRecord {
+	recent := []ProposalRecord{}
+	for i := len(h.Proposals) - 1; i >= 0 && len(recent) < count; i-- {
+		recent = append(recent, h.Proposals[i])
+	}
+	return recent
+}
+
+// Gets the number of proposals that used an incorrect fee recipient
+func (h *ProposalHistory) GetIncorrectProposalCount() int {
+	count := 0
+	for _, proposal := range h.Proposals {
+		if !proposal.IsCorrect {
+			count++
+		}
+	}
+	return count
+}
+
+// Records the latest Smoothing Pool state for a rewards interval, replacing any earlier snapshot of the same interval
+func (h *ProposalHistory) UpdateInterval(snapshot IntervalSnapshot) {
+	for i, interval := range h.Intervals {
+		if interval.Index == snapshot.Index {
+			h.Intervals[i] = snapshot
+			return
+		}
+	}
+	h.Intervals = append(h.Intervals, snapshot)
+}
+
+// Totals the value of the node's proposals in each rewards interval, newest first
+func (h *ProposalHistory) GetIntervalSummaries() []IntervalSummary {
+	summaries := map[uint64]*IntervalSummary{}
+	getSummary := func(index uint64) *IntervalSummary {
+		summary, exists := summaries[index]
+		if !exists {
+			summary = &IntervalSummary{
+				IntervalSnapshot: IntervalSnapshot{
+					Index: index,
+				},
+				TotalPayloadValue: big.NewInt(0),
+			}
+			summaries[index] = summary
+		}
+		return summary
+	}
+
+	for _, interval := range h.Intervals {
+		summary := getSummary(interval.Index)
+		summary.IntervalSnapshot = interval
+		if interval.SmoothingPoolBalance != nil && interval.SmoothingPoolNodeCount > 0 {
+			summary.HasSmoothingPoolStats = true
+			summary.SmoothingPoolNodeAvg = big.NewInt(0).Div(interval.SmoothingPoolBalance, big.NewInt(0).SetUint64(interval.SmoothingPoolNodeCount))
+		}
+	}
+	for _, proposal := range h.Proposals {
+		summary := getSummary(proposal.RewardsInterval)
+		summary.ProposalCount++
+		if proposal.IsFromRelay {
+			summary.RelayProposalCount++
+		}
+		if proposal.PayloadValue != nil {
+			summary.TotalPayloadValue.Add(summary.TotalPayloadValue, proposal.PayloadValue)
+		}
+	}
+
+	list := make([]IntervalSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		list = append(list, *summary)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Index > list[j].Index
+	})
+	return list
+}
@@ -0,0 +1,99 @@
+package blocks
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// Config
+const (
+	relayDeliveredPayloadPath string        = "/relay/v1/data/bidtraces/proposer_payload_delivered"
+	relayRequestTimeout       time.Duration = 10 * time.Second
+)
+
+// A payload that a relay delivered to a proposer, as reported by the relay's data API
+type DeliveredPayload struct {
+	Slot                 uint64
+	BlockNumber          uint64
+	ProposerFeeRecipient common.Address
+	Value                *big.Int
+}
+
+// The raw bid trace returned by the data API
+type bidTrace struct {
+	Slot                 string         `json:"slot"`
+	BlockNumber          string         `json:"block_number"`
+	ProposerFeeRecipient common.Address `json:"proposer_fee_recipient"`
+	Value                string         `json:"value"`
+}
+
+// Asks a relay whether it delivered the payload for the given slot
+func GetDeliveredPayload(relayUrl string, slot uint64) (DeliveredPayload, bool, error) {
+
+	// Build the request URL, dropping the relay's public key and any query parameters
+	parsedUrl, err := url.Parse(relayUrl)
+	if err != nil {
+		return DeliveredPayload{}, false, fmt.Errorf("invalid relay URL: %w", err)
+	}
+	requestUrl := url.URL{
+		Scheme:   parsedUrl.Scheme,
+		Host:     parsedUrl.Host,
+		Path:     relayDeliveredPayloadPath,
+		RawQuery: url.Values{"slot": []string{strconv.FormatUint(slot, 10)}}.Encode(),
+	}
+
+	// Send the request
+	client := http.Client{
+		Timeout: relayRequestTimeout,
+	}
+	response, err := client.Get(requestUrl.String())
+	if err != nil {
+		return DeliveredPayload{}, false, fmt.Errorf("error querying relay %s: %w", parsedUrl.Host, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return DeliveredPayload{}, false, fmt.Errorf("error reading response from relay %s: %w", parsedUrl.Host, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return DeliveredPayload{}, false, fmt.Errorf("relay %s responded with HTTP status %d; response body: '%s'", parsedUrl.Host, response.StatusCode, string(body))
+	}
+
+	// Find the trace for the slot
+	var traces []bidTrace
+	if err := json.Unmarshal(body, &traces); err != nil {
+		return DeliveredPayload{}, false, fmt.Errorf("error deserializing response from relay %s: %w", parsedUrl.Host, err)
+	}
+	for _, trace := range traces {
+		traceSlot, err := strconv.ParseUint(trace.Slot, 10, 64)
+		if err != nil || traceSlot != slot {
+			continue
+		}
+		blockNumber, err := strconv.ParseUint(trace.BlockNumber, 10, 64)
+		if err != nil {
+			return DeliveredPayload{}, false, fmt.Errorf("relay %s returned invalid block number '%s'", parsedUrl.Host, trace.BlockNumber)
+		}
+		value, ok := big.NewInt(0).SetString(trace.Value, 10)
+		if !ok {
+			return DeliveredPayload{}, false, fmt.Errorf("relay %s returned invalid payload value '%s'", parsedUrl.Host, trace.Value)
+		}
+		return DeliveredPayload{
+			Slot:                 traceSlot,
+			BlockNumber:          blockNumber,
+			ProposerFeeRecipient: trace.ProposerFeeRecipient,
+			Value:                value,
+		}, true, nil
+	}
+	return DeliveredPayload{}, false, nil
+
+}
@@ -72,6 +72,13 @@ type AlertmanagerConfig struct {
 	AlertEnabled_MinipoolStaked              config.Parameter `yaml:"alertEnabled_MinipoolStaked,omitempty"`
 	AlertEnabled_ExecutionClientSyncComplete config.Parameter `yaml:"alertEnabled_ExecutionClientSyncComplete,omitempty"`
 	AlertEnabled_BeaconClientSyncComplete    config.Parameter `yaml:"alertEnabled_BeaconClientSyncComplete,omitempty"`
+	AlertEnabled_FeeRecipientMismatch        config.Parameter `yaml:"alertEnabled_FeeRecipientMismatch,omitempty"`
+	AlertEnabled_PDAOProposals               config.Parameter `yaml:"alertEnabled_PDAOProposals,omitempty"`
+	AlertEnabled_ODAOProposals               config.Parameter `yaml:"alertEnabled_ODAOProposals,omitempty"`
+	AlertEnabled_SecurityCouncilProposals    config.Parameter `yaml:"alertEnabled_SecurityCouncilProposals,omitempty"`
+	AlertEnabled_OracleDaoDutyMissed         config.Parameter `yaml:"alertEnabled_OracleDaoDutyMissed,omitempty"`
+	AlertEnabled_RplPriceDivergence          config.Parameter `yaml:"alertEnabled_RplPriceDivergence,omitempty"`
+	AlertEnabled_MinipoolScrubCheckFailed    config.Parameter `yaml:"alertEnabled_MinipoolScrubCheckFailed,omitempty"`
 }
 
 func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
@@ -226,6 +233,34 @@ func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
 		AlertEnabled_BeaconClientSyncComplete: createParameterForAlertEnablement(
 			"BeaconClientSyncComplete",
 			"beacon client is synced"),
+
+		AlertEnabled_FeeRecipientMismatch: createParameterForAlertEnablement(
+			"FeeRecipientMismatch",
+			"Fee Recipient Mismatch"),
+
+		AlertEnabled_PDAOProposals: createParameterForAlertEnablement(
+			"PDAOProposals",
+			"Protocol DAO Proposal Activity"),
+
+		AlertEnabled_ODAOProposals: createParameterForAlertEnablement(
+			"ODAOProposals",
+			"Oracle DAO Proposal Activity"),
+
+		AlertEnabled_SecurityCouncilProposals: createParameterForAlertEnablement(
+			"SecurityCouncilProposals",
+			"Security Council Proposal Activity"),
+
+		AlertEnabled_OracleDaoDutyMissed: createParameterForAlertEnablement(
+			"OracleDaoDutyMissed",
+			"Oracle DAO Duty Missed"),
+
+		AlertEnabled_RplPriceDivergence: createParameterForAlertEnablement(
+			"RplPriceDivergence",
+			"RPL Price Sources Diverge"),
+
+		AlertEnabled_MinipoolScrubCheckFailed: createParameterForAlertEnablement(
+			"MinipoolScrubCheckFailed",
+			"a prelaunch minipool fails the scrub check"),
 	}
 }
 
@@ -269,6 +304,13 @@ func (cfg *AlertmanagerConfig) GetParameters() []*config.Parameter {
 		&cfg.AlertEnabled_MinipoolStaked,
 		&cfg.AlertEnabled_ExecutionClientSyncComplete,
 		&cfg.AlertEnabled_BeaconClientSyncComplete,
+		&cfg.AlertEnabled_FeeRecipientMismatch,
+		&cfg.AlertEnabled_PDAOProposals,
+		&cfg.AlertEnabled_ODAOProposals,
+		&cfg.AlertEnabled_SecurityCouncilProposals,
+		&cfg.AlertEnabled_OracleDaoDutyMissed,
+		&cfg.AlertEnabled_RplPriceDivergence,
+		&cfg.AlertEnabled_MinipoolScrubCheckFailed,
 	}
 }
 
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"github.com/rocket-pool/smartnode/shared/types/config"
@@ -33,29 +34,14 @@ type MevBoostConfig struct {
 	// Unregulated, all types
 	EnableUnregulatedAllMev config.Parameter `yaml:"enableUnregulatedAllMev,omitempty"`
 
-	// Flashbots relay
-	FlashbotsRelay config.Parameter `yaml:"flashbotsEnabled,omitempty"`
+	// The toggles for each relay in the registry, in registry order
+	RelayParameters []*config.Parameter `yaml:"-"`
 
-	// bloXroute max profit relay
-	BloxRouteMaxProfitRelay config.Parameter `yaml:"bloxRouteMaxProfitEnabled,omitempty"`
+	// User-defined relays
+	CustomRelays config.Parameter `yaml:"customRelays,omitempty"`
 
-	// bloXroute regulated relay
-	BloxRouteRegulatedRelay config.Parameter `yaml:"bloxRouteRegulatedEnabled,omitempty"`
-
-	// Eden relay
-	EdenRelay config.Parameter `yaml:"edenEnabled,omitempty"`
-
-	// Ultra sound relay
-	UltrasoundRelay config.Parameter `yaml:"ultrasoundEnabled,omitempty"`
-
-	// Aestus relay
-	AestusRelay config.Parameter `yaml:"aestusEnabled,omitempty"`
-
-	// Titan Global relay
-	TitanGlobalRelay config.Parameter `yaml:"titanGlobalEnabled,omitempty"`
-
-	// Titan Regional relay
-	TitanRegionalRelay config.Parameter `yaml:"titanRegionalEnabled,omitempty"`
+	// Toggle for looking up the node's proposals in the relays' data APIs
+	QueryRelayData config.Parameter `yaml:"queryRelayData,omitempty"`
 
 	// The RPC port
 	Port config.Parameter `yaml:"port,omitempty"`
@@ -76,23 +62,16 @@ type MevBoostConfig struct {
 	// Non-editable settings //
 	///////////////////////////
 
-	parentConfig *RocketPoolConfig                     `yaml:"-"`
-	relays       []config.MevRelay                     `yaml:"-"`
-	relayMap     map[config.MevRelayID]config.MevRelay `yaml:"-"`
+	parentConfig  *RocketPoolConfig `yaml:"-"`
+	relayRegistry *MevRelayRegistry `yaml:"-"`
+	registryError error             `yaml:"-"`
 }
 
 // Generates a new MEV-Boost configuration
 func NewMevBoostConfig(cfg *RocketPoolConfig) *MevBoostConfig {
-	// Generate the relays
-	relays := createDefaultRelays()
-	relayMap := map[config.MevRelayID]config.MevRelay{}
-	for _, relay := range relays {
-		relayMap[relay.ID] = relay
-	}
-
 	rpcPortModes := config.PortModes("")
 
-	return &MevBoostConfig{
+	mevBoostConfig := &MevBoostConfig{
 		Title: "MEV-Boost Settings",
 
 		parentConfig: cfg,
@@ -137,18 +116,27 @@ func NewMevBoostConfig(cfg *RocketPoolConfig) *MevBoostConfig {
 			}},
 		},
 
-		EnableRegulatedAllMev:   generateProfileParameter("enableRegulatedAllMev", relays, true),
-		EnableUnregulatedAllMev: generateProfileParameter("enableUnregulatedAllMev", relays, false),
+		CustomRelays: config.Parameter{
+			ID:                 "customRelays",
+			Name:               "Custom Relays",
+			Description:        "Add the URLs of any relays that aren't in the list above here, separated by commas. Each URL must include the relay's public key (e.g. https://0xabc...@relay.example.com).\nThese relays will always be used when MEV-Boost is enabled, regardless of the profiles or relays selected above.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_MevBoost},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
 
-		// Explicit relay params
-		FlashbotsRelay:          generateRelayParameter("flashbotsEnabled", relayMap[config.MevRelayID_Flashbots]),
-		BloxRouteMaxProfitRelay: generateRelayParameter("bloxRouteMaxProfitEnabled", relayMap[config.MevRelayID_BloxrouteMaxProfit]),
-		BloxRouteRegulatedRelay: generateRelayParameter("bloxRouteRegulatedEnabled", relayMap[config.MevRelayID_BloxrouteRegulated]),
-		EdenRelay:               generateRelayParameter("edenEnabled", relayMap[config.MevRelayID_Eden]),
-		UltrasoundRelay:         generateRelayParameter("ultrasoundEnabled", relayMap[config.MevRelayID_Ultrasound]),
-		AestusRelay:             generateRelayParameter("aestusEnabled", relayMap[config.MevRelayID_Aestus]),
-		TitanGlobalRelay:        generateRelayParameter("titanGlobalEnabled", relayMap[config.MevRelayID_TitanGlobal]),
-		TitanRegionalRelay:      generateRelayParameter("titanRegionalEnabled", relayMap[config.MevRelayID_TitanRegional]),
+		QueryRelayData: config.Parameter{
+			ID:                 "queryRelayData",
+			Name:               "Query Relay Data",
+			Description:        "When your validators propose a block, ask the relays which one delivered it and how much it was worth using their public data APIs. The results are shown in `rocketpool node proposals`.\n\nWhen this is disabled, the value of each proposal is estimated from the Execution client instead.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
 
 		Port: config.Parameter{
 			ID:                 "port",
@@ -205,33 +193,72 @@ func NewMevBoostConfig(cfg *RocketPoolConfig) *MevBoostConfig {
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
 		},
+	}
+
+	// Generate the relay settings
+	mevBoostConfig.setRelayRegistry(LoadMevRelayRegistry(cfg.RocketPoolDirectory))
+	return mevBoostConfig
+}
+
+// Reload the relay registry from the Rocket Pool directory, keeping the values of any relay settings that still exist
+func (cfg *MevBoostConfig) ReloadRelayRegistry() {
+	cfg.setRelayRegistry(LoadMevRelayRegistry(cfg.parentConfig.RocketPoolDirectory))
+}
+
+// Get the relay registry in use, and the error that occurred while loading the registry file if it couldn't be used
+func (cfg *MevBoostConfig) GetRelayRegistry() (*MevRelayRegistry, error) {
+	return cfg.relayRegistry, cfg.registryError
+}
+
+// Regenerate the profile and relay parameters from a relay registry
+func (cfg *MevBoostConfig) setRelayRegistry(registry *MevRelayRegistry, registryError error) {
+	oldValues := map[string]interface{}{}
+	for _, param := range cfg.RelayParameters {
+		oldValues[param.ID] = param.Value
+	}
+
+	cfg.relayRegistry = registry
+	cfg.registryError = registryError
+
+	regulatedAllMev := cfg.EnableRegulatedAllMev.Value
+	unregulatedAllMev := cfg.EnableUnregulatedAllMev.Value
+	cfg.EnableRegulatedAllMev = generateProfileParameter("enableRegulatedAllMev", registry.Relays, true)
+	cfg.EnableUnregulatedAllMev = generateProfileParameter("enableUnregulatedAllMev", registry.Relays, false)
+	if regulatedAllMev != nil {
+		cfg.EnableRegulatedAllMev.Value = regulatedAllMev
+	}
+	if unregulatedAllMev != nil {
+		cfg.EnableUnregulatedAllMev.Value = unregulatedAllMev
+	}
 
-		relays:   relays,
-		relayMap: relayMap,
+	cfg.RelayParameters = make([]*config.Parameter, len(registry.Relays))
+	for i, relay := range registry.Relays {
+		param := generateRelayParameter(relay.SettingID, relay)
+		if value, exists := oldValues[param.ID]; exists {
+			param.Value = value
+		}
+		cfg.RelayParameters[i] = &param
 	}
 }
 
 // Get the config.Parameters for this config
 func (cfg *MevBoostConfig) GetParameters() []*config.Parameter {
-	return []*config.Parameter{
+	params := []*config.Parameter{
 		&cfg.Mode,
 		&cfg.SelectionMode,
 		&cfg.EnableRegulatedAllMev,
 		&cfg.EnableUnregulatedAllMev,
-		&cfg.FlashbotsRelay,
-		&cfg.BloxRouteMaxProfitRelay,
-		&cfg.BloxRouteRegulatedRelay,
-		&cfg.EdenRelay,
-		&cfg.UltrasoundRelay,
-		&cfg.AestusRelay,
-		&cfg.TitanGlobalRelay,
-		&cfg.TitanRegionalRelay,
+	}
+	params = append(params, cfg.RelayParameters...)
+	return append(params,
+		&cfg.CustomRelays,
+		&cfg.QueryRelayData,
 		&cfg.Port,
 		&cfg.OpenRpcPort,
 		&cfg.ContainerTag,
 		&cfg.AdditionalFlags,
 		&cfg.ExternalUrl,
-	}
+	)
 }
 
 // The title for the config
@@ -245,7 +272,7 @@ func (cfg *MevBoostConfig) GetAvailableProfiles() (bool, bool) {
 	unregulatedAllMev := false
 
 	currentNetwork := cfg.parentConfig.Smartnode.Network.Value.(config.Network)
-	for _, relay := range cfg.relays {
+	for _, relay := range cfg.relayRegistry.Relays {
 		_, exists := relay.Urls[currentNetwork]
 		if !exists {
 			continue
@@ -261,7 +288,7 @@ func (cfg *MevBoostConfig) GetAvailableProfiles() (bool, bool) {
 func (cfg *MevBoostConfig) GetAvailableRelays() []config.MevRelay {
 	relays := []config.MevRelay{}
 	currentNetwork := cfg.parentConfig.Smartnode.Network.Value.(config.Network)
-	for _, relay := range cfg.relays {
+	for _, relay := range cfg.relayRegistry.Relays {
 		_, exists := relay.Urls[currentNetwork]
 		if !exists {
 			continue
@@ -279,7 +306,7 @@ func (cfg *MevBoostConfig) GetEnabledMevRelays() []config.MevRelay {
 	currentNetwork := cfg.parentConfig.Smartnode.Network.Value.(config.Network)
 	switch cfg.SelectionMode.Value.(config.MevSelectionMode) {
 	case config.MevSelectionMode_Profile:
-		for _, relay := range cfg.relays {
+		for _, relay := range cfg.relayRegistry.Relays {
 			_, exists := relay.Urls[currentNetwork]
 			if !exists {
 				// Skip relays that don't exist on the current network
@@ -297,56 +324,40 @@ func (cfg *MevBoostConfig) GetEnabledMevRelays() []config.MevRelay {
 		}
 
 	case config.MevSelectionMode_Relay:
-		if cfg.FlashbotsRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_Flashbots].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_Flashbots])
-			}
-		}
-		if cfg.BloxRouteMaxProfitRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_BloxrouteMaxProfit].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_BloxrouteMaxProfit])
-			}
-		}
-		if cfg.BloxRouteRegulatedRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_BloxrouteRegulated].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_BloxrouteRegulated])
-			}
-		}
-		if cfg.EdenRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_Eden].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_Eden])
-			}
-		}
-		if cfg.UltrasoundRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_Ultrasound].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_Ultrasound])
-			}
-		}
-		if cfg.AestusRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_Aestus].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_Aestus])
+		for i, relay := range cfg.relayRegistry.Relays {
+			_, exists := relay.Urls[currentNetwork]
+			if exists && cfg.RelayParameters[i].Value == true {
+				relays = append(relays, relay)
 			}
 		}
-		if cfg.TitanGlobalRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_TitanGlobal].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_TitanGlobal])
-			}
+	}
+
+	return append(relays, cfg.GetCustomRelays()...)
+}
+
+// Get the user-defined relays
+func (cfg *MevBoostConfig) GetCustomRelays() []config.MevRelay {
+	relays := []config.MevRelay{}
+	currentNetwork := cfg.parentConfig.Smartnode.Network.Value.(config.Network)
+	for _, relayUrl := range strings.Split(cfg.CustomRelays.Value.(string), ",") {
+		relayUrl = strings.TrimSpace(relayUrl)
+		if relayUrl == "" {
+			continue
 		}
-		if cfg.TitanRegionalRelay.Value == true {
-			_, exists := cfg.relayMap[config.MevRelayID_TitanRegional].Urls[currentNetwork]
-			if exists {
-				relays = append(relays, cfg.relayMap[config.MevRelayID_TitanRegional])
-			}
+		name := relayUrl
+		parsedUrl, err := url.Parse(relayUrl)
+		if err == nil && parsedUrl.Host != "" {
+			name = parsedUrl.Host
 		}
+		relays = append(relays, config.MevRelay{
+			ID:          config.MevRelayID_Custom,
+			Name:        fmt.Sprintf("Custom (%s)", name),
+			Description: "A user-defined relay.",
+			Urls: map[config.Network]string{
+				currentNetwork: relayUrl,
+			},
+		})
 	}
-
 	return relays
 }
 
@@ -363,108 +374,6 @@ func (cfg *MevBoostConfig) GetRelayString() string {
 	return relayString
 }
 
-// Create the default MEV relays
-func createDefaultRelays() []config.MevRelay {
-	relays := []config.MevRelay{
-		// Flashbots
-		{
-			ID:          config.MevRelayID_Flashbots,
-			Name:        "Flashbots",
-			Description: "Flashbots is the developer of MEV-Boost, and one of the best-known and most trusted relays in the space.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0xac6e77dfe25ecd6110b8e780608cce0dab71fdd5ebea22a16c0205200f2f8e2e3ad3b71d3499c54ad14d6c21b41a37ae@boost-relay.flashbots.net?id=rocketpool",
-				config.Network_Devnet:  "https://0xafa4c6985aa049fb79dd37010438cfebeb0f2bd42b115b89dd678dab0670c1de38da0c4e9138c9290a398ecd9a0b3110@builder-relay-goerli.flashbots.net?id=rocketpool",
-			},
-			Regulated: true,
-		},
-
-		// bloXroute Max Profit
-		{
-			ID:          config.MevRelayID_BloxrouteMaxProfit,
-			Name:        "bloXroute Max Profit",
-			Description: "Select this to enable the \"max profit\" relay from bloXroute.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0x8b5d2e73e2a3a55c6c87b8b6eb92e0149a125c852751db1422fa951e42a09b82c142c3ea98d0d9930b056a3bc9896b8f@bloxroute.max-profit.blxrbdn.com?id=rocketpool",
-				config.Network_Devnet:  "https://0x821f2a65afb70e7f2e820a925a9b4c80a159620582c1766b1b09729fec178b11ea22abb3a51f07b288be815a1a2ff516@bloxroute.max-profit.builder.goerli.blxrbdn.com?id=rocketpool",
-			},
-			Regulated: false,
-		},
-
-		// bloXroute Regulated
-		{
-			ID:          config.MevRelayID_BloxrouteRegulated,
-			Name:        "bloXroute Regulated",
-			Description: "Select this to enable the \"regulated\" relay from bloXroute.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0xb0b07cd0abef743db4260b0ed50619cf6ad4d82064cb4fbec9d3ec530f7c5e6793d9f286c4e082c0244ffb9f2658fe88@bloxroute.regulated.blxrbdn.com?id=rocketpool",
-			},
-			Regulated: true,
-		},
-
-		// Eden
-		{
-			ID:          config.MevRelayID_Eden,
-			Name:        "Eden Network",
-			Description: "Eden Network is the home of Eden Relay, a block building hub focused on optimising block rewards for validators.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0xb3ee7afcf27f1f1259ac1787876318c6584ee353097a50ed84f51a1f21a323b3736f271a895c7ce918c038e4265918be@relay.edennetwork.io?id=rocketpool",
-				config.Network_Devnet:  "https://0xaa1488eae4b06a1fff840a2b6db167afc520758dc2c8af0dfb57037954df3431b747e2f900fe8805f05d635e9a29717b@relay-goerli.edennetwork.io?id=rocketpool",
-			},
-			Regulated: true,
-		},
-
-		// Ultrasound
-		{
-			ID:          config.MevRelayID_Ultrasound,
-			Name:        "Ultra Sound",
-			Description: "The ultra sound relay is a credibly-neutral and permissionless relay — a public good from the ultrasound.money team.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0xa1559ace749633b997cb3fdacffb890aeebdb0f5a3b6aaa7eeeaf1a38af0a8fe88b9e4b1f61f236d2e64d95733327a62@relay.ultrasound.money?id=rocketpool",
-				config.Network_Devnet:  "https://0xb1559beef7b5ba3127485bbbb090362d9f497ba64e177ee2c8e7db74746306efad687f2cf8574e38d70067d40ef136dc@relay-stag.ultrasound.money?id=rocketpool",
-			},
-			Regulated: false,
-		},
-
-		// Aestus
-		{
-			ID:          config.MevRelayID_Aestus,
-			Name:        "Aestus",
-			Description: "The Aestus MEV-Boost Relay is an independent and non-censoring relay. It is committed to neutrality and the development of a healthy MEV-Boost ecosystem.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0xa15b52576bcbf1072f4a011c0f99f9fb6c66f3e1ff321f11f461d15e31b1cb359caa092c71bbded0bae5b5ea401aab7e@aestus.live?id=rocketpool",
-				config.Network_Devnet:  "https://0xab78bf8c781c58078c3beb5710c57940874dd96aef2835e7742c866b4c7c0406754376c2c8285a36c630346aa5c5f833@goerli.aestus.live?id=rocketpool",
-			},
-			Regulated: false,
-		},
-
-		// Titan Global
-		{
-			ID:          config.MevRelayID_TitanGlobal,
-			Name:        "Titan Global (non-filtering)",
-			Description: "Titan Relay is a neutral, Rust-based MEV-Boost Relay optimized for low latency throughput, geographical distribution, and robustness. Select this to enable the \"non-filtering\" relay from Titan.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0x8c4ed5e24fe5c6ae21018437bde147693f68cda427cd1122cf20819c30eda7ed74f72dece09bb313f2a1855595ab677d@global.titanrelay.xyz",
-				config.Network_Devnet:  "",
-			},
-			Regulated: false,
-		},
-
-		// Titan Regional
-		{
-			ID:          config.MevRelayID_TitanRegional,
-			Name:        "Titan Regional (filtering)",
-			Description: "Titan Relay is a neutral, Rust-based MEV-Boost Relay optimized for low latency throughput, geographical distribution, and robustness. Select this to enable the \"filtering\" relay from Titan.",
-			Urls: map[config.Network]string{
-				config.Network_Mainnet: "https://0x8c4ed5e24fe5c6ae21018437bde147693f68cda427cd1122cf20819c30eda7ed74f72dece09bb313f2a1855595ab677d@regional.titanrelay.xyz",
-				config.Network_Devnet:  "",
-			},
-			Regulated: true,
-		},
-	}
-
-	return relays
-}
-
 // Generate one of the profile parameters
 func generateProfileParameter(id string, relays []config.MevRelay, regulated bool) config.Parameter {
 	name := "Enable "
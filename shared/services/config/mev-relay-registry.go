@@ -0,0 +1,90 @@
+package config
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	MevRelayRegistryFilename string = "mev-relays.json"
+	mevRelaySettingIdSuffix  string = "Enabled"
+)
+
+// The relay registry that ships with this version of the Smartnode
+//
+//go:embed mev-relays.json
+var defaultMevRelayRegistry []byte
+
+// A versioned list of the MEV-Boost relays that can be enabled
+type MevRelayRegistry struct {
+	Version uint64            `json:"version"`
+	Relays  []config.MevRelay `json:"relays"`
+
+	// The file this registry was loaded from; blank for the built-in registry
+	Source string `json:"-"`
+}
+
+// Parse a relay registry and make sure every relay in it can be mapped to a setting
+func parseMevRelayRegistry(bytes []byte) (*MevRelayRegistry, error) {
+	registry := new(MevRelayRegistry)
+	err := json.Unmarshal(bytes, registry)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing relay registry: %w", err)
+	}
+
+	ids := map[config.MevRelayID]bool{}
+	for i, relay := range registry.Relays {
+		if relay.ID == config.MevRelayID_Unknown {
+			return nil, fmt.Errorf("relay %d (%s) in the registry does not have an ID", i, relay.Name)
+		}
+		if ids[relay.ID] {
+			return nil, fmt.Errorf("relay %s is defined more than once in the registry", relay.ID)
+		}
+		ids[relay.ID] = true
+		if relay.SettingID == "" {
+			registry.Relays[i].SettingID = string(relay.ID) + mevRelaySettingIdSuffix
+		}
+	}
+	return registry, nil
+}
+
+// Load the relay registry. If the Rocket Pool directory has a registry file with a version at least as new as the built-in one,
+// that file is used instead so relays can be added or removed without a new Smartnode release.
+// If the file can't be loaded, the built-in registry is returned along with the error.
+func LoadMevRelayRegistry(rpDir string) (*MevRelayRegistry, error) {
+	registry, err := parseMevRelayRegistry(defaultMevRelayRegistry)
+	if err != nil {
+		panic(fmt.Sprintf("Built-in MEV relay registry is invalid: %s", err.Error()))
+	}
+	if rpDir == "" {
+		return registry, nil
+	}
+
+	path, err := homedir.Expand(filepath.Join(rpDir, MevRelayRegistryFilename))
+	if err != nil {
+		return registry, fmt.Errorf("error expanding relay registry path: %w", err)
+	}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return registry, nil
+	}
+	if err != nil {
+		return registry, fmt.Errorf("error reading relay registry [%s]: %w", path, err)
+	}
+	override, err := parseMevRelayRegistry(bytes)
+	if err != nil {
+		return registry, fmt.Errorf("error loading relay registry [%s]: %w", path, err)
+	}
+	if override.Version < registry.Version {
+		return registry, nil
+	}
+	override.Source = path
+	return override, nil
+}
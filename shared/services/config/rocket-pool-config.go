@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/alessio/shellescape"
+	"github.com/ethereum/go-ethereum/common"
 	externalip "github.com/glendc/go-external-ip"
 	"github.com/pbnjay/memory"
 	"github.com/rocket-pool/smartnode/addons"
@@ -24,7 +25,9 @@ import (
 
 // Constants
 const (
-	rootConfigName string = "root"
+	rootConfigName                  string = "root"
+	graffitiOverridesConfigName     string = "graffitiOverrides"
+	feeRecipientOverridesConfigName string = "feeRecipientOverrides"
 
 	ApiContainerName          string = "api"
 	Eth1ContainerName         string = "eth1"
@@ -47,6 +50,7 @@ const defaultNodeMetricsPort uint16 = 9102
 const defaultExporterMetricsPort uint16 = 9103
 const defaultWatchtowerMetricsPort uint16 = 9104
 const defaultEcMetricsPort uint16 = 9105
+const KeymanagerApiPort uint16 = 5062
 
 // The master configuration struct
 type RocketPoolConfig struct {
@@ -127,6 +131,10 @@ type RocketPoolConfig struct {
 	// Addons
 	GraffitiWallWriter addontypes.SmartnodeAddon `yaml:"addon-gww,omitempty"`
 	RescueNode         addontypes.SmartnodeAddon `yaml:"addon-rescue-node,omitempty"`
+
+	// Per-minipool proposal overrides, keyed by minipool address
+	GraffitiOverrides     map[string]string `yaml:"-"`
+	FeeRecipientOverrides map[string]string `yaml:"-"`
 }
 
 // Get the external IP address. Try finding an IPv4 address first to:
@@ -192,9 +200,11 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	}}
 
 	cfg := &RocketPoolConfig{
-		Title:               "Top-level Settings",
-		RocketPoolDirectory: rpDir,
-		IsNativeMode:        isNativeMode,
+		Title:                 "Top-level Settings",
+		RocketPoolDirectory:   rpDir,
+		IsNativeMode:          isNativeMode,
+		GraffitiOverrides:     map[string]string{},
+		FeeRecipientOverrides: map[string]string{},
 
 		ExecutionClientMode: config.Parameter{
 			ID:                 "executionClientMode",
@@ -526,6 +536,13 @@ func (cfg *RocketPoolConfig) CreateCopy() *RocketPoolConfig {
 		}
 	}
 
+	for minipool, graffiti := range cfg.GraffitiOverrides {
+		newConfig.GraffitiOverrides[minipool] = graffiti
+	}
+	for minipool, feeRecipient := range cfg.FeeRecipientOverrides {
+		newConfig.FeeRecipientOverrides[minipool] = feeRecipient
+	}
+
 	return newConfig
 }
 
@@ -769,6 +786,18 @@ func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 		masterMap[name] = subconfigParams
 	}
 
+	// Serialize the per-minipool overrides
+	graffitiOverrides := map[string]string{}
+	for minipool, graffiti := range cfg.GraffitiOverrides {
+		graffitiOverrides[minipool] = graffiti
+	}
+	masterMap[graffitiOverridesConfigName] = graffitiOverrides
+	feeRecipientOverrides := map[string]string{}
+	for minipool, feeRecipient := range cfg.FeeRecipientOverrides {
+		feeRecipientOverrides[minipool] = feeRecipient
+	}
+	masterMap[feeRecipientOverridesConfigName] = feeRecipientOverrides
+
 	return masterMap
 }
 
@@ -813,6 +842,9 @@ func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string)
 	}
 	cfg.Version = masterMap[rootConfigName]["version"]
 
+	// Reload the relay registry now that the Rocket Pool directory is known, so relays that only exist in its registry file keep their settings
+	cfg.MevBoost.ReloadRelayRegistry()
+
 	// Deserialize the subconfigs
 	for name, subconfig := range cfg.GetSubconfigs() {
 		subconfigParams := masterMap[name]
@@ -825,6 +857,16 @@ func (cfg *RocketPoolConfig) Deserialize(masterMap map[string]map[string]string)
 		}
 	}
 
+	// Deserialize the per-minipool overrides
+	cfg.GraffitiOverrides = map[string]string{}
+	for minipool, graffiti := range masterMap[graffitiOverridesConfigName] {
+		cfg.GraffitiOverrides[minipool] = graffiti
+	}
+	cfg.FeeRecipientOverrides = map[string]string{}
+	for minipool, feeRecipient := range masterMap[feeRecipientOverridesConfigName] {
+		cfg.FeeRecipientOverrides[minipool] = feeRecipient
+	}
+
 	return nil
 }
 
@@ -1029,15 +1071,64 @@ func (cfg *RocketPoolConfig) GraffitiPrefix() string {
 
 // Used by text/template to format validator.yml
 func (cfg *RocketPoolConfig) Graffiti() (string, error) {
-	prefix := cfg.GraffitiPrefix()
 	customGraffiti, err := cfg.CustomGraffiti()
 	if err != nil {
 		return "", err
 	}
+	return cfg.FormatGraffiti(customGraffiti), nil
+}
+
+// Combines the Smartnode's graffiti prefix with the given user-entered graffiti
+func (cfg *RocketPoolConfig) FormatGraffiti(customGraffiti string) string {
+	prefix := cfg.GraffitiPrefix()
 	if customGraffiti == "" {
-		return prefix, nil
+		return prefix
+	}
+	return fmt.Sprintf("%s (%s)", prefix, customGraffiti)
+}
+
+// Gets the graffiti override for a minipool, if one is set
+func (cfg *RocketPoolConfig) GetGraffitiOverride(minipoolAddress common.Address) (string, bool) {
+	for minipool, graffiti := range cfg.GraffitiOverrides {
+		if common.HexToAddress(minipool) == minipoolAddress {
+			return graffiti, true
+		}
+	}
+	return "", false
+}
+
+// Gets the fee recipient override for a minipool, if one is set
+func (cfg *RocketPoolConfig) GetFeeRecipientOverride(minipoolAddress common.Address) (common.Address, bool) {
+	for minipool, feeRecipient := range cfg.FeeRecipientOverrides {
+		if common.HexToAddress(minipool) == minipoolAddress {
+			return common.HexToAddress(feeRecipient), true
+		}
+	}
+	return common.Address{}, false
+}
+
+// Sets (or clears, if blank) the graffiti override for a minipool
+func (cfg *RocketPoolConfig) SetGraffitiOverride(minipoolAddress common.Address, graffiti string) {
+	for minipool := range cfg.GraffitiOverrides {
+		if common.HexToAddress(minipool) == minipoolAddress {
+			delete(cfg.GraffitiOverrides, minipool)
+		}
+	}
+	if graffiti != "" {
+		cfg.GraffitiOverrides[minipoolAddress.Hex()] = graffiti
+	}
+}
+
+// Sets (or clears, if nil) the fee recipient override for a minipool
+func (cfg *RocketPoolConfig) SetFeeRecipientOverride(minipoolAddress common.Address, feeRecipient *common.Address) {
+	for minipool := range cfg.FeeRecipientOverrides {
+		if common.HexToAddress(minipool) == minipoolAddress {
+			delete(cfg.FeeRecipientOverrides, minipool)
+		}
+	}
+	if feeRecipient != nil {
+		cfg.FeeRecipientOverrides[minipoolAddress.Hex()] = feeRecipient.Hex()
 	}
-	return fmt.Sprintf("%s (%s)", prefix, customGraffiti), nil
 }
 
 // Used by text/template to format validator.yml
@@ -1095,11 +1186,18 @@ func (cfg *RocketPoolConfig) VcAdditionalFlags() (string, error) {
 		return "", fmt.Errorf("unknown consensus client mode [%v]", mode)
 	}
 
+	// The proposer settings flags go through here since every client's start script passes these flags on
 	first := true
-	out := ""
+	out := cfg.ProposerSettingsVcFlags()
+	if out != "" {
+		first = false
+	}
 	if addtlFlags != "" {
+		if !first {
+			out = out + " "
+		}
 		first = false
-		out = addtlFlags
+		out = out + addtlFlags
 	}
 	if overrides != nil && overrides.VcAdditionalFlags != "" {
 		if !first {
@@ -1115,6 +1213,34 @@ func (cfg *RocketPoolConfig) FeeRecipientFile() string {
 	return FeeRecipientFilename
 }
 
+// Gets the flags that point the Validator Client at the per-validator graffiti and fee recipient overrides.
+// Teku and Prysm read the proposer config files the node daemon writes, Nimbus and Lodestar get them through the keymanager API,
+// and Lighthouse reads them from its validator definitions file without any flags.
+// Used by text/template to format validator.yml
+func (cfg *RocketPoolConfig) ProposerSettingsVcFlags() string {
+	cc, _ := cfg.GetSelectedConsensusClient()
+	switch cc {
+	case config.ConsensusClient_Teku:
+		return fmt.Sprintf("--validators-proposer-config=%s --validators-proposer-config-refresh-enabled=true", cfg.Smartnode.GetTekuProposerConfigPath(true))
+	case config.ConsensusClient_Prysm:
+		return fmt.Sprintf("--proposer-settings-file=%s --graffiti-file=%s", cfg.Smartnode.GetPrysmProposerSettingsPath(true), cfg.Smartnode.GetPrysmGraffitiPath(true))
+	case config.ConsensusClient_Nimbus:
+		return fmt.Sprintf("--keymanager --keymanager-address=0.0.0.0 --keymanager-port=%d --keymanager-token-file=%s", KeymanagerApiPort, cfg.Smartnode.GetKeymanagerTokenPathInVC())
+	case config.ConsensusClient_Lodestar:
+		return fmt.Sprintf("--keymanager --keymanager.address=0.0.0.0 --keymanager.port=%d --keymanager.tokenFile=%s", KeymanagerApiPort, cfg.Smartnode.GetKeymanagerTokenPathInVC())
+	}
+	return ""
+}
+
+// Gets the URL of the Validator Client's keymanager API
+func (cfg *RocketPoolConfig) GetKeymanagerApiUrl() string {
+	if cfg.IsNativeMode {
+		return fmt.Sprintf("http://localhost:%d", KeymanagerApiPort)
+	}
+
+	return fmt.Sprintf("http://%s:%d", ValidatorContainerName, KeymanagerApiPort)
+}
+
 // Used by text/template to format validator.yml
 func (cfg *RocketPoolConfig) MevBoostUrl() string {
 	if !cfg.EnableMevBoost.Value.(bool) {
@@ -1472,6 +1598,13 @@ func (cfg *RocketPoolConfig) Validate() []string {
 			if len(relays) == 0 {
 				errors = append(errors, "You have MEV-boost enabled in local mode but don't have any profiles or relays enabled. Please select at least one profile or relay to use MEV-boost.")
 			}
+			for _, relay := range cfg.MevBoost.GetCustomRelays() {
+				relayUrl := relay.Urls[cfg.Smartnode.Network.Value.(config.Network)]
+				parsedUrl, err := url.Parse(relayUrl)
+				if err != nil || (parsedUrl.Scheme != "https" && parsedUrl.Scheme != "http") || parsedUrl.Host == "" || parsedUrl.User.Username() == "" {
+					errors = append(errors, fmt.Sprintf("The custom MEV-boost relay [%s] is not a valid relay URL. Relay URLs must be of the form https://<relay public key>@<relay host>.", relayUrl))
+				}
+			}
 		case config.Mode_External:
 			// In external MEV-boost mode, the user has to have an external URL if they're running Docker mode
 			if cfg.ExecutionClientMode.Value.(config.Mode) == config.Mode_Local && cfg.MevBoost.ExternalUrl.Value.(string) == "" {
@@ -1522,6 +1655,24 @@ func (cfg *RocketPoolConfig) Validate() []string {
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.Alertmanager.Port, errors)
 	_, errors = addAndCheckForDuplicate(portMap, cfg.Lighthouse.P2pQuicPort, errors)
 
+	// Check the per-minipool overrides
+	for minipool, graffiti := range cfg.GraffitiOverrides {
+		if !common.IsHexAddress(minipool) {
+			errors = append(errors, fmt.Sprintf("Graffiti override has an invalid minipool address [%s].", minipool))
+		}
+		if len(graffiti) > cfg.ConsensusCommon.Graffiti.MaxLength {
+			errors = append(errors, fmt.Sprintf("Graffiti override for minipool %s is longer than %d characters.", minipool, cfg.ConsensusCommon.Graffiti.MaxLength))
+		}
+	}
+	for minipool, feeRecipient := range cfg.FeeRecipientOverrides {
+		if !common.IsHexAddress(minipool) {
+			errors = append(errors, fmt.Sprintf("Fee recipient override has an invalid minipool address [%s].", minipool))
+		}
+		if !common.IsHexAddress(feeRecipient) {
+			errors = append(errors, fmt.Sprintf("Fee recipient override for minipool %s is not a valid address [%s].", minipool, feeRecipient))
+		}
+	}
+
 	return errors
 }
 
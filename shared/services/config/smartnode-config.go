@@ -7,6 +7,8 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
 	"github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -26,6 +28,7 @@ const (
 	DaemonDataPath                     string = "/.rocketpool/data"
 	WatchtowerFolder                   string = "watchtower"
 	WatchtowerStateFile                string = "state.yml"
+	MigrationFolder                    string = "migration"
 	RegenerateRewardsTreeRequestSuffix string = ".request"
 	RegenerateRewardsTreeRequestFormat string = "%d" + RegenerateRewardsTreeRequestSuffix
 	PrimaryRewardsFileUrl              string = "https://%s.ipfs.dweb.link/%s"
@@ -33,6 +36,20 @@ const (
 	GithubRewardsFileUrl               string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
 	FeeRecipientFilename               string = "rp-fee-recipient.txt"
 	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	ProposerSettingsStateFilename      string = "rp-proposer-settings.json"
+	KeymanagerTokenFilename            string = "rp-keymanager-token.txt"
+	TekuProposerConfigFilename         string = "rp-proposer-config.json"
+	PrysmProposerSettingsFilename      string = "rp-proposer-settings.json"
+	PrysmGraffitiFilename              string = "rp-graffiti.yml"
+	ProposalHistoryFilename            string = "proposal-history.json"
+	ParticipationHistoryFilename       string = "participation-history.json"
+	WatchtowerTaskStatusFilename       string = "task-status.json"
+	DeferredTxScheduleFilename         string = "deferred-txs.json"
+	GovernanceAlertStateFilename       string = "governance-alerts.json"
+	StateSnapshotFolder                string = "state-snapshots"
+	StateSnapshotFilenameFormat        string = "%s-%d.json.zst"
+	WatchOnlyFolder                    string = "watch-only"
+	ValidatorContainerKeychainPath     string = "/validators"
 )
 
 // Defaults
@@ -71,12 +88,27 @@ type SmartnodeConfig struct {
 	// Manual priority fee override
 	PriorityFee config.Parameter `yaml:"priorityFee,omitempty"`
 
+	// Where to get gas price suggestions from
+	GasEstimateSource config.Parameter `yaml:"gasEstimateSource,omitempty"`
+
 	// Threshold for automatic transactions
 	AutoTxGasThreshold config.Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// The most an automatic transaction can cost (in ETH) before it's deferred
+	AutoTxMaxCost config.Parameter `yaml:"autoTxMaxCost,omitempty"`
+
+	// The longest an automatic transaction can be deferred for (in hours)
+	AutoTxMaxDeferral config.Parameter `yaml:"autoTxMaxDeferral,omitempty"`
+
+	// Toggle for automatically claiming rewards
+	AutoClaimRewards config.Parameter `yaml:"autoClaimRewards,omitempty"`
+
+	// The amount of ETH in the fee distributor before it's automatically distributed
+	AutoDistributeFeesThreshold config.Parameter `yaml:"autoDistributeFeesThreshold,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
@@ -89,12 +121,36 @@ type SmartnodeConfig struct {
 	// URL for an EC with archive mode, for manual rewards tree generation
 	ArchiveECUrl config.Parameter `yaml:"archiveEcUrl,omitempty"`
 
+	// The number of requests the Smartnode sends to the Beacon node at the same time
+	BeaconRequestConcurrency config.Parameter `yaml:"beaconRequestConcurrency,omitempty"`
+
+	// Toggle for requesting SSZ-encoded responses from the Beacon node where they're supported
+	BeaconUseSsz config.Parameter `yaml:"beaconUseSsz,omitempty"`
+
+	// The node addresses to monitor in watch-only mode, instead of the node wallet
+	WatchOnlyNodes config.Parameter `yaml:"watchOnlyNodes,omitempty"`
+
+	// The number of network state snapshots to keep on disk
+	StateSnapshotCount config.Parameter `yaml:"stateSnapshotCount,omitempty"`
+
 	// Manual override for the watchtower's max fee
 	WatchtowerMaxFeeOverride config.Parameter `yaml:"watchtowerMaxFeeOverride,omitempty"`
 
 	// Manual override for the watchtower's priority fee
 	WatchtowerPrioFeeOverride config.Parameter `yaml:"watchtowerPrioFeeOverride,omitempty"`
 
+	// Extra Uniswap v3-style RPL pools for the watchtower's RPL price, on top of the default pool
+	RplPriceSources config.Parameter `yaml:"rplPriceSources,omitempty"`
+
+	// How the watchtower combines the prices of its RPL price sources
+	RplPriceAggregation config.Parameter `yaml:"rplPriceAggregation,omitempty"`
+
+	// The largest difference (in percent) between an RPL price source and the combined price before the watchtower refuses to submit
+	RplPriceMaxDivergence config.Parameter `yaml:"rplPriceMaxDivergence,omitempty"`
+
+	// The per-L2 toggles and gas ceilings for RPL price submissions to the L2 price messengers
+	L2PriceSubmissionOverrides config.Parameter `yaml:"l2PriceSubmissionOverrides,omitempty"`
+
 	// The toggle for rolling records
 	UseRollingRecords config.Parameter `yaml:"useRollingRecords,omitempty"`
 
@@ -288,6 +344,26 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		GasEstimateSource: config.Parameter{
+			ID:                 "gasEstimateSource",
+			Name:               "Gas Estimate Source",
+			Description:        "Select where the Smartnode gets its suggested max fees from when you haven't set one manually.\n\nIf the selected source isn't available, the other one will be used instead.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.GasEstimateSource_Local},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Execution Client",
+				Description: "Estimate the fees from the recent blocks of your own Execution Client, using its fee history.",
+				Value:       config.GasEstimateSource_Local,
+			}, {
+				Name:        "External Oracles",
+				Description: "Use the gas oracles from beaconcha.in and Etherscan. These may be unavailable if the services are down, rate-limited, or your node can't reach them.",
+				Value:       config.GasEstimateSource_External,
+			}},
+		},
+
 		AutoTxGasThreshold: config.Parameter{
 			ID:   "minipoolStakeGasThreshold",
 			Name: "Automatic TX Gas Threshold",
@@ -312,6 +388,50 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		AutoTxMaxCost: config.Parameter{
+			ID:                 "autoTxMaxCost",
+			Name:               "Automatic TX Max Cost",
+			Description:        "The most (in ETH) you're willing to pay for a single automatic transaction. If the transaction would cost more than this, your node will wait for cheaper gas even if the max fee is below the Automatic TX Gas Threshold.\n\nTransactions with a deadline (such as minipool staking or bond reduction) will still be submitted before their deadline, regardless of their cost.\n\nSet this to 0 to disable the limit.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoTxMaxDeferral: config.Parameter{
+			ID:                 "autoTxMaxDeferral",
+			Name:               "Automatic TX Max Deferral",
+			Description:        "The longest (in hours) your node will wait for gas to become cheap enough before submitting an automatic transaction anyway. This acts as a deadline for transactions that don't have one of their own, such as minipool balance distribution or rewards claims.\n\nSet this to 0 to wait indefinitely for transactions without a deadline of their own.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimRewards: config.Parameter{
+			ID:                 "autoClaimRewards",
+			Name:               "Auto-Claim Rewards",
+			Description:        "Check this box to have your node automatically claim its RPL and Smoothing Pool rewards once a rewards interval has finished and its tree has been downloaded. The rewards will be sent to your withdrawal address; none of the RPL will be restaked.\n\nClaims follow the Automatic TX Gas Threshold, Max Cost, and Max Deferral settings.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoDistributeFeesThreshold: config.Parameter{
+			ID:                 "autoDistributeFeesThreshold",
+			Name:               "Auto-Distribute Fees Threshold",
+			Description:        "The Smartnode will regularly check the balance of your node's fee distributor. If it has more than this threshold (in ETH), the Smartnode will automatically distribute it. This will send your share of the balance to your withdrawal address.\n\nSet this to 0 to disable automatic fee distributor distribution.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		VerifyProposals: config.Parameter{
 			ID:                 "verifyProposals",
 			Name:               "Enable PDAO Proposal Checker",
@@ -376,6 +496,50 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		BeaconRequestConcurrency: config.Parameter{
+			ID:                 "beaconRequestConcurrency",
+			Name:               "Beacon Node Request Concurrency",
+			Description:        "The number of requests the Smartnode will send to your Beacon Node at the same time when it needs a lot of data at once, such as when building the network state or generating rewards trees.\n\nHigher values are faster on powerful machines, but may overload your Beacon Node on slower ones.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(12)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		BeaconUseSsz: config.Parameter{
+			ID:                 "beaconUseSsz",
+			Name:               "Use SSZ for Beacon Node Requests",
+			Description:        "Ask your Beacon Node for blocks in its compact binary SSZ format instead of JSON. This is much faster to download and decode, and the Smartnode falls back to JSON for anything it can't read as SSZ.\n\nOnly disable this if your Beacon Node has trouble serving SSZ responses.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: true},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		WatchOnlyNodes: config.Parameter{
+			ID:                 "watchOnlyNodes",
+			Name:               "Watch-Only Node Addresses",
+			Description:        "A comma-separated list of node addresses to monitor without a node wallet. When this is set, the node daemon runs in watch-only mode: it never loads a wallet or sends transactions, and only tracks metrics, Beacon Chain duties, and alerts for these nodes. Each node's metrics are labelled with its address.\n\nLeave this blank to run the node daemon normally for your own node.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		StateSnapshotCount: config.Parameter{
+			ID:                 "stateSnapshotCount",
+			Name:               "Network State Snapshots",
+			Description:        "The number of network state snapshots to keep on disk. The Smartnode saves a compressed copy of the network state whenever it builds one for a specific slot, such as when generating rewards trees, so it can load it again later instead of querying every node, minipool, and validator again.\n\nSet this to 0 to disable snapshots.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(8)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		WatchtowerMaxFeeOverride: config.Parameter{
 			ID:                 "watchtowerMaxFeeOverride",
 			Name:               "Watchtower Max Fee Override",
@@ -398,6 +562,60 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: true,
 		},
 
+		RplPriceSources: config.Parameter{
+			ID:                 "rplPriceSources",
+			Name:               "Extra RPL Price Sources",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of extra Uniswap v3-style RPL / ETH pool addresses, such as other fee tiers, to calculate the RPL price from alongside the default RPL TWAP pool.\n\n[red]WARNING: the Oracle DAO only reaches consensus on a price when enough members submit exactly the same value. If your extra sources change the price, your submissions will not match the members using the default pool and will not count towards consensus. Only use this if the whole Oracle DAO has agreed on the same sources and aggregation.\n\n[white]Leave this blank to only use the default pool.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		RplPriceAggregation: config.Parameter{
+			ID:                 "rplPriceAggregation",
+			Name:               "RPL Price Aggregation",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]Select how the watchtower combines the RPL prices of its price sources.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: config.RplPriceAggregation_Median},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Median",
+				Description: "Use the median of the source prices.",
+				Value:       config.RplPriceAggregation_Median,
+			}, {
+				Name:        "Liquidity Weighted",
+				Description: "Weight each source's price by the pool's average in-range liquidity over the TWAP window.",
+				Value:       config.RplPriceAggregation_LiquidityWeighted,
+			}},
+		},
+
+		RplPriceMaxDivergence: config.Parameter{
+			ID:                 "rplPriceMaxDivergence",
+			Name:               "RPL Price Max Divergence",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]The largest difference (in percent) allowed between any RPL price source and the combined price. If a source is further off than this, the watchtower will not submit the RPL price and will send an alert instead.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(5)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		L2PriceSubmissionOverrides: config.Parameter{
+			ID:                 "l2PriceSubmissionOverrides",
+			Name:               "L2 Price Submission Overrides",
+			Description:        "[orange]**For Oracle DAO members only.**\n\n[white]A comma-separated list of overrides for the watchtower's RPL price submissions to the L2 price messengers, each in the form `<l2>=<setting>`. The setting can be `off` to stop submitting to that L2, or a max fee in gwei to cap the fee of its submissions; if the network's base fee is above the cap, the watchtower will skip its turn instead of submitting.\n\nThe L2s are `optimism`, `polygon`, `arbitrum`, `zksync-era`, `base` and `scroll`. For example, `polygon=off,arbitrum=20`.\n\nLeave this blank to submit to every L2 with the watchtower's max fee.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+			Regex:              "^\\s*$|^(\\s*[A-Za-z0-9-]+\\s*=\\s*(off|[0-9]+(\\.[0-9]+)?)\\s*)(,\\s*[A-Za-z0-9-]+\\s*=\\s*(off|[0-9]+(\\.[0-9]+)?)\\s*)*$",
+		},
+
 		UseRollingRecords: config.Parameter{
 			ID:                 "useRollingRecords",
 			Name:               "Use Rolling Records",
@@ -657,15 +875,28 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.DataPath,
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
+		&cfg.GasEstimateSource,
 		&cfg.AutoTxGasThreshold,
 		&cfg.DistributeThreshold,
+		&cfg.AutoTxMaxCost,
+		&cfg.AutoTxMaxDeferral,
+		&cfg.AutoClaimRewards,
+		&cfg.AutoDistributeFeesThreshold,
 		&cfg.VerifyProposals,
 		&cfg.RewardsTreeMode,
 		&cfg.PriceBalanceSubmissionReferenceTimestamp,
 		&cfg.RewardsTreeCustomUrl,
 		&cfg.ArchiveECUrl,
+		&cfg.BeaconRequestConcurrency,
+		&cfg.BeaconUseSsz,
+		&cfg.WatchOnlyNodes,
+		&cfg.StateSnapshotCount,
 		&cfg.WatchtowerMaxFeeOverride,
 		&cfg.WatchtowerPrioFeeOverride,
+		&cfg.RplPriceSources,
+		&cfg.RplPriceAggregation,
+		&cfg.RplPriceMaxDivergence,
+		&cfg.L2PriceSubmissionOverrides,
 		&cfg.UseRollingRecords,
 		&cfg.RecordCheckpointInterval,
 		&cfg.CheckpointRetentionLimit,
@@ -747,6 +978,72 @@ func (config *SmartnodeConfig) GetWatchtowerStatePath() string {
 	return filepath.Join(DaemonDataPath, WatchtowerFolder, "state.yml")
 }
 
+func (cfg *SmartnodeConfig) GetProposalHistoryPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), ProposalHistoryFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, ProposalHistoryFilename)
+}
+
+func (cfg *SmartnodeConfig) GetDeferredTxSchedulePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), DeferredTxScheduleFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, DeferredTxScheduleFilename)
+}
+
+func (cfg *SmartnodeConfig) GetGovernanceAlertStatePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), GovernanceAlertStateFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, GovernanceAlertStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetStateSnapshotFolder() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), StateSnapshotFolder)
+	}
+
+	return filepath.Join(DaemonDataPath, StateSnapshotFolder)
+}
+
+func (cfg *SmartnodeConfig) GetStateSnapshotPath(slot uint64) string {
+	return filepath.Join(cfg.GetStateSnapshotFolder(), fmt.Sprintf(StateSnapshotFilenameFormat, string(cfg.Network.Value.(config.Network)), slot))
+}
+
+func (cfg *SmartnodeConfig) GetWatchOnlyProposalHistoryPath(nodeAddress common.Address) string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), WatchOnlyFolder, nodeAddress.Hex(), ProposalHistoryFilename)
+	}
+
+	return filepath.Join(DaemonDataPath, WatchOnlyFolder, nodeAddress.Hex(), ProposalHistoryFilename)
+}
+
+// Get the node addresses to monitor in watch-only mode; if there aren't any, the node daemon runs normally
+func (cfg *SmartnodeConfig) GetWatchOnlyNodeAddresses() ([]common.Address, error) {
+	addresses := []common.Address{}
+	seen := map[common.Address]bool{}
+	for _, entry := range strings.Split(cfg.WatchOnlyNodes.Value.(string), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !common.IsHexAddress(entry) {
+			return nil, fmt.Errorf("watch-only node [%s] is not a valid address", entry)
+		}
+		address := common.HexToAddress(entry)
+		if seen[address] {
+			continue
+		}
+		seen[address] = true
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
 func (cfg *SmartnodeConfig) GetCustomKeyPath() string {
 	if cfg.parent.IsNativeMode {
 		return filepath.Join(cfg.DataPath.Value.(string), "custom-keys")
@@ -852,6 +1149,22 @@ func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder)
 }
 
+func (cfg *SmartnodeConfig) GetParticipationHistoryPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), ParticipationHistoryFilename)
+}
+
+func (cfg *SmartnodeConfig) GetWatchtowerTaskStatusPath(daemon bool) string {
+	return filepath.Join(cfg.GetWatchtowerFolder(daemon), WatchtowerTaskStatusFilename)
+}
+
+func (cfg *SmartnodeConfig) GetMigrationFolder(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, MigrationFolder)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), MigrationFolder)
+}
+
 func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	if !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, "validators", FeeRecipientFilename)
@@ -860,6 +1173,50 @@ func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators", NativeFeeRecipientFilename)
 }
 
+func (cfg *SmartnodeConfig) GetProposerSettingsStatePath() string {
+	return filepath.Join(cfg.GetValidatorKeychainPath(), ProposerSettingsStateFilename)
+}
+
+func (cfg *SmartnodeConfig) GetKeymanagerTokenPath() string {
+	return filepath.Join(cfg.GetValidatorKeychainPath(), KeymanagerTokenFilename)
+}
+
+// Get the path of the validator keychain as seen by the Validator Client
+func (cfg *SmartnodeConfig) GetValidatorKeychainPathInVC() string {
+	if cfg.parent.IsNativeMode {
+		return cfg.GetValidatorKeychainPath()
+	}
+
+	return ValidatorContainerKeychainPath
+}
+
+// Get the path of Teku's proposer config file, on the host or as seen by the Validator Client
+func (cfg *SmartnodeConfig) GetTekuProposerConfigPath(inVC bool) string {
+	return filepath.Join(cfg.getKeychainPath(inVC), teku.KeystoreDir, TekuProposerConfigFilename)
+}
+
+// Get the path of Prysm's proposer settings file, on the host or as seen by the Validator Client
+func (cfg *SmartnodeConfig) GetPrysmProposerSettingsPath(inVC bool) string {
+	return filepath.Join(cfg.getKeychainPath(inVC), prysm.KeystoreDir, PrysmProposerSettingsFilename)
+}
+
+// Get the path of Prysm's graffiti file, on the host or as seen by the Validator Client
+func (cfg *SmartnodeConfig) GetPrysmGraffitiPath(inVC bool) string {
+	return filepath.Join(cfg.getKeychainPath(inVC), prysm.KeystoreDir, PrysmGraffitiFilename)
+}
+
+// Get the path of the keymanager API token file as seen by the Validator Client
+func (cfg *SmartnodeConfig) GetKeymanagerTokenPathInVC() string {
+	return filepath.Join(cfg.GetValidatorKeychainPathInVC(), KeymanagerTokenFilename)
+}
+
+func (cfg *SmartnodeConfig) getKeychainPath(inVC bool) string {
+	if inVC {
+		return cfg.GetValidatorKeychainPathInVC()
+	}
+	return cfg.GetValidatorKeychainPath()
+}
+
 func (cfg *SmartnodeConfig) GetV100RewardsPoolAddress() common.Address {
 	return common.HexToAddress(cfg.v1_0_0_RewardsPoolAddress[cfg.Network.Value.(config.Network)])
 }
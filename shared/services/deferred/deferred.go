@@ -0,0 +1,394 @@
+package deferred
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	// The number of recent blocks used to estimate when the gas price will be low enough
+	HistoryBlockCount uint64 = 1024
+
+	// The average time between blocks, used to turn a number of blocks into a duration
+	SecondsPerBlock uint64 = 12
+
+	// Transactions are forced once their deadline is this close, so they're included before it passes
+	DeadlineMargin time.Duration = 30 * time.Minute
+
+	ScheduleVersion  uint64      = 1
+	scheduleFileMode os.FileMode = 0644
+)
+
+// The kind of transaction that's been deferred
+type ActionType string
+
+const (
+	ActionType_Stake              ActionType = "stake"
+	ActionType_Promote            ActionType = "promote"
+	ActionType_DistributeMinipool ActionType = "distributeMinipool"
+	ActionType_ReduceBond         ActionType = "reduceBond"
+	ActionType_DistributeFees     ActionType = "distributeFees"
+	ActionType_ClaimRewards       ActionType = "claimRewards"
+)
+
+// A transaction the daemon wants to submit
+type Request struct {
+	// The kind of transaction
+	Type ActionType
+
+	// Identifies the transaction among others of the same type, such as a minipool address
+	Key string
+
+	// A human-readable description of the transaction
+	Description string
+
+	// The latest time the transaction can be submitted; zero if it doesn't have one
+	Deadline time.Time
+
+	// The gas limit of the transaction
+	GasLimit uint64
+
+	// The max fee the transaction would be submitted with right now
+	MaxFeeWei *big.Int
+}
+
+// A transaction that's waiting for gas to become cheap enough
+type Action struct {
+	Type                ActionType `json:"type"`
+	Key                 string     `json:"key"`
+	Description         string     `json:"description"`
+	FirstSeen           time.Time  `json:"firstSeen"`
+	Deadline            time.Time  `json:"deadline"`
+	TargetMaxFeeGwei    float64    `json:"targetMaxFeeGwei"`
+	MaxCostEth          float64    `json:"maxCostEth"`
+	GasLimit            uint64     `json:"gasLimit"`
+	LastChecked         time.Time  `json:"lastChecked"`
+	LastMaxFeeGwei      float64    `json:"lastMaxFeeGwei"`
+	LastCostEth         float64    `json:"lastCostEth"`
+	EstimatedSubmission time.Time  `json:"estimatedSubmission"`
+	Reason              string     `json:"reason"`
+}
+
+// The transactions the daemon is waiting to submit, as saved to disk
+type Schedule struct {
+	Version     uint64    `json:"version"`
+	UpdatedTime time.Time `json:"updatedTime"`
+	Actions     []*Action `json:"actions"`
+}
+
+// What to do with a transaction right now
+type Decision struct {
+	// True if the transaction should be submitted now
+	Submit bool
+
+	// True if the transaction is being submitted because its deadline is close, regardless of the gas price
+	Forced bool
+
+	// Why the transaction is being submitted or deferred
+	Reason string
+
+	// When the transaction is expected to be submitted if it's deferred; zero if there's no estimate
+	EstimatedSubmission time.Time
+}
+
+// Decides when the daemon's automatic transactions are submitted, based on their deadline, a target gas price, and a maximum cost.
+// Transactions that can't be submitted yet are recorded in a schedule so the user can see them.
+type Scheduler struct {
+	cfg     *config.RocketPoolConfig
+	ec      feehistory.Client
+	path    string
+	actions map[string]*Action
+	lock    *sync.Mutex
+}
+
+// Create a new scheduler, loading any transactions that were deferred before the daemon restarted.
+// The fee history client is optional; without it, the scheduler can't estimate when deferred transactions will be submitted.
+func NewScheduler(cfg *config.RocketPoolConfig, ec feehistory.Client) (*Scheduler, error) {
+	path := cfg.Smartnode.GetDeferredTxSchedulePath()
+	schedule, err := LoadSchedule(path)
+	if err != nil {
+		return nil, err
+	}
+
+	actions := map[string]*Action{}
+	if schedule != nil {
+		for _, action := range schedule.Actions {
+			actions[getActionID(action.Type, action.Key)] = action
+		}
+	}
+
+	return &Scheduler{
+		cfg:     cfg,
+		ec:      ec,
+		path:    path,
+		actions: actions,
+		lock:    &sync.Mutex{},
+	}, nil
+}
+
+// Check if a transaction should be submitted now or deferred until gas is cheaper
+func (s *Scheduler) Check(request Request, logger *log.ColorLogger) (Decision, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	targetMaxFeeGwei := s.cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	maxCostEth := s.cfg.Smartnode.AutoTxMaxCost.Value.(float64)
+	maxDeferral := time.Duration(s.cfg.Smartnode.AutoTxMaxDeferral.Value.(uint64)) * time.Hour
+
+	// Get the action, keeping the time it was first seen if it's already been deferred
+	id := getActionID(request.Type, request.Key)
+	action, exists := s.actions[id]
+	if !exists {
+		action = &Action{
+			Type:      request.Type,
+			Key:       request.Key,
+			FirstSeen: now,
+		}
+	}
+	action.Description = request.Description
+	action.TargetMaxFeeGwei = targetMaxFeeGwei
+	action.MaxCostEth = maxCostEth
+	action.GasLimit = request.GasLimit
+	action.LastChecked = now
+
+	// Use the earlier of the transaction's own deadline and the max deferral
+	action.Deadline = request.Deadline
+	if maxDeferral > 0 {
+		deferralDeadline := action.FirstSeen.Add(maxDeferral)
+		if action.Deadline.IsZero() || deferralDeadline.Before(action.Deadline) {
+			action.Deadline = deferralDeadline
+		}
+	}
+
+	// Get the cost at the current max fee
+	gasLimit := big.NewInt(0).SetUint64(request.GasLimit)
+	cost := big.NewInt(0).Mul(request.MaxFeeWei, gasLimit)
+	action.LastMaxFeeGwei = eth.WeiToGwei(request.MaxFeeWei)
+	action.LastCostEth = eth.WeiToEth(cost)
+	logger.Printlnf("This transaction will use a max fee of %.6f Gwei, for a total of up to %.6f ETH.", action.LastMaxFeeGwei, action.LastCostEth)
+
+	// Submit if the deadline is close
+	var decision Decision
+	if !action.Deadline.IsZero() && now.Add(DeadlineMargin).After(action.Deadline) {
+		decision = Decision{
+			Submit: true,
+			Forced: true,
+			Reason: fmt.Sprintf("its deadline of %s is close", action.Deadline.Format(time.RFC822)),
+		}
+		logger.Printlnf("NOTICE: This transaction will be submitted at the current gas price because %s.", decision.Reason)
+		return decision, s.remove(id)
+	}
+
+	// Submit if the gas price and cost are acceptable
+	targetMaxFee := eth.GweiToWei(targetMaxFeeGwei)
+	maxCost := eth.EthToWei(maxCostEth)
+	isBelowTarget := request.MaxFeeWei.Cmp(targetMaxFee) < 0
+	isWithinCost := maxCostEth == 0 || cost.Cmp(maxCost) <= 0
+	if isBelowTarget && isWithinCost {
+		decision = Decision{
+			Submit: true,
+			Reason: "the gas price and cost are acceptable",
+		}
+		return decision, s.remove(id)
+	}
+
+	// Defer the transaction
+	if !isBelowTarget {
+		decision.Reason = fmt.Sprintf("the max fee of %.2f Gwei is not lower than the target of %.2f Gwei", action.LastMaxFeeGwei, targetMaxFeeGwei)
+	} else {
+		decision.Reason = fmt.Sprintf("the cost of %.6f ETH is higher than the limit of %.6f ETH", action.LastCostEth, maxCostEth)
+	}
+	estimatedWait, err := s.estimateWait(request, targetMaxFee, maxCost)
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't estimate when the gas price will be low enough: %s", err.Error())
+	}
+	if estimatedWait > 0 {
+		decision.EstimatedSubmission = now.Add(estimatedWait)
+	}
+	if !action.Deadline.IsZero() {
+		forceTime := action.Deadline.Add(-DeadlineMargin)
+		if decision.EstimatedSubmission.IsZero() || decision.EstimatedSubmission.After(forceTime) {
+			decision.EstimatedSubmission = forceTime
+		}
+	}
+	action.EstimatedSubmission = decision.EstimatedSubmission
+	action.Reason = decision.Reason
+	logger.Printlnf("Deferring this transaction because %s.", decision.Reason)
+	if decision.EstimatedSubmission.IsZero() {
+		logger.Println("There isn't enough recent gas price history to estimate when it will be submitted.")
+	} else {
+		logger.Printlnf("It's expected to be submitted around %s.", decision.EstimatedSubmission.Format(time.RFC822))
+	}
+	s.actions[id] = action
+	return decision, s.save()
+}
+
+// Remove any deferred transactions of the provided type that aren't in the list of keys, such as ones that were submitted manually
+func (s *Scheduler) Retain(actionType ActionType, keys []string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	keep := map[string]bool{}
+	for _, key := range keys {
+		keep[getActionID(actionType, key)] = true
+	}
+
+	changed := false
+	for id, action := range s.actions {
+		if action.Type == actionType && !keep[id] {
+			delete(s.actions, id)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return s.save()
+}
+
+// Remove a deferred transaction that doesn't need to be submitted any more
+func (s *Scheduler) Remove(actionType ActionType, key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.remove(getActionID(actionType, key))
+}
+
+// Estimate how long it will take until the transaction's max fee and cost are acceptable.
+// Each recent block's base fee is scaled by the ratio of the current max fee to the current base fee, which is what the max fee would have been at that block.
+// The estimate is the average wait from each of those blocks until the next block where the transaction would have been submitted.
+func (s *Scheduler) estimateWait(request Request, targetMaxFee *big.Int, maxCost *big.Int) (time.Duration, error) {
+	if s.ec == nil {
+		return 0, nil
+	}
+	history, err := s.ec.FeeHistory(context.Background(), HistoryBlockCount, nil, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error getting fee history: %w", err)
+	}
+	if len(history.BaseFee) < 2 {
+		return 0, nil
+	}
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+	if nextBaseFee.Sign() == 0 {
+		return 0, nil
+	}
+
+	// Find the blocks where the transaction would have been submitted
+	pastBaseFees := history.BaseFee[:len(history.BaseFee)-1]
+	gasLimit := big.NewInt(0).SetUint64(request.GasLimit)
+	isAcceptable := make([]bool, len(pastBaseFees))
+	for i, baseFee := range pastBaseFees {
+		maxFee := big.NewInt(0).Mul(baseFee, request.MaxFeeWei)
+		maxFee.Div(maxFee, nextBaseFee)
+		cost := big.NewInt(0).Mul(maxFee, gasLimit)
+		isAcceptable[i] = maxFee.Cmp(targetMaxFee) < 0 && (maxCost.Sign() == 0 || cost.Cmp(maxCost) <= 0)
+	}
+
+	// Get the average wait until the next acceptable block
+	totalWait := uint64(0)
+	samples := uint64(0)
+	nextAcceptable := -1
+	for i := len(isAcceptable) - 1; i >= 0; i-- {
+		if isAcceptable[i] {
+			nextAcceptable = i
+		}
+		if nextAcceptable >= 0 {
+			totalWait += uint64(nextAcceptable - i)
+			samples++
+		}
+	}
+	if samples == 0 {
+		// There weren't any acceptable blocks, so there's no estimate
+		return 0, nil
+	}
+	averageWaitBlocks := totalWait / samples
+	if averageWaitBlocks == 0 {
+		averageWaitBlocks = 1
+	}
+	return time.Duration(averageWaitBlocks*SecondsPerBlock) * time.Second, nil
+}
+
+// Remove a transaction from the schedule once it's been submitted
+func (s *Scheduler) remove(id string) error {
+	if _, exists := s.actions[id]; !exists {
+		return nil
+	}
+	delete(s.actions, id)
+	return s.save()
+}
+
+// Save the schedule to disk
+func (s *Scheduler) save() error {
+	schedule := Schedule{
+		Version:     ScheduleVersion,
+		UpdatedTime: time.Now(),
+		Actions:     make([]*Action, 0, len(s.actions)),
+	}
+	for _, action := range s.actions {
+		schedule.Actions = append(schedule.Actions, action)
+	}
+	sort.Slice(schedule.Actions, func(i, j int) bool {
+		return schedule.Actions[i].FirstSeen.Before(schedule.Actions[j].FirstSeen)
+	})
+	return SaveSchedule(s.path, schedule)
+}
+
+// Save a schedule of deferred transactions
+func SaveSchedule(path string, schedule Schedule) error {
+	bytes, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("error serializing deferred transaction schedule: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating deferred transaction schedule folder: %w", err)
+	}
+
+	// Write to a temp file first so the schedule is never left half-written
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, scheduleFileMode); err != nil {
+		return fmt.Errorf("error writing deferred transaction schedule: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving deferred transaction schedule: %w", err)
+	}
+	return nil
+}
+
+// Load a schedule of deferred transactions, returning nil if it hasn't been saved yet
+func LoadSchedule(path string) (*Schedule, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading deferred transaction schedule: %w", err)
+	}
+	schedule := &Schedule{}
+	if err := json.Unmarshal(bytes, schedule); err != nil {
+		return nil, fmt.Errorf("error deserializing deferred transaction schedule: %w", err)
+	}
+	if schedule.Version != ScheduleVersion {
+		return nil, fmt.Errorf("deferred transaction schedule has unsupported version %d (expected %d)", schedule.Version, ScheduleVersion)
+	}
+	return schedule, nil
+}
+
+// Get the unique ID of an action in the schedule
+func getActionID(actionType ActionType, key string) string {
+	return fmt.Sprintf("%s:%s", actionType, key)
+}
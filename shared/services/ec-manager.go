@@ -144,6 +144,18 @@ func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big
 	return result.(*types.Header), err
 }
 
+// BlockByNumber returns a block from the current canonical chain. If number is nil, the
+// latest known block is returned.
+func (p *ExecutionClientManager) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Block), err
+}
+
 // PendingCodeAt returns the code of the given account in the pending state.
 func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
 	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
@@ -190,6 +202,17 @@ func (p *ExecutionClientManager) SuggestGasTipCap(ctx context.Context) (*big.Int
 	return result.(*big.Int), err
 }
 
+// FeeHistory retrieves the fee market history.
+func (p *ExecutionClientManager) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*ethereum.FeeHistory), err
+}
+
 // EstimateGas tries to estimate the gas needed to execute a specific
 // transaction based on the current pending state of the backend blockchain.
 // There is no guarantee that this is the true gas limit requirement as other
@@ -404,6 +427,11 @@ func checkEcStatus(client *ethclient.Client) api.ClientStatus {
 		status.NetworkId = uint(networkId.Uint64())
 	}
 
+	// Get the peer count; not every client supports it, so it isn't required for the client to be working
+	if peerCount, err := client.PeerCount(context.Background()); err == nil {
+		status.PeerCount = peerCount
+	}
+
 	// Get the fallback's sync progress
 	progress, err := client.SyncProgress(context.Background())
 	if err != nil {
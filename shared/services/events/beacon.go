@@ -0,0 +1,92 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The delay before resubscribing after the event stream fails; it doubles after each failure up to the max
+	BeaconReconnectDelay    time.Duration = 15 * time.Second
+	BeaconMaxReconnectDelay time.Duration = 5 * time.Minute
+
+	// A head event arrives every slot, so a stream that's been silent for a few slots has stalled and is resubscribed
+	BeaconIdleTimeout time.Duration = 60 * time.Second
+
+	// Reorgs shallower than this are common and don't change anything the daemons have acted on
+	MinReorgDepth uint64 = 2
+)
+
+// The Beacon node event topics the daemons react to
+var beaconTopics = []beacon.EventTopic{
+	beacon.EventTopic_Head,
+	beacon.EventTopic_FinalizedCheckpoint,
+	beacon.EventTopic_ChainReorg,
+}
+
+// Publish triggers for the Beacon node's head, finalized checkpoint, and chain reorg events.
+// The subscription is retried forever, including when it stays open but stops delivering events; while it's down, the daemons fall back to polling.
+func WatchBeaconEvents(bc beacon.Client, bus *Bus, logger *log.ColorLogger) {
+	delay := BeaconReconnectDelay
+	for {
+		eventChannel := make(chan beacon.Event)
+		done := make(chan error, 1)
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			done <- bc.SubscribeEvents(ctx, beaconTopics, eventChannel)
+		}()
+
+		// Publish events until the subscription ends
+		var err error
+		receivedEvents := false
+		for running := true; running; {
+			select {
+			case event := <-eventChannel:
+				if !receivedEvents {
+					logger.Println("Receiving events from the Beacon node.")
+					receivedEvents = true
+					delay = BeaconReconnectDelay
+				}
+				publishBeaconEvent(bus, event)
+			case err = <-done:
+				running = false
+			case <-time.After(BeaconIdleTimeout):
+				cancel()
+				<-done
+				err = fmt.Errorf("no events were received for %s", BeaconIdleTimeout)
+				running = false
+			}
+		}
+		cancel()
+
+		if err != nil {
+			logger.Printlnf("WARNING: Beacon node event subscription failed (%s); polling until it's restored.", err.Error())
+		} else {
+			logger.Println("WARNING: Beacon node event subscription ended; polling until it's restored.")
+		}
+		time.Sleep(delay)
+		delay *= 2
+		if delay > BeaconMaxReconnectDelay {
+			delay = BeaconMaxReconnectDelay
+		}
+	}
+}
+
+// Publish the trigger for a Beacon node event
+func publishBeaconEvent(bus *Bus, event beacon.Event) {
+	switch event.Topic {
+	case beacon.EventTopic_Head:
+		bus.Publish(Trigger_Head)
+	case beacon.EventTopic_FinalizedCheckpoint:
+		bus.Publish(Trigger_FinalizedEpoch)
+	case beacon.EventTopic_ChainReorg:
+		if event.Depth >= MinReorgDepth {
+			bus.Publish(Trigger_ChainReorg)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Something that happened on the chain that daemon tasks can react to
+type Trigger string
+
+const (
+	// A new block was added to the head of the Beacon Chain
+	Trigger_Head Trigger = "head"
+
+	// A new epoch was finalized
+	Trigger_FinalizedEpoch Trigger = "finalizedEpoch"
+
+	// The Beacon Chain reorged deeply enough that any state derived from the head may be wrong
+	Trigger_ChainReorg Trigger = "chainReorg"
+
+	// A minipool deposited to the Beacon Chain and entered prelaunch
+	Trigger_MinipoolPrestaked Trigger = "minipoolPrestaked"
+
+	// A rewards interval finished and its Merkle root was submitted
+	Trigger_RewardSnapshot Trigger = "rewardSnapshot"
+
+	// A member of the Oracle DAO was challenged
+	Trigger_OdaoChallengeMade Trigger = "odaoChallengeMade"
+
+	// A Protocol DAO proposal's voting tree root was submitted
+	Trigger_PdaoRootSubmitted Trigger = "pdaoRootSubmitted"
+
+	// A Protocol DAO proposal's voting tree root was challenged
+	Trigger_PdaoChallengeSubmitted Trigger = "pdaoChallengeSubmitted"
+
+	// A minipool's scrub period ended, so it can be staked or promoted
+	Trigger_ScrubPeriodEnded Trigger = "scrubPeriodEnded"
+
+	// The daemon's copy of the network state was updated
+	Trigger_NetworkStateUpdated Trigger = "networkStateUpdated"
+)
+
+// Delivers triggers to the tasks that are waiting for them.
+// Triggers are coalesced: a subscriber that hasn't handled its last wake-up yet won't receive another one.
+type Bus struct {
+	lock        *sync.Mutex
+	subscribers map[Trigger][]chan struct{}
+	timers      map[Trigger]*scheduledTrigger
+}
+
+// A trigger that will be published in the future
+type scheduledTrigger struct {
+	time  time.Time
+	timer *time.Timer
+}
+
+// Create a new trigger bus
+func NewBus() *Bus {
+	return &Bus{
+		lock:        &sync.Mutex{},
+		subscribers: map[Trigger][]chan struct{}{},
+		timers:      map[Trigger]*scheduledTrigger{},
+	}
+}
+
+// Get a channel that receives a value whenever any of the provided triggers are published
+func (b *Bus) Subscribe(triggers ...Trigger) <-chan struct{} {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	wake := make(chan struct{}, 1)
+	for _, trigger := range triggers {
+		b.subscribers[trigger] = append(b.subscribers[trigger], wake)
+	}
+	return wake
+}
+
+// Wake every subscriber of the trigger
+func (b *Bus) Publish(trigger Trigger) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for _, wake := range b.subscribers[trigger] {
+		select {
+		case wake <- struct{}{}:
+		default:
+			// The subscriber already has a pending wake-up
+		}
+	}
+}
+
+// Publish the trigger at the provided time. If the trigger is already scheduled, only the earlier of the two times is kept.
+func (b *Bus) PublishAt(trigger Trigger, at time.Time) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	scheduled, exists := b.timers[trigger]
+	if exists {
+		if !at.Before(scheduled.time) {
+			return
+		}
+		scheduled.timer.Stop()
+	}
+
+	scheduled = &scheduledTrigger{
+		time: at,
+	}
+	scheduled.timer = time.AfterFunc(time.Until(at), func() {
+		b.lock.Lock()
+		if b.timers[trigger] == scheduled {
+			delete(b.timers, trigger)
+		}
+		b.lock.Unlock()
+		b.Publish(trigger)
+	})
+	b.timers[trigger] = scheduled
+}
+
+// Check if a subscription has a pending wake-up, consuming it if so
+func IsTriggered(wake <-chan struct{}) bool {
+	if wake == nil {
+		return false
+	}
+	select {
+	case <-wake:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,222 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// How often to check for new logs if there aren't any head events from the Beacon node
+	LogPollInterval time.Duration = time.Minute
+
+	// The most blocks to check for logs at once; if the watcher falls further behind than this, the blocks are checked in pages
+	MaxLogBlockRange uint64 = 1000
+
+	// How long to wait before trying to load a contract's ABI again after it failed
+	AbiRetryInterval time.Duration = 5 * time.Minute
+)
+
+// A Rocket Pool contract event that publishes a trigger
+type logEvent struct {
+	trigger      Trigger
+	contractName string
+	eventName    string
+
+	// True if the event is emitted by the named contract itself; minipool events are emitted by each minipool instead
+	checkAddress bool
+}
+
+// The contract events the daemons react to
+var logEvents = []logEvent{
+	{Trigger_MinipoolPrestaked, "rocketMinipool", "MinipoolPrestaked", false},
+	{Trigger_RewardSnapshot, "rocketRewardsPool", "RewardSnapshot", true},
+	{Trigger_OdaoChallengeMade, "rocketDAONodeTrustedActions", "ActionChallengeMade", true},
+	{Trigger_PdaoRootSubmitted, "rocketDAOProtocolVerifier", "RootSubmitted", true},
+	{Trigger_PdaoChallengeSubmitted, "rocketDAOProtocolVerifier", "ChallengeSubmitted", true},
+}
+
+// Watches new blocks for Rocket Pool contract events
+type logWatcher struct {
+	rp        *rocketpool.RocketPool
+	bus       *Bus
+	log       *log.ColorLogger
+	getAbi    func(contractName string) (*abi.ABI, error)
+	topics    map[common.Hash]logEvent
+	addresses map[string]common.Address
+	lastBlock uint64
+
+	// Events the contract's ABI doesn't have, which are never watched
+	missing map[string]bool
+
+	// When to try loading an event's ABI again after it failed
+	retryTimes map[string]time.Time
+}
+
+// Publish triggers for Rocket Pool contract events.
+// New blocks are checked whenever the Beacon Chain head changes, and on a fixed interval in case head events aren't available.
+func WatchLogs(rp *rocketpool.RocketPool, bus *Bus, logger *log.ColorLogger) {
+	watcher := &logWatcher{
+		rp:  rp,
+		bus: bus,
+		log: logger,
+		getAbi: func(contractName string) (*abi.ABI, error) {
+			return rp.GetABI(contractName, nil)
+		},
+		topics:     map[common.Hash]logEvent{},
+		addresses:  map[string]common.Address{},
+		missing:    map[string]bool{},
+		retryTimes: map[string]time.Time{},
+	}
+	head := bus.Subscribe(Trigger_Head)
+	ticker := time.NewTicker(LogPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := watcher.check(); err != nil {
+			logger.Printlnf("WARNING: couldn't check for Rocket Pool events: %s", err.Error())
+		}
+		select {
+		case <-head:
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check the blocks since the last check for contract events
+func (w *logWatcher) check() error {
+
+	// Get the topics of any events that haven't been loaded yet, such as ones from contracts that were deployed after the daemon started
+	w.loadTopics()
+	if len(w.topics) == 0 {
+		return nil
+	}
+
+	// Get the blocks to check
+	latestBlock, err := w.rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return fmt.Errorf("error getting latest block: %w", err)
+	}
+	if w.lastBlock == 0 || latestBlock < w.lastBlock {
+		// Only watch for new events
+		w.lastBlock = latestBlock
+		return nil
+	}
+	if latestBlock == w.lastBlock {
+		return nil
+	}
+
+	// Get the logs, a page at a time if the watcher has fallen behind
+	topics := make([]common.Hash, 0, len(w.topics))
+	for topic := range w.topics {
+		topics = append(topics, topic)
+	}
+	published := map[Trigger]bool{}
+	refreshed := map[string]bool{}
+	for fromBlock := w.lastBlock + 1; fromBlock <= latestBlock; fromBlock += MaxLogBlockRange {
+		toBlock := fromBlock + MaxLogBlockRange - 1
+		if toBlock > latestBlock {
+			toBlock = latestBlock
+		}
+		logs, err := w.rp.Client.FilterLogs(context.Background(), ethereum.FilterQuery{
+			FromBlock: big.NewInt(0).SetUint64(fromBlock),
+			ToBlock:   big.NewInt(0).SetUint64(toBlock),
+			Topics:    [][]common.Hash{topics},
+		})
+		if err != nil {
+			return fmt.Errorf("error getting logs for blocks %d to %d: %w", fromBlock, toBlock, err)
+		}
+
+		// Publish a trigger for each event type that was found
+		for _, entry := range logs {
+			if len(entry.Topics) == 0 || entry.Removed {
+				continue
+			}
+			event, exists := w.topics[entry.Topics[0]]
+			if !exists || published[event.trigger] {
+				continue
+			}
+			if event.checkAddress {
+				isMatch, err := w.isContractAddress(event.contractName, entry.Address, refreshed)
+				if err != nil {
+					return err
+				}
+				if !isMatch {
+					continue
+				}
+			}
+			w.bus.Publish(event.trigger)
+			published[event.trigger] = true
+		}
+
+		// Only move past the blocks that were checked, so a failure on a later page is retried from there
+		w.lastBlock = toBlock
+	}
+	return nil
+
+}
+
+// Check if an address belongs to the named contract. Addresses are cached, and only looked up again (at most once per check)
+// when a log comes from a different address, since the contract may have been upgraded.
+func (w *logWatcher) isContractAddress(contractName string, address common.Address, refreshed map[string]bool) (bool, error) {
+	cached, exists := w.addresses[contractName]
+	if exists && cached == address {
+		return true, nil
+	}
+	if exists && refreshed[contractName] {
+		return false, nil
+	}
+
+	contractAddress, err := w.rp.GetAddress(contractName, nil)
+	if err != nil {
+		return false, fmt.Errorf("error getting address of %s: %w", contractName, err)
+	}
+	w.addresses[contractName] = *contractAddress
+	refreshed[contractName] = true
+	return *contractAddress == address, nil
+}
+
+// Load the topic of each contract event from its ABI.
+// ABIs that fail to load are tried again after AbiRetryInterval, so a transient error doesn't stop an event from being watched.
+func (w *logWatcher) loadTopics() {
+	for _, event := range logEvents {
+		key := event.contractName + event.eventName
+		if w.missing[key] || time.Now().Before(w.retryTimes[key]) {
+			continue
+		}
+		loaded := false
+		for _, existing := range w.topics {
+			if existing.trigger == event.trigger {
+				loaded = true
+				break
+			}
+		}
+		if loaded {
+			continue
+		}
+
+		contractAbi, err := w.getAbi(event.contractName)
+		if err != nil {
+			w.log.Printlnf("WARNING: couldn't load the ABI for %s, so its %s events won't be watched until it's tried again in %s: %s", event.contractName, event.eventName, AbiRetryInterval, err.Error())
+			w.retryTimes[key] = time.Now().Add(AbiRetryInterval)
+			continue
+		}
+		delete(w.retryTimes, key)
+		abiEvent, exists := contractAbi.Events[event.eventName]
+		if !exists {
+			w.log.Printlnf("WARNING: %s doesn't have a %s event, so it won't be watched.", event.contractName, event.eventName)
+			w.missing[key] = true
+			continue
+		}
+		w.topics[abiEvent.ID] = event
+	}
+}
@@ -0,0 +1,104 @@
+package events
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// An ABI with every event the watcher looks for
+const testEventsAbi string = `[
+	{"type": "event", "name": "MinipoolPrestaked", "inputs": []},
+	{"type": "event", "name": "RewardSnapshot", "inputs": []},
+	{"type": "event", "name": "ActionChallengeMade", "inputs": []},
+	{"type": "event", "name": "RootSubmitted", "inputs": []},
+	{"type": "event", "name": "ChallengeSubmitted", "inputs": []}
+]`
+
+func newTestLogWatcher(getAbi func(contractName string) (*abi.ABI, error)) *logWatcher {
+	logger := log.NewColorLogger(color.FgRed)
+	return &logWatcher{
+		log:        &logger,
+		getAbi:     getAbi,
+		topics:     map[common.Hash]logEvent{},
+		addresses:  map[string]common.Address{},
+		missing:    map[string]bool{},
+		retryTimes: map[string]time.Time{},
+	}
+}
+
+func parseTestAbi(t *testing.T, definition string) *abi.ABI {
+	contractAbi, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		t.Fatalf("error parsing ABI: %s", err.Error())
+	}
+	return &contractAbi
+}
+
+func TestLoadTopicsRetriesFailedAbis(t *testing.T) {
+	contractAbi := parseTestAbi(t, testEventsAbi)
+	calls := map[string]int{}
+	failing := true
+	w := newTestLogWatcher(func(contractName string) (*abi.ABI, error) {
+		calls[contractName]++
+		if failing && contractName == "rocketDAOProtocolVerifier" {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return contractAbi, nil
+	})
+
+	// The failed events aren't watched yet
+	w.loadTopics()
+	if len(w.topics) != len(logEvents)-2 {
+		t.Fatalf("loaded %d topics, expected %d", len(w.topics), len(logEvents)-2)
+	}
+	if w.missing["rocketDAOProtocolVerifierRootSubmitted"] {
+		t.Fatal("an ABI load error marked the event as missing")
+	}
+
+	// They aren't tried again until the retry interval has passed
+	w.loadTopics()
+	if calls["rocketDAOProtocolVerifier"] != 2 {
+		t.Fatalf("loaded the ABI %d times before the retry interval, expected 2", calls["rocketDAOProtocolVerifier"])
+	}
+
+	// Once it has, they're loaded
+	failing = false
+	for key := range w.retryTimes {
+		w.retryTimes[key] = time.Now().Add(-time.Second)
+	}
+	w.loadTopics()
+	if len(w.topics) != len(logEvents) {
+		t.Fatalf("loaded %d topics after the retry, expected %d", len(w.topics), len(logEvents))
+	}
+	if len(w.retryTimes) != 0 {
+		t.Errorf("%d events are still waiting to be retried", len(w.retryTimes))
+	}
+}
+
+func TestLoadTopicsSkipsMissingEvents(t *testing.T) {
+	contractAbi := parseTestAbi(t, `[{"type": "event", "name": "MinipoolPrestaked", "inputs": []}]`)
+	calls := 0
+	w := newTestLogWatcher(func(contractName string) (*abi.ABI, error) {
+		calls++
+		return contractAbi, nil
+	})
+
+	w.loadTopics()
+	if len(w.topics) != 1 {
+		t.Fatalf("loaded %d topics, expected 1", len(w.topics))
+	}
+
+	// Events the ABI doesn't have are never looked up again
+	w.loadTopics()
+	if calls != len(logEvents) {
+		t.Errorf("loaded ABIs %d times, expected %d", calls, len(logEvents))
+	}
+}
@@ -0,0 +1,139 @@
+package feehistory
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// Settings
+const (
+	// The number of recent blocks to sample
+	BlockCount uint64 = 20
+
+	// The number of blocks the fast and standard tiers' max fees must cover a full base fee increase for
+	FastBaseFeeBlocks     int = 3
+	StandardBaseFeeBlocks int = 1
+)
+
+// The priority fee percentiles of the slow, standard and fast tiers
+var rewardPercentiles = []float64{10, 50, 90}
+
+// The most the base fee can increase by in a single block, as a fraction (EIP-1559)
+var maxBaseFeeChangeNumerator = big.NewInt(9)
+var maxBaseFeeChangeDenominator = big.NewInt(8)
+
+// A client that can read the fee history of the chain
+type Client interface {
+	FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error)
+}
+
+// The suggested fees for a speed tier
+type FeeTier struct {
+	BaseFeeWei     *big.Int `json:"baseFeeWei"`
+	PriorityFeeWei *big.Int `json:"priorityFeeWei"`
+	MaxFeeWei      *big.Int `json:"maxFeeWei"`
+}
+
+// The suggested fees based on the recent blocks of the chain
+type GasFeeSuggestion struct {
+	NewestBlock    uint64   `json:"newestBlock"`
+	NextBaseFeeWei *big.Int `json:"nextBaseFeeWei"`
+	Fast           FeeTier  `json:"fast"`
+	Standard       FeeTier  `json:"standard"`
+	Slow           FeeTier  `json:"slow"`
+}
+
+// Get gas prices from the fee history of the recent blocks.
+// The fast and standard tiers can pay the worst-case base fee after a few full blocks; the slow tier pays the lower of the next base fee and the recent average, so it may wait until fees drop back.
+func GetGasPrices(client Client) (GasFeeSuggestion, error) {
+
+	// Get the fee history
+	history, err := client.FeeHistory(context.Background(), BlockCount, nil, rewardPercentiles)
+	if err != nil {
+		return GasFeeSuggestion{}, fmt.Errorf("error getting fee history: %w", err)
+	}
+	if len(history.BaseFee) == 0 {
+		return GasFeeSuggestion{}, fmt.Errorf("fee history did not contain any base fees")
+	}
+
+	// The last base fee is the one for the next block
+	nextBaseFee := history.BaseFee[len(history.BaseFee)-1]
+	pastBaseFees := history.BaseFee[:len(history.BaseFee)-1]
+	averageBaseFee := big.NewInt(0).Set(nextBaseFee)
+	if len(pastBaseFees) > 0 {
+		averageBaseFee.SetUint64(0)
+		for _, baseFee := range pastBaseFees {
+			averageBaseFee.Add(averageBaseFee, baseFee)
+		}
+		averageBaseFee.Div(averageBaseFee, big.NewInt(int64(len(pastBaseFees))))
+	}
+	newestBlock := big.NewInt(0).Add(history.OldestBlock, big.NewInt(int64(len(pastBaseFees)-1)))
+
+	// Get the base fee each tier needs to cover
+	slowBaseFee := averageBaseFee
+	if slowBaseFee.Cmp(nextBaseFee) > 0 {
+		slowBaseFee = nextBaseFee
+	}
+	standardBaseFee := getMaxBaseFee(nextBaseFee, StandardBaseFeeBlocks)
+	fastBaseFee := getMaxBaseFee(nextBaseFee, FastBaseFeeBlocks)
+
+	// Get the median priority fee of each percentile across the recent blocks
+	priorityFees := make([]*big.Int, len(rewardPercentiles))
+	for i := range rewardPercentiles {
+		priorityFees[i] = getMedianReward(history.Reward, i)
+	}
+
+	return GasFeeSuggestion{
+		NewestBlock:    newestBlock.Uint64(),
+		NextBaseFeeWei: nextBaseFee,
+		Slow:           newFeeTier(slowBaseFee, priorityFees[0]),
+		Standard:       newFeeTier(standardBaseFee, priorityFees[1]),
+		Fast:           newFeeTier(fastBaseFee, priorityFees[2]),
+	}, nil
+
+}
+
+// Create a fee tier from its base fee and priority fee
+func newFeeTier(baseFee *big.Int, priorityFee *big.Int) FeeTier {
+	return FeeTier{
+		BaseFeeWei:     baseFee,
+		PriorityFeeWei: priorityFee,
+		MaxFeeWei:      big.NewInt(0).Add(baseFee, priorityFee),
+	}
+}
+
+// Get the highest the base fee can be after the provided number of full blocks
+func getMaxBaseFee(baseFee *big.Int, blocks int) *big.Int {
+	maxBaseFee := big.NewInt(0).Set(baseFee)
+	for i := 0; i < blocks; i++ {
+		maxBaseFee.Mul(maxBaseFee, maxBaseFeeChangeNumerator)
+		maxBaseFee.Div(maxBaseFee, maxBaseFeeChangeDenominator)
+	}
+	return maxBaseFee
+}
+
+// Get the median of a reward percentile across blocks, ignoring blocks that didn't report it
+func getMedianReward(rewards [][]*big.Int, percentileIndex int) *big.Int {
+	values := []*big.Int{}
+	for _, blockRewards := range rewards {
+		if percentileIndex < len(blockRewards) && blockRewards[percentileIndex] != nil {
+			values = append(values, blockRewards[percentileIndex])
+		}
+	}
+	if len(values) == 0 {
+		return big.NewInt(0)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i].Cmp(values[j]) < 0
+	})
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return big.NewInt(0).Set(values[middle])
+	}
+	median := big.NewInt(0).Add(values[middle-1], values[middle])
+	return median.Div(median, big.NewInt(2))
+}
@@ -7,9 +7,12 @@ import (
 
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherchain"
 	"github.com/rocket-pool/smartnode/shared/services/gas/etherscan"
+	"github.com/rocket-pool/smartnode/shared/services/gas/feehistory"
 	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 	"github.com/rocket-pool/smartnode/shared/utils/math"
 )
@@ -87,28 +90,21 @@ func GetMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless bo
 
 	} else {
 		if headless {
-			maxFeeWei, err := GetHeadlessMaxFeeWei()
+			maxFeeWei, err := getHeadlessMaxFeeWei(cfg, func() (*big.Int, error) {
+				response, err := rp.GetGasEstimate()
+				if err != nil {
+					return nil, err
+				}
+				return response.Suggestion.Fast.MaxFeeWei, nil
+			})
 			if err != nil {
 				return Gas{}, err
 			}
 			maxFeeGwei = eth.WeiToGwei(maxFeeWei)
 		} else {
-			// Try to get the latest gas prices from Etherchain
-			etherchainData, err := etherchain.GetGasPrices()
-			if err == nil {
-				// Print the Etherchain data and ask for an amount
-				maxFeeGwei = handleEtherchainGasPrices(etherchainData, gasInfo, maxPriorityFeeGwei, gasLimit)
-
-			} else {
-				// Fallback to Etherscan
-				fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
-				etherscanData, err := etherscan.GetGasPrices()
-				if err == nil {
-					// Print the Etherscan data and ask for an amount
-					maxFeeGwei = handleEtherscanGasPrices(etherscanData, gasInfo, maxPriorityFeeGwei, gasLimit)
-				} else {
-					return Gas{}, fmt.Errorf("Error getting gas price suggestions: %w", err)
-				}
+			maxFeeGwei, err = getInteractiveMaxFeeGwei(cfg, rp, gasInfo, maxPriorityFeeGwei, gasLimit)
+			if err != nil {
+				return Gas{}, err
 			}
 		}
 		fmt.Printf("%sUsing a max fee of %.2f gwei and a priority fee of %.2f gwei.\n%s", colorBlue, maxFeeGwei, maxPriorityFeeGwei, colorReset)
@@ -141,7 +137,41 @@ func GetMaxFeeAndLimit(gasInfo rocketpool.GasInfo, rp *rpsvc.Client, headless bo
 }
 
 // Get the suggested max fee for service operations
-func GetHeadlessMaxFeeWei() (*big.Int, error) {
+func GetHeadlessMaxFeeWei(cfg *config.RocketPoolConfig, ec rocketpool.ExecutionClient) (*big.Int, error) {
+	return getHeadlessMaxFeeWei(cfg, func() (*big.Int, error) {
+		client, ok := ec.(feehistory.Client)
+		if !ok {
+			return nil, fmt.Errorf("the Execution Client does not support fee history")
+		}
+		suggestion, err := feehistory.GetGasPrices(client)
+		if err != nil {
+			return nil, err
+		}
+		return suggestion.Fast.MaxFeeWei, nil
+	})
+}
+
+// Get the suggested max fee for service operations from the preferred source, falling back to the other one if it's unavailable
+func getHeadlessMaxFeeWei(cfg *config.RocketPoolConfig, getLocalMaxFeeWei func() (*big.Int, error)) (*big.Int, error) {
+	if getGasEstimateSource(cfg) == cfgtypes.GasEstimateSource_External {
+		maxFee, err := getExternalHeadlessMaxFeeWei()
+		if err == nil {
+			return maxFee, nil
+		}
+		fmt.Printf("%sWarning: couldn't get gas estimates from the external oracles - %s\nFalling back to the Execution Client%s\n", colorYellow, err.Error(), colorReset)
+		return getLocalMaxFeeWei()
+	}
+
+	maxFee, err := getLocalMaxFeeWei()
+	if err == nil {
+		return maxFee, nil
+	}
+	fmt.Printf("%sWarning: couldn't get gas estimates from the Execution Client - %s\nFalling back to the external oracles%s\n", colorYellow, err.Error(), colorReset)
+	return getExternalHeadlessMaxFeeWei()
+}
+
+// Get the suggested max fee for service operations from the external oracles
+func getExternalHeadlessMaxFeeWei() (*big.Int, error) {
 	etherchainData, err := etherchain.GetGasPrices()
 	if err == nil {
 		return etherchainData.RapidWei, nil
@@ -156,6 +186,63 @@ func GetHeadlessMaxFeeWei() (*big.Int, error) {
 	}
 }
 
+// Show the suggested max fees from the preferred source and ask for one, falling back to the other source if it's unavailable
+func getInteractiveMaxFeeGwei(cfg *config.RocketPoolConfig, rp *rpsvc.Client, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) (float64, error) {
+	if getGasEstimateSource(cfg) == cfgtypes.GasEstimateSource_External {
+		maxFeeGwei, err := getExternalMaxFeeGwei(gasInfo, priorityFee, gasLimit)
+		if err == nil {
+			return maxFeeGwei, nil
+		}
+		fmt.Printf("%sWarning: couldn't get gas estimates from the external oracles - %s\nFalling back to your Execution Client%s\n", colorYellow, err.Error(), colorReset)
+		return getLocalMaxFeeGwei(rp, gasInfo, priorityFee, gasLimit)
+	}
+
+	maxFeeGwei, err := getLocalMaxFeeGwei(rp, gasInfo, priorityFee, gasLimit)
+	if err == nil {
+		return maxFeeGwei, nil
+	}
+	fmt.Printf("%sWarning: couldn't get gas estimates from your Execution Client - %s\nFalling back to the external oracles%s\n", colorYellow, err.Error(), colorReset)
+	return getExternalMaxFeeGwei(gasInfo, priorityFee, gasLimit)
+}
+
+// Show the suggested max fees from the Execution Client's fee history and ask for one
+func getLocalMaxFeeGwei(rp *rpsvc.Client, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) (float64, error) {
+	response, err := rp.GetGasEstimate()
+	if err != nil {
+		return 0, err
+	}
+	return handleLocalGasPrices(response.Suggestion, gasInfo, priorityFee, gasLimit), nil
+}
+
+// Show the suggested max fees from Etherchain, or Etherscan if it's unavailable, and ask for one
+func getExternalMaxFeeGwei(gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) (float64, error) {
+	// Try to get the latest gas prices from Etherchain
+	etherchainData, err := etherchain.GetGasPrices()
+	if err == nil {
+		// Print the Etherchain data and ask for an amount
+		return handleEtherchainGasPrices(etherchainData, gasInfo, priorityFee, gasLimit), nil
+	}
+
+	// Fallback to Etherscan
+	fmt.Printf("%sWarning: couldn't get gas estimates from Etherchain - %s\nFalling back to Etherscan%s\n", colorYellow, err.Error(), colorReset)
+	etherscanData, err := etherscan.GetGasPrices()
+	if err != nil {
+		return 0, fmt.Errorf("Error getting gas price suggestions: %w", err)
+	}
+
+	// Print the Etherscan data and ask for an amount
+	return handleEtherscanGasPrices(etherscanData, gasInfo, priorityFee, gasLimit), nil
+}
+
+// Get the configured gas estimate source
+func getGasEstimateSource(cfg *config.RocketPoolConfig) cfgtypes.GasEstimateSource {
+	source, ok := cfg.Smartnode.GasEstimateSource.Value.(cfgtypes.GasEstimateSource)
+	if !ok || source == cfgtypes.GasEstimateSource_Unknown {
+		return cfgtypes.GasEstimateSource_Local
+	}
+	return source
+}
+
 func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
 
 	rapidGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.RapidWei)+priorityFee, 0)
@@ -250,6 +337,87 @@ func handleEtherchainGasPrices(gasSuggestion etherchain.GasFeeSuggestion, gasInf
 
 }
 
+func handleLocalGasPrices(gasSuggestion feehistory.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
+
+	fastGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.Fast.BaseFeeWei)+priorityFee, 0)
+	fastEth := eth.WeiToEth(gasSuggestion.Fast.BaseFeeWei)
+
+	var fastLowLimit float64
+	var fastHighLimit float64
+	if gasLimit == 0 {
+		fastLowLimit = fastEth * float64(gasInfo.EstGasLimit)
+		fastHighLimit = fastEth * float64(gasInfo.SafeGasLimit)
+	} else {
+		fastLowLimit = fastEth * float64(gasLimit)
+		fastHighLimit = fastLowLimit
+	}
+
+	standardGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.Standard.BaseFeeWei)+priorityFee, 0)
+	standardEth := eth.WeiToEth(gasSuggestion.Standard.BaseFeeWei)
+
+	var standardLowLimit float64
+	var standardHighLimit float64
+	if gasLimit == 0 {
+		standardLowLimit = standardEth * float64(gasInfo.EstGasLimit)
+		standardHighLimit = standardEth * float64(gasInfo.SafeGasLimit)
+	} else {
+		standardLowLimit = standardEth * float64(gasLimit)
+		standardHighLimit = standardLowLimit
+	}
+
+	slowGwei := math.RoundUp(eth.WeiToGwei(gasSuggestion.Slow.BaseFeeWei)+priorityFee, 0)
+	slowEth := eth.WeiToEth(gasSuggestion.Slow.BaseFeeWei)
+
+	var slowLowLimit float64
+	var slowHighLimit float64
+	if gasLimit == 0 {
+		slowLowLimit = slowEth * float64(gasInfo.EstGasLimit)
+		slowHighLimit = slowEth * float64(gasInfo.SafeGasLimit)
+	} else {
+		slowLowLimit = slowEth * float64(gasLimit)
+		slowHighLimit = slowLowLimit
+	}
+
+	fmt.Printf("%s+============ Suggested Gas Prices ============+\n", colorBlue)
+	fmt.Println("|   Speed   |  Max Fee  |    Total Gas Cost    |")
+	fmt.Printf("| Fast      | %-9s | %.4f to %.4f ETH |\n",
+		fmt.Sprintf("%d gwei", int(fastGwei)), fastLowLimit, fastHighLimit)
+	fmt.Printf("| Standard  | %-9s | %.4f to %.4f ETH |\n",
+		fmt.Sprintf("%d gwei", int(standardGwei)), standardLowLimit, standardHighLimit)
+	fmt.Printf("| Slow      | %-9s | %.4f to %.4f ETH |\n",
+		fmt.Sprintf("%d gwei", int(slowGwei)), slowLowLimit, slowHighLimit)
+	fmt.Printf("+==============================================+\n\n%s", colorReset)
+
+	fmt.Printf("These prices are based on your Execution Client's fee history up to block %d, and include a maximum priority fee of %.2f gwei.\n", gasSuggestion.NewestBlock, priorityFee)
+	fmt.Printf("Recent blocks paid priority fees of %.2f (slow), %.2f (standard) and %.2f (fast) gwei.\n",
+		eth.WeiToGwei(gasSuggestion.Slow.PriorityFeeWei), eth.WeiToGwei(gasSuggestion.Standard.PriorityFeeWei), eth.WeiToGwei(gasSuggestion.Fast.PriorityFeeWei))
+
+	for {
+		desiredPrice := cliutils.Prompt(
+			fmt.Sprintf("Please enter your max fee (including the priority fee) or leave blank for the default of %d gwei:", int(fastGwei)),
+			"^(?:[1-9]\\d*|0)?(?:\\.\\d+)?$",
+			"Not a valid gas price, try again:")
+
+		if desiredPrice == "" {
+			return fastGwei
+		}
+
+		desiredPriceFloat, err := strconv.ParseFloat(desiredPrice, 64)
+		if err != nil {
+			fmt.Printf("Not a valid gas price (%s), try again.", err.Error())
+			fmt.Println("")
+			continue
+		}
+		if desiredPriceFloat <= 0 {
+			fmt.Println("Max fee must be greater than zero.")
+			continue
+		}
+
+		return desiredPriceFloat
+	}
+
+}
+
 func handleEtherscanGasPrices(gasSuggestion etherscan.GasFeeSuggestion, gasInfo rocketpool.GasInfo, priorityFee float64, gasLimit uint64) float64 {
 
 	fastGwei := math.RoundUp(gasSuggestion.FastGwei+priorityFee, 0)
@@ -0,0 +1,120 @@
+package keymanager
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	RequestUrlFormat   = "%s%s"
+	RequestContentType = "application/json"
+	RequestTimeout     = 10 * time.Second
+
+	RequestFeeRecipientPath = "/eth/v1/validator/%s/feerecipient"
+	RequestGraffitiPath     = "/eth/v1/validator/%s/graffiti"
+)
+
+// Client for a Validator Client's keymanager API
+type Client struct {
+	providerAddress string
+	token           string
+	client          http.Client
+}
+
+// Request bodies
+type setFeeRecipientRequest struct {
+	EthAddress string `json:"ethaddress"`
+}
+type setGraffitiRequest struct {
+	Graffiti string `json:"graffiti"`
+}
+
+// Create a new keymanager API client with the given bearer token
+func NewClient(providerAddress string, token string) *Client {
+	return &Client{
+		providerAddress: providerAddress,
+		token:           strings.TrimSpace(token),
+		client: http.Client{
+			Timeout: RequestTimeout,
+		},
+	}
+}
+
+// Set the fee recipient for a validator
+func (c *Client) SetFeeRecipient(pubkey types.ValidatorPubkey, feeRecipient common.Address) error {
+	return c.sendRequest(http.MethodPost, fmt.Sprintf(RequestFeeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), setFeeRecipientRequest{
+		EthAddress: feeRecipient.Hex(),
+	})
+}
+
+// Remove a validator's fee recipient so it reverts to the Validator Client's default
+func (c *Client) DeleteFeeRecipient(pubkey types.ValidatorPubkey) error {
+	return c.sendRequest(http.MethodDelete, fmt.Sprintf(RequestFeeRecipientPath, hexutil.AddPrefix(pubkey.Hex())), nil)
+}
+
+// Set the graffiti for a validator
+func (c *Client) SetGraffiti(pubkey types.ValidatorPubkey, graffiti string) error {
+	return c.sendRequest(http.MethodPost, fmt.Sprintf(RequestGraffitiPath, hexutil.AddPrefix(pubkey.Hex())), setGraffitiRequest{
+		Graffiti: graffiti,
+	})
+}
+
+// Remove a validator's graffiti so it reverts to the Validator Client's default
+func (c *Client) DeleteGraffiti(pubkey types.ValidatorPubkey) error {
+	return c.sendRequest(http.MethodDelete, fmt.Sprintf(RequestGraffitiPath, hexutil.AddPrefix(pubkey.Hex())), nil)
+}
+
+// Send an authenticated request to the keymanager API
+func (c *Client) sendRequest(method string, requestPath string, requestBody interface{}) error {
+
+	// Get request body
+	var requestBodyReader io.Reader
+	if requestBody != nil {
+		requestBodyBytes, err := json.Marshal(requestBody)
+		if err != nil {
+			return err
+		}
+		requestBodyReader = bytes.NewReader(requestBodyBytes)
+	}
+
+	// Build the request
+	request, err := http.NewRequest(method, fmt.Sprintf(RequestUrlFormat, c.providerAddress, requestPath), requestBodyReader)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.token)
+	if requestBody != nil {
+		request.Header.Set("Content-Type", RequestContentType)
+	}
+
+	// Send request
+	response, err := c.client.Do(request)
+	if err != nil {
+		return fmt.Errorf("error sending keymanager request to %s: %w", requestPath, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	// Check the response
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("keymanager request to %s failed: HTTP status %d; response body: '%s'", requestPath, response.StatusCode, string(body))
+	}
+	return nil
+
+}
@@ -0,0 +1,241 @@
+package participation
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// Config
+const (
+	HistoryVersion   uint64      = 1
+	MaxHistoryRounds int         = 2000
+	historyFileMode  os.FileMode = 0644
+)
+
+// An Oracle DAO duty that members submit for
+type Duty string
+
+const (
+	Duty_Balances    Duty = "balances"
+	Duty_Prices      Duty = "prices"
+	Duty_L2Prices    Duty = "l2-prices"
+	Duty_RewardsTree Duty = "rewards-tree"
+	Duty_ScrubVote   Duty = "scrub-vote"
+)
+
+// All of the duties, in display order
+var Duties = []Duty{
+	Duty_Balances,
+	Duty_Prices,
+	Duty_L2Prices,
+	Duty_RewardsTree,
+	Duty_ScrubVote,
+}
+
+// A single round of a duty, such as one balances block or one rewards interval, and the members that submitted for it
+type DutyRound struct {
+	Duty Duty `json:"duty"`
+
+	// What the round was for beyond the duty itself, such as the L2 name or the scrubbed minipool address
+	Target string `json:"target,omitempty"`
+
+	// The block number, rewards interval, or L2 turn the round was for
+	Round uint64 `json:"round"`
+
+	Time             time.Time        `json:"time"`
+	ConsensusReached bool             `json:"consensusReached"`
+	Members          []common.Address `json:"members"`
+	Submitters       []common.Address `json:"submitters"`
+
+	// The member whose turn it was to submit, for duties that take turns instead of reaching consensus
+	ResponsibleMember *common.Address `json:"responsibleMember,omitempty"`
+}
+
+// Submission totals for one member and one duty
+type DutySummary struct {
+	Duty              Duty      `json:"duty"`
+	Expected          int       `json:"expected"`
+	Submitted         int       `json:"submitted"`
+	MissedRecent      int       `json:"missedRecent"`
+	LastSubmittedTime time.Time `json:"lastSubmittedTime"`
+}
+
+// The submission record of one Oracle DAO member
+type MemberSummary struct {
+	Address common.Address `json:"address"`
+	Duties  []DutySummary  `json:"duties"`
+}
+
+// The history of every Oracle DAO member's duty submissions, as seen by the watchtower
+type ParticipationHistory struct {
+	Version          uint64      `json:"version"`
+	LastScannedBlock uint64      `json:"lastScannedBlock"`
+	Rounds           []DutyRound `json:"rounds"`
+}
+
+// Loads the participation history from disk, returning an empty history if it doesn't exist yet
+func LoadParticipationHistory(path string) (*ParticipationHistory, error) {
+	history := &ParticipationHistory{
+		Version: HistoryVersion,
+		Rounds:  []DutyRound{},
+	}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return history, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading participation history: %w", err)
+	}
+	if err := json.Unmarshal(bytes, history); err != nil {
+		return nil, fmt.Errorf("error deserializing participation history: %w", err)
+	}
+	if history.Version != HistoryVersion {
+		return nil, fmt.Errorf("participation history has unsupported version %d (expected %d)", history.Version, HistoryVersion)
+	}
+	return history, nil
+}
+
+// Saves the participation history to disk, trimming the oldest rounds if there are too many
+func (h *ParticipationHistory) Save(path string) error {
+	if len(h.Rounds) > MaxHistoryRounds {
+		h.Rounds = h.Rounds[len(h.Rounds)-MaxHistoryRounds:]
+	}
+	bytes, err := json.Marshal(h)
+	if err != nil {
+		return fmt.Errorf("error serializing participation history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating participation history folder: %w", err)
+	}
+
+	// Write to a temp file first so the history is never left half-written
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, historyFileMode); err != nil {
+		return fmt.Errorf("error writing participation history: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving participation history: %w", err)
+	}
+	return nil
+}
+
+// Gets a round that has already been recorded, or nil if it hasn't been
+func (h *ParticipationHistory) GetRound(duty Duty, target string, round uint64) *DutyRound {
+	for i := len(h.Rounds) - 1; i >= 0; i-- {
+		existing := &h.Rounds[i]
+		if existing.Duty == duty && existing.Target == target && existing.Round == round {
+			return existing
+		}
+	}
+	return nil
+}
+
+// Gets the most recently recorded round of a duty, or nil if there aren't any
+func (h *ParticipationHistory) GetLatestRound(duty Duty, target string) *DutyRound {
+	for i := len(h.Rounds) - 1; i >= 0; i-- {
+		existing := &h.Rounds[i]
+		if existing.Duty == duty && existing.Target == target {
+			return existing
+		}
+	}
+	return nil
+}
+
+// Records a round, replacing any earlier record of the same round
+func (h *ParticipationHistory) UpdateRound(round DutyRound) {
+	if existing := h.GetRound(round.Duty, round.Target, round.Round); existing != nil {
+		*existing = round
+		return
+	}
+	h.Rounds = append(h.Rounds, round)
+}
+
+// Checks whether a member submitted for a round
+func (r *DutyRound) HasSubmitted(address common.Address) bool {
+	for _, submitter := range r.Submitters {
+		if submitter == address {
+			return true
+		}
+	}
+	return false
+}
+
+// Checks whether a member was expected to submit for a round
+func (r *DutyRound) IsExpected(address common.Address) bool {
+	if r.ResponsibleMember != nil {
+		return *r.ResponsibleMember == address
+	}
+	for _, member := range r.Members {
+		if member == address {
+			return true
+		}
+	}
+	return false
+}
+
+// Totals up each member's submissions for every duty; the recent window is the number of latest rounds of each duty to count misses in
+func (h *ParticipationHistory) GetMemberSummaries(recentWindow int) []MemberSummary {
+	// Get the latest rounds first so the recent window can be counted
+	rounds := make([]DutyRound, len(h.Rounds))
+	copy(rounds, h.Rounds)
+	sort.SliceStable(rounds, func(i, j int) bool {
+		return rounds[i].Time.After(rounds[j].Time)
+	})
+
+	summaries := map[common.Address]map[Duty]*DutySummary{}
+	order := []common.Address{}
+	roundsSeen := map[Duty]int{}
+	for _, round := range rounds {
+		roundsSeen[round.Duty]++
+		isRecent := roundsSeen[round.Duty] <= recentWindow
+		members := round.Members
+		if round.ResponsibleMember != nil {
+			members = []common.Address{*round.ResponsibleMember}
+		}
+		for _, member := range members {
+			duties, exists := summaries[member]
+			if !exists {
+				duties = map[Duty]*DutySummary{}
+				summaries[member] = duties
+				order = append(order, member)
+			}
+			summary, exists := duties[round.Duty]
+			if !exists {
+				summary = &DutySummary{
+					Duty: round.Duty,
+				}
+				duties[round.Duty] = summary
+			}
+			summary.Expected++
+			if round.HasSubmitted(member) {
+				summary.Submitted++
+				if round.Time.After(summary.LastSubmittedTime) {
+					summary.LastSubmittedTime = round.Time
+				}
+			} else if isRecent {
+				summary.MissedRecent++
+			}
+		}
+	}
+
+	list := make([]MemberSummary, 0, len(order))
+	for _, member := range order {
+		memberSummary := MemberSummary{
+			Address: member,
+			Duties:  []DutySummary{},
+		}
+		for _, duty := range Duties {
+			if summary, exists := summaries[member][duty]; exists {
+				memberSummary.Duties = append(memberSummary.Duties, *summary)
+			}
+		}
+		list = append(list, memberSummary)
+	}
+	return list
+}
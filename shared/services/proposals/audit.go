@@ -0,0 +1,146 @@
+package proposals
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// A tree node in a submitted root or pollard that doesn't match the locally generated tree
+type TreeNodeMismatch struct {
+	// The virtual index of the tree node
+	Index uint64 `json:"index"`
+
+	// The node whose delegation tree this tree node belongs to; nil for tree nodes in the network tree
+	TreeOwner *common.Address `json:"treeOwner,omitempty"`
+
+	// The range of Rocket Pool nodes (by index in the voting info snapshot) covered by this tree node
+	FirstNodeIndex uint64 `json:"firstNodeIndex"`
+	LastNodeIndex  uint64 `json:"lastNodeIndex"`
+
+	// The Rocket Pool node this tree node represents, if it's a leaf
+	NodeAddress *common.Address `json:"nodeAddress,omitempty"`
+
+	// True if this tree node is the total voting power delegated to the tree owner (a leaf of the network tree)
+	IsDelegationTotal bool `json:"isDelegationTotal"`
+
+	// The tree node in the local tree and in the submission
+	LocalNode    types.VotingTreeNode `json:"localNode"`
+	ProposedNode types.VotingTreeNode `json:"proposedNode"`
+}
+
+// Compares the root and the entire pollard of a RootSubmitted event against the local artifacts, returning every tree node that doesn't match
+func (m *ProposalManager) AuditRootSubmission(event protocol.RootSubmitted) ([]TreeNodeMismatch, error) {
+	// Load the voting info snapshot
+	blockNumber := event.BlockNumber
+	index := event.Index.Uint64()
+	snapshot, err := m.GetVotingInfoSnapshot(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the proper tree
+	tree, err := m.getTreeForIndex(snapshot, index)
+	if err != nil {
+		return nil, err
+	}
+
+	return compareSubmission(snapshot, tree, index, event.Root, event.TreeNodes)
+}
+
+// Compares a submitted root and pollard against the ones generated from the local tree, returning every tree node that doesn't match
+func compareSubmission(snapshot *VotingInfoSnapshot, tree *VotingTree, index uint64, root types.VotingTreeNode, pollard []types.VotingTreeNode) ([]TreeNodeMismatch, error) {
+	// Compare the root
+	mismatches := []TreeNodeMismatch{}
+	localRoot, localPollard := tree.generatePollard(index)
+	if !isTreeNodeMatch(localRoot, &root) {
+		mismatches = append(mismatches, newTreeNodeMismatch(snapshot, index, localRoot, root))
+	}
+
+	// Compare every node in the pollard
+	if len(localPollard) != len(pollard) {
+		return nil, fmt.Errorf("pollard size mismatch: local pollard = %d nodes, proposed pollard size = %d nodes", len(localPollard), len(pollard))
+	}
+	firstPollardIndex := uint64(len(localPollard)) // The pollard is a full row, so its first index relative to its root is its length
+	for i, localNode := range localPollard {
+		proposedNode := pollard[i]
+		if isTreeNodeMatch(localNode, &proposedNode) {
+			continue
+		}
+		virtualIndex := tree.getVirtualIndexFromLocalIndex(firstPollardIndex+uint64(i), index)
+		mismatches = append(mismatches, newTreeNodeMismatch(snapshot, virtualIndex, localNode, proposedNode))
+	}
+	return mismatches, nil
+}
+
+// Get the tree that holds the provided virtual index: the network tree, or the delegation tree of one of its leaves
+func (m *ProposalManager) getTreeForIndex(snapshot *VotingInfoSnapshot, index uint64) (*VotingTree, error) {
+	rpNodeIndex := getRPNodeIndexFromTreeNodeIndex(snapshot, index)
+	if rpNodeIndex == nil {
+		networkTree, err := m.GetNetworkTree(snapshot.BlockNumber, snapshot)
+		if err != nil {
+			return nil, err
+		}
+		return networkTree.VotingTree, nil
+	}
+	nodeTree, err := m.GetNodeTree(snapshot.BlockNumber, *rpNodeIndex, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	return nodeTree.VotingTree, nil
+}
+
+// Describe a mismatching tree node by the Rocket Pool nodes it covers
+func newTreeNodeMismatch(snapshot *VotingInfoSnapshot, virtualIndex uint64, localNode *types.VotingTreeNode, proposedNode types.VotingTreeNode) TreeNodeMismatch {
+	mismatch := TreeNodeMismatch{
+		Index:        virtualIndex,
+		LocalNode:    *localNode,
+		ProposedNode: proposedNode,
+	}
+
+	// The network tree and every delegation tree have the same number of leaves
+	totalLeafNodes := getTreeNodeIndexFromRPNodeIndex(snapshot, 0)
+	treeDepth := uint64(math.Log2(float64(totalLeafNodes)))
+	level := uint64(math.Floor(math.Log2(float64(virtualIndex))))
+
+	// Get the index of this node relative to the root of the tree it belongs to
+	relativeIndex := virtualIndex
+	relativeLevel := level
+	if rpNodeIndex := getRPNodeIndexFromTreeNodeIndex(snapshot, virtualIndex); rpNodeIndex != nil && *rpNodeIndex < uint64(len(snapshot.Info)) {
+		owner := snapshot.Info[*rpNodeIndex].NodeAddress
+		mismatch.TreeOwner = &owner
+		relativeLevel = level - treeDepth
+		treeRootIndex := totalLeafNodes + *rpNodeIndex
+		offset := virtualIndex - treeRootIndex*uint64(math.Pow(2, float64(relativeLevel)))
+		relativeIndex = uint64(math.Pow(2, float64(relativeLevel))) + offset
+		if relativeLevel == 0 {
+			mismatch.NodeAddress = &owner
+			mismatch.IsDelegationTotal = true
+		}
+	}
+
+	// Get the leaves under it
+	leavesPerNode := uint64(math.Pow(2, float64(treeDepth-relativeLevel)))
+	firstLeaf := relativeIndex*leavesPerNode - totalLeafNodes
+	lastLeaf := firstLeaf + leavesPerNode - 1
+	nodeCount := uint64(len(snapshot.Info))
+	if firstLeaf < nodeCount && lastLeaf >= nodeCount {
+		// Leave out the padding at the end of the tree
+		lastLeaf = nodeCount - 1
+	}
+	mismatch.FirstNodeIndex = firstLeaf
+	mismatch.LastNodeIndex = lastLeaf
+	if leavesPerNode == 1 && firstLeaf < nodeCount {
+		address := snapshot.Info[firstLeaf].NodeAddress
+		mismatch.NodeAddress = &address
+	}
+	return mismatch
+}
+
+// Check if two tree nodes are identical
+func isTreeNodeMatch(localNode *types.VotingTreeNode, proposedNode *types.VotingTreeNode) bool {
+	return localNode.Hash == proposedNode.Hash && localNode.Sum.Cmp(proposedNode.Sum) == 0
+}
@@ -0,0 +1,218 @@
+package proposals
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+var (
+	testNode0 = common.HexToAddress("0x1000000000000000000000000000000000000000")
+	testNode1 = common.HexToAddress("0x2000000000000000000000000000000000000000")
+	testNode2 = common.HexToAddress("0x3000000000000000000000000000000000000000")
+)
+
+// Three nodes padded to four leaves per tree; node 2 delegates to node 1.
+// The network tree's leaves are virtual indices 4-7, and node 1's delegation tree hangs off of index 5
+// with its children at 10-11 and its leaves at 20-23.
+func newTestSnapshot() *VotingInfoSnapshot {
+	return &VotingInfoSnapshot{
+		Info: []types.NodeVotingInfo{
+			{NodeAddress: testNode0, VotingPower: big.NewInt(10), Delegate: testNode0},
+			{NodeAddress: testNode1, VotingPower: big.NewInt(20), Delegate: testNode1},
+			{NodeAddress: testNode2, VotingPower: big.NewInt(30), Delegate: testNode1},
+		},
+	}
+}
+
+// Build the network tree, or the delegation tree of the provided node, the same way the tree managers do
+func newTestTree(snapshot *VotingInfoSnapshot, owner *common.Address, virtualRootIndex uint64, depthPerRound uint64) *VotingTree {
+	votingPower := map[common.Address]*big.Int{}
+	for _, info := range snapshot.Info {
+		if owner == nil {
+			if votingPower[info.Delegate] == nil {
+				votingPower[info.Delegate] = big.NewInt(0)
+			}
+			votingPower[info.Delegate].Add(votingPower[info.Delegate], info.VotingPower)
+		} else if info.Delegate == *owner {
+			votingPower[info.NodeAddress] = info.VotingPower
+		}
+	}
+
+	leaves := make([]*types.VotingTreeNode, len(snapshot.Info))
+	for i, info := range snapshot.Info {
+		vp, exists := votingPower[info.NodeAddress]
+		if !exists {
+			vp = big.NewInt(0)
+		}
+		leaves[i] = &types.VotingTreeNode{
+			Sum:  vp,
+			Hash: getHashForBalance(vp),
+		}
+	}
+	return CreateTreeFromLeaves(0, "", leaves, virtualRootIndex, depthPerRound)
+}
+
+// Make a copy of a tree node with a different sum
+func tamperTreeNode(node *types.VotingTreeNode) types.VotingTreeNode {
+	return types.VotingTreeNode{
+		Sum:  big.NewInt(0).Add(node.Sum, common.Big1),
+		Hash: node.Hash,
+	}
+}
+
+type expectedMismatch struct {
+	index             uint64
+	treeOwner         *common.Address
+	firstNodeIndex    uint64
+	lastNodeIndex     uint64
+	nodeAddress       *common.Address
+	isDelegationTotal bool
+}
+
+func checkMismatch(t *testing.T, mismatch TreeNodeMismatch, expected expectedMismatch) {
+	t.Helper()
+	if mismatch.Index != expected.index {
+		t.Errorf("index was %d, expected %d", mismatch.Index, expected.index)
+	}
+	if !isSameAddress(mismatch.TreeOwner, expected.treeOwner) {
+		t.Errorf("tree owner was %v, expected %v", mismatch.TreeOwner, expected.treeOwner)
+	}
+	if mismatch.FirstNodeIndex != expected.firstNodeIndex || mismatch.LastNodeIndex != expected.lastNodeIndex {
+		t.Errorf("node range was %d-%d, expected %d-%d", mismatch.FirstNodeIndex, mismatch.LastNodeIndex, expected.firstNodeIndex, expected.lastNodeIndex)
+	}
+	if !isSameAddress(mismatch.NodeAddress, expected.nodeAddress) {
+		t.Errorf("node address was %v, expected %v", mismatch.NodeAddress, expected.nodeAddress)
+	}
+	if mismatch.IsDelegationTotal != expected.isDelegationTotal {
+		t.Errorf("delegation total was %t, expected %t", mismatch.IsDelegationTotal, expected.isDelegationTotal)
+	}
+}
+
+func isSameAddress(first *common.Address, second *common.Address) bool {
+	if first == nil || second == nil {
+		return first == second
+	}
+	return *first == *second
+}
+
+func TestNewTreeNodeMismatch(t *testing.T) {
+	snapshot := newTestSnapshot()
+	node := &types.VotingTreeNode{Sum: big.NewInt(0)}
+	tests := []struct {
+		name     string
+		expected expectedMismatch
+	}{
+		{"network root", expectedMismatch{index: 1, firstNodeIndex: 0, lastNodeIndex: 2}},
+		{"network branch", expectedMismatch{index: 2, firstNodeIndex: 0, lastNodeIndex: 1}},
+		{"network branch with padding", expectedMismatch{index: 3, firstNodeIndex: 2, lastNodeIndex: 2}},
+		{"network leaf", expectedMismatch{index: 5, treeOwner: &testNode1, firstNodeIndex: 0, lastNodeIndex: 2, nodeAddress: &testNode1, isDelegationTotal: true}},
+		{"network padding leaf", expectedMismatch{index: 7, firstNodeIndex: 3, lastNodeIndex: 3}},
+		{"delegation branch", expectedMismatch{index: 10, treeOwner: &testNode1, firstNodeIndex: 0, lastNodeIndex: 1}},
+		{"delegation branch with padding", expectedMismatch{index: 11, treeOwner: &testNode1, firstNodeIndex: 2, lastNodeIndex: 2}},
+		{"delegation leaf", expectedMismatch{index: 22, treeOwner: &testNode1, firstNodeIndex: 2, lastNodeIndex: 2, nodeAddress: &testNode2}},
+		{"delegation padding leaf", expectedMismatch{index: 23, treeOwner: &testNode1, firstNodeIndex: 3, lastNodeIndex: 3}},
+		{"leaf of the last delegation tree", expectedMismatch{index: 24, treeOwner: &testNode2, firstNodeIndex: 0, lastNodeIndex: 0, nodeAddress: &testNode0}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mismatch := newTreeNodeMismatch(snapshot, test.expected.index, node, *node)
+			checkMismatch(t, mismatch, test.expected)
+		})
+	}
+}
+
+func TestCompareSubmission(t *testing.T) {
+	snapshot := newTestSnapshot()
+	tests := []struct {
+		name          string
+		tree          *VotingTree
+		index         uint64
+		tamperRoot    bool
+		tamperPollard []int
+		expected      []expectedMismatch
+	}{
+		{
+			name:     "matching submission",
+			tree:     newTestTree(snapshot, nil, 1, 1),
+			index:    1,
+			expected: []expectedMismatch{},
+		}, {
+			name:       "root mismatch",
+			tree:       newTestTree(snapshot, nil, 1, 1),
+			index:      1,
+			tamperRoot: true,
+			expected: []expectedMismatch{
+				{index: 1, firstNodeIndex: 0, lastNodeIndex: 2},
+			},
+		}, {
+			name:          "network leaves at the end of a pollard",
+			tree:          newTestTree(snapshot, nil, 1, 1),
+			index:         3,
+			tamperPollard: []int{0, 1},
+			expected: []expectedMismatch{
+				{index: 6, treeOwner: &testNode2, firstNodeIndex: 0, lastNodeIndex: 2, nodeAddress: &testNode2, isDelegationTotal: true},
+				{index: 7, firstNodeIndex: 3, lastNodeIndex: 3},
+			},
+		}, {
+			name:          "first and last leaves of a delegation tree pollard",
+			tree:          newTestTree(snapshot, &testNode1, 5, 2),
+			index:         5,
+			tamperPollard: []int{0, 3},
+			expected: []expectedMismatch{
+				{index: 20, treeOwner: &testNode1, firstNodeIndex: 0, lastNodeIndex: 0, nodeAddress: &testNode0},
+				{index: 23, treeOwner: &testNode1, firstNodeIndex: 3, lastNodeIndex: 3},
+			},
+		}, {
+			name:          "leaf mismatch in a later delegation tree round",
+			tree:          newTestTree(snapshot, &testNode1, 5, 1),
+			index:         11,
+			tamperPollard: []int{0},
+			expected: []expectedMismatch{
+				{index: 22, treeOwner: &testNode1, firstNodeIndex: 2, lastNodeIndex: 2, nodeAddress: &testNode2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Build the submission from the local tree, then tamper with it
+			localRoot, localPollard := test.tree.generatePollard(test.index)
+			root := *localRoot
+			if test.tamperRoot {
+				root = tamperTreeNode(localRoot)
+			}
+			pollard := make([]types.VotingTreeNode, len(localPollard))
+			for i, node := range localPollard {
+				pollard[i] = *node
+			}
+			for _, i := range test.tamperPollard {
+				pollard[i] = tamperTreeNode(localPollard[i])
+			}
+
+			mismatches, err := compareSubmission(snapshot, test.tree, test.index, root, pollard)
+			if err != nil {
+				t.Fatalf("error comparing submission: %s", err.Error())
+			}
+			if len(mismatches) != len(test.expected) {
+				t.Fatalf("found %d mismatches, expected %d", len(mismatches), len(test.expected))
+			}
+			for i, expected := range test.expected {
+				checkMismatch(t, mismatches[i], expected)
+			}
+		})
+	}
+}
+
+func TestCompareSubmissionWithWrongPollardSize(t *testing.T) {
+	snapshot := newTestSnapshot()
+	tree := newTestTree(snapshot, nil, 1, 1)
+	localRoot, localPollard := tree.generatePollard(1)
+	_, err := compareSubmission(snapshot, tree, 1, *localRoot, []types.VotingTreeNode{*localPollard[0]})
+	if err == nil {
+		t.Error("pollard with the wrong size was compared")
+	}
+}
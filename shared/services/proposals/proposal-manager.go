@@ -121,6 +121,11 @@ func (m *ProposalManager) GetVotingInfoSnapshot(blockNumber uint32) (*VotingInfo
 	return snapshot, nil
 }
 
+// Get the voting info snapshot for a block only if it has already been saved to disk; returns nil if it hasn't
+func (m *ProposalManager) GetCachedVotingInfoSnapshot(blockNumber uint32) (*VotingInfoSnapshot, error) {
+	return m.viSnapshotMgr.LoadFromDisk(blockNumber)
+}
+
 func (m *ProposalManager) GetNetworkTree(blockNumber uint32, snapshot *VotingInfoSnapshot) (*NetworkVotingTree, error) {
 	// Try to load the network tree from disk
 	tree, err := m.networkTreeMgr.LoadFromDisk(blockNumber)
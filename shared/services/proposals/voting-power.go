@@ -0,0 +1,29 @@
+package proposals
+
+import (
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+// Calculates a node's voting power the same way RocketNetworkVoting does:
+// sqrt(min(rplStake, bondedEth * maxStakeFraction / rplPrice) * 1 Eth)
+// NOTE: maxStakeFraction is a percentage scaled by 1 Eth, so multiplying by it and dividing by the RPL price cancels out the need for normalization
+func CalculateVotingPower(rplStake *big.Int, bondedEth *big.Int, rplPrice *big.Int, maxStakeFraction *big.Int) *big.Int {
+	votingStake := big.NewInt(0).Set(rplStake)
+	maxVotingStake := GetMaximumVotingStake(bondedEth, rplPrice, maxStakeFraction)
+	if votingStake.Cmp(maxVotingStake) > 0 {
+		votingStake.Set(maxVotingStake)
+	}
+	votingStake.Mul(votingStake, eth.EthToWei(1))
+	return votingStake.Sqrt(votingStake)
+}
+
+// Gets the amount of staked RPL that counts towards a node's voting power
+func GetMaximumVotingStake(bondedEth *big.Int, rplPrice *big.Int, maxStakeFraction *big.Int) *big.Int {
+	if rplPrice.Sign() == 0 {
+		return big.NewInt(0)
+	}
+	maxVotingStake := big.NewInt(0).Mul(bondedEth, maxStakeFraction)
+	return maxVotingStake.Div(maxVotingStake, rplPrice)
+}
@@ -322,7 +322,12 @@ func (r *treeGeneratorImpl_v8) calculateRplRewards() error {
 	// Before entering this function, make sure to hard-code MaxCollateralFraction to 1.5 eth (150% in wei), to comply with RPIP-30.
 	// Do it here, as the network state value will still be used for vote power, so doing it upstream is likely to introduce more issues.
 	// Doing it here also ensures that v1-7 continue to run correctly on networks other than mainnet where the max collateral fraction may not have always been 150%.
-	r.networkState.NetworkDetails.MaxCollateralFraction = big.NewInt(1.5e18) // 1.5 eth is 150% in wei
+	// The state may be cached and shared with other tasks, so override the value on a copy of it.
+	networkState := *r.networkState
+	networkDetails := *networkState.NetworkDetails
+	networkDetails.MaxCollateralFraction = big.NewInt(1.5e18) // 1.5 eth is 150% in wei
+	networkState.NetworkDetails = &networkDetails
+	r.networkState = &networkState
 	trueNodeEffectiveStakes, totalNodeEffectiveStake, err := r.networkState.CalculateTrueEffectiveStakes(true, true)
 	if err != nil {
 		return fmt.Errorf("error calculating effective RPL stakes: %w", err)
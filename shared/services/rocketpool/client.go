@@ -16,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/fatih/color"
 	"github.com/goccy/go-json"
 	"github.com/urfave/cli"
@@ -29,6 +30,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool/template"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/cli/output"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
 
@@ -81,6 +83,8 @@ type Client struct {
 	debugPrint         bool
 	ignoreSyncCheck    bool
 	forceFallbacks     bool
+	context            *Context
+	recorder           *output.Recorder
 }
 
 func getClientStatusString(clientStatus api.ClientStatus) string {
@@ -143,6 +147,15 @@ func checkClientStatus(rp *Client) (bool, error) {
 // Only use this function from commands that may work if the Daemon service doesn't exist
 // Most users should call NewClientFromCtx(c).WithStatus() or NewClientFromCtx(c).WithReady()
 func NewClientFromCtx(c *cli.Context) *Client {
+	client := newClient(c)
+	if context, ok := c.App.Metadata["context"]; ok {
+		client.useContext(context.(*Context))
+	}
+	return client
+}
+
+// Create new Rocket Pool client from CLI context for this machine's Smart Node, ignoring the selected context
+func newClient(c *cli.Context) *Client {
 
 	// Return client
 	client := &Client{
@@ -157,6 +170,7 @@ func NewClientFromCtx(c *cli.Context) *Client {
 		debugPrint:         c.GlobalBool("debug"),
 		forceFallbacks:     false,
 		ignoreSyncCheck:    false,
+		recorder:           output.GetRecorder(c),
 	}
 
 	if nonce, ok := c.App.Metadata["nonce"]; ok {
@@ -214,6 +228,10 @@ func (c *Client) ConfigPath() string {
 // Load the config
 // Returns the RocketPoolConfig and whether or not it was newly generated
 func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
+	if c.isRemote() {
+		return c.loadRemoteConfig()
+	}
+
 	settingsFilePath := filepath.Join(c.configPath, SettingsFile)
 	expandedPath, err := homedir.Expand(settingsFilePath)
 	if err != nil {
@@ -236,6 +254,10 @@ func (c *Client) LoadConfig() (*config.RocketPoolConfig, bool, error) {
 
 // Load the backup config
 func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
+	if err := c.requireLocal("load the backup config"); err != nil {
+		return nil, err
+	}
+
 	settingsFilePath := filepath.Join(c.configPath, BackupSettingsFile)
 	expandedPath, err := homedir.Expand(settingsFilePath)
 	if err != nil {
@@ -247,6 +269,10 @@ func (c *Client) LoadBackupConfig() (*config.RocketPoolConfig, error) {
 
 // Save the config
 func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
+	if err := c.requireLocal("save the config"); err != nil {
+		return err
+	}
+
 	settingsFileDirectoryPath, err := homedir.Expand(c.configPath)
 	if err != nil {
 		return err
@@ -256,6 +282,10 @@ func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 
 // Remove the upgrade flag file
 func (c *Client) RemoveUpgradeFlagFile() error {
+	if err := c.requireLocal("remove the upgrade flag"); err != nil {
+		return err
+	}
+
 	expandedPath, err := homedir.Expand(c.configPath)
 	if err != nil {
 		return err
@@ -265,6 +295,10 @@ func (c *Client) RemoveUpgradeFlagFile() error {
 
 // Returns whether or not this is the first run of the configurator since a previous installation
 func (c *Client) IsFirstRun() (bool, error) {
+	if err := c.requireLocal("check for a previous installation"); err != nil {
+		return false, err
+	}
+
 	expandedPath, err := homedir.Expand(c.configPath)
 	if err != nil {
 		return false, fmt.Errorf("error expanding settings file path: %w", err)
@@ -274,6 +308,10 @@ func (c *Client) IsFirstRun() (bool, error) {
 
 // Load the Prometheus template, do an template variable substitution, and save it
 func (c *Client) UpdatePrometheusConfiguration(config *config.RocketPoolConfig) error {
+	if err := c.requireLocal("update the Prometheus configuration"); err != nil {
+		return err
+	}
+
 	prometheusTemplatePath, err := homedir.Expand(fmt.Sprintf("%s/%s", c.configPath, PrometheusConfigTemplate))
 	if err != nil {
 		return fmt.Errorf("Error expanding Prometheus template path: %w", err)
@@ -591,6 +629,11 @@ func (c *Client) PrintServiceCompose(composeFiles []string) error {
 // Get the Rocket Pool service version
 func (c *Client) GetServiceVersion() (string, error) {
 
+	// Ask the API server if the service is behind one
+	if c.context != nil && c.context.IsHttp() {
+		return c.getHttpServiceVersion()
+	}
+
 	// Get service container version output
 	var cmd string
 	if c.daemonPath == "" {
@@ -1061,6 +1104,11 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 		return "", errors.New("command unavailable in Native Mode (with '--daemon-path' option specified)")
 	}
 
+	// Cancel if the service is on another machine
+	if err := c.requireLocal("manage the service"); err != nil {
+		return "", err
+	}
+
 	// Get the expanded config path
 	expandedConfigPath, err := homedir.Expand(c.configPath)
 	if err != nil {
@@ -1248,7 +1296,14 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 }
 
 // Call the Rocket Pool API
-func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
+func (c *Client) callAPI(args string, otherArgs ...string) (response []byte, err error) {
+	defer func() {
+		c.recordTransactions(response, err)
+	}()
+	if c.context != nil && c.context.IsHttp() {
+		return c.callHttpAPI(args, otherArgs...)
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1276,7 +1331,14 @@ func (c *Client) callAPI(args string, otherArgs ...string) ([]byte, error) {
 }
 
 // Call the Rocket Pool API with some custom environment variables
-func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, otherArgs ...string) ([]byte, error) {
+func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, otherArgs ...string) (response []byte, err error) {
+	defer func() {
+		c.recordTransactions(response, err)
+	}()
+	if c.context != nil && c.context.IsHttp() {
+		return nil, fmt.Errorf("the API server of context [%s] doesn't support custom environment variables", c.context.Name)
+	}
+
 	// Sanitize and parse the args
 	ignoreSyncCheckFlag, forceFallbackECFlag, args := c.getApiCallArgs(args, otherArgs...)
 
@@ -1313,6 +1375,26 @@ func (c *Client) callAPIWithEnvVars(envVars map[string]string, args string, othe
 	return c.runApiCall(cmd)
 }
 
+// Record the transactions an API call submitted, so commands that send transactions can return their hashes in the machine-readable output
+func (c *Client) recordTransactions(response []byte, err error) {
+	if c.recorder == nil || err != nil {
+		return
+	}
+	var fields map[string]json.RawMessage
+	if json.Unmarshal(response, &fields) != nil {
+		return
+	}
+	for name, value := range fields {
+		if name != "txHash" && !strings.HasSuffix(name, "TxHash") {
+			continue
+		}
+		var hash common.Hash
+		if json.Unmarshal(value, &hash) == nil && hash != (common.Hash{}) {
+			c.recorder.RecordTransaction(hash)
+		}
+	}
+}
+
 func (c *Client) getApiCallArgs(args string, otherArgs ...string) (string, string, string) {
 	// Sanitize arguments
 	var sanitizedArgs []string
@@ -1,6 +1,7 @@
 package rocketpool
 
 import (
+	"fmt"
 	"io"
 	"os/exec"
 
@@ -16,6 +17,16 @@ type command struct {
 
 // Create a command to be run by the Rocket Pool client
 func (c *Client) newCommand(cmdText string) (*command, error) {
+	if c.context != nil && c.context.IsHttp() {
+		return nil, fmt.Errorf("context [%s] only has access to its Smart Node's API server, so it can't run commands on that machine", c.context.Name)
+	}
+	if c.client == nil && c.context != nil && c.context.IsSsh() {
+		client, err := c.context.dialSsh()
+		if err != nil {
+			return nil, err
+		}
+		c.client = client
+	}
 	if c.client == nil {
 		return &command{
 			cmd:     exec.Command("sh", "-c", cmdText),
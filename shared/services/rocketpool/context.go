@@ -0,0 +1,244 @@
+package rocketpool
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"gopkg.in/yaml.v2"
+)
+
+// Config
+const (
+	ContextsFile string = "contexts.yml"
+
+	defaultSshPort        string = "22"
+	defaultKnownHostsPath string = "~/.ssh/known_hosts"
+	defaultRemoteCliPath  string = "rocketpool"
+)
+
+// A named Smart Node that the CLI can target instead of the one on this machine.
+// A context with an SSH host runs commands on that machine, a context with a URL calls the API server of a remote daemon,
+// and a context with neither targets a Smart Node on this machine.
+type Context struct {
+	Name string `yaml:"name"`
+
+	// SSH settings
+	Host           string `yaml:"host,omitempty"`
+	User           string `yaml:"user,omitempty"`
+	KeyPath        string `yaml:"keyPath,omitempty"`
+	KnownHostsPath string `yaml:"knownHostsPath,omitempty"`
+	CliPath        string `yaml:"cliPath,omitempty"`
+
+	// HTTP settings
+	Url   string `yaml:"url,omitempty"`
+	Token string `yaml:"token,omitempty"`
+
+	// The Smart Node's config and daemon paths on the machine it runs on
+	ConfigPath string `yaml:"configPath,omitempty"`
+	DaemonPath string `yaml:"daemonPath,omitempty"`
+}
+
+// The layout of the contexts file
+type contextsFile struct {
+	Contexts []Context `yaml:"contexts"`
+}
+
+// Check if the context connects to its Smart Node over SSH
+func (ctx *Context) IsSsh() bool {
+	return ctx.Host != ""
+}
+
+// Check if the context connects to its Smart Node's API server over HTTP
+func (ctx *Context) IsHttp() bool {
+	return ctx.Url != ""
+}
+
+// Get a short description of where the context points
+func (ctx *Context) Target() string {
+	if ctx.IsSsh() {
+		if ctx.User != "" {
+			return fmt.Sprintf("ssh://%s@%s", ctx.User, ctx.Host)
+		}
+		return fmt.Sprintf("ssh://%s", ctx.Host)
+	}
+	if ctx.IsHttp() {
+		return ctx.Url
+	}
+	return "local"
+}
+
+// Get the path of the Rocket Pool CLI on the context's machine
+func (ctx *Context) GetCliPath() string {
+	if ctx.CliPath == "" {
+		return defaultRemoteCliPath
+	}
+	return ctx.CliPath
+}
+
+// Make sure the context's settings are usable
+func (ctx *Context) Validate() error {
+	if strings.TrimSpace(ctx.Name) == "" {
+		return fmt.Errorf("context name cannot be blank")
+	}
+	if strings.ContainsAny(ctx.Name, ", \t") {
+		return fmt.Errorf("context name [%s] cannot contain commas or whitespace", ctx.Name)
+	}
+	if ctx.IsSsh() && ctx.IsHttp() {
+		return fmt.Errorf("context [%s] can have an SSH host or an API server URL, but not both", ctx.Name)
+	}
+	if ctx.IsHttp() {
+		apiUrl, err := url.Parse(ctx.Url)
+		if err != nil {
+			return fmt.Errorf("context [%s] has an invalid API server URL: %w", ctx.Name, err)
+		}
+		if apiUrl.Scheme != "http" && apiUrl.Scheme != "https" {
+			return fmt.Errorf("context [%s] has an API server URL that isn't http or https", ctx.Name)
+		}
+		if apiUrl.Scheme == "http" && !isLoopbackHost(apiUrl.Hostname()) {
+			return fmt.Errorf("context [%s] has an http:// API server URL for another machine; use https:// so its token isn't sent unencrypted", ctx.Name)
+		}
+		if ctx.Token == "" {
+			return fmt.Errorf("context [%s] needs a token for its API server", ctx.Name)
+		}
+	}
+	return nil
+}
+
+// Connect to the context's machine over SSH, checking its host key against the known hosts file
+func (ctx *Context) dialSsh() (*ssh.Client, error) {
+
+	// Get the address
+	address := ctx.Host
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, defaultSshPort)
+	}
+
+	// Get the user
+	user := ctx.User
+	if user == "" {
+		user = os.Getenv("USER")
+	}
+
+	// Load the known hosts
+	knownHostsPath := ctx.KnownHostsPath
+	if knownHostsPath == "" {
+		knownHostsPath = defaultKnownHostsPath
+	}
+	knownHostsPath, err := homedir.Expand(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding known hosts path: %w", err)
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsPath)
+	if err != nil {
+		return nil, fmt.Errorf("error loading known hosts from %s: %w", knownHostsPath, err)
+	}
+
+	// Use the key file if there is one, and the SSH agent if it's running
+	authMethods := []ssh.AuthMethod{}
+	if ctx.KeyPath != "" {
+		keyPath, err := homedir.Expand(ctx.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding SSH key path: %w", err)
+		}
+		keyBytes, err := os.ReadFile(keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading SSH key %s: %w", keyPath, err)
+		}
+		signer, err := ssh.ParsePrivateKey(keyBytes)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing SSH key %s (keys with a passphrase must be loaded into ssh-agent instead): %w", keyPath, err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err == nil {
+			authMethods = append(authMethods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+	if len(authMethods) == 0 {
+		return nil, fmt.Errorf("context [%s] has no SSH key and no SSH agent is running", ctx.Name)
+	}
+
+	// Connect
+	client, err := ssh.Dial("tcp", address, &ssh.ClientConfig{
+		User:            user,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to %s over SSH: %w", ctx.Target(), err)
+	}
+	return client, nil
+
+}
+
+// Load the saved contexts from the CLI's config directory
+func LoadContexts(configPath string) ([]Context, error) {
+	path, err := homedir.Expand(filepath.Join(configPath, ContextsFile))
+	if err != nil {
+		return nil, fmt.Errorf("error expanding contexts file path: %w", err)
+	}
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return []Context{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading contexts file %s: %w", path, err)
+	}
+
+	var file contextsFile
+	if err := yaml.Unmarshal(bytes, &file); err != nil {
+		return nil, fmt.Errorf("error parsing contexts file %s: %w", path, err)
+	}
+	if file.Contexts == nil {
+		file.Contexts = []Context{}
+	}
+	return file.Contexts, nil
+}
+
+// Save the contexts to the CLI's config directory; the file can hold API tokens, so only the user can read it
+func SaveContexts(configPath string, contexts []Context) error {
+	path, err := homedir.Expand(filepath.Join(configPath, ContextsFile))
+	if err != nil {
+		return fmt.Errorf("error expanding contexts file path: %w", err)
+	}
+	bytes, err := yaml.Marshal(contextsFile{Contexts: contexts})
+	if err != nil {
+		return fmt.Errorf("error serializing contexts: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating config directory: %w", err)
+	}
+
+	// The file holds API tokens; WriteFile only applies the mode to new files, so tighten an existing one before writing to it
+	if err := os.Chmod(path, 0600); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error setting permissions on contexts file %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, bytes, 0600); err != nil {
+		return fmt.Errorf("error writing contexts file %s: %w", path, err)
+	}
+	return nil
+}
+
+// Get a saved context by name
+func GetContext(configPath string, name string) (*Context, error) {
+	contexts, err := LoadContexts(configPath)
+	if err != nil {
+		return nil, err
+	}
+	for i := range contexts {
+		if contexts[i].Name == name {
+			return &contexts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("context [%s] does not exist; use `rocketpool fleet context add` to create it", name)
+}
@@ -56,6 +56,22 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 	return response, nil
 }
 
+// Run the scrub checks against the node's prelaunch minipools
+func (c *Client) MinipoolScrubCheck() (api.MinipoolScrubCheckResponse, error) {
+	responseBytes, err := c.callAPI("minipool scrub-check")
+	if err != nil {
+		return api.MinipoolScrubCheckResponse{}, fmt.Errorf("Could not run minipool scrub check: %w", err)
+	}
+	var response api.MinipoolScrubCheckResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolScrubCheckResponse{}, fmt.Errorf("Could not decode minipool scrub check response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolScrubCheckResponse{}, fmt.Errorf("Could not run minipool scrub check: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for a refund
 func (c *Client) CanRefundMinipool(address common.Address) (api.CanRefundMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-refund %s", address.Hex()))
@@ -535,3 +551,19 @@ func (c *Client) RescueDissolvedMinipool(address common.Address, amount *big.Int
 	}
 	return response, nil
 }
+
+// Check whether a minipool's validator can use a custom fee recipient without being penalized
+func (c *Client) CanSetFeeRecipientOverride(address common.Address, feeRecipient common.Address) (api.CanSetFeeRecipientOverrideResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-set-fee-recipient-override %s %s", address.Hex(), feeRecipient.Hex()))
+	if err != nil {
+		return api.CanSetFeeRecipientOverrideResponse{}, fmt.Errorf("Could not get can set fee recipient override status: %w", err)
+	}
+	var response api.CanSetFeeRecipientOverrideResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CanSetFeeRecipientOverrideResponse{}, fmt.Errorf("Could not decode can set fee recipient override response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CanSetFeeRecipientOverrideResponse{}, fmt.Errorf("Could not get can set fee recipient override status: %s", response.Error)
+	}
+	return response, nil
+}
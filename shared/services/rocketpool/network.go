@@ -176,3 +176,19 @@ func (c *Client) GetLatestDelegate() (api.GetLatestDelegateResponse, error) {
 	}
 	return response, nil
 }
+
+// Get suggested max fees from the fee history of the Execution Client
+func (c *Client) GetGasEstimate() (api.GasEstimateResponse, error) {
+	responseBytes, err := c.callAPI("network gas-estimate")
+	if err != nil {
+		return api.GasEstimateResponse{}, fmt.Errorf("could not get gas estimate: %w", err)
+	}
+	var response api.GasEstimateResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.GasEstimateResponse{}, fmt.Errorf("could not decode gas estimate response: %w", err)
+	}
+	if response.Error != "" {
+		return api.GasEstimateResponse{}, fmt.Errorf("could not get gas estimate: %s", response.Error)
+	}
+	return response, nil
+}
@@ -1175,3 +1175,84 @@ func (c *Client) SendMessage(address common.Address, message []byte) (api.NodeSe
 	}
 	return response, nil
 }
+
+// Get the blocks proposed by the node's validators; a limit of 0 returns all of them
+func (c *Client) NodeProposals(limit uint64) (api.NodeProposalsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node proposals %d", limit))
+	if err != nil {
+		return api.NodeProposalsResponse{}, fmt.Errorf("Could not get node proposals: %w", err)
+	}
+	var response api.NodeProposalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeProposalsResponse{}, fmt.Errorf("Could not decode node proposals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeProposalsResponse{}, fmt.Errorf("Could not get node proposals: %s", response.Error)
+	}
+	utils.ZeroIfNil(&response.TotalPayloadValue)
+	return response, nil
+}
+
+// Get the automatic transactions the node daemon is waiting for cheaper gas to submit
+func (c *Client) NodeDeferredTxs() (api.NodeDeferredTxsResponse, error) {
+	responseBytes, err := c.callAPI("node deferred-txs")
+	if err != nil {
+		return api.NodeDeferredTxsResponse{}, fmt.Errorf("Could not get deferred transactions: %w", err)
+	}
+	var response api.NodeDeferredTxsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDeferredTxsResponse{}, fmt.Errorf("Could not decode deferred transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDeferredTxsResponse{}, fmt.Errorf("Could not get deferred transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the upcoming duties of the node's validators
+func (c *Client) NodeDuties() (api.NodeDutiesResponse, error) {
+	responseBytes, err := c.callAPI("node duties")
+	if err != nil {
+		return api.NodeDutiesResponse{}, fmt.Errorf("Could not get node duties: %w", err)
+	}
+	var response api.NodeDutiesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeDutiesResponse{}, fmt.Errorf("Could not decode node duties response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeDutiesResponse{}, fmt.Errorf("Could not get node duties: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the number of the node's transactions waiting in the mempool
+func (c *Client) NodePendingTxs() (api.NodePendingTxsResponse, error) {
+	responseBytes, err := c.callAPI("node pending-txs")
+	if err != nil {
+		return api.NodePendingTxsResponse{}, fmt.Errorf("Could not get pending transactions: %w", err)
+	}
+	var response api.NodePendingTxsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodePendingTxsResponse{}, fmt.Errorf("Could not decode pending transactions response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodePendingTxsResponse{}, fmt.Errorf("Could not get pending transactions: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's ledger of rewards claims, distributions, RPL staking and gas between two times; zero times mean the node's registration and now
+func (c *Client) NodeExportLedger(fromTime uint64, toTime uint64) (api.NodeExportLedgerResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("node export-ledger %d %d", fromTime, toTime))
+	if err != nil {
+		return api.NodeExportLedgerResponse{}, fmt.Errorf("Could not export node ledger: %w", err)
+	}
+	var response api.NodeExportLedgerResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeExportLedgerResponse{}, fmt.Errorf("Could not decode node ledger response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeExportLedgerResponse{}, fmt.Errorf("Could not export node ledger: %s", response.Error)
+	}
+	return response, nil
+}
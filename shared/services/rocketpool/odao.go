@@ -49,6 +49,38 @@ func (c *Client) TNDAOMembers() (api.TNDAOMembersResponse, error) {
 	return response, nil
 }
 
+// Get the duty participation history of the oracle DAO members
+func (c *Client) TNDAOParticipation() (api.TNDAOParticipationResponse, error) {
+	responseBytes, err := c.callAPI("odao participation")
+	if err != nil {
+		return api.TNDAOParticipationResponse{}, fmt.Errorf("Could not get oracle DAO participation: %w", err)
+	}
+	var response api.TNDAOParticipationResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOParticipationResponse{}, fmt.Errorf("Could not decode oracle DAO participation response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOParticipationResponse{}, fmt.Errorf("Could not get oracle DAO participation: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the status of the watchtower's tasks
+func (c *Client) TNDAOWatchtowerStatus() (api.TNDAOWatchtowerStatusResponse, error) {
+	responseBytes, err := c.callAPI("odao watchtower-status")
+	if err != nil {
+		return api.TNDAOWatchtowerStatusResponse{}, fmt.Errorf("Could not get oracle DAO watchtower status: %w", err)
+	}
+	var response api.TNDAOWatchtowerStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAOWatchtowerStatusResponse{}, fmt.Errorf("Could not decode oracle DAO watchtower status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAOWatchtowerStatusResponse{}, fmt.Errorf("Could not get oracle DAO watchtower status: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get oracle DAO proposals
 func (c *Client) TNDAOProposals() (api.TNDAOProposalsResponse, error) {
 	responseBytes, err := c.callAPI("odao proposals")
@@ -59,6 +59,22 @@ func (c *Client) PDAOProposalDetails(proposalID uint64) (api.PDAOProposalRespons
 	return response, nil
 }
 
+// Simulate the effect of a proposal's setting changes on the current network state
+func (c *Client) PDAOProposalImpact(proposalID uint64) (api.PDAOProposalImpactResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao proposal-impact %d", proposalID))
+	if err != nil {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not get protocol DAO proposal impact: %w", err)
+	}
+	var response api.PDAOProposalImpactResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not decode protocol DAO proposal impact response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not get protocol DAO proposal impact: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Check whether the node can vote on a proposal
 func (c *Client) PDAOCanVoteProposal(proposalID uint64, voteDirection types.VoteDirection) (api.CanVoteOnPDAOProposalResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("pdao can-vote-proposal %d %s", proposalID, getVoteDirectionString(voteDirection)))
@@ -187,6 +203,22 @@ func (c *Client) PDAOCanProposeSetting(contract string, setting string, value st
 	return response, nil
 }
 
+// Simulate the effect of changing a PDAO setting on the current network state
+func (c *Client) PDAOSettingImpact(contract string, setting string, value string) (api.PDAOProposalImpactResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao setting-impact %s %s %s", contract, setting, value))
+	if err != nil {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not get protocol DAO setting impact: %w", err)
+	}
+	var response api.PDAOProposalImpactResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not decode protocol DAO setting impact response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not get protocol DAO setting impact: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Propose updating a PDAO setting (use can-propose-setting to get the pollard)
 func (c *Client) PDAOProposeSetting(contract string, setting string, value string, blockNumber uint32) (api.ProposePDAOSettingResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("pdao propose-setting %s %s %s %d", contract, setting, value, blockNumber))
@@ -235,6 +267,22 @@ func (c *Client) PDAOCanProposeRewardsPercentages(node *big.Int, odao *big.Int,
 	return response, nil
 }
 
+// Simulate the effect of new RPL rewards allocation percentages on the current network state
+func (c *Client) PDAORewardsPercentagesImpact(node *big.Int, odao *big.Int, pdao *big.Int) (api.PDAOProposalImpactResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao rewards-percentages-impact %s %s %s", node.String(), odao.String(), pdao.String()))
+	if err != nil {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not get protocol DAO rewards percentages impact: %w", err)
+	}
+	var response api.PDAOProposalImpactResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not decode protocol DAO rewards percentages impact response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOProposalImpactResponse{}, fmt.Errorf("Could not get protocol DAO rewards percentages impact: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Propose new RPL rewards allocation percentages for the Oracle DAO, the Protocol DAO, and the node operators
 func (c *Client) PDAOProposeRewardsPercentages(node *big.Int, odao *big.Int, pdao *big.Int, blockNumber uint32) (api.ProposePDAOSettingResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("pdao propose-rewards-percentages %s %s %s %d", node, odao, pdao, blockNumber))
@@ -559,6 +607,38 @@ func (c *Client) PDAOCanDefeatProposal(proposalID uint64, index uint64) (api.PDA
 	return response, nil
 }
 
+// Get the node's voting power, its history, and an estimate with additional RPL or minipools
+func (c *Client) PDAOVotingPower(additionalRpl *big.Int, additionalMinipools uint64, bondAmount *big.Int) (api.PDAOVotingPowerResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao voting-power %s %d %s", additionalRpl.String(), additionalMinipools, bondAmount.String()))
+	if err != nil {
+		return api.PDAOVotingPowerResponse{}, fmt.Errorf("Could not get protocol DAO voting-power: %w", err)
+	}
+	var response api.PDAOVotingPowerResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOVotingPowerResponse{}, fmt.Errorf("Could not decode protocol DAO voting-power response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOVotingPowerResponse{}, fmt.Errorf("Could not get protocol DAO voting-power: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Audit the voting tree submissions for a proposal
+func (c *Client) PDAOAuditProposal(proposalID uint64) (api.PDAOProposalAuditResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao audit-proposal %d", proposalID))
+	if err != nil {
+		return api.PDAOProposalAuditResponse{}, fmt.Errorf("Could not get protocol DAO audit-proposal: %w", err)
+	}
+	var response api.PDAOProposalAuditResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOProposalAuditResponse{}, fmt.Errorf("Could not decode protocol DAO audit-proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOProposalAuditResponse{}, fmt.Errorf("Could not get protocol DAO audit-proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Defeat a proposal
 func (c *Client) PDAODefeatProposal(proposalID uint64, index uint64) (api.PDAODefeatProposalResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("pdao defeat-proposal %d %d", proposalID, index))
@@ -702,3 +782,19 @@ func (c *Client) PDAOStatus() (api.PDAOStatusResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the onchain voting power of each delegate, the node's delegators, and how the node's delegate has voted
+func (c *Client) PDAODelegates() (api.PDAODelegatesResponse, error) {
+	responseBytes, err := c.callAPI("pdao delegates")
+	if err != nil {
+		return api.PDAODelegatesResponse{}, fmt.Errorf("Could not get protocol DAO delegates: %w", err)
+	}
+	var response api.PDAODelegatesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAODelegatesResponse{}, fmt.Errorf("Could not decode protocol DAO delegates response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAODelegatesResponse{}, fmt.Errorf("Could not get protocol DAO delegates: %s", response.Error)
+	}
+	return response, nil
+}
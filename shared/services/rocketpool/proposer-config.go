@@ -0,0 +1,480 @@
+package rocketpool
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/keymanager"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+)
+
+// Config
+const (
+	LighthouseDefinitionsFilename string      = "validator_definitions.yml"
+	lighthouseLocalKeystoreType   string      = "local_keystore"
+	lighthouseGraffitiKey         string      = "graffiti"
+	lighthouseFeeRecipientKey     string      = "suggested_fee_recipient"
+	lighthouseVotingPublicKeyKey  string      = "voting_public_key"
+	keymanagerTokenLength         int         = 32
+	keymanagerTokenFileMode       os.FileMode = 0600
+)
+
+// The proposal settings for a single validator.
+// A nil fee recipient or a blank graffiti means the validator uses the node-wide default.
+type ValidatorProposerSettings struct {
+	Pubkey       types.ValidatorPubkey
+	FeeRecipient *common.Address
+	Graffiti     string
+}
+
+// Teku / Prysm proposer config file format
+type proposerConfigFile struct {
+	ProposerConfig map[string]proposerConfigEntry `json:"proposer_config"`
+	DefaultConfig  proposerConfigEntry            `json:"default_config"`
+}
+type proposerConfigEntry struct {
+	FeeRecipient string                `json:"fee_recipient"`
+	Builder      *proposerBuilderEntry `json:"builder,omitempty"`
+}
+type proposerBuilderEntry struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Prysm graffiti file format
+type prysmGraffitiFile struct {
+	Default  string            `yaml:"default"`
+	Specific map[string]string `yaml:"specific,omitempty"`
+}
+
+// The per-validator settings that were last pushed to the Validator Client's keymanager API
+type keymanagerState map[string]keymanagerStateEntry
+type keymanagerStateEntry struct {
+	FeeRecipient string `json:"feeRecipient,omitempty"`
+	Graffiti     string `json:"graffiti,omitempty"`
+}
+
+// Renders the per-validator proposal settings into the proposer config files used by the selected Validator Client.
+// Returns true if any of the files changed, in which case the VC must be restarted to pick them up.
+// Clients that are configured through the keymanager API instead (Nimbus and Lodestar) are skipped; see ApplyKeymanagerProposerSettings.
+func UpdateProposerConfigFiles(defaultFeeRecipient common.Address, settings []ValidatorProposerSettings, cfg *config.RocketPoolConfig) (bool, error) {
+	cc, _ := cfg.GetSelectedConsensusClient()
+	switch cc {
+	case cfgtypes.ConsensusClient_Teku:
+		return updateTekuProposerConfig(defaultFeeRecipient, settings, cfg)
+	case cfgtypes.ConsensusClient_Prysm:
+		return updatePrysmProposerSettings(defaultFeeRecipient, settings, cfg)
+	case cfgtypes.ConsensusClient_Lighthouse:
+		return updateLighthouseDefinitions(settings, cfg)
+	}
+	return false, nil
+}
+
+// Creates the files the Validator Client is pointed at for its proposal settings if they don't exist yet, so it can start before the node daemon has filled them in.
+// The proposer config files only get the default fee recipient; the node daemon replaces them with the real settings once it's running.
+func DeployDefaultProposerSettings(defaultFeeRecipient common.Address, cfg *config.RocketPoolConfig) error {
+	cc, _ := cfg.GetSelectedConsensusClient()
+	var paths []string
+	switch cc {
+	case cfgtypes.ConsensusClient_Teku:
+		paths = []string{cfg.Smartnode.GetTekuProposerConfigPath(false)}
+	case cfgtypes.ConsensusClient_Prysm:
+		paths = []string{cfg.Smartnode.GetPrysmProposerSettingsPath(false), cfg.Smartnode.GetPrysmGraffitiPath(false)}
+	case cfgtypes.ConsensusClient_Nimbus, cfgtypes.ConsensusClient_Lodestar:
+		_, err := GetKeymanagerToken(cfg)
+		return err
+	default:
+		return nil
+	}
+
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			_, err = UpdateProposerConfigFiles(defaultFeeRecipient, nil, cfg)
+			return err
+		}
+		if err != nil {
+			return fmt.Errorf("error checking [%s]: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Pushes any changed per-validator proposal settings to the Validator Client's keymanager API, for clients that don't support a proposer config file (Nimbus and Lodestar).
+// Overrides applied earlier to validators that are no longer in the list (such as finalized minipools) are removed.
+// These take effect immediately and don't require a VC restart.
+func ApplyKeymanagerProposerSettings(settings []ValidatorProposerSettings, cfg *config.RocketPoolConfig) error {
+	cc, _ := cfg.GetSelectedConsensusClient()
+	if cc != cfgtypes.ConsensusClient_Nimbus && cc != cfgtypes.ConsensusClient_Lodestar {
+		return nil
+	}
+
+	// Load what was applied last time
+	statePath := cfg.Smartnode.GetProposerSettingsStatePath()
+	appliedState := keymanagerState{}
+	stateBytes, err := os.ReadFile(statePath)
+	if err == nil {
+		if err := json.Unmarshal(stateBytes, &appliedState); err != nil {
+			return fmt.Errorf("error deserializing proposer settings state: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("error reading proposer settings state: %w", err)
+	}
+
+	token, err := GetKeymanagerToken(cfg)
+	if err != nil {
+		return err
+	}
+	client := keymanager.NewClient(cfg.GetKeymanagerApiUrl(), token)
+
+	// Apply the differences; if a request fails, the progress so far is saved so the successful requests aren't repeated
+	newState := keymanagerState{}
+	for _, validator := range settings {
+		key := hexutil.AddPrefix(validator.Pubkey.Hex())
+		applied := appliedState[key]
+		desired := keymanagerStateEntry{
+			Graffiti: validator.Graffiti,
+		}
+		if validator.FeeRecipient != nil {
+			desired.FeeRecipient = validator.FeeRecipient.Hex()
+		}
+
+		if desired.FeeRecipient != applied.FeeRecipient {
+			if desired.FeeRecipient != "" {
+				err = client.SetFeeRecipient(validator.Pubkey, *validator.FeeRecipient)
+			} else {
+				err = client.DeleteFeeRecipient(validator.Pubkey)
+			}
+			if err != nil {
+				saveKeymanagerState(statePath, mergeKeymanagerState(appliedState, newState))
+				return fmt.Errorf("error updating the fee recipient for validator %s: %w", key, err)
+			}
+		}
+		if desired.Graffiti != applied.Graffiti {
+			if desired.Graffiti != "" {
+				err = client.SetGraffiti(validator.Pubkey, cfg.FormatGraffiti(desired.Graffiti))
+			} else {
+				err = client.DeleteGraffiti(validator.Pubkey)
+			}
+			if err != nil {
+				newState[key] = keymanagerStateEntry{FeeRecipient: desired.FeeRecipient, Graffiti: applied.Graffiti}
+				saveKeymanagerState(statePath, mergeKeymanagerState(appliedState, newState))
+				return fmt.Errorf("error updating the graffiti for validator %s: %w", key, err)
+			}
+		}
+		if desired.FeeRecipient != "" || desired.Graffiti != "" {
+			newState[key] = desired
+		} else {
+			delete(appliedState, key)
+		}
+	}
+
+	// Remove the overrides of validators that are no longer in the list
+	listed := map[string]bool{}
+	for _, validator := range settings {
+		listed[hexutil.AddPrefix(validator.Pubkey.Hex())] = true
+	}
+	for key, applied := range appliedState {
+		if listed[key] {
+			continue
+		}
+		pubkey, err := types.HexToValidatorPubkey(hexutil.RemovePrefix(key))
+		if err != nil {
+			// It can't have been applied, so there's nothing to remove
+			delete(appliedState, key)
+			continue
+		}
+		if applied.FeeRecipient != "" {
+			if err := client.DeleteFeeRecipient(pubkey); err != nil {
+				saveKeymanagerState(statePath, mergeKeymanagerState(appliedState, newState))
+				return fmt.Errorf("error removing the fee recipient for validator %s: %w", key, err)
+			}
+		}
+		if applied.Graffiti != "" {
+			if err := client.DeleteGraffiti(pubkey); err != nil {
+				appliedState[key] = keymanagerStateEntry{Graffiti: applied.Graffiti}
+				saveKeymanagerState(statePath, mergeKeymanagerState(appliedState, newState))
+				return fmt.Errorf("error removing the graffiti for validator %s: %w", key, err)
+			}
+		}
+		delete(appliedState, key)
+	}
+
+	return saveKeymanagerState(statePath, newState)
+}
+
+// Gets the bearer token for the Validator Client's keymanager API, creating it if it doesn't exist yet
+func GetKeymanagerToken(cfg *config.RocketPoolConfig) (string, error) {
+	path := cfg.Smartnode.GetKeymanagerTokenPath()
+	existingToken, err := os.ReadFile(path)
+	if err == nil {
+		return strings.TrimSpace(string(existingToken)), nil
+	}
+	if !os.IsNotExist(err) {
+		return "", fmt.Errorf("error reading keymanager API token: %w", err)
+	}
+
+	tokenBytes := make([]byte, keymanagerTokenLength)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", fmt.Errorf("error generating keymanager API token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	if err := os.WriteFile(path, []byte(token), keymanagerTokenFileMode); err != nil {
+		return "", fmt.Errorf("error writing keymanager API token: %w", err)
+	}
+	return token, nil
+}
+
+// Renders Teku's proposer config file
+func updateTekuProposerConfig(defaultFeeRecipient common.Address, settings []ValidatorProposerSettings, cfg *config.RocketPoolConfig) (bool, error) {
+	file := proposerConfigFile{
+		ProposerConfig: map[string]proposerConfigEntry{},
+		DefaultConfig: proposerConfigEntry{
+			FeeRecipient: defaultFeeRecipient.Hex(),
+			Builder: &proposerBuilderEntry{
+				Enabled: cfg.EnableMevBoost.Value.(bool),
+			},
+		},
+	}
+	for _, validator := range settings {
+		if validator.FeeRecipient != nil {
+			file.ProposerConfig[hexutil.AddPrefix(validator.Pubkey.Hex())] = proposerConfigEntry{
+				FeeRecipient: validator.FeeRecipient.Hex(),
+			}
+		}
+	}
+	contents, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("error serializing Teku proposer config: %w", err)
+	}
+
+	// Teku's proposer config has no graffiti support, so graffiti overrides don't apply to it; the CLI refuses to set them for Teku
+	return writeIfChanged(cfg.Smartnode.GetTekuProposerConfigPath(false), contents)
+}
+
+// Renders Prysm's proposer settings and graffiti files
+func updatePrysmProposerSettings(defaultFeeRecipient common.Address, settings []ValidatorProposerSettings, cfg *config.RocketPoolConfig) (bool, error) {
+	file := proposerConfigFile{
+		ProposerConfig: map[string]proposerConfigEntry{},
+		DefaultConfig: proposerConfigEntry{
+			FeeRecipient: defaultFeeRecipient.Hex(),
+			Builder: &proposerBuilderEntry{
+				Enabled: cfg.EnableMevBoost.Value.(bool),
+			},
+		},
+	}
+	graffitiFile := prysmGraffitiFile{
+		Specific: map[string]string{},
+	}
+	defaultGraffiti, err := cfg.Graffiti()
+	if err != nil {
+		return false, err
+	}
+	graffitiFile.Default = defaultGraffiti
+	for _, validator := range settings {
+		key := hexutil.AddPrefix(validator.Pubkey.Hex())
+		if validator.FeeRecipient != nil {
+			file.ProposerConfig[key] = proposerConfigEntry{
+				FeeRecipient: validator.FeeRecipient.Hex(),
+			}
+		}
+		if validator.Graffiti != "" {
+			graffitiFile.Specific[key] = cfg.FormatGraffiti(validator.Graffiti)
+		}
+	}
+
+	// Write the proposer settings
+	contents, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("error serializing Prysm proposer settings: %w", err)
+	}
+	settingsChanged, err := writeIfChanged(cfg.Smartnode.GetPrysmProposerSettingsPath(false), contents)
+	if err != nil {
+		return false, err
+	}
+
+	// Write the graffiti
+	contents, err = yaml.Marshal(graffitiFile)
+	if err != nil {
+		return false, fmt.Errorf("error serializing Prysm graffiti file: %w", err)
+	}
+	graffitiChanged, err := writeIfChanged(cfg.Smartnode.GetPrysmGraffitiPath(false), contents)
+	if err != nil {
+		return false, err
+	}
+
+	return settingsChanged || graffitiChanged, nil
+}
+
+// Updates the graffiti and fee recipient of each of the node's validators in Lighthouse's validator definitions file.
+// Lighthouse owns this file and adds newly discovered keys to it on startup, so existing entries are edited in place
+// and the file is only rewritten if one of them actually changed.
+func updateLighthouseDefinitions(settings []ValidatorProposerSettings, cfg *config.RocketPoolConfig) (bool, error) {
+
+	// Load the existing definitions
+	lighthousePath := filepath.Join(cfg.Smartnode.GetValidatorKeychainPath(), lighthouse.KeystoreDir)
+	path := filepath.Join(lighthousePath, lighthouse.ValidatorsDir, LighthouseDefinitionsFilename)
+	definitions := []yaml.MapSlice{}
+	existingDefinitions, err := os.ReadFile(path)
+	if err == nil {
+		if err := yaml.Unmarshal(existingDefinitions, &definitions); err != nil {
+			return false, fmt.Errorf("error deserializing Lighthouse validator definitions: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return false, fmt.Errorf("error reading Lighthouse validator definitions: %w", err)
+	}
+
+	// Update the existing entries
+	changed := false
+	found := map[types.ValidatorPubkey]bool{}
+	settingsByPubkey := map[string]ValidatorProposerSettings{}
+	for _, validator := range settings {
+		settingsByPubkey[strings.ToLower(hexutil.AddPrefix(validator.Pubkey.Hex()))] = validator
+	}
+	for i, definition := range definitions {
+		var pubkey string
+		for _, item := range definition {
+			if item.Key == lighthouseVotingPublicKeyKey {
+				pubkey = strings.ToLower(fmt.Sprint(item.Value))
+			}
+		}
+		validator, exists := settingsByPubkey[pubkey]
+		if !exists {
+			continue
+		}
+		found[validator.Pubkey] = true
+		var definitionChanged bool
+		definitions[i], definitionChanged = setLighthouseOverrides(definition, validator, cfg)
+		changed = changed || definitionChanged
+	}
+
+	// Add any validators with overrides that Lighthouse hasn't discovered yet
+	vcLighthousePath := filepath.Join(cfg.Smartnode.GetValidatorKeychainPathInVC(), lighthouse.KeystoreDir)
+	for _, validator := range settings {
+		if found[validator.Pubkey] || (validator.FeeRecipient == nil && validator.Graffiti == "") {
+			continue
+		}
+		pubkey := hexutil.AddPrefix(validator.Pubkey.Hex())
+		definition := yaml.MapSlice{
+			{Key: "enabled", Value: true},
+			{Key: lighthouseVotingPublicKeyKey, Value: pubkey},
+			{Key: "description", Value: ""},
+			{Key: "type", Value: lighthouseLocalKeystoreType},
+			{Key: "voting_keystore_path", Value: filepath.Join(vcLighthousePath, lighthouse.ValidatorsDir, pubkey, lighthouse.KeyFileName)},
+			{Key: "voting_keystore_password_path", Value: filepath.Join(vcLighthousePath, lighthouse.SecretsDir, pubkey)},
+		}
+		definition, _ = setLighthouseOverrides(definition, validator, cfg)
+		definitions = append(definitions, definition)
+		changed = true
+	}
+	if !changed {
+		return false, nil
+	}
+
+	// Write the file
+	contents, err := yaml.Marshal(definitions)
+	if err != nil {
+		return false, fmt.Errorf("error serializing Lighthouse validator definitions: %w", err)
+	}
+	contents = append([]byte("---\n"), contents...)
+	return writeIfChanged(path, contents)
+
+}
+
+// Sets or removes the graffiti and fee recipient fields of a Lighthouse validator definition.
+// Returns true if the definition was changed.
+func setLighthouseOverrides(definition yaml.MapSlice, validator ValidatorProposerSettings, cfg *config.RocketPoolConfig) (yaml.MapSlice, bool) {
+	desired := map[string]string{}
+	if validator.Graffiti != "" {
+		desired[lighthouseGraffitiKey] = cfg.FormatGraffiti(validator.Graffiti)
+	}
+	if validator.FeeRecipient != nil {
+		desired[lighthouseFeeRecipientKey] = validator.FeeRecipient.Hex()
+	}
+
+	changed := false
+	updated := yaml.MapSlice{}
+	for _, item := range definition {
+		if item.Key != lighthouseGraffitiKey && item.Key != lighthouseFeeRecipientKey {
+			updated = append(updated, item)
+			continue
+		}
+		key := item.Key.(string)
+		value, exists := desired[key]
+		if !exists {
+			// Remove a stale override
+			changed = true
+			continue
+		}
+		if !strings.EqualFold(fmt.Sprint(item.Value), value) {
+			item.Value = value
+			changed = true
+		}
+		updated = append(updated, item)
+		delete(desired, key)
+	}
+
+	// Add the new overrides
+	for _, key := range []string{lighthouseGraffitiKey, lighthouseFeeRecipientKey} {
+		if value, exists := desired[key]; exists {
+			updated = append(updated, yaml.MapItem{Key: key, Value: value})
+			changed = true
+		}
+	}
+	return updated, changed
+}
+
+// Writes the contents to the file if they're different from what's already there.
+// Returns true if the file was written.
+func writeIfChanged(path string, contents []byte) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, contents) {
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("error reading [%s]: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return false, fmt.Errorf("error creating folder for [%s]: %w", path, err)
+	}
+	if err := os.WriteFile(path, contents, FileMode); err != nil {
+		return false, fmt.Errorf("error writing [%s]: %w", path, err)
+	}
+	return true, nil
+}
+
+// Combines the previously applied keymanager state with the entries that were applied this time around
+func mergeKeymanagerState(applied keymanagerState, updated keymanagerState) keymanagerState {
+	merged := keymanagerState{}
+	for key, entry := range applied {
+		merged[key] = entry
+	}
+	for key, entry := range updated {
+		merged[key] = entry
+	}
+	return merged
+}
+
+// Saves the keymanager state to disk
+func saveKeymanagerState(path string, state keymanagerState) error {
+	contents, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("error serializing proposer settings state: %w", err)
+	}
+	if err := os.WriteFile(path, contents, FileMode); err != nil {
+		return fmt.Errorf("error saving proposer settings state: %w", err)
+	}
+	return nil
+}
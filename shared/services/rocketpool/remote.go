@@ -0,0 +1,246 @@
+package rocketpool
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alessio/shellescape"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Settings
+const (
+	defaultRemoteConfigPath string = ".rocketpool"
+	apiServerTimeout               = 5 * time.Minute
+)
+
+// Create a new Rocket Pool client for a saved context without checking for sync status
+func NewClientFromContext(c *cli.Context, ctx *Context) *Client {
+	client := newClient(c)
+	client.useContext(ctx)
+	return client
+}
+
+// Point the client at the Smart Node of a context
+func (c *Client) useContext(ctx *Context) {
+	c.context = ctx
+	if ctx.ConfigPath != "" {
+		c.configPath = ctx.ConfigPath
+	} else if c.isRemote() {
+		c.configPath = defaultRemoteConfigPath
+	}
+	if ctx.DaemonPath != "" || c.isRemote() {
+		c.daemonPath = ctx.DaemonPath
+	}
+}
+
+// Get the context the client targets, or nil if it targets this machine's Smart Node directly
+func (c *Client) Context() *Context {
+	return c.context
+}
+
+// Check if the client targets a Smart Node on another machine
+func (c *Client) isRemote() bool {
+	return c.context != nil && (c.context.IsSsh() || c.context.IsHttp())
+}
+
+// Return an error if the client targets a Smart Node on another machine; managing the service has to happen on that machine
+func (c *Client) requireLocal(action string) error {
+	if c.isRemote() {
+		return fmt.Errorf("cannot %s on a remote Smart Node (context [%s]); run this command on that machine instead", action, c.context.Name)
+	}
+	return nil
+}
+
+// Get the version of the Rocket Pool CLI on the context's machine
+func (c *Client) GetContextCliVersion() (string, error) {
+	if c.context == nil {
+		return "", fmt.Errorf("the client doesn't target a context")
+	}
+	versionBytes, err := c.readOutput(fmt.Sprintf("%s --version", shellescape.Quote(c.context.GetCliPath())))
+	if err != nil {
+		return "", fmt.Errorf("Could not get the Rocket Pool CLI version of context [%s]: %w", c.context.Name, err)
+	}
+	elements := strings.Fields(string(versionBytes))
+	if len(elements) < 1 {
+		return "", fmt.Errorf("Could not parse the Rocket Pool CLI version of context [%s] from output '%s'", c.context.Name, string(versionBytes))
+	}
+	return elements[len(elements)-1], nil
+}
+
+// Install the given Smart Node package version on the context's machine with the CLI there, then restart the service with it
+func (c *Client) UpdateContextService(version string) error {
+	if c.context == nil {
+		return fmt.Errorf("the client doesn't target a context")
+	}
+	cliArgs := shellescape.Quote(c.context.GetCliPath())
+	if c.context.ConfigPath != "" {
+		cliArgs += fmt.Sprintf(" --config-path %s", shellescape.Quote(c.context.ConfigPath))
+	}
+	cmd := fmt.Sprintf("%s service install -d -y -v %s && %s service start -y", cliArgs, shellescape.Quote(version), cliArgs)
+	return c.printOutput(cmd)
+}
+
+// Get a path on the remote machine that its shell will resolve; SSH sessions start in the user's home directory
+func remotePath(path string) string {
+	if path == "~" {
+		return "."
+	}
+	return strings.TrimPrefix(path, "~/")
+}
+
+// Load the config of a remote Smart Node
+// Returns the RocketPoolConfig and whether or not it was newly generated
+func (c *Client) loadRemoteConfig() (*config.RocketPoolConfig, bool, error) {
+	var configBytes []byte
+	var err error
+	if c.context.IsHttp() {
+		configBytes, err = c.callApiServer(http.MethodGet, api.ApiServerConfigPath, nil)
+	} else {
+		configBytes, err = c.readOutput(fmt.Sprintf("cat %s", shellescape.Quote(remotePath(c.configPath)+"/"+SettingsFile)))
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading the settings file of context [%s]: %w", c.context.Name, err)
+	}
+	if len(configBytes) == 0 {
+		return nil, false, fmt.Errorf("the Smart Node of context [%s] has not been configured yet", c.context.Name)
+	}
+
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
+		return nil, false, fmt.Errorf("could not parse the settings file of context [%s]: %w", c.context.Name, err)
+	}
+	cfg := config.NewRocketPoolConfig(c.configPath, c.daemonPath != "")
+	if err := cfg.Deserialize(settings); err != nil {
+		return nil, false, fmt.Errorf("could not deserialize the settings file of context [%s]: %w", c.context.Name, err)
+	}
+	return cfg, false, nil
+}
+
+// Get the arguments for an API call, including the global flags the daemon needs
+func (c *Client) getApiCallArgList(args string, otherArgs ...string) []string {
+	argList := []string{}
+	if c.ignoreSyncCheck {
+		argList = append(argList, "--ignore-sync-check")
+	}
+	if c.forceFallbacks {
+		argList = append(argList, "--force-fallbacks")
+	}
+	argList = append(argList,
+		"--maxFee", fmt.Sprintf("%f", c.maxFee),
+		"--maxPrioFee", fmt.Sprintf("%f", c.maxPrioFee),
+		"--gasLimit", fmt.Sprint(c.gasLimit),
+	)
+	if c.customNonce != nil {
+		argList = append(argList, "--nonce", c.customNonce.String())
+	}
+	argList = append(argList, "api")
+	argList = append(argList, strings.Fields(args)...)
+	return append(argList, otherArgs...)
+}
+
+// Call the Rocket Pool API through a remote daemon's API server
+func (c *Client) callHttpAPI(args string, otherArgs ...string) ([]byte, error) {
+	request, err := json.Marshal(api.ApiServerRequest{
+		Args: c.getApiCallArgList(args, otherArgs...),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error serializing API request: %w", err)
+	}
+	if c.debugPrint {
+		fmt.Printf("To API server %s:\n", c.context.Url)
+		fmt.Println(string(request))
+	}
+
+	output, err := c.callApiServer(http.MethodPost, api.ApiServerCommandPath, request)
+
+	if c.debugPrint {
+		if output != nil {
+			fmt.Println("API Out:")
+			fmt.Println(string(output))
+		}
+		if err != nil {
+			fmt.Println("API Err:")
+			fmt.Println(err.Error())
+		}
+	}
+
+	// Reset the gas settings after the call
+	c.maxFee = c.originalMaxFee
+	c.maxPrioFee = c.originalMaxPrioFee
+	c.gasLimit = c.originalGasLimit
+
+	return output, err
+}
+
+// Get the Rocket Pool service version from a remote daemon's API server
+func (c *Client) getHttpServiceVersion() (string, error) {
+	responseBytes, err := c.callApiServer(http.MethodGet, api.ApiServerVersionPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("Could not get Rocket Pool service version: %w", err)
+	}
+	var response api.ApiServerVersionResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return "", fmt.Errorf("Could not decode Rocket Pool service version response: %w", err)
+	}
+	if response.Error != "" {
+		return "", fmt.Errorf("Could not get Rocket Pool service version: %s", response.Error)
+	}
+	return response.Version, nil
+}
+
+// Send a request to a remote daemon's API server.
+// Plain HTTP is only allowed for servers on this machine, since the token and responses would otherwise cross the network unencrypted.
+func (c *Client) callApiServer(method string, path string, body []byte) ([]byte, error) {
+	apiUrl, err := url.Parse(c.context.Url)
+	if err != nil {
+		return nil, fmt.Errorf("context [%s] has an invalid API server URL: %w", c.context.Name, err)
+	}
+	if apiUrl.Scheme != "https" && !isLoopbackHost(apiUrl.Hostname()) {
+		return nil, fmt.Errorf("the API server of context [%s] isn't on this machine, so it must use an https:// URL", c.context.Name)
+	}
+
+	request, err := http.NewRequest(method, strings.TrimSuffix(c.context.Url, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Authorization", "Bearer "+c.context.Token)
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	client := http.Client{Timeout: apiServerTimeout}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error calling the API server of context [%s]: %w", c.context.Name, err)
+	}
+	defer response.Body.Close()
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the response from the API server of context [%s]: %w", c.context.Name, err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("the API server of context [%s] returned %s: %s", c.context.Name, response.Status, strings.TrimSpace(string(responseBytes)))
+	}
+	return responseBytes, nil
+}
+
+// Check if a host name refers to this machine
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
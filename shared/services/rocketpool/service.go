@@ -56,3 +56,51 @@ func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	}
 	return response, nil
 }
+
+// Creates an encrypted migration bundle in the daemon's migration folder
+func (c *Client) CreateMigrationBundle(passphrase string) (api.CreateMigrationBundleResponse, error) {
+	responseBytes, err := c.callAPI("service create-migration-bundle", passphrase)
+	if err != nil {
+		return api.CreateMigrationBundleResponse{}, fmt.Errorf("Could not create migration bundle: %w", err)
+	}
+	var response api.CreateMigrationBundleResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CreateMigrationBundleResponse{}, fmt.Errorf("Could not decode create-migration-bundle response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CreateMigrationBundleResponse{}, fmt.Errorf("Could not create migration bundle: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Restores a migration bundle that has been placed in the daemon's migration folder
+func (c *Client) RestoreMigrationBundle(filename string, passphrase string) (api.RestoreMigrationBundleResponse, error) {
+	responseBytes, err := c.callAPI("service restore-migration-bundle", filename, passphrase)
+	if err != nil {
+		return api.RestoreMigrationBundleResponse{}, fmt.Errorf("Could not restore migration bundle: %w", err)
+	}
+	var response api.RestoreMigrationBundleResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RestoreMigrationBundleResponse{}, fmt.Errorf("Could not decode restore-migration-bundle response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RestoreMigrationBundleResponse{}, fmt.Errorf("Could not restore migration bundle: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Checks whether any of the node's validators performed duties during the last completed epoch
+func (c *Client) GetValidatorLiveness() (api.ValidatorLivenessResponse, error) {
+	responseBytes, err := c.callAPI("service get-validator-liveness")
+	if err != nil {
+		return api.ValidatorLivenessResponse{}, fmt.Errorf("Could not get validator liveness: %w", err)
+	}
+	var response api.ValidatorLivenessResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ValidatorLivenessResponse{}, fmt.Errorf("Could not decode validator liveness response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ValidatorLivenessResponse{}, fmt.Errorf("Could not get validator liveness: %s", response.Error)
+	}
+	return response, nil
+}
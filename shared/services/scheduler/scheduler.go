@@ -0,0 +1,385 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Config
+const (
+	DefaultTimeout    time.Duration = 15 * time.Minute
+	DefaultMaxBackoff time.Duration = 15 * time.Minute
+	StatusVersion     uint64        = 1
+	statusFileMode    os.FileMode   = 0644
+)
+
+// A task the scheduler runs in its own goroutine on its own interval
+type Task struct {
+	// The name of the task, used in logs and the status
+	Name string
+
+	// The time between the end of one run and the start of the next
+	Interval time.Duration
+
+	// A random extra delay of up to this much is added to each interval
+	Jitter time.Duration
+
+	// How long a run can take before its context is cancelled and it's reported as timed out.
+	// Runs that don't stop when their context is cancelled keep going, and the task's runs are skipped until they finish.
+	Timeout time.Duration
+
+	// The longest delay after consecutive failures; the interval doubles after each failure until it reaches this
+	MaxBackoff time.Duration
+
+	// If set, the task runs early whenever this receives a value instead of waiting for the rest of its interval
+	Wake <-chan struct{}
+
+	// The shortest time between the starts of two runs, even when the task is woken early
+	MinInterval time.Duration
+
+	// If set, the task holds the scheduler's transaction lock while it runs so it never sends transactions from the node wallet at the same time as another task
+	SendsTransactions bool
+
+	// Runs the task; the context is cancelled when the run times out
+	Run func(ctx context.Context) error
+}
+
+// Makes sure only one task at a time sends transactions from the node wallet, so they don't race each other for the same nonce
+type TransactionLock struct {
+	sem chan struct{}
+}
+
+// Create a new transaction lock
+func NewTransactionLock() *TransactionLock {
+	return &TransactionLock{
+		sem: make(chan struct{}, 1),
+	}
+}
+
+// Wait for the lock, giving up if the context is cancelled first
+func (l *TransactionLock) Lock(ctx context.Context) error {
+	select {
+	case l.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Release the lock
+func (l *TransactionLock) Unlock() {
+	<-l.sem
+}
+
+// The status of a task
+type TaskStatus struct {
+	Name                string        `json:"name"`
+	Interval            time.Duration `json:"interval"`
+	IsRunning           bool          `json:"isRunning"`
+	LastRun             time.Time     `json:"lastRun"`
+	LastSuccess         time.Time     `json:"lastSuccess"`
+	LastDuration        time.Duration `json:"lastDuration"`
+	NextRun             time.Time     `json:"nextRun"`
+	LastError           string        `json:"lastError"`
+	LastErrorTime       time.Time     `json:"lastErrorTime"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	SkippedRuns         int           `json:"skippedRuns"`
+}
+
+// The status of every task, as saved to disk
+type SchedulerStatus struct {
+	Version     uint64       `json:"version"`
+	UpdatedTime time.Time    `json:"updatedTime"`
+	Tasks       []TaskStatus `json:"tasks"`
+}
+
+// Runs tasks independently of each other, so a slow task doesn't delay the rest
+type Scheduler struct {
+	log        *log.ColorLogger
+	statusPath string
+	tasks      []Task
+	status     map[string]*TaskStatus
+	lock       *sync.Mutex
+	txLock     *TransactionLock
+}
+
+// Create a new scheduler. If the status path isn't empty, the status of every task is saved there after each run.
+func NewScheduler(errorLog *log.ColorLogger, statusPath string) *Scheduler {
+	return &Scheduler{
+		log:        errorLog,
+		statusPath: statusPath,
+		tasks:      []Task{},
+		status:     map[string]*TaskStatus{},
+		lock:       &sync.Mutex{},
+		txLock:     NewTransactionLock(),
+	}
+}
+
+// Add a task to the scheduler; tasks must be added before the scheduler starts
+func (s *Scheduler) AddTask(task Task) {
+	if task.Timeout == 0 {
+		task.Timeout = DefaultTimeout
+	}
+	if task.MaxBackoff == 0 {
+		task.MaxBackoff = DefaultMaxBackoff
+	}
+	s.tasks = append(s.tasks, task)
+	s.status[task.Name] = &TaskStatus{
+		Name:     task.Name,
+		Interval: task.Interval,
+	}
+}
+
+// Start running every task, staggering their first runs by the provided delay. The tasks stop when the context is cancelled.
+func (s *Scheduler) Start(ctx context.Context, stagger time.Duration) {
+	for i, task := range s.tasks {
+		go s.runTask(ctx, task, time.Duration(i)*stagger)
+	}
+}
+
+// Get the lock that tasks which send transactions hold while they run, for work that sends transactions outside of a task's run
+func (s *Scheduler) TransactionLock() *TransactionLock {
+	return s.txLock
+}
+
+// Get the status of every task, in the order they were added
+func (s *Scheduler) GetStatus() SchedulerStatus {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.getStatusImpl()
+}
+
+// Serve the status of every task as JSON
+func (s *Scheduler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bytes, err := json.Marshal(s.GetStatus())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(bytes)
+}
+
+// Run a task until the context is cancelled
+func (s *Scheduler) runTask(ctx context.Context, task Task, initialDelay time.Duration) {
+	s.updateStatus(task.Name, func(status *TaskStatus) {
+		status.NextRun = time.Now().Add(initialDelay)
+	})
+	timer := time.NewTimer(initialDelay)
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		timer.Stop()
+		return
+	}
+
+	for {
+		start := time.Now()
+		err := s.runOnce(ctx, task)
+
+		// Record the result and get the next delay
+		var delay time.Duration
+		s.updateStatus(task.Name, func(status *TaskStatus) {
+			now := time.Now()
+			status.IsRunning = false
+			status.LastDuration = now.Sub(start)
+			if err != nil {
+				status.LastError = err.Error()
+				status.LastErrorTime = now
+				status.ConsecutiveFailures++
+			} else {
+				status.LastSuccess = now
+				status.ConsecutiveFailures = 0
+			}
+			delay = getDelay(task, status.ConsecutiveFailures)
+			status.NextRun = now.Add(delay)
+		})
+		if err != nil {
+			s.log.Println(err)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-task.Wake:
+			timer.Stop()
+			if !sleep(ctx, task.MinInterval-time.Since(start)) {
+				return
+			}
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// Wait for the provided duration, returning false if the context is cancelled first
+func sleep(ctx context.Context, duration time.Duration) bool {
+	if duration <= 0 {
+		return true
+	}
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Run a task once. If it times out, its context is cancelled and any runs that come due before it finishes are skipped.
+func (s *Scheduler) runOnce(ctx context.Context, task Task) error {
+	runCtx, cancel := context.WithTimeout(ctx, task.Timeout)
+	defer cancel()
+
+	// Run the task in the background so a hung run can be reported
+	s.updateStatus(task.Name, func(status *TaskStatus) {
+		status.IsRunning = true
+		status.LastRun = time.Now()
+	})
+	done := make(chan error, 1)
+	go func() {
+		if task.SendsTransactions {
+			if err := s.txLock.Lock(runCtx); err != nil {
+				done <- fmt.Errorf("task %s gave up waiting for another task to finish sending transactions: %w", task.Name, err)
+				return
+			}
+			defer s.txLock.Unlock()
+		}
+		done <- task.Run(runCtx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-runCtx.Done():
+	}
+
+	timeoutErr := fmt.Errorf("task %s has been running for more than %s", task.Name, task.Timeout)
+	s.log.Println(timeoutErr)
+	s.updateStatus(task.Name, func(status *TaskStatus) {
+		status.LastError = timeoutErr.Error()
+		status.LastErrorTime = time.Now()
+	})
+
+	// Wait for the run to stop, skipping the runs that come due in the meantime
+	if task.Interval <= 0 {
+		return firstError(<-done, timeoutErr)
+	}
+	for {
+		timer := time.NewTimer(getDelay(task, 0))
+		select {
+		case err := <-done:
+			timer.Stop()
+			return firstError(err, timeoutErr)
+		case <-timer.C:
+			s.log.Printlnf("Skipping a run of task %s because its previous run is still going.", task.Name)
+			s.updateStatus(task.Name, func(status *TaskStatus) {
+				status.SkippedRuns++
+			})
+		}
+	}
+}
+
+// Get the first error that isn't nil
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get the delay before a task's next run, backing off after consecutive failures
+func getDelay(task Task, consecutiveFailures int) time.Duration {
+	delay := task.Interval
+	for i := 1; i < consecutiveFailures; i++ {
+		if delay*2 > task.MaxBackoff {
+			if task.MaxBackoff > delay {
+				delay = task.MaxBackoff
+			}
+			break
+		}
+		delay *= 2
+	}
+	if task.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(task.Jitter)))
+	}
+	return delay
+}
+
+// Update the status of a task and save it
+func (s *Scheduler) updateStatus(name string, update func(status *TaskStatus)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	update(s.status[name])
+	if s.statusPath == "" {
+		return
+	}
+	if err := SaveStatus(s.statusPath, s.getStatusImpl()); err != nil {
+		s.log.Printlnf("WARNING: couldn't save the task status: %s", err.Error())
+	}
+}
+
+// Get the status of every task; the caller must hold the lock
+func (s *Scheduler) getStatusImpl() SchedulerStatus {
+	status := SchedulerStatus{
+		Version:     StatusVersion,
+		UpdatedTime: time.Now(),
+		Tasks:       make([]TaskStatus, 0, len(s.tasks)),
+	}
+	for _, task := range s.tasks {
+		status.Tasks = append(status.Tasks, *s.status[task.Name])
+	}
+	return status
+}
+
+// Save the status of a scheduler's tasks
+func SaveStatus(path string, status SchedulerStatus) error {
+	bytes, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("error serializing task status: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("error creating task status folder: %w", err)
+	}
+
+	// Write to a temp file first so the status is never left half-written
+	tempPath := path + ".tmp"
+	if err := os.WriteFile(tempPath, bytes, statusFileMode); err != nil {
+		return fmt.Errorf("error writing task status: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		return fmt.Errorf("error saving task status: %w", err)
+	}
+	return nil
+}
+
+// Load the status of a scheduler's tasks, returning nil if it hasn't been saved yet
+func LoadStatus(path string) (*SchedulerStatus, error) {
+	bytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading task status: %w", err)
+	}
+	status := &SchedulerStatus{}
+	if err := json.Unmarshal(bytes, status); err != nil {
+		return nil, fmt.Errorf("error deserializing task status: %w", err)
+	}
+	if status.Version != StatusVersion {
+		return nil, fmt.Errorf("task status has unsupported version %d (expected %d)", status.Version, StatusVersion)
+	}
+	return status, nil
+}
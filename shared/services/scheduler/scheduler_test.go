@@ -0,0 +1,253 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+func newTestScheduler() *Scheduler {
+	logger := log.NewColorLogger(color.FgRed)
+	return NewScheduler(&logger, "")
+}
+
+func TestGetDelayBacksOff(t *testing.T) {
+	task := Task{
+		Interval:   time.Minute,
+		MaxBackoff: 5 * time.Minute,
+	}
+	expected := []time.Duration{
+		time.Minute,     // Success
+		time.Minute,     // First failure
+		2 * time.Minute, // Second failure
+		4 * time.Minute,
+		5 * time.Minute, // Capped
+		5 * time.Minute,
+	}
+	for failures, delay := range expected {
+		if actual := getDelay(task, failures); actual != delay {
+			t.Errorf("delay after %d failures was %s, expected %s", failures, actual, delay)
+		}
+	}
+}
+
+func TestGetDelayAddsJitter(t *testing.T) {
+	task := Task{
+		Interval:   time.Minute,
+		Jitter:     time.Second,
+		MaxBackoff: time.Minute,
+	}
+	for i := 0; i < 100; i++ {
+		delay := getDelay(task, 0)
+		if delay < time.Minute || delay >= time.Minute+time.Second {
+			t.Fatalf("delay %s was outside of the jitter range", delay)
+		}
+	}
+}
+
+func TestStartStaggersTasks(t *testing.T) {
+	s := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	lock := &sync.Mutex{}
+	runTimes := map[string]time.Time{}
+	for _, name := range []string{"first", "second", "third"} {
+		name := name
+		s.AddTask(Task{
+			Name:     name,
+			Interval: time.Hour,
+			Run: func(ctx context.Context) error {
+				lock.Lock()
+				defer lock.Unlock()
+				if _, exists := runTimes[name]; !exists {
+					runTimes[name] = time.Now()
+				}
+				return nil
+			},
+		})
+	}
+
+	stagger := 50 * time.Millisecond
+	start := time.Now()
+	s.Start(ctx, stagger)
+	time.Sleep(4 * stagger)
+
+	lock.Lock()
+	defer lock.Unlock()
+	for i, name := range []string{"first", "second", "third"} {
+		runTime, exists := runTimes[name]
+		if !exists {
+			t.Fatalf("task %s never ran", name)
+		}
+		if runTime.Sub(start) < time.Duration(i)*stagger {
+			t.Errorf("task %s ran after %s, expected at least %s", name, runTime.Sub(start), time.Duration(i)*stagger)
+		}
+	}
+}
+
+func TestWakeRunsTaskEarly(t *testing.T) {
+	s := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wake := make(chan struct{}, 1)
+	var runs int32
+	s.AddTask(Task{
+		Name:     "task",
+		Interval: time.Hour,
+		Wake:     wake,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	})
+	s.Start(ctx, 0)
+
+	time.Sleep(20 * time.Millisecond)
+	wake <- struct{}{}
+	time.Sleep(20 * time.Millisecond)
+	if count := atomic.LoadInt32(&runs); count != 2 {
+		t.Errorf("task ran %d times, expected 2", count)
+	}
+}
+
+func TestMinIntervalLimitsWakes(t *testing.T) {
+	s := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wake := make(chan struct{}, 1)
+	var runs int32
+	s.AddTask(Task{
+		Name:        "task",
+		Interval:    time.Hour,
+		MinInterval: 100 * time.Millisecond,
+		Wake:        wake,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	})
+	s.Start(ctx, 0)
+
+	time.Sleep(20 * time.Millisecond)
+	wake <- struct{}{}
+	time.Sleep(20 * time.Millisecond)
+	if count := atomic.LoadInt32(&runs); count != 1 {
+		t.Fatalf("task ran %d times before its minimum interval, expected 1", count)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if count := atomic.LoadInt32(&runs); count != 2 {
+		t.Errorf("task ran %d times after its minimum interval, expected 2", count)
+	}
+}
+
+func TestTimeoutCancelsRunAndSkipsRuns(t *testing.T) {
+	s := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cancelled := make(chan struct{})
+	release := make(chan struct{})
+	var runs int32
+	s.AddTask(Task{
+		Name:     "task",
+		Interval: 20 * time.Millisecond,
+		Timeout:  20 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			if atomic.AddInt32(&runs, 1) > 1 {
+				return nil
+			}
+			// Ignore the cancellation so the run keeps going past its timeout
+			<-ctx.Done()
+			close(cancelled)
+			<-release
+			return nil
+		},
+	})
+	s.Start(ctx, 0)
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("run context wasn't cancelled after the timeout")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if count := atomic.LoadInt32(&runs); count != 1 {
+		t.Fatalf("task ran %d times while its first run was still going, expected 1", count)
+	}
+	status := s.GetStatus().Tasks[0]
+	if status.SkippedRuns == 0 {
+		t.Error("no runs were skipped while the first run was still going")
+	}
+	if status.LastError == "" {
+		t.Error("the timeout wasn't recorded")
+	}
+
+	// The task should go back to normal once the run finishes
+	close(release)
+	time.Sleep(100 * time.Millisecond)
+	if count := atomic.LoadInt32(&runs); count < 2 {
+		t.Errorf("task didn't run again after its first run finished")
+	}
+}
+
+func TestTransactionLockSerializesTasks(t *testing.T) {
+	s := newTestScheduler()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var active, maxActive, runs int32
+	for _, name := range []string{"first", "second", "third"} {
+		s.AddTask(Task{
+			Name:              name,
+			Interval:          time.Millisecond,
+			SendsTransactions: true,
+			Run: func(ctx context.Context) error {
+				count := atomic.AddInt32(&active, 1)
+				defer atomic.AddInt32(&active, -1)
+				for {
+					current := atomic.LoadInt32(&maxActive)
+					if count <= current || atomic.CompareAndSwapInt32(&maxActive, current, count) {
+						break
+					}
+				}
+				atomic.AddInt32(&runs, 1)
+				time.Sleep(5 * time.Millisecond)
+				return nil
+			},
+		})
+	}
+	s.Start(ctx, 0)
+	time.Sleep(100 * time.Millisecond)
+
+	if atomic.LoadInt32(&runs) < 3 {
+		t.Fatalf("tasks only ran %d times", atomic.LoadInt32(&runs))
+	}
+	if max := atomic.LoadInt32(&maxActive); max != 1 {
+		t.Errorf("%d tasks held the transaction lock at once", max)
+	}
+}
+
+func TestTransactionLockGivesUpOnCancel(t *testing.T) {
+	l := NewTransactionLock()
+	if err := l.Lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Lock(ctx); err == nil {
+		t.Fatal("got the lock while it was held")
+	}
+	l.Unlock()
+	if err := l.Lock(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
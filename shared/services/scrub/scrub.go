@@ -0,0 +1,285 @@
+package scrub
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v5/beacon-chain/core/signing"
+	prdeposit "github.com/prysmaticlabs/prysm/v5/contracts/deposit"
+	ethpb "github.com/prysmaticlabs/prysm/v5/proto/prysm/v1alpha1"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rputils "github.com/rocket-pool/rocketpool-go/utils"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	eth2types "github.com/wealdtech/go-eth2-types/v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Settings, matching the Oracle DAO's scrub check
+const (
+	DepositSearchOffset uint64 = 100000
+	SafetyScrubDivider  int64  = 2
+)
+
+// The outcome of a minipool's scrub check
+type CheckResult string
+
+const (
+	// The minipool's withdrawal credentials were verified
+	CheckResult_Passed CheckResult = "passed"
+
+	// The minipool is vacant, so its withdrawal credentials aren't checked
+	CheckResult_Vacant CheckResult = "vacant"
+
+	// No valid deposit has been found for the minipool yet
+	CheckResult_NoDeposit CheckResult = "noDeposit"
+
+	// The validator on the Beacon Chain has the wrong withdrawal credentials
+	CheckResult_BeaconMismatch CheckResult = "beaconMismatch"
+
+	// The minipool's prestake event has an invalid signature
+	CheckResult_InvalidPrestake CheckResult = "invalidPrestake"
+
+	// The first valid deposit for the validator has the wrong withdrawal credentials
+	CheckResult_DepositMismatch CheckResult = "depositMismatch"
+
+	// The minipool has gone so long without a valid deposit that the Oracle DAO will scrub it as a precaution
+	CheckResult_SafetyScrub CheckResult = "safetyScrub"
+
+	// The minipool couldn't be checked; the reason has the error
+	CheckResult_Error CheckResult = "error"
+)
+
+// A deposit to the Beacon deposit contract for a minipool's validator
+type Deposit struct {
+	TxHash                common.Hash `json:"txHash"`
+	BlockNumber           uint64      `json:"blockNumber"`
+	TxIndex               uint        `json:"txIndex"`
+	DepositIndex          int         `json:"depositIndex"`
+	Amount                uint64      `json:"amount"`
+	WithdrawalCredentials common.Hash `json:"withdrawalCredentials"`
+	SignatureError        string      `json:"signatureError,omitempty"`
+}
+
+// The scrub check of a single minipool
+type MinipoolCheck struct {
+	Address                       common.Address        `json:"address"`
+	Pubkey                        types.ValidatorPubkey `json:"pubkey"`
+	Result                        CheckResult           `json:"result"`
+	Reason                        string                `json:"reason"`
+	ExpectedWithdrawalCredentials common.Hash           `json:"expectedWithdrawalCredentials"`
+	ActualWithdrawalCredentials   common.Hash           `json:"actualWithdrawalCredentials"`
+	OffendingDeposit              *Deposit              `json:"offendingDeposit,omitempty"`
+	InvalidDeposits               []Deposit             `json:"invalidDeposits"`
+	PrelaunchTime                 time.Time             `json:"prelaunchTime"`
+	ScrubPeriodEnd                time.Time             `json:"scrubPeriodEnd"`
+	Notes                         []string              `json:"notes"`
+}
+
+// Check if the minipool will be scrubbed by the Oracle DAO
+func (c *MinipoolCheck) IsFailed() bool {
+	switch c.Result {
+	case CheckResult_BeaconMismatch, CheckResult_InvalidPrestake, CheckResult_DepositMismatch, CheckResult_SafetyScrub:
+		return true
+	}
+	return false
+}
+
+// Run the Oracle DAO's scrub checks against the provided prelaunch minipools.
+// The checks are run in the same order the Oracle DAO uses: the Beacon Chain withdrawal credentials, the prestake event signature, and the first valid deposit.
+// A minipool that can't be checked gets the error result; the rest are still checked.
+// A minipool whose prestake event can't be retrieved still goes through the deposit checks, with the error recorded as a note.
+func CheckMinipools(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, state *state.NetworkState, minipools []*rpstate.NativeMinipoolDetails) ([]*MinipoolCheck, error) {
+
+	checks := make([]*MinipoolCheck, 0, len(minipools))
+	if len(minipools) == 0 {
+		return checks, nil
+	}
+
+	// Get the time of the state's block
+	genesisTime := time.Unix(int64(state.BeaconConfig.GenesisTime), 0)
+	stateBlockTime := genesisTime.Add(time.Duration(state.BeaconSlotNumber*state.BeaconConfig.SecondsPerSlot) * time.Second)
+	scrubPeriod := state.NetworkDetails.ScrubPeriod
+
+	// Get the deposit signature domain
+	depositDomain, err := signing.ComputeDomain(eth2types.DomainDeposit, state.BeaconConfig.GenesisForkVersion, eth2types.ZeroGenesisValidatorsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("error computing deposit domain: %w", err)
+	}
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, fmt.Errorf("error getting event log interval: %w", err)
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// Step 1: Verify the Beacon Chain credentials if the validator exists
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	remaining := map[*MinipoolCheck]minipool.Minipool{}
+	beaconMismatches := []*MinipoolCheck{}
+	for _, mpd := range minipools {
+		prelaunchTime := time.Unix(mpd.StatusTime.Int64(), 0)
+		check := &MinipoolCheck{
+			Address:                       mpd.MinipoolAddress,
+			Pubkey:                        mpd.Pubkey,
+			ExpectedWithdrawalCredentials: mpd.WithdrawalCredentials,
+			InvalidDeposits:               []Deposit{},
+			Notes:                         []string{},
+			PrelaunchTime:                 prelaunchTime,
+			ScrubPeriodEnd:                prelaunchTime.Add(scrubPeriod),
+		}
+		checks = append(checks, check)
+
+		// Vacant minipools have the wrong withdrawal credentials (temporarily) by design
+		if mpd.IsVacant {
+			check.Result = CheckResult_Vacant
+			check.Reason = "vacant minipools are not checked"
+			continue
+		}
+
+		status := state.ValidatorDetails[mpd.Pubkey]
+		if status.Exists {
+			check.ActualWithdrawalCredentials = status.WithdrawalCredentials
+			if status.WithdrawalCredentials != mpd.WithdrawalCredentials {
+				check.Result = CheckResult_BeaconMismatch
+				check.Reason = "the validator on the Beacon Chain has the wrong withdrawal credentials"
+				beaconMismatches = append(beaconMismatches, check)
+			} else {
+				check.Result = CheckResult_Passed
+				check.Reason = "the validator on the Beacon Chain has the correct withdrawal credentials"
+			}
+			continue
+		}
+
+		mp, err := minipool.NewMinipoolFromVersion(rp, mpd.MinipoolAddress, mpd.Version, opts)
+		if err != nil {
+			check.Result = CheckResult_Error
+			check.Reason = fmt.Sprintf("error creating minipool wrapper: %s", err.Error())
+			continue
+		}
+		remaining[check] = mp
+	}
+
+	// Step 2: Verify the signature of the MinipoolPrestaked event
+	weiPerGwei := big.NewInt(int64(eth.WeiPerGwei))
+	for check, mp := range remaining {
+		prestakeData, err := mp.GetPrestakeEvent(intervalSize, nil)
+		if err != nil {
+			// Like the Oracle DAO, skip the signature check but keep checking the deposits
+			check.Notes = append(check.Notes, fmt.Sprintf("the prestake event signature wasn't checked: error getting prestake event: %s", err.Error()))
+			continue
+		}
+
+		depositData := new(ethpb.Deposit_Data)
+		depositData.Amount = big.NewInt(0).Div(prestakeData.Amount, weiPerGwei).Uint64()
+		depositData.PublicKey = prestakeData.Pubkey.Bytes()
+		depositData.WithdrawalCredentials = prestakeData.WithdrawalCredentials.Bytes()
+		depositData.Signature = prestakeData.Signature.Bytes()
+		if err := prdeposit.VerifyDepositSignature(depositData, depositDomain); err != nil {
+			check.Result = CheckResult_InvalidPrestake
+			check.Reason = fmt.Sprintf("the prestake event has an invalid signature (%s)", err.Error())
+			delete(remaining, check)
+		}
+	}
+	if len(remaining) == 0 && len(beaconMismatches) == 0 {
+		return checks, nil
+	}
+
+	// Step 3: Verify the withdrawal credentials of the first valid deposit
+	startBlock := uint64(0)
+	if state.ElBlockNumber > DepositSearchOffset {
+		startBlock = state.ElBlockNumber - DepositSearchOffset
+	}
+	pubkeys := make(map[types.ValidatorPubkey]bool, len(remaining)+len(beaconMismatches))
+	for check := range remaining {
+		pubkeys[check.Pubkey] = true
+	}
+	for _, check := range beaconMismatches {
+		pubkeys[check.Pubkey] = true
+	}
+	depositMap, err := rputils.GetDeposits(rp, pubkeys, big.NewInt(0).SetUint64(startBlock), intervalSize, nil)
+	if err != nil {
+		// The Beacon Chain mismatches stand without their offending deposits, but the rest can't be checked
+		for check := range remaining {
+			check.Result = CheckResult_Error
+			check.Reason = fmt.Sprintf("error getting deposits: %s", err.Error())
+		}
+		return checks, nil
+	}
+
+	// Find the deposit that set the wrong credentials on the Beacon Chain
+	for _, check := range beaconMismatches {
+		var deposit *Deposit
+		deposit, check.InvalidDeposits = getFirstValidDeposit(depositMap[check.Pubkey], depositDomain)
+		if deposit != nil && deposit.WithdrawalCredentials != check.ExpectedWithdrawalCredentials {
+			check.OffendingDeposit = deposit
+		}
+	}
+
+	for check := range remaining {
+		var deposit *Deposit
+		deposit, check.InvalidDeposits = getFirstValidDeposit(depositMap[check.Pubkey], depositDomain)
+		if deposit != nil {
+			check.ActualWithdrawalCredentials = deposit.WithdrawalCredentials
+			if deposit.WithdrawalCredentials != check.ExpectedWithdrawalCredentials {
+				check.Result = CheckResult_DepositMismatch
+				check.Reason = "the first valid deposit for the validator has the wrong withdrawal credentials"
+				check.OffendingDeposit = deposit
+			} else {
+				check.Result = CheckResult_Passed
+				check.Reason = "the first valid deposit for the validator has the correct withdrawal credentials"
+			}
+			continue
+		}
+
+		// Step 4: Minipools without a valid deposit are scrubbed after part of the scrub period for safety
+		safetyPeriod := time.Duration(int64(scrubPeriod) / SafetyScrubDivider)
+		if stateBlockTime.Sub(check.PrelaunchTime) > safetyPeriod {
+			check.Result = CheckResult_SafetyScrub
+			check.Reason = fmt.Sprintf("no valid deposit was found within %s of entering prelaunch", safetyPeriod)
+		} else {
+			check.Result = CheckResult_NoDeposit
+			check.Reason = fmt.Sprintf("no valid deposit was found yet; the Oracle DAO will scrub the minipool if there isn't one by %s", check.PrelaunchTime.Add(safetyPeriod).Format(time.RFC822))
+		}
+	}
+
+	return checks, nil
+
+}
+
+// Get the first deposit with a valid signature, which is the one the Beacon Chain uses, along with the invalid deposits before it
+func getFirstValidDeposit(deposits []rputils.DepositData, depositDomain []byte) (*Deposit, []Deposit) {
+	invalidDeposits := []Deposit{}
+	for depositIndex, deposit := range deposits {
+		depositData := new(ethpb.Deposit_Data)
+		depositData.Amount = deposit.Amount
+		depositData.PublicKey = deposit.Pubkey.Bytes()
+		depositData.WithdrawalCredentials = deposit.WithdrawalCredentials.Bytes()
+		depositData.Signature = deposit.Signature.Bytes()
+		record := Deposit{
+			TxHash:                deposit.TxHash,
+			BlockNumber:           deposit.BlockNumber,
+			TxIndex:               deposit.TxIndex,
+			DepositIndex:          depositIndex,
+			Amount:                deposit.Amount,
+			WithdrawalCredentials: deposit.WithdrawalCredentials,
+		}
+
+		// Invalid deposits are ignored by the Beacon Chain
+		if err := prdeposit.VerifyDepositSignature(depositData, depositDomain); err != nil {
+			record.SignatureError = err.Error()
+			invalidDeposits = append(invalidDeposits, record)
+			continue
+		}
+		return &record, invalidDeposits
+	}
+	return nil, invalidDeposits
+}
@@ -0,0 +1,449 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+// Settings
+const (
+	// The most EL blocks an incremental update can cover; the state is rebuilt from scratch if it's further behind than this
+	MaxIncrementalUpdateBlocks uint64 = 1000
+
+	// The most nodes and minipools an incremental update can refresh before rebuilding the state from scratch is faster
+	MaxIncrementalUpdateChanges int = 500
+
+	// How long a state can be updated incrementally before it's rebuilt from scratch, in case something changed that the updates can't see
+	MaxIncrementalUpdateAge time.Duration = 6 * time.Hour
+)
+
+// The contracts that emit minipool events; each minipool emits them itself, so they're found by topic instead of address
+var minipoolEventContracts = []string{"rocketMinipoolDelegate", "rocketMinipool"}
+
+// The nodes and minipools that changed since the previous state
+type stateChanges struct {
+	nodes     map[common.Address]bool
+	minipools map[common.Address]bool
+}
+
+// Create an empty set of changes
+func newStateChanges() stateChanges {
+	return stateChanges{
+		nodes:     map[common.Address]bool{},
+		minipools: map[common.Address]bool{},
+	}
+}
+
+// Update a network state to a later slot by only refreshing the nodes, minipools, and validators that changed since it was created.
+// Returns an error if the state can't be updated safely, in which case it should be rebuilt from scratch.
+func updateNetworkState(m *NetworkStateManager, prev *NetworkState, prevHash common.Hash, slotNumber uint64) (*NetworkState, common.Hash, error) {
+	// Get the execution block for the given slot
+	beaconBlock, exists, err := m.bc.GetBeaconBlock(fmt.Sprintf("%d", slotNumber))
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error getting Beacon block for slot %d: %w", slotNumber, err)
+	}
+	if !exists {
+		return nil, common.Hash{}, fmt.Errorf("slot %d did not have a Beacon block", slotNumber)
+	}
+	elBlockNumber := beaconBlock.ExecutionBlockNumber
+	if elBlockNumber < prev.ElBlockNumber {
+		return nil, common.Hash{}, fmt.Errorf("EL block %d is before the previous state's block %d", elBlockNumber, prev.ElBlockNumber)
+	}
+	if elBlockNumber-prev.ElBlockNumber > MaxIncrementalUpdateBlocks {
+		return nil, common.Hash{}, fmt.Errorf("the previous state is %d blocks behind", elBlockNumber-prev.ElBlockNumber)
+	}
+
+	// Make sure the previous state is still on the canonical chain
+	prevHeader, err := m.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(prev.ElBlockNumber))
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error getting EL block %d: %w", prev.ElBlockNumber, err)
+	}
+	if prevHeader.Hash() != prevHash {
+		return nil, common.Hash{}, fmt.Errorf("EL block %d was reorged out", prev.ElBlockNumber)
+	}
+	header, err := m.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(elBlockNumber))
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error getting EL block %d: %w", elBlockNumber, err)
+	}
+
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(elBlockNumber),
+	}
+	isHoustonDeployed, err := IsHoustonDeployed(m.rp, opts)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error checking if Houston is deployed: %w", err)
+	}
+	if isHoustonDeployed != prev.IsHoustonDeployed {
+		return nil, common.Hash{}, fmt.Errorf("the network was upgraded")
+	}
+
+	// Create the state wrapper
+	state := &NetworkState{
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		BeaconSlotNumber:         slotNumber,
+		ElBlockNumber:            elBlockNumber,
+		BeaconConfig:             prev.BeaconConfig,
+		log:                      m.log,
+		IsHoustonDeployed:        isHoustonDeployed,
+	}
+
+	state.logLine("Updating network state from EL block %d to %d, Beacon slot %d", prev.ElBlockNumber, elBlockNumber, slotNumber)
+	start := time.Now()
+
+	// Network contracts and details
+	multicallerAddress := common.HexToAddress(m.cfg.Smartnode.GetMulticallAddress())
+	balanceBatcherAddress := common.HexToAddress(m.cfg.Smartnode.GetBalanceBatcherAddress())
+	contracts, err := rpstate.NewNetworkContracts(m.rp, multicallerAddress, balanceBatcherAddress, isHoustonDeployed, opts)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error getting network contracts: %w", err)
+	}
+	state.NetworkDetails, err = rpstate.NewNetworkDetails(m.rp, contracts, isHoustonDeployed)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error getting network details: %w", err)
+	}
+
+	// The RPL price and collateral limits feed into every node's details
+	if state.NetworkDetails.RplPrice.Cmp(prev.NetworkDetails.RplPrice) != 0 ||
+		state.NetworkDetails.MinCollateralFraction.Cmp(prev.NetworkDetails.MinCollateralFraction) != 0 ||
+		state.NetworkDetails.MaxCollateralFraction.Cmp(prev.NetworkDetails.MaxCollateralFraction) != 0 {
+		return nil, common.Hash{}, fmt.Errorf("the RPL price or collateral limits changed")
+	}
+	state.logLine("1/5 - Retrieved network details (%s so far)", time.Since(start))
+
+	// Find the nodes and minipools that changed
+	changes := newStateChanges()
+	newNodes, newMinipools, err := getNewNodesAndMinipools(m.rp, prev, opts)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	if elBlockNumber > prev.ElBlockNumber {
+		err = findLogChanges(m.rp, m.ec, contracts, prev, prev.ElBlockNumber+1, elBlockNumber, &changes)
+		if err != nil {
+			return nil, common.Hash{}, err
+		}
+		err = findBalanceChanges(contracts, prev, opts, &changes)
+		if err != nil {
+			return nil, common.Hash{}, err
+		}
+	}
+	for address := range changes.minipools {
+		// A minipool's node tracks its matched ETH and collateral, which change with the minipool
+		changes.nodes[prev.MinipoolDetailsByAddress[address].NodeAddress] = true
+	}
+	changeCount := len(changes.nodes) + len(changes.minipools) + len(newNodes) + len(newMinipools)
+	if changeCount > MaxIncrementalUpdateChanges {
+		return nil, common.Hash{}, fmt.Errorf("%d nodes and minipools changed", changeCount)
+	}
+	state.logLine("2/5 - Found %d changed nodes and %d changed minipools (%s so far)", len(changes.nodes)+len(newNodes), len(changes.minipools)+len(newMinipools), time.Since(start))
+
+	// Minipool details
+	state.MinipoolDetails = make([]rpstate.NativeMinipoolDetails, len(prev.MinipoolDetails), len(prev.MinipoolDetails)+len(newMinipools))
+	copy(state.MinipoolDetails, prev.MinipoolDetails)
+	for i, details := range state.MinipoolDetails {
+		if changes.minipools[details.MinipoolAddress] {
+			state.MinipoolDetails[i], err = rpstate.GetNativeMinipoolDetails(m.rp, contracts, details.MinipoolAddress)
+			if err != nil {
+				return nil, common.Hash{}, fmt.Errorf("error getting details for minipool %s: %w", details.MinipoolAddress.Hex(), err)
+			}
+		}
+	}
+	for _, address := range newMinipools {
+		details, err := rpstate.GetNativeMinipoolDetails(m.rp, contracts, address)
+		if err != nil {
+			return nil, common.Hash{}, fmt.Errorf("error getting details for minipool %s: %w", address.Hex(), err)
+		}
+		state.MinipoolDetails = append(state.MinipoolDetails, details)
+		changes.minipools[address] = true
+		changes.nodes[details.NodeAddress] = true
+	}
+
+	// Node details
+	state.NodeDetails = make([]rpstate.NativeNodeDetails, len(prev.NodeDetails), len(prev.NodeDetails)+len(newNodes))
+	copy(state.NodeDetails, prev.NodeDetails)
+	for i, details := range state.NodeDetails {
+		if changes.nodes[details.NodeAddress] {
+			state.NodeDetails[i], err = rpstate.GetNativeNodeDetails(m.rp, contracts, details.NodeAddress)
+			if err != nil {
+				return nil, common.Hash{}, fmt.Errorf("error getting details for node %s: %w", details.NodeAddress.Hex(), err)
+			}
+		}
+	}
+	for _, address := range newNodes {
+		details, err := rpstate.GetNativeNodeDetails(m.rp, contracts, address)
+		if err != nil {
+			return nil, common.Hash{}, fmt.Errorf("error getting details for node %s: %w", address.Hex(), err)
+		}
+		state.NodeDetails = append(state.NodeDetails, details)
+		changes.nodes[address] = true
+	}
+	state.logLine("3/5 - Retrieved changed node and minipool details (%s so far)", time.Since(start))
+
+	// Create the lookups
+	pubkeys := state.createLookups()
+
+	// Calculate avg node fees and distributor shares for the nodes that changed
+	for i := range state.NodeDetails {
+		details := &state.NodeDetails[i]
+		if !changes.nodes[details.NodeAddress] {
+			continue
+		}
+		// These are updated in place, so the previous state's values can't be shared
+		details.AverageNodeFee = big.NewInt(0).Set(details.AverageNodeFee)
+		details.DistributorBalanceNodeETH = big.NewInt(0).Set(details.DistributorBalanceNodeETH)
+		details.DistributorBalanceUserETH = big.NewInt(0).Set(details.DistributorBalanceUserETH)
+		rpstate.CalculateAverageFeeAndDistributorShares(m.rp, contracts, *details, state.MinipoolDetailsByNode[details.NodeAddress])
+	}
+
+	// Oracle DAO member details
+	state.OracleDaoMemberDetails, err = rpstate.GetAllOracleDaoMemberDetails(m.rp, contracts)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error getting Oracle DAO details: %w", err)
+	}
+	state.logLine("4/5 - Retrieved Oracle DAO details (%s so far)", time.Since(start))
+
+	// Get the validator stats from Beacon
+	state.ValidatorDetails, err = m.bc.GetValidatorStatuses(pubkeys, &beacon.ValidatorStatusOptions{
+		Slot: &slotNumber,
+	})
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+
+	// Get the complete node and user shares for the minipools that changed, or whose validators did
+	mpds := []*rpstate.NativeMinipoolDetails{}
+	beaconBalances := []*big.Int{}
+	for i, mpd := range state.MinipoolDetails {
+		validator := state.ValidatorDetails[mpd.Pubkey]
+		prevValidator := prev.ValidatorDetails[mpd.Pubkey]
+		if !changes.minipools[mpd.MinipoolAddress] && validator.Exists == prevValidator.Exists && validator.Balance == prevValidator.Balance {
+			continue
+		}
+		mpds = append(mpds, &state.MinipoolDetails[i])
+		if !validator.Exists {
+			beaconBalances = append(beaconBalances, big.NewInt(0))
+		} else {
+			beaconBalances = append(beaconBalances, eth.GweiToWei(float64(validator.Balance)))
+		}
+	}
+	err = rpstate.CalculateCompleteMinipoolShares(m.rp, contracts, mpds, beaconBalances)
+	if err != nil {
+		return nil, common.Hash{}, err
+	}
+	state.logLine("5/5 - Retrieved validator details and updated the balance shares of %d minipools (total time: %s)", len(mpds), time.Since(start))
+
+	return state, header.Hash(), nil
+}
+
+// Create the node and minipool lookups for a state, returning the pubkeys of its minipools
+func (s *NetworkState) createLookups() []types.ValidatorPubkey {
+	for i, details := range s.NodeDetails {
+		s.NodeDetailsByAddress[details.NodeAddress] = &s.NodeDetails[i]
+	}
+
+	pubkeys := make([]types.ValidatorPubkey, 0, len(s.MinipoolDetails))
+	emptyPubkey := types.ValidatorPubkey{}
+	for i, details := range s.MinipoolDetails {
+		s.MinipoolDetailsByAddress[details.MinipoolAddress] = &s.MinipoolDetails[i]
+		if details.Pubkey != emptyPubkey {
+			pubkeys = append(pubkeys, details.Pubkey)
+		}
+		s.MinipoolDetailsByNode[details.NodeAddress] = append(s.MinipoolDetailsByNode[details.NodeAddress], &s.MinipoolDetails[i])
+	}
+	return pubkeys
+}
+
+// Get the addresses of the nodes and minipools that were created since the previous state
+func getNewNodesAndMinipools(rp *rocketpool.RocketPool, prev *NetworkState, opts *bind.CallOpts) ([]common.Address, []common.Address, error) {
+	nodeCount, err := node.GetNodeCount(rp, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting node count: %w", err)
+	}
+	if nodeCount < uint64(len(prev.NodeDetails)) {
+		return nil, nil, fmt.Errorf("the node count went down from %d to %d", len(prev.NodeDetails), nodeCount)
+	}
+	newNodes := []common.Address{}
+	for i := uint64(len(prev.NodeDetails)); i < nodeCount; i++ {
+		address, err := node.GetNodeAt(rp, i, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting node %d: %w", i, err)
+		}
+		newNodes = append(newNodes, address)
+	}
+
+	minipoolCount, err := minipool.GetMinipoolCount(rp, opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting minipool count: %w", err)
+	}
+	if minipoolCount < uint64(len(prev.MinipoolDetails)) {
+		return nil, nil, fmt.Errorf("the minipool count went down from %d to %d", len(prev.MinipoolDetails), minipoolCount)
+	}
+	newMinipools := []common.Address{}
+	for i := uint64(len(prev.MinipoolDetails)); i < minipoolCount; i++ {
+		address, err := minipool.GetMinipoolAt(rp, i, opts)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting minipool %d: %w", i, err)
+		}
+		newMinipools = append(newMinipools, address)
+	}
+
+	return newNodes, newMinipools, nil
+}
+
+// Mark the nodes and minipools that were mentioned by Rocket Pool contract events in the provided blocks as changed
+func findLogChanges(rp *rocketpool.RocketPool, ec rocketpool.ExecutionClient, contracts *rpstate.NetworkContracts, prev *NetworkState, fromBlock uint64, toBlock uint64, changes *stateChanges) error {
+	// Events from the network contracts
+	addresses := []common.Address{}
+	for _, contract := range []*rocketpool.Contract{
+		contracts.RocketDAONodeTrusted,
+		contracts.RocketDepositPool,
+		contracts.RocketMinipoolManager,
+		contracts.RocketMinipoolQueue,
+		contracts.RocketNodeDeposit,
+		contracts.RocketNodeDistributorFactory,
+		contracts.RocketNodeManager,
+		contracts.RocketNodeStaking,
+		contracts.RocketRewardsPool,
+		contracts.RocketSmoothingPool,
+		contracts.RocketStorage,
+		contracts.RocketTokenRETH,
+		contracts.RocketTokenRPL,
+		contracts.RocketTokenRPLFixedSupply,
+		contracts.RocketMinipoolBondReducer,
+	} {
+		if contract != nil {
+			addresses = append(addresses, *contract.Address)
+		}
+	}
+	logs, err := ec.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(0).SetUint64(fromBlock),
+		ToBlock:   big.NewInt(0).SetUint64(toBlock),
+		Addresses: addresses,
+	})
+	if err != nil {
+		return fmt.Errorf("error getting network contract logs for blocks %d to %d: %w", fromBlock, toBlock, err)
+	}
+
+	// Events from the minipools themselves
+	topicSet := map[common.Hash]bool{}
+	for _, contractName := range minipoolEventContracts {
+		contractAbi, err := rp.GetABI(contractName, nil)
+		if err != nil {
+			return fmt.Errorf("error getting %s ABI: %w", contractName, err)
+		}
+		for _, event := range contractAbi.Events {
+			topicSet[event.ID] = true
+		}
+	}
+	topics := make([]common.Hash, 0, len(topicSet))
+	for topic := range topicSet {
+		topics = append(topics, topic)
+	}
+	minipoolLogs, err := ec.FilterLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(0).SetUint64(fromBlock),
+		ToBlock:   big.NewInt(0).SetUint64(toBlock),
+		Topics:    [][]common.Hash{topics},
+	})
+	if err != nil {
+		return fmt.Errorf("error getting minipool logs for blocks %d to %d: %w", fromBlock, toBlock, err)
+	}
+	logs = append(logs, minipoolLogs...)
+
+	// Destroyed minipools are removed from the list of all minipools, which moves the others around
+	var destroyedTopic common.Hash
+	if event, exists := contracts.RocketMinipoolManager.ABI.Events["MinipoolDestroyed"]; exists {
+		destroyedTopic = event.ID
+	}
+
+	for _, entry := range logs {
+		if len(entry.Topics) == 0 {
+			continue
+		}
+		if entry.Topics[0] == destroyedTopic && entry.Address == *contracts.RocketMinipoolManager.Address {
+			return fmt.Errorf("a minipool was destroyed in block %d", entry.BlockNumber)
+		}
+		markLogChanges(prev, entry, changes)
+	}
+	return nil
+}
+
+// Mark the nodes and minipools a contract event came from or has as indexed arguments as changed
+func markLogChanges(prev *NetworkState, entry ethtypes.Log, changes *stateChanges) {
+	if _, exists := prev.MinipoolDetailsByAddress[entry.Address]; exists {
+		changes.minipools[entry.Address] = true
+	}
+	if len(entry.Topics) == 0 {
+		return
+	}
+	for _, topic := range entry.Topics[1:] {
+		// Addresses are left-padded with zeros
+		if common.BytesToHash(topic.Bytes()[:12]) != (common.Hash{}) {
+			continue
+		}
+		address := common.BytesToAddress(topic.Bytes())
+		if _, exists := prev.NodeDetailsByAddress[address]; exists {
+			changes.nodes[address] = true
+		}
+		if _, exists := prev.MinipoolDetailsByAddress[address]; exists {
+			changes.minipools[address] = true
+		}
+	}
+}
+
+// Mark the nodes, fee distributors, and minipools whose ETH balances changed since the previous state as changed
+func findBalanceChanges(contracts *rpstate.NetworkContracts, prev *NetworkState, opts *bind.CallOpts, changes *stateChanges) error {
+	balances, err := contracts.BalanceBatcher.GetEthBalances(getBalanceAddresses(prev), opts)
+	if err != nil {
+		return fmt.Errorf("error getting ETH balances: %w", err)
+	}
+	return markBalanceChanges(prev, balances, changes)
+}
+
+// Get the addresses whose ETH balances a state tracks: every node, then every node's fee distributor, then every minipool
+func getBalanceAddresses(state *NetworkState) []common.Address {
+	addresses := make([]common.Address, 0, len(state.NodeDetails)*2+len(state.MinipoolDetails))
+	for _, details := range state.NodeDetails {
+		addresses = append(addresses, details.NodeAddress)
+	}
+	for _, details := range state.NodeDetails {
+		addresses = append(addresses, details.FeeDistributorAddress)
+	}
+	for _, details := range state.MinipoolDetails {
+		addresses = append(addresses, details.MinipoolAddress)
+	}
+	return addresses
+}
+
+// Mark the nodes and minipools whose balances don't match the state's as changed; the balances are in the order of getBalanceAddresses
+func markBalanceChanges(prev *NetworkState, balances []*big.Int, changes *stateChanges) error {
+	nodeCount := len(prev.NodeDetails)
+	if len(balances) != nodeCount*2+len(prev.MinipoolDetails) {
+		return fmt.Errorf("got %d ETH balances for %d nodes and %d minipools", len(balances), nodeCount, len(prev.MinipoolDetails))
+	}
+	for i, details := range prev.NodeDetails {
+		if balances[i].Cmp(details.BalanceETH) != 0 || balances[nodeCount+i].Cmp(details.DistributorBalance) != 0 {
+			changes.nodes[details.NodeAddress] = true
+		}
+	}
+	for i, details := range prev.MinipoolDetails {
+		if balances[nodeCount*2+i].Cmp(details.Balance) != 0 {
+			changes.minipools[details.MinipoolAddress] = true
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+)
+
+var (
+	testNode        = common.HexToAddress("0x1000000000000000000000000000000000000001")
+	testOtherNode   = common.HexToAddress("0x1000000000000000000000000000000000000002")
+	testDistributor = common.HexToAddress("0x2000000000000000000000000000000000000001")
+	testOtherDist   = common.HexToAddress("0x2000000000000000000000000000000000000002")
+	testMinipool    = common.HexToAddress("0x3000000000000000000000000000000000000001")
+	testOtherPool   = common.HexToAddress("0x3000000000000000000000000000000000000002")
+	testPubkey      = types.BytesToValidatorPubkey(common.FromHex("0xa1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90"))
+)
+
+// Create a network state with two nodes that each have a minipool
+func newTestState() *NetworkState {
+	state := &NetworkState{
+		ElBlockNumber:    100,
+		BeaconSlotNumber: 200,
+		NetworkDetails: &rpstate.NetworkDetails{
+			RplPrice:      big.NewInt(5),
+			TotalRPLStake: big.NewInt(1000),
+		},
+		NodeDetails: []rpstate.NativeNodeDetails{
+			{NodeAddress: testNode, FeeDistributorAddress: testDistributor, BalanceETH: big.NewInt(1), DistributorBalance: big.NewInt(2), RplStake: big.NewInt(600)},
+			{NodeAddress: testOtherNode, FeeDistributorAddress: testOtherDist, BalanceETH: big.NewInt(3), DistributorBalance: big.NewInt(4), RplStake: big.NewInt(400)},
+		},
+		MinipoolDetails: []rpstate.NativeMinipoolDetails{
+			{MinipoolAddress: testMinipool, NodeAddress: testNode, Pubkey: testPubkey, Balance: big.NewInt(5)},
+			{MinipoolAddress: testOtherPool, NodeAddress: testOtherNode, Balance: big.NewInt(6)},
+		},
+		NodeDetailsByAddress:     map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetailsByAddress: map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:    map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		ValidatorDetails: map[types.ValidatorPubkey]beacon.ValidatorStatus{
+			testPubkey: {Pubkey: testPubkey, Index: "42", Balance: 32000000000, Status: beacon.ValidatorState_ActiveOngoing, Exists: true},
+		},
+	}
+	state.createLookups()
+	return state
+}
+
+// Check that exactly the expected addresses were marked as changed
+func checkChanges(t *testing.T, kind string, actual map[common.Address]bool, expected ...common.Address) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Errorf("%d %s were marked as changed, expected %d", len(actual), kind, len(expected))
+	}
+	for _, address := range expected {
+		if !actual[address] {
+			t.Errorf("%s %s wasn't marked as changed", kind, address.Hex())
+		}
+	}
+}
+
+func TestMarkLogChangesFindsAddressesInTopics(t *testing.T) {
+	state := newTestState()
+	changes := newStateChanges()
+	unknown := common.HexToAddress("0x4000000000000000000000000000000000000001")
+	markLogChanges(state, ethtypes.Log{
+		Address: unknown,
+		Topics: []common.Hash{
+			common.BytesToHash(testNode.Bytes()), // The event signature is never treated as an address
+			common.BytesToHash(testOtherNode.Bytes()),
+			common.BytesToHash(testOtherPool.Bytes()),
+			common.BytesToHash(unknown.Bytes()),
+		},
+	}, &changes)
+	checkChanges(t, "nodes", changes.nodes, testOtherNode)
+	checkChanges(t, "minipools", changes.minipools, testOtherPool)
+}
+
+func TestMarkLogChangesFindsEmittingMinipool(t *testing.T) {
+	state := newTestState()
+	changes := newStateChanges()
+	markLogChanges(state, ethtypes.Log{
+		Address: testMinipool,
+		Topics:  []common.Hash{common.HexToHash("0x01")},
+	}, &changes)
+	checkChanges(t, "nodes", changes.nodes)
+	checkChanges(t, "minipools", changes.minipools, testMinipool)
+}
+
+func TestMarkLogChangesIgnoresNonAddressTopics(t *testing.T) {
+	state := newTestState()
+	changes := newStateChanges()
+
+	// A topic that ends with a node's address but isn't zero-padded isn't an address
+	topic := common.BytesToHash(testNode.Bytes())
+	topic[0] = 0xff
+	markLogChanges(state, ethtypes.Log{
+		Address: testDistributor,
+		Topics:  []common.Hash{common.HexToHash("0x01"), topic},
+	}, &changes)
+	markLogChanges(state, ethtypes.Log{Address: testDistributor}, &changes)
+	checkChanges(t, "nodes", changes.nodes)
+	checkChanges(t, "minipools", changes.minipools)
+}
+
+func TestGetBalanceAddressesOrder(t *testing.T) {
+	expected := []common.Address{testNode, testOtherNode, testDistributor, testOtherDist, testMinipool, testOtherPool}
+	actual := getBalanceAddresses(newTestState())
+	if len(actual) != len(expected) {
+		t.Fatalf("got %d addresses, expected %d", len(actual), len(expected))
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Errorf("address %d is %s, expected %s", i, actual[i].Hex(), expected[i].Hex())
+		}
+	}
+}
+
+func TestMarkBalanceChanges(t *testing.T) {
+	state := newTestState()
+
+	// Nothing changed
+	changes := newStateChanges()
+	balances := []*big.Int{big.NewInt(1), big.NewInt(3), big.NewInt(2), big.NewInt(4), big.NewInt(5), big.NewInt(6)}
+	if err := markBalanceChanges(state, balances, &changes); err != nil {
+		t.Fatal(err)
+	}
+	checkChanges(t, "nodes", changes.nodes)
+	checkChanges(t, "minipools", changes.minipools)
+
+	// The second node's distributor and the first minipool changed
+	balances[3] = big.NewInt(40)
+	balances[4] = big.NewInt(50)
+	if err := markBalanceChanges(state, balances, &changes); err != nil {
+		t.Fatal(err)
+	}
+	checkChanges(t, "nodes", changes.nodes, testOtherNode)
+	checkChanges(t, "minipools", changes.minipools, testMinipool)
+
+	// The first node's own balance changed
+	changes = newStateChanges()
+	balances = []*big.Int{big.NewInt(10), big.NewInt(3), big.NewInt(2), big.NewInt(4), big.NewInt(5), big.NewInt(6)}
+	if err := markBalanceChanges(state, balances, &changes); err != nil {
+		t.Fatal(err)
+	}
+	checkChanges(t, "nodes", changes.nodes, testNode)
+	checkChanges(t, "minipools", changes.minipools)
+}
+
+func TestMarkBalanceChangesRejectsWrongCount(t *testing.T) {
+	changes := newStateChanges()
+	if err := markBalanceChanges(newTestState(), []*big.Int{big.NewInt(1)}, &changes); err == nil {
+		t.Error("a balance list of the wrong length was accepted")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -24,6 +25,14 @@ type NetworkStateManager struct {
 	Network      cfgtypes.Network
 	ChainID      uint
 	BeaconConfig beacon.Eth2Config
+
+	// The latest full network state, which is updated incrementally for head states when possible.
+	// States are shared with every caller that asks for the same slot, so they must not be modified.
+	lastState       *NetworkState
+	lastStateHash   common.Hash
+	lastStateIsFull bool
+	lastFullBuild   time.Time
+	lock            *sync.Mutex
 }
 
 // Create a new manager for the network state
@@ -39,6 +48,7 @@ func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 		Config:  cfg,
 		Network: cfg.Smartnode.Network.Value.(cfgtypes.Network),
 		ChainID: cfg.Smartnode.GetChainID(),
+		lock:    &sync.Mutex{},
 	}
 
 	// Get the Beacon config info
@@ -52,13 +62,15 @@ func NewNetworkStateManager(rp *rocketpool.RocketPool, cfg *config.RocketPoolCon
 
 }
 
-// Get the state of the network using the latest Execution layer block
+// Get the state of the network using the latest Execution layer block.
+// The state may have been updated incrementally from an earlier one, so anything that has to match the Oracle DAO's consensus should use GetStateForSlot instead.
+// The state is shared with other callers and must not be modified.
 func (m *NetworkStateManager) GetHeadState() (*NetworkState, error) {
 	targetSlot, err := m.GetHeadSlot()
 	if err != nil {
 		return nil, fmt.Errorf("error getting latest Beacon slot: %w", err)
 	}
-	return m.getState(targetSlot)
+	return m.getState(targetSlot, true)
 }
 
 // Get the state of the network for a single node using the latest Execution layer block, along with the total effective RPL stake for the network
@@ -70,9 +82,11 @@ func (m *NetworkStateManager) GetHeadStateForNode(nodeAddress common.Address, ca
 	return m.getStateForNode(nodeAddress, targetSlot, calculateTotalEffectiveStake)
 }
 
-// Get the state of the network at the provided Beacon slot
+// Get the state of the network at the provided Beacon slot, using a saved snapshot of it if there is one.
+// The state is always built from scratch or loaded from a verified snapshot of a state that was, never updated incrementally.
+// The state is shared with other callers and must not be modified.
 func (m *NetworkStateManager) GetStateForSlot(slotNumber uint64) (*NetworkState, error) {
-	return m.getState(slotNumber)
+	return m.getState(slotNumber, false)
 }
 
 // Gets the latest valid block
@@ -129,15 +143,82 @@ func (m *NetworkStateManager) GetLatestProposedBeaconBlock(targetSlot uint64) (b
 	}
 }
 
-// Get the state of the network at the provided Beacon slot
-func (m *NetworkStateManager) getState(slotNumber uint64) (*NetworkState, error) {
-	state, err := CreateNetworkState(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
-	if err != nil {
-		return nil, err
+// Get the state of the network at the provided Beacon slot.
+// The latest state is kept so later head states can be created by updating it with what changed instead of rebuilding everything.
+func (m *NetworkStateManager) getState(slotNumber uint64, allowIncremental bool) (*NetworkState, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	// Reuse the latest state if it's for the same slot and still on the canonical chain
+	if m.lastState != nil && m.lastState.BeaconSlotNumber == slotNumber && (allowIncremental || m.lastStateIsFull) {
+		blockHash, err := m.getBlockHash(m.lastState.ElBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if blockHash == m.lastStateHash {
+			return m.lastState, nil
+		}
+	}
+
+	// Load the snapshot for this slot if there is one
+	if !allowIncremental {
+		state := m.loadSnapshot(slotNumber)
+		if state != nil {
+			return state, nil
+		}
+	}
+
+	// Update the latest state if possible
+	var state *NetworkState
+	var blockHash common.Hash
+	var err error
+	isNewest := m.lastState == nil || slotNumber > m.lastState.BeaconSlotNumber
+	if allowIncremental && m.lastState != nil && isNewest && time.Since(m.lastFullBuild) < MaxIncrementalUpdateAge {
+		state, blockHash, err = updateNetworkState(m, m.lastState, m.lastStateHash, slotNumber)
+		if err != nil {
+			m.logLine("Couldn't update the network state incrementally (%s), rebuilding it instead.", err.Error())
+			state = nil
+		}
+	}
+
+	// Otherwise build it from scratch
+	isFull := state == nil
+	if isFull {
+		state, err = CreateNetworkState(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig)
+		if err != nil {
+			return nil, err
+		}
+		blockHash, err = m.getBlockHash(state.ElBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		if isNewest {
+			m.lastFullBuild = time.Now()
+		}
+	}
+
+	if isNewest {
+		m.lastState = state
+		m.lastStateHash = blockHash
+		m.lastStateIsFull = isFull
+	}
+
+	// Only states that were built from scratch are saved, so a snapshot never carries a mistake from an incremental update
+	if !allowIncremental {
+		m.saveFinalizedSnapshot(state, blockHash)
 	}
 	return state, nil
 }
 
+// Get the hash of an EL block
+func (m *NetworkStateManager) getBlockHash(blockNumber uint64) (common.Hash, error) {
+	header, err := m.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(blockNumber))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("error getting EL block %d: %w", blockNumber, err)
+	}
+	return header.Hash(), nil
+}
+
 // Get the state of the network for a specific node only at the provided Beacon slot
 func (m *NetworkStateManager) getStateForNode(nodeAddress common.Address, slotNumber uint64, calculateTotalEffectiveStake bool) (*NetworkState, *big.Int, error) {
 	state, totalEffectiveStake, err := CreateNetworkStateForNode(m.cfg, m.rp, m.ec, m.bc, m.log, slotNumber, m.BeaconConfig, nodeAddress, calculateTotalEffectiveStake)
@@ -150,6 +231,6 @@ func (m *NetworkStateManager) getStateForNode(nodeAddress common.Address, slotNu
 // Logs a line if the logger is specified
 func (m *NetworkStateManager) logLine(format string, v ...interface{}) {
 	if m.log != nil {
-		m.log.Printlnf(format, v)
+		m.log.Printlnf(format, v...)
 	}
 }
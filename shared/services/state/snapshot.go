@@ -0,0 +1,524 @@
+package state
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/types"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Settings
+const (
+	// The version of the snapshot format; snapshots from other versions are ignored.
+	// Version 1 snapshots could be saved from incrementally updated states, so they aren't trusted.
+	stateSnapshotVersion int = 2
+
+	// The number of nodes, minipools, and validators whose full details are compared against the chain when loading a snapshot
+	snapshotSpotCheckCount int = 20
+)
+
+// Returned when a snapshot's values don't match the chain
+var errSnapshotMismatch = errors.New("snapshot doesn't match the chain")
+
+// A network state saved to disk
+type stateSnapshot struct {
+	Version                    int                                   `json:"version"`
+	ElBlockHash                common.Hash                           `json:"elBlockHash"`
+	IsHoustonDeployed          bool                                  `json:"isHoustonDeployed"`
+	ElBlockNumber              uint64                                `json:"elBlockNumber"`
+	BeaconSlotNumber           uint64                                `json:"beaconSlotNumber"`
+	BeaconConfig               beacon.Eth2Config                     `json:"beaconConfig"`
+	NetworkDetails             *rpstate.NetworkDetails               `json:"networkDetails"`
+	NodeDetails                []rpstate.NativeNodeDetails           `json:"nodeDetails"`
+	MinipoolDetails            []rpstate.NativeMinipoolDetails       `json:"minipoolDetails"`
+	ValidatorDetails           map[string]beacon.ValidatorStatus     `json:"validatorDetails"`
+	OracleDaoMemberDetails     []rpstate.OracleDaoMemberDetails      `json:"oracleDaoMemberDetails"`
+	ProtocolDaoProposalDetails []protocol.ProtocolDaoProposalDetails `json:"protocolDaoProposalDetails"`
+}
+
+// Save a network state to disk, then delete the least recently used snapshots beyond the configured limit
+func (m *NetworkStateManager) saveSnapshot(state *NetworkState, blockHash common.Hash) error {
+	count := m.cfg.Smartnode.StateSnapshotCount.Value.(uint64)
+	if count == 0 {
+		return nil
+	}
+
+	compressedBytes, err := encodeSnapshot(state, blockHash)
+	if err != nil {
+		return err
+	}
+
+	// Write it to a temporary file first so a partial snapshot is never loaded
+	folder := m.cfg.Smartnode.GetStateSnapshotFolder()
+	err = os.MkdirAll(folder, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating snapshot folder: %w", err)
+	}
+	path := m.cfg.Smartnode.GetStateSnapshotPath(state.BeaconSlotNumber)
+	tempPath := path + ".tmp"
+	err = os.WriteFile(tempPath, compressedBytes, 0664)
+	if err != nil {
+		return fmt.Errorf("error writing snapshot: %w", err)
+	}
+	err = os.Rename(tempPath, path)
+	if err != nil {
+		return fmt.Errorf("error moving snapshot into place: %w", err)
+	}
+	m.logLine("Saved network state snapshot for slot %d (%.2f MB).", state.BeaconSlotNumber, float64(len(compressedBytes))/1e6)
+
+	return m.pruneSnapshots(int(count))
+}
+
+// Serialize and compress a network state
+func encodeSnapshot(state *NetworkState, blockHash common.Hash) ([]byte, error) {
+	snapshot := stateSnapshot{
+		Version:                    stateSnapshotVersion,
+		ElBlockHash:                blockHash,
+		IsHoustonDeployed:          state.IsHoustonDeployed,
+		ElBlockNumber:              state.ElBlockNumber,
+		BeaconSlotNumber:           state.BeaconSlotNumber,
+		BeaconConfig:               state.BeaconConfig,
+		NetworkDetails:             state.NetworkDetails,
+		NodeDetails:                state.NodeDetails,
+		MinipoolDetails:            state.MinipoolDetails,
+		ValidatorDetails:           make(map[string]beacon.ValidatorStatus, len(state.ValidatorDetails)),
+		OracleDaoMemberDetails:     state.OracleDaoMemberDetails,
+		ProtocolDaoProposalDetails: state.ProtocolDaoProposalDetails,
+	}
+	for pubkey, status := range state.ValidatorDetails {
+		snapshot.ValidatorDetails[pubkey.Hex()] = status
+	}
+	bytes, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing state: %w", err)
+	}
+	encoder, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd compressor: %w", err)
+	}
+	defer encoder.Close()
+	return encoder.EncodeAll(bytes, make([]byte, 0, len(bytes)/8)), nil
+}
+
+// Load the network state snapshot for a slot, if there is one.
+// Returns nil if there isn't a snapshot or it couldn't be loaded or verified; snapshots that are corrupt or don't match the chain are deleted.
+func (m *NetworkStateManager) loadSnapshot(slotNumber uint64) *NetworkState {
+	if m.cfg.Smartnode.StateSnapshotCount.Value.(uint64) == 0 {
+		return nil
+	}
+	path := m.cfg.Smartnode.GetStateSnapshotPath(slotNumber)
+	compressedBytes, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			m.logLine("WARNING: couldn't read network state snapshot for slot %d: %s", slotNumber, err.Error())
+		}
+		return nil
+	}
+
+	state, blockHash, err := decodeSnapshot(compressedBytes, m.log)
+	if err == nil {
+		err = m.verifySnapshot(state, blockHash)
+		if err != nil && !errors.Is(err, errSnapshotMismatch) {
+			// The snapshot might be fine, it just can't be checked right now
+			m.logLine("WARNING: couldn't check network state snapshot for slot %d: %s", slotNumber, err.Error())
+			return nil
+		}
+	}
+	if err != nil {
+		m.logLine("WARNING: discarding network state snapshot for slot %d: %s", slotNumber, err.Error())
+		if removeErr := os.Remove(path); removeErr != nil {
+			m.logLine("WARNING: couldn't delete network state snapshot for slot %d: %s", slotNumber, removeErr.Error())
+		}
+		return nil
+	}
+
+	// Mark it as recently used so it's kept when older snapshots are pruned
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+	m.logLine("Loaded network state snapshot for slot %d.", slotNumber)
+	return state
+}
+
+// Decompress and deserialize a network state snapshot
+func decodeSnapshot(compressedBytes []byte, logger *log.ColorLogger) (*NetworkState, common.Hash, error) {
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error creating zstd decompressor: %w", err)
+	}
+	defer decoder.Close()
+	bytes, err := decoder.DecodeAll(compressedBytes, []byte{})
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error decompressing snapshot: %w", err)
+	}
+
+	var snapshot stateSnapshot
+	err = json.Unmarshal(bytes, &snapshot)
+	if err != nil {
+		return nil, common.Hash{}, fmt.Errorf("error deserializing snapshot: %w", err)
+	}
+	if snapshot.Version != stateSnapshotVersion {
+		return nil, common.Hash{}, fmt.Errorf("snapshot version %d is not supported", snapshot.Version)
+	}
+
+	state := &NetworkState{
+		IsHoustonDeployed:          snapshot.IsHoustonDeployed,
+		ElBlockNumber:              snapshot.ElBlockNumber,
+		BeaconSlotNumber:           snapshot.BeaconSlotNumber,
+		BeaconConfig:               snapshot.BeaconConfig,
+		NetworkDetails:             snapshot.NetworkDetails,
+		NodeDetails:                snapshot.NodeDetails,
+		NodeDetailsByAddress:       map[common.Address]*rpstate.NativeNodeDetails{},
+		MinipoolDetails:            snapshot.MinipoolDetails,
+		MinipoolDetailsByAddress:   map[common.Address]*rpstate.NativeMinipoolDetails{},
+		MinipoolDetailsByNode:      map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		ValidatorDetails:           make(map[types.ValidatorPubkey]beacon.ValidatorStatus, len(snapshot.ValidatorDetails)),
+		OracleDaoMemberDetails:     snapshot.OracleDaoMemberDetails,
+		ProtocolDaoProposalDetails: snapshot.ProtocolDaoProposalDetails,
+		log:                        logger,
+	}
+	if state.NetworkDetails == nil {
+		return nil, common.Hash{}, fmt.Errorf("snapshot is missing the network details")
+	}
+	for pubkeyString, status := range snapshot.ValidatorDetails {
+		pubkey, err := types.HexToValidatorPubkey(pubkeyString)
+		if err != nil {
+			return nil, common.Hash{}, fmt.Errorf("error parsing validator pubkey %s: %w", pubkeyString, err)
+		}
+		state.ValidatorDetails[pubkey] = status
+	}
+	state.createLookups()
+
+	return state, snapshot.ElBlockHash, nil
+}
+
+// Check a snapshot against the chain.
+// If the Execution client still has the state for the snapshot's block, the node and minipool counts and every ETH balance are compared at that block along with the full details of a sample of nodes, minipools, and validators.
+// Otherwise, only the node count and the values that never change for a sample are compared at the latest block.
+func (m *NetworkStateManager) verifySnapshot(state *NetworkState, blockHash common.Hash) error {
+	if state.BeaconConfig.GenesisTime != m.BeaconConfig.GenesisTime {
+		return fmt.Errorf("%w: it's for a different network", errSnapshotMismatch)
+	}
+
+	// Make sure the block is still on the canonical chain
+	header, err := m.ec.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(state.ElBlockNumber))
+	if err != nil {
+		return fmt.Errorf("error getting EL block %d: %w", state.ElBlockNumber, err)
+	}
+	if header.Hash() != blockHash {
+		return fmt.Errorf("%w: EL block %d was reorged out", errSnapshotMismatch, state.ElBlockNumber)
+	}
+
+	// Pick the nodes, minipools, and validators to check
+	nodes := sampleIndices(len(state.NodeDetails), snapshotSpotCheckCount)
+	minipools := sampleIndices(len(state.MinipoolDetails), snapshotSpotCheckCount)
+
+	multicallerAddress := common.HexToAddress(m.cfg.Smartnode.GetMulticallAddress())
+	balanceBatcherAddress := common.HexToAddress(m.cfg.Smartnode.GetBalanceBatcherAddress())
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+	contracts, err := rpstate.NewNetworkContracts(m.rp, multicallerAddress, balanceBatcherAddress, state.IsHoustonDeployed, opts)
+	if err == nil {
+		err = checkSnapshotValues(m, contracts, state, nodes, minipools, opts)
+		if err == nil || errors.Is(err, errSnapshotMismatch) {
+			return err
+		}
+	}
+
+	// The Execution client has pruned the state for the snapshot's block, so only check the values that can't have changed since
+	m.logLine("Block %d isn't available for checking the network state snapshot (%s), checking the values that can't change instead.", state.ElBlockNumber, err.Error())
+	isHoustonDeployed, err := IsHoustonDeployed(m.rp, nil)
+	if err != nil {
+		return fmt.Errorf("error checking if Houston is deployed: %w", err)
+	}
+	contracts, err = rpstate.NewNetworkContracts(m.rp, multicallerAddress, balanceBatcherAddress, isHoustonDeployed, nil)
+	if err != nil {
+		return fmt.Errorf("error getting network contracts: %w", err)
+	}
+	return checkSnapshotConstants(m, contracts, state, nodes, minipools)
+}
+
+// Compare a snapshot's counts and balances and a sample of its other values with the chain at the snapshot's block
+func checkSnapshotValues(m *NetworkStateManager, contracts *rpstate.NetworkContracts, state *NetworkState, nodes []int, minipools []int, opts *bind.CallOpts) error {
+	nodeCount, err := node.GetNodeCount(m.rp, opts)
+	if err != nil {
+		return fmt.Errorf("error getting node count: %w", err)
+	}
+	minipoolCount, err := minipool.GetMinipoolCount(m.rp, opts)
+	if err != nil {
+		return fmt.Errorf("error getting minipool count: %w", err)
+	}
+	err = compareSnapshotValues("the network", []snapshotValue{
+		{"node count", uint64(len(state.NodeDetails)), nodeCount},
+		{"minipool count", uint64(len(state.MinipoolDetails)), minipoolCount},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Every ETH balance is checked, since they change the most often
+	balances, err := contracts.BalanceBatcher.GetEthBalances(getBalanceAddresses(state), opts)
+	if err != nil {
+		return fmt.Errorf("error getting ETH balances: %w", err)
+	}
+	changes := newStateChanges()
+	err = markBalanceChanges(state, balances, &changes)
+	if err != nil {
+		return err
+	}
+	if len(changes.nodes) > 0 || len(changes.minipools) > 0 {
+		return fmt.Errorf("%w: %d nodes and %d minipools have different ETH balances", errSnapshotMismatch, len(changes.nodes), len(changes.minipools))
+	}
+
+	details, err := rpstate.NewNetworkDetails(m.rp, contracts, state.IsHoustonDeployed)
+	if err != nil {
+		return fmt.Errorf("error getting network details: %w", err)
+	}
+	err = compareSnapshotValues("the network", []snapshotValue{
+		{"RPL price", state.NetworkDetails.RplPrice, details.RplPrice},
+		{"total RPL stake", state.NetworkDetails.TotalRPLStake, details.TotalRPLStake},
+		{"total ETH balance", state.NetworkDetails.TotalETHBalance, details.TotalETHBalance},
+		{"reward index", state.NetworkDetails.RewardIndex, details.RewardIndex},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, i := range nodes {
+		expected := &state.NodeDetails[i]
+		actual, err := rpstate.GetNativeNodeDetails(m.rp, contracts, expected.NodeAddress)
+		if err != nil {
+			return fmt.Errorf("error getting details for node %s: %w", expected.NodeAddress.Hex(), err)
+		}
+		err = compareSnapshotValues("node "+expected.NodeAddress.Hex(), []snapshotValue{
+			{"RPL stake", expected.RplStake, actual.RplStake},
+			{"effective RPL stake", expected.EffectiveRPLStake, actual.EffectiveRPLStake},
+			{"matched ETH", expected.EthMatched, actual.EthMatched},
+			{"minipool count", expected.MinipoolCount, actual.MinipoolCount},
+			{"ETH balance", expected.BalanceETH, actual.BalanceETH},
+			{"fee distributor balance", expected.DistributorBalance, actual.DistributorBalance},
+			{"withdrawal address", expected.WithdrawalAddress, actual.WithdrawalAddress},
+			{"smoothing pool registration", expected.SmoothingPoolRegistrationState, actual.SmoothingPoolRegistrationState},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, i := range minipools {
+		expected := &state.MinipoolDetails[i]
+		actual, err := rpstate.GetNativeMinipoolDetails(m.rp, contracts, expected.MinipoolAddress)
+		if err != nil {
+			return fmt.Errorf("error getting details for minipool %s: %w", expected.MinipoolAddress.Hex(), err)
+		}
+		err = compareSnapshotValues("minipool "+expected.MinipoolAddress.Hex(), []snapshotValue{
+			{"node", expected.NodeAddress, actual.NodeAddress},
+			{"status", expected.StatusRaw, actual.StatusRaw},
+			{"finalized", expected.Finalised, actual.Finalised},
+			{"node fee", expected.NodeFee, actual.NodeFee},
+			{"node deposit balance", expected.NodeDepositBalance, actual.NodeDepositBalance},
+			{"user deposit balance", expected.UserDepositBalance, actual.UserDepositBalance},
+			{"balance", expected.Balance, actual.Balance},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Beacon nodes don't always keep old states either, so validators are only checked if the state is available
+		validator, exists := state.ValidatorDetails[expected.Pubkey]
+		if !exists || !validator.Exists {
+			continue
+		}
+		slot := state.BeaconSlotNumber
+		status, err := m.bc.GetValidatorStatus(expected.Pubkey, &beacon.ValidatorStatusOptions{Slot: &slot})
+		if err != nil {
+			m.logLine("Couldn't get validator %s at slot %d to check the network state snapshot, skipping it (%s).", expected.Pubkey.Hex(), slot, err.Error())
+			continue
+		}
+		err = compareSnapshotValues("validator "+expected.Pubkey.Hex(), []snapshotValue{
+			{"index", validator.Index, status.Index},
+			{"status", validator.Status, status.Status},
+			{"balance", validator.Balance, status.Balance},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Compare a sample of a snapshot's values that never change with the chain at the latest block
+func checkSnapshotConstants(m *NetworkStateManager, contracts *rpstate.NetworkContracts, state *NetworkState, nodes []int, minipools []int) error {
+	// Nodes are never removed, so there can't be fewer of them now
+	nodeCount, err := node.GetNodeCount(m.rp, nil)
+	if err != nil {
+		return fmt.Errorf("error getting node count: %w", err)
+	}
+	if uint64(len(state.NodeDetails)) > nodeCount {
+		return fmt.Errorf("%w: it has %d nodes but the chain has %d", errSnapshotMismatch, len(state.NodeDetails), nodeCount)
+	}
+
+	for _, i := range nodes {
+		expected := &state.NodeDetails[i]
+		address, err := node.GetNodeAt(m.rp, uint64(i), nil)
+		if err != nil {
+			return fmt.Errorf("error getting node %d: %w", i, err)
+		}
+		if address != expected.NodeAddress {
+			return fmt.Errorf("%w: node %d is %s but the chain has %s", errSnapshotMismatch, i, expected.NodeAddress.Hex(), address.Hex())
+		}
+		actual, err := rpstate.GetNativeNodeDetails(m.rp, contracts, expected.NodeAddress)
+		if err != nil {
+			return fmt.Errorf("error getting details for node %s: %w", expected.NodeAddress.Hex(), err)
+		}
+		err = compareSnapshotValues("node "+expected.NodeAddress.Hex(), []snapshotValue{
+			{"existence", expected.Exists, actual.Exists},
+			{"registration time", expected.RegistrationTime, actual.RegistrationTime},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, i := range minipools {
+		expected := &state.MinipoolDetails[i]
+		actual, err := rpstate.GetNativeMinipoolDetails(m.rp, contracts, expected.MinipoolAddress)
+		if err != nil {
+			return fmt.Errorf("error getting details for minipool %s: %w", expected.MinipoolAddress.Hex(), err)
+		}
+		if !actual.Exists {
+			// It was destroyed after the snapshot was taken
+			continue
+		}
+		err = compareSnapshotValues("minipool "+expected.MinipoolAddress.Hex(), []snapshotValue{
+			{"node", expected.NodeAddress, actual.NodeAddress},
+			{"pubkey", expected.Pubkey, actual.Pubkey},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// A value from a snapshot and the same value from the chain
+type snapshotValue struct {
+	name     string
+	expected interface{}
+	actual   interface{}
+}
+
+// Compare snapshot values with the chain, returning an errSnapshotMismatch for the first one that doesn't match
+func compareSnapshotValues(owner string, values []snapshotValue) error {
+	for _, value := range values {
+		var matches bool
+		expectedInt, isBigInt := value.expected.(*big.Int)
+		if isBigInt {
+			actualInt := value.actual.(*big.Int)
+			matches = (expectedInt == nil && actualInt == nil) || (expectedInt != nil && actualInt != nil && expectedInt.Cmp(actualInt) == 0)
+		} else {
+			matches = value.expected == value.actual
+		}
+		if !matches {
+			return fmt.Errorf("%w: %s of %s is %v but the chain has %v", errSnapshotMismatch, value.name, owner, value.expected, value.actual)
+		}
+	}
+	return nil
+}
+
+// Pick up to count random indices below length
+func sampleIndices(length int, count int) []int {
+	if length <= count {
+		indices := make([]int, length)
+		for i := range indices {
+			indices[i] = i
+		}
+		return indices
+	}
+	return rand.Perm(length)[:count]
+}
+
+// Delete the least recently used snapshots for this network until only the provided number are left
+func (m *NetworkStateManager) pruneSnapshots(count int) error {
+	folder := m.cfg.Smartnode.GetStateSnapshotFolder()
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return fmt.Errorf("error reading snapshot folder: %w", err)
+	}
+
+	prefix := string(m.cfg.Smartnode.Network.Value.(cfgtypes.Network)) + "-"
+	type snapshotFile struct {
+		name    string
+		modTime time.Time
+	}
+	files := []snapshotFile{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) || !strings.HasSuffix(entry.Name(), ".json.zst") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, snapshotFile{entry.Name(), info.ModTime()})
+	}
+	if len(files) <= count {
+		return nil
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.After(files[j].modTime)
+	})
+	for _, file := range files[count:] {
+		err = os.Remove(filepath.Join(folder, file.name))
+		if err != nil {
+			return fmt.Errorf("error deleting snapshot %s: %w", file.name, err)
+		}
+	}
+	return nil
+}
+
+// Save a network state to disk if its slot has been finalized, so the snapshot can't be reorged out later
+func (m *NetworkStateManager) saveFinalizedSnapshot(state *NetworkState, blockHash common.Hash) {
+	if m.cfg.Smartnode.StateSnapshotCount.Value.(uint64) == 0 {
+		return
+	}
+	if _, err := os.Stat(m.cfg.Smartnode.GetStateSnapshotPath(state.BeaconSlotNumber)); err == nil {
+		return
+	}
+
+	head, err := m.bc.GetBeaconHead()
+	if err != nil {
+		m.logLine("WARNING: couldn't get the Beacon head to check if the network state can be saved: %s", err.Error())
+		return
+	}
+	if state.BeaconSlotNumber >= (head.FinalizedEpoch+1)*m.BeaconConfig.SlotsPerEpoch {
+		return
+	}
+
+	err = m.saveSnapshot(state, blockHash)
+	if err != nil {
+		m.logLine("WARNING: couldn't save network state snapshot for slot %d: %s", state.BeaconSlotNumber, err.Error())
+	}
+}
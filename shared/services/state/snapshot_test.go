@@ -0,0 +1,60 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	state := newTestState()
+	blockHash := common.HexToHash("0xabcdef")
+	compressedBytes, err := encodeSnapshot(state, blockHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, decodedHash, err := decodeSnapshot(compressedBytes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decodedHash != blockHash {
+		t.Errorf("block hash is %s, expected %s", decodedHash.Hex(), blockHash.Hex())
+	}
+	if decoded.ElBlockNumber != state.ElBlockNumber || decoded.BeaconSlotNumber != state.BeaconSlotNumber {
+		t.Errorf("block %d and slot %d were decoded as %d and %d", state.ElBlockNumber, state.BeaconSlotNumber, decoded.ElBlockNumber, decoded.BeaconSlotNumber)
+	}
+	if decoded.NetworkDetails.RplPrice.Cmp(state.NetworkDetails.RplPrice) != 0 || decoded.NetworkDetails.TotalRPLStake.Cmp(state.NetworkDetails.TotalRPLStake) != 0 {
+		t.Error("network details weren't decoded correctly")
+	}
+
+	// The lookups should be rebuilt to point into the decoded lists
+	node, exists := decoded.NodeDetailsByAddress[testOtherNode]
+	if !exists || node != &decoded.NodeDetails[1] || node.RplStake.Int64() != 400 || node.DistributorBalance.Int64() != 4 {
+		t.Errorf("node %s wasn't decoded correctly", testOtherNode.Hex())
+	}
+	minipool, exists := decoded.MinipoolDetailsByAddress[testMinipool]
+	if !exists || minipool != &decoded.MinipoolDetails[0] || minipool.Pubkey != testPubkey || minipool.Balance.Int64() != 5 {
+		t.Errorf("minipool %s wasn't decoded correctly", testMinipool.Hex())
+	}
+	if len(decoded.MinipoolDetailsByNode[testNode]) != 1 || decoded.MinipoolDetailsByNode[testNode][0] != minipool {
+		t.Errorf("the minipools of node %s weren't decoded correctly", testNode.Hex())
+	}
+	if decoded.ValidatorDetails[testPubkey] != state.ValidatorDetails[testPubkey] {
+		t.Errorf("validator was decoded as %+v, expected %+v", decoded.ValidatorDetails[testPubkey], state.ValidatorDetails[testPubkey])
+	}
+}
+
+func TestDecodeSnapshotRejectsCorruptData(t *testing.T) {
+	compressedBytes, err := encodeSnapshot(newTestState(), common.Hash{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressedBytes[len(compressedBytes)/2] ^= 0xff
+	if _, _, err := decodeSnapshot(compressedBytes, nil); err == nil {
+		t.Error("a corrupt snapshot was decoded")
+	}
+	if _, _, err := decodeSnapshot(compressedBytes[:len(compressedBytes)-4], nil); err == nil {
+		t.Error("a truncated snapshot was decoded")
+	}
+}
@@ -4,3 +4,21 @@ type APIResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// Routes served by the daemon's API server
+const (
+	ApiServerCommandPath string = "/api"
+	ApiServerConfigPath  string = "/config"
+	ApiServerVersionPath string = "/version"
+)
+
+// A request for the daemon's API server to run an API command
+type ApiServerRequest struct {
+	Args []string `json:"args"`
+}
+
+type ApiServerVersionResponse struct {
+	Status  string `json:"status"`
+	Error   string `json:"error"`
+	Version string `json:"version"`
+}
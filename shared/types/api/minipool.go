@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/scrub"
 )
 
 type MinipoolStatusResponse struct {
@@ -349,3 +350,22 @@ type RescueDissolvedMinipoolResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+type CanSetFeeRecipientOverrideResponse struct {
+	Status                string         `json:"status"`
+	Error                 string         `json:"error"`
+	CanSet                bool           `json:"canSet"`
+	WouldBePenalized      bool           `json:"wouldBePenalized"`
+	SmoothingPoolAddress  common.Address `json:"smoothingPoolAddress"`
+	FeeDistributorAddress common.Address `json:"feeDistributorAddress"`
+	RethAddress           common.Address `json:"rethAddress"`
+	IsInSmoothingPool     bool           `json:"isInSmoothingPool"`
+	IsInOptOutCooldown    bool           `json:"isInOptOutCooldown"`
+}
+
+type MinipoolScrubCheckResponse struct {
+	Status      string                 `json:"status"`
+	Error       string                 `json:"error"`
+	BlockNumber uint64                 `json:"blockNumber"`
+	Minipools   []*scrub.MinipoolCheck `json:"minipools"`
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	rptypes "github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/blocks"
+	"github.com/rocket-pool/smartnode/shared/services/deferred"
 	"github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/utils/rp"
 )
@@ -84,7 +86,10 @@ type NodeStatusResponse struct {
 		ProposalVotes           []SnapshotProposalVote `json:"proposalVotes"`
 		ActiveSnapshotProposals []SnapshotProposal     `json:"activeSnapshotProposals"`
 	} `json:"snapshotResponse"`
-	Alerts []NodeAlert `json:"alerts"`
+	Alerts                 []NodeAlert             `json:"alerts"`
+	RecentProposals        []blocks.ProposalRecord `json:"recentProposals"`
+	ProposalCount          int                     `json:"proposalCount"`
+	IncorrectProposalCount int                     `json:"incorrectProposalCount"`
 }
 
 type NodeAlert struct {
@@ -683,3 +688,90 @@ type NodeAlertsResponse struct {
 	// TODO: change to GettableAlerts
 	Message string `json:"message"`
 }
+
+type NodeProposalsResponse struct {
+	Status            string                   `json:"status"`
+	Error             string                   `json:"error"`
+	Proposals         []blocks.ProposalRecord  `json:"proposals"`
+	ProposalCount     int                      `json:"proposalCount"`
+	Intervals         []blocks.IntervalSummary `json:"intervals"`
+	TotalPayloadValue *big.Int                 `json:"totalPayloadValue"`
+	QueryRelayData    bool                     `json:"queryRelayData"`
+}
+
+type NodeDeferredTxsResponse struct {
+	Status      string             `json:"status"`
+	Error       string             `json:"error"`
+	HasSchedule bool               `json:"hasSchedule"`
+	UpdatedTime time.Time          `json:"updatedTime"`
+	Actions     []*deferred.Action `json:"actions"`
+}
+
+type NodeDutiesResponse struct {
+	Status                    string                 `json:"status"`
+	Error                     string                 `json:"error"`
+	Epoch                     uint64                 `json:"epoch"`
+	SecondsPerEpoch           uint64                 `json:"secondsPerEpoch"`
+	NextSyncCommitteeEpoch    uint64                 `json:"nextSyncCommitteeEpoch"`
+	ValidatorCount            int                    `json:"validatorCount"`
+	ProposalsThisEpoch        uint64                 `json:"proposalsThisEpoch"`
+	CurrentSyncCommitteeCount int                    `json:"currentSyncCommitteeCount"`
+	NextSyncCommitteeCount    int                    `json:"nextSyncCommitteeCount"`
+	Validators                []ValidatorDutyDetails `json:"validators"`
+}
+type ValidatorDutyDetails struct {
+	Pubkey                 rptypes.ValidatorPubkey `json:"pubkey"`
+	Index                  string                  `json:"index"`
+	ProposalsThisEpoch     uint64                  `json:"proposalsThisEpoch"`
+	InCurrentSyncCommittee bool                    `json:"inCurrentSyncCommittee"`
+	InNextSyncCommittee    bool                    `json:"inNextSyncCommittee"`
+}
+
+type NodePendingTxsResponse struct {
+	Status       string `json:"status"`
+	Error        string `json:"error"`
+	LatestNonce  uint64 `json:"latestNonce"`
+	PendingNonce uint64 `json:"pendingNonce"`
+	PendingCount uint64 `json:"pendingCount"`
+}
+
+// The kinds of entries in a node's ledger
+type LedgerEntryType string
+
+const (
+	LedgerEntry_RplRewards           LedgerEntryType = "rpl-rewards"
+	LedgerEntry_SmoothingPoolRewards LedgerEntryType = "smoothing-pool-rewards"
+	LedgerEntry_MinipoolDistribution LedgerEntryType = "minipool-distribution"
+	LedgerEntry_MinipoolRefund       LedgerEntryType = "minipool-refund"
+	LedgerEntry_FeeDistribution      LedgerEntryType = "fee-distribution"
+	LedgerEntry_RplStake             LedgerEntryType = "rpl-stake"
+	LedgerEntry_RplWithdrawal        LedgerEntryType = "rpl-withdrawal"
+	LedgerEntry_RplSlash             LedgerEntryType = "rpl-slash"
+	LedgerEntry_NodeDeposit          LedgerEntryType = "node-deposit"
+	LedgerEntry_Gas                  LedgerEntryType = "gas"
+)
+
+type NodeExportLedgerResponse struct {
+	Status      string         `json:"status"`
+	Error       string         `json:"error"`
+	NodeAddress common.Address `json:"nodeAddress"`
+	FromBlock   uint64         `json:"fromBlock"`
+	ToBlock     uint64         `json:"toBlock"`
+	FromTime    time.Time      `json:"fromTime"`
+	ToTime      time.Time      `json:"toTime"`
+	Entries     []LedgerEntry  `json:"entries"`
+	Warnings    []string       `json:"warnings"`
+}
+
+// A single movement of ETH or RPL; the amount is positive if the node (or its withdrawal address) received it and negative if it paid it
+type LedgerEntry struct {
+	Type     LedgerEntryType `json:"type"`
+	Block    uint64          `json:"block"`
+	Time     time.Time       `json:"time"`
+	TxHash   common.Hash     `json:"txHash"`
+	Contract common.Address  `json:"contract"`
+	Interval *uint64         `json:"interval,omitempty"`
+	Asset    string          `json:"asset"`
+	Amount   *big.Int        `json:"amount"`
+	RplPrice *big.Int        `json:"rplPrice"`
+}
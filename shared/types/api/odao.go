@@ -2,11 +2,15 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
 	tn "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/participation"
+	"github.com/rocket-pool/smartnode/shared/services/scheduler"
 )
 
 type TNDAOStatusResponse struct {
@@ -314,3 +318,28 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	BondReductionWindowStart  uint64 `json:"bondReductionWindowStart"`
 	BondReductionWindowLength uint64 `json:"bondReductionWindowLength"`
 }
+
+type TNDAOMemberParticipation struct {
+	Address     common.Address              `json:"address"`
+	ID          string                      `json:"id"`
+	IsLocalNode bool                        `json:"isLocalNode"`
+	Duties      []participation.DutySummary `json:"duties"`
+}
+
+type TNDAOParticipationResponse struct {
+	Status           string                     `json:"status"`
+	Error            string                     `json:"error"`
+	HasHistory       bool                       `json:"hasHistory"`
+	LastScannedBlock uint64                     `json:"lastScannedBlock"`
+	RecentWindow     int                        `json:"recentWindow"`
+	Members          []TNDAOMemberParticipation `json:"members"`
+	RecentRounds     []participation.DutyRound  `json:"recentRounds"`
+}
+
+type TNDAOWatchtowerStatusResponse struct {
+	Status      string                 `json:"status"`
+	Error       string                 `json:"error"`
+	HasStatus   bool                   `json:"hasStatus"`
+	UpdatedTime time.Time              `json:"updatedTime"`
+	Tasks       []scheduler.TaskStatus `json:"tasks"`
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/dao/protocol"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
 )
 
 type PDAOProposalWithNodeVoteDirection struct {
@@ -427,3 +428,148 @@ type PDAOStatusResponse struct {
 	TotalDelegatedVp        *big.Int       `json:"totalDelegateVp"`
 	SumVotingPower          *big.Int       `json:"sumVotingPower"`
 }
+
+// The unit of a quantity in a proposal impact preview
+type PDAOImpactUnit string
+
+const (
+	PDAOImpactUnit_Eth     PDAOImpactUnit = "eth"
+	PDAOImpactUnit_Rpl     PDAOImpactUnit = "rpl"
+	PDAOImpactUnit_Percent PDAOImpactUnit = "percent"
+	PDAOImpactUnit_Count   PDAOImpactUnit = "count"
+	PDAOImpactUnit_Bool    PDAOImpactUnit = "bool"
+)
+
+// A quantity before and after a proposal is executed
+type PDAOImpactRow struct {
+	Name   string         `json:"name"`
+	Unit   PDAOImpactUnit `json:"unit"`
+	Before *big.Int       `json:"before"`
+	After  *big.Int       `json:"after"`
+}
+
+// A single setting change made by a proposal
+type PDAOSettingChange struct {
+	Contract string `json:"contract"`
+	Setting  string `json:"setting"`
+	Value    string `json:"value"`
+}
+
+type PDAOProposalImpactResponse struct {
+	Status           string              `json:"status"`
+	Error            string              `json:"error"`
+	IsSettingChange  bool                `json:"isSettingChange"`
+	Changes          []PDAOSettingChange `json:"changes"`
+	HasImpact        bool                `json:"hasImpact"`
+	ElBlockNumber    uint64              `json:"elBlockNumber"`
+	IsNodeRegistered bool                `json:"isNodeRegistered"`
+	Rows             []PDAOImpactRow     `json:"rows"`
+}
+
+// The combined voting power delegated to an address
+type PDAODelegateSummary struct {
+	Delegate       common.Address `json:"delegate"`
+	VotingPower    *big.Int       `json:"votingPower"`
+	DelegatorCount uint64         `json:"delegatorCount"`
+}
+
+// A node that delegates its voting power to this node
+type PDAODelegator struct {
+	NodeAddress common.Address `json:"nodeAddress"`
+	VotingPower *big.Int       `json:"votingPower"`
+}
+
+// How the node's delegate voted on a proposal
+type PDAODelegateVote struct {
+	ProposalID            uint64                         `json:"proposalId"`
+	Message               string                         `json:"message"`
+	State                 types.ProtocolDaoProposalState `json:"state"`
+	Phase1EndTime         time.Time                      `json:"phase1EndTime"`
+	DelegateVoteDirection types.VoteDirection            `json:"delegateVoteDirection"`
+	NodeVoteDirection     types.VoteDirection            `json:"nodeVoteDirection"`
+	IsAwaitingDelegate    bool                           `json:"isAwaitingDelegate"`
+}
+
+type PDAODelegatesResponse struct {
+	Status                         string                `json:"status"`
+	Error                          string                `json:"error"`
+	BlockNumber                    uint32                `json:"blockNumber"`
+	AccountAddress                 common.Address        `json:"accountAddress"`
+	OnchainVotingDelegate          common.Address        `json:"onchainVotingDelegate"`
+	OnchainVotingDelegateFormatted string                `json:"onchainVotingDelegateFormatted"`
+	NodeVotingPower                *big.Int              `json:"nodeVotingPower"`
+	TotalVotingPower               *big.Int              `json:"totalVotingPower"`
+	DelegatedVotingPower           *big.Int              `json:"delegatedVotingPower"`
+	Delegates                      []PDAODelegateSummary `json:"delegates"`
+	Delegators                     []PDAODelegator       `json:"delegators"`
+	DelegateVotes                  []PDAODelegateVote    `json:"delegateVotes"`
+}
+
+// The result of comparing a root submission (the proposal's pollard or a challenge response) against the local voting tree
+type PDAORootSubmissionAudit struct {
+	Index          uint64                       `json:"index"`
+	Submitter      common.Address               `json:"submitter"`
+	Timestamp      time.Time                    `json:"timestamp"`
+	ChallengeState types.ChallengeState         `json:"challengeState"`
+	Mismatches     []proposals.TreeNodeMismatch `json:"mismatches"`
+}
+
+// A challenge raised against a proposal
+type PDAOChallengeAudit struct {
+	Index         uint64               `json:"index"`
+	Challenger    common.Address       `json:"challenger"`
+	Timestamp     time.Time            `json:"timestamp"`
+	State         types.ChallengeState `json:"state"`
+	CanBeDefeated bool                 `json:"canBeDefeated"`
+}
+
+type PDAOProposalAuditResponse struct {
+	Status          string                         `json:"status"`
+	Error           string                         `json:"error"`
+	DoesExist       bool                           `json:"doesExist"`
+	ProposalID      uint64                         `json:"proposalId"`
+	State           types.ProtocolDaoProposalState `json:"state"`
+	Proposer        common.Address                 `json:"proposer"`
+	TargetBlock     uint32                         `json:"targetBlock"`
+	NodeCount       uint64                         `json:"nodeCount"`
+	LocalRoot       types.VotingTreeNode           `json:"localRoot"`
+	ProposedRoot    types.VotingTreeNode           `json:"proposedRoot"`
+	RootSubmissions []PDAORootSubmissionAudit      `json:"rootSubmissions"`
+	Challenges      []PDAOChallengeAudit           `json:"challenges"`
+	VotingStartTime time.Time                      `json:"votingStartTime"`
+	IsDefeatable    bool                           `json:"isDefeatable"`
+	DefeatIndex     uint64                         `json:"defeatIndex"`
+}
+
+type PDAOVotingPowerHistoryEntry struct {
+	ProposalID           uint64    `json:"proposalId"`
+	Message              string    `json:"message"`
+	CreatedTime          time.Time `json:"createdTime"`
+	TargetBlock          uint32    `json:"targetBlock"`
+	VotingPower          *big.Int  `json:"votingPower"`
+	IsSnapshotCached     bool      `json:"isSnapshotCached"`
+	DelegatedVotingPower *big.Int  `json:"delegatedVotingPower"`
+	TotalVotingPower     *big.Int  `json:"totalVotingPower"`
+}
+
+type PDAOVotingPowerEstimate struct {
+	RplStake       *big.Int `json:"rplStake"`
+	BondedEth      *big.Int `json:"bondedEth"`
+	MaxVotingStake *big.Int `json:"maxVotingStake"`
+	VotingPower    *big.Int `json:"votingPower"`
+}
+
+type PDAOVotingPowerResponse struct {
+	Status               string                        `json:"status"`
+	Error                string                        `json:"error"`
+	AccountAddress       common.Address                `json:"accountAddress"`
+	BlockNumber          uint32                        `json:"blockNumber"`
+	VotingPower          *big.Int                      `json:"votingPower"`
+	DelegatedVotingPower *big.Int                      `json:"delegatedVotingPower"`
+	TotalVotingPower     *big.Int                      `json:"totalVotingPower"`
+	RplPrice             *big.Int                      `json:"rplPrice"`
+	MaxStakeFraction     *big.Int                      `json:"maxStakeFraction"`
+	Current              PDAOVotingPowerEstimate       `json:"current"`
+	WhatIf               *PDAOVotingPowerEstimate      `json:"whatIf,omitempty"`
+	History              []PDAOVotingPowerHistoryEntry `json:"history"`
+}
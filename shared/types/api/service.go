@@ -1,6 +1,10 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -20,6 +24,7 @@ type ClientStatus struct {
 	IsSynced     bool    `json:"isSynced"`
 	SyncProgress float64 `json:"syncProgress"`
 	NetworkId    uint    `json:"networkId"`
+	PeerCount    uint64  `json:"peerCount"`
 	Error        string  `json:"error"`
 }
 
@@ -41,3 +46,35 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+type CreateMigrationBundleResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	BundleFile     string         `json:"bundleFile"`
+	NodeAddress    common.Address `json:"nodeAddress"`
+	StopEpoch      uint64         `json:"stopEpoch"`
+	ValidatorCount int            `json:"validatorCount"`
+	Entries        []string       `json:"entries"`
+}
+
+type RestoreMigrationBundleResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	NodeAddress    common.Address `json:"nodeAddress"`
+	Network        string         `json:"network"`
+	CreatedAt      time.Time      `json:"createdAt"`
+	StopEpoch      uint64         `json:"stopEpoch"`
+	ValidatorCount int            `json:"validatorCount"`
+	Entries        []string       `json:"entries"`
+	Settings       string         `json:"settings"`
+}
+
+type ValidatorLivenessResponse struct {
+	Status          string   `json:"status"`
+	Error           string   `json:"error"`
+	Epoch           uint64   `json:"epoch"`
+	FinalizedEpoch  uint64   `json:"finalizedEpoch"`
+	ValidatorCount  int      `json:"validatorCount"`
+	LiveValidators  []string `json:"liveValidators"`
+	SecondsPerEpoch uint64   `json:"secondsPerEpoch"`
+}
@@ -11,6 +11,8 @@ type MevRelayID string
 type MevSelectionMode string
 type NimbusPruningMode string
 type PBSubmissionRef int
+type RplPriceAggregation string
+type GasEstimateSource string
 
 // Enum to describe which container(s) a parameter impacts, so the Smartnode knows which
 // ones to restart upon a settings change
@@ -88,6 +90,20 @@ const (
 	PBSubmission_6AM PBSubmissionRef = 1713420000
 )
 
+// Enum to describe how the watchtower combines the RPL prices of its price sources
+const (
+	RplPriceAggregation_Unknown           RplPriceAggregation = ""
+	RplPriceAggregation_Median            RplPriceAggregation = "median"
+	RplPriceAggregation_LiquidityWeighted RplPriceAggregation = "liquidityWeighted"
+)
+
+// Enum to describe where the Smartnode gets its gas price suggestions from
+const (
+	GasEstimateSource_Unknown  GasEstimateSource = ""
+	GasEstimateSource_Local    GasEstimateSource = "local"
+	GasEstimateSource_External GasEstimateSource = "external"
+)
+
 // Enum to identify MEV-boost relays
 const (
 	MevRelayID_Unknown            MevRelayID = ""
@@ -100,6 +116,7 @@ const (
 	MevRelayID_Aestus             MevRelayID = "aestus"
 	MevRelayID_TitanGlobal        MevRelayID = "titanGlobal"
 	MevRelayID_TitanRegional      MevRelayID = "titanRegional"
+	MevRelayID_Custom             MevRelayID = "custom"
 )
 
 // Enum to describe MEV-Boost relay selection mode
@@ -146,9 +163,10 @@ type ChangedSetting struct {
 
 // A MEV relay
 type MevRelay struct {
-	ID          MevRelayID
-	Name        string
-	Description string
-	Urls        map[Network]string
-	Regulated   bool
+	ID          MevRelayID         `json:"id"`
+	SettingID   string             `json:"settingId,omitempty"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Urls        map[Network]string `json:"urls"`
+	Regulated   bool               `json:"regulated"`
 }
@@ -0,0 +1,803 @@
+package results
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/security"
+	tn "github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Create the result of `wallet status`
+func NewWalletStatus(status api.WalletStatusResponse) WalletStatus {
+	return WalletStatus{
+		PasswordSet:       status.PasswordSet,
+		WalletInitialized: status.WalletInitialized,
+		AccountAddress:    status.AccountAddress,
+	}
+}
+
+// Create the result of `node status`
+func NewNodeStatus(status api.NodeStatusResponse) NodeStatus {
+	rplWithdrawalAddress := status.PrimaryWithdrawalAddress
+	if status.IsRPLWithdrawalAddressSet {
+		rplWithdrawalAddress = status.RPLWithdrawalAddress
+	}
+	return NodeStatus{
+		AccountAddress:           status.AccountAddress,
+		Registered:               status.Registered,
+		Trusted:                  status.Trusted,
+		TimezoneLocation:         status.TimezoneLocation,
+		PrimaryWithdrawalAddress: status.PrimaryWithdrawalAddress,
+		RplWithdrawalAddress:     rplWithdrawalAddress,
+		EthBalance:               status.AccountBalances.ETH,
+		RplBalance:               status.AccountBalances.RPL,
+		CreditBalance:            status.CreditBalance,
+		RplStake:                 status.RplStake,
+		EffectiveRplStake:        status.EffectiveRplStake,
+		MinimumRplStake:          status.MinimumRplStake,
+		MaximumRplStake:          status.MaximumRplStake,
+		BorrowedCollateralRatio:  status.BorrowedCollateralRatio,
+		BondedCollateralRatio:    status.BondedCollateralRatio,
+		EthMatched:               status.EthMatched,
+		EthMatchedLimit:          status.EthMatchedLimit,
+		IsInSmoothingPool:        status.FeeRecipientInfo.IsInSmoothingPool,
+		FeeDistributorAddress:    status.FeeRecipientInfo.FeeDistributorAddress,
+		FeeDistributorBalance:    status.FeeDistributorBalance,
+		MinipoolCounts: NodeMinipoolCounts{
+			Total:        status.MinipoolCounts.Total,
+			Initialized:  status.MinipoolCounts.Initialized,
+			Prelaunch:    status.MinipoolCounts.Prelaunch,
+			Staking:      status.MinipoolCounts.Staking,
+			Withdrawable: status.MinipoolCounts.Withdrawable,
+			Dissolved:    status.MinipoolCounts.Dissolved,
+			Finalised:    status.MinipoolCounts.Finalised,
+		},
+	}
+}
+
+// Create the result of `node rewards`
+func NewNodeRewards(rewards api.NodeRewardsResponse) NodeRewards {
+	return NodeRewards{
+		Registered:                  rewards.Registered,
+		Trusted:                     rewards.Trusted,
+		RewardsInterval:             rewards.RewardsInterval,
+		LastCheckpoint:              rewards.LastCheckpoint,
+		EffectiveRplStake:           rewards.EffectiveRplStake,
+		TotalRplStake:               rewards.TotalRplStake,
+		EstimatedRplRewards:         rewards.EstimatedRewards,
+		EstimatedTrustedRplRewards:  rewards.EstimatedTrustedRplRewards,
+		CumulativeRplRewards:        rewards.CumulativeRplRewards,
+		CumulativeTrustedRplRewards: rewards.CumulativeTrustedRplRewards,
+		CumulativeEthRewards:        rewards.CumulativeEthRewards,
+		UnclaimedRplRewards:         rewards.UnclaimedRplRewards,
+		UnclaimedTrustedRplRewards:  rewards.UnclaimedTrustedRplRewards,
+		UnclaimedEthRewards:         rewards.UnclaimedEthRewards,
+		BeaconRewards:               rewards.BeaconRewards,
+	}
+}
+
+// Create the result of `node sync`
+func NewNodeSync(status api.NodeSyncProgressResponse) NodeSync {
+	return NodeSync{
+		ExecutionClients: newClientSync(status.EcStatus),
+		BeaconClients:    newClientSync(status.BcStatus),
+	}
+}
+
+// Get the sync status of a primary client and its fallback
+func newClientSync(status api.ClientManagerStatus) ClientSync {
+	sync := ClientSync{
+		Primary:         newClientSyncStatus(status.PrimaryClientStatus),
+		FallbackEnabled: status.FallbackEnabled,
+	}
+	if status.FallbackEnabled {
+		fallback := newClientSyncStatus(status.FallbackClientStatus)
+		sync.Fallback = &fallback
+	}
+	return sync
+}
+
+// Get the sync status of one client
+func newClientSyncStatus(status api.ClientStatus) ClientSyncStatus {
+	return ClientSyncStatus{
+		IsWorking:    status.IsWorking,
+		IsSynced:     status.IsSynced,
+		SyncProgress: status.SyncProgress,
+		Error:        status.Error,
+	}
+}
+
+// Create the result of `minipool status` for the provided minipools
+func NewMinipoolStatus(minipools []api.MinipoolDetails) MinipoolStatus {
+	result := MinipoolStatus{
+		Minipools: make([]Minipool, len(minipools)),
+	}
+	for i, minipool := range minipools {
+		result.Minipools[i] = Minipool{
+			Address:            minipool.Address,
+			ValidatorPubkey:    minipool.ValidatorPubkey,
+			Status:             minipool.Status.Status,
+			StatusTime:         minipool.Status.StatusTime,
+			IsVacant:           minipool.Status.IsVacant,
+			Finalised:          minipool.Finalised,
+			NodeFee:            minipool.Node.Fee,
+			NodeDepositBalance: minipool.Node.DepositBalance,
+			UserDepositBalance: minipool.User.DepositBalance,
+			Balance:            minipool.Balances.ETH,
+			NodeShareOfBalance: minipool.NodeShareOfETHBalance,
+			ValidatorIndex:     minipool.Validator.Index,
+			ValidatorActive:    minipool.Validator.Active,
+			BeaconBalance:      minipool.Validator.Balance,
+			NodeBeaconBalance:  minipool.Validator.NodeBalance,
+			CanStake:           minipool.CanStake,
+			RefundAvailable:    minipool.RefundAvailable,
+			CloseAvailable:     minipool.CloseAvailable,
+			Delegate:           minipool.EffectiveDelegate,
+			UseLatestDelegate:  minipool.UseLatestDelegate,
+			Penalties:          minipool.Penalties,
+		}
+	}
+	return result
+}
+
+// Create the result of `network stats`
+func NewNetworkStats(stats api.NetworkStatsResponse) NetworkStats {
+	return NetworkStats{
+		TotalValueLocked:          stats.TotalValueLocked,
+		DepositPoolBalance:        stats.DepositPoolBalance,
+		MinipoolCapacity:          stats.MinipoolCapacity,
+		StakerUtilization:         stats.StakerUtilization,
+		NodeFee:                   stats.NodeFee,
+		NodeCount:                 stats.NodeCount,
+		InitializedMinipoolCount:  stats.InitializedMinipoolCount,
+		PrelaunchMinipoolCount:    stats.PrelaunchMinipoolCount,
+		StakingMinipoolCount:      stats.StakingMinipoolCount,
+		WithdrawableMinipoolCount: stats.WithdrawableMinipoolCount,
+		DissolvedMinipoolCount:    stats.DissolvedMinipoolCount,
+		FinalizedMinipoolCount:    stats.FinalizedMinipoolCount,
+		RplPrice:                  stats.RplPrice,
+		TotalRplStaked:            stats.TotalRplStaked,
+		EffectiveRplStaked:        stats.EffectiveRplStaked,
+		RethPrice:                 stats.RethPrice,
+		SmoothingPoolNodes:        stats.SmoothingPoolNodes,
+		SmoothingPoolAddress:      stats.SmoothingPoolAddress,
+		SmoothingPoolBalance:      stats.SmoothingPoolBalance,
+	}
+}
+
+// Create the result of `network node-fee`
+func NewNetworkNodeFee(fee api.NodeFeeResponse) NetworkNodeFee {
+	return NetworkNodeFee{
+		NodeFee:       fee.NodeFee,
+		MinNodeFee:    fee.MinNodeFee,
+		TargetNodeFee: fee.TargetNodeFee,
+		MaxNodeFee:    fee.MaxNodeFee,
+	}
+}
+
+// Create the result of `network rpl-price`
+func NewNetworkRplPrice(price api.RplPriceResponse) NetworkRplPrice {
+	return NetworkRplPrice{
+		RplPrice:                    price.RplPrice,
+		RplPriceBlock:               price.RplPriceBlock,
+		MinPer8EthMinipoolRplStake:  price.MinPer8EthMinipoolRplStake,
+		MinPer16EthMinipoolRplStake: price.MinPer16EthMinipoolRplStake,
+	}
+}
+
+// Create the result of `queue status`
+func NewQueueStatus(status api.QueueStatusResponse) QueueStatus {
+	return QueueStatus{
+		DepositPoolBalance:    status.DepositPoolBalance,
+		MinipoolQueueLength:   status.MinipoolQueueLength,
+		MinipoolQueueCapacity: status.MinipoolQueueCapacity,
+	}
+}
+
+// Create the result of `auction status`
+func NewAuctionStatus(status api.AuctionStatusResponse) AuctionStatus {
+	return AuctionStatus{
+		TotalRplBalance:     status.TotalRPLBalance,
+		AllottedRplBalance:  status.AllottedRPLBalance,
+		RemainingRplBalance: status.RemainingRPLBalance,
+		CanCreateLot:        status.CanCreateLot,
+		LotCounts: AuctionLotCounts{
+			ClaimAvailable:       status.LotCounts.ClaimAvailable,
+			BiddingAvailable:     status.LotCounts.BiddingAvailable,
+			RplRecoveryAvailable: status.LotCounts.RPLRecoveryAvailable,
+		},
+	}
+}
+
+// Create the result of `auction lots` for the provided lots
+func NewAuctionLots(lots []api.LotDetails) AuctionLots {
+	result := AuctionLots{
+		Lots: make([]AuctionLot, len(lots)),
+	}
+	for i, lot := range lots {
+		result.Lots[i] = AuctionLot{
+			Index:                lot.Details.Index,
+			StartBlock:           lot.Details.StartBlock,
+			EndBlock:             lot.Details.EndBlock,
+			StartPrice:           lot.Details.StartPrice,
+			ReservePrice:         lot.Details.ReservePrice,
+			CurrentPrice:         lot.Details.CurrentPrice,
+			TotalRplAmount:       lot.Details.TotalRPLAmount,
+			ClaimedRplAmount:     lot.Details.ClaimedRPLAmount,
+			RemainingRplAmount:   lot.Details.RemainingRPLAmount,
+			TotalBidAmount:       lot.Details.TotalBidAmount,
+			AddressBidAmount:     lot.Details.AddressBidAmount,
+			Cleared:              lot.Details.Cleared,
+			RplRecovered:         lot.Details.RPLRecovered,
+			ClaimAvailable:       lot.ClaimAvailable,
+			BiddingAvailable:     lot.BiddingAvailable,
+			RplRecoveryAvailable: lot.RPLRecoveryAvailable,
+		}
+	}
+	return result
+}
+
+// Create the result of `odao status`
+func NewOdaoStatus(status api.TNDAOStatusResponse) OdaoStatus {
+	return OdaoStatus{
+		IsMember:       status.IsMember,
+		CanJoin:        status.CanJoin,
+		CanLeave:       status.CanLeave,
+		CanReplace:     status.CanReplace,
+		TotalMembers:   status.TotalMembers,
+		ProposalCounts: DaoProposalCounts(status.ProposalCounts),
+	}
+}
+
+// Create the result of `odao members` for the provided members
+func NewOdaoMembers(members []tn.MemberDetails) OdaoMembers {
+	result := OdaoMembers{
+		Members: make([]OdaoMember, len(members)),
+	}
+	for i, member := range members {
+		result.Members[i] = OdaoMember{
+			Address:                member.Address,
+			ID:                     member.ID,
+			Url:                    member.Url,
+			JoinedTime:             time.Unix(int64(member.JoinedTime), 0),
+			LastProposalTime:       time.Unix(int64(member.LastProposalTime), 0),
+			RplBondAmount:          member.RPLBondAmount,
+			UnbondedValidatorCount: member.UnbondedValidatorCount,
+		}
+	}
+	return result
+}
+
+// Create the result of `security status`
+func NewSecurityStatus(status api.SecurityStatusResponse) SecurityStatus {
+	return SecurityStatus{
+		IsMember:       status.IsMember,
+		CanJoin:        status.CanJoin,
+		CanLeave:       status.CanLeave,
+		TotalMembers:   status.TotalMembers,
+		ProposalCounts: DaoProposalCounts(status.ProposalCounts),
+	}
+}
+
+// Create the result of `security members` for the provided members
+func NewSecurityMembers(members []security.SecurityDAOMemberDetails) SecurityMembers {
+	result := SecurityMembers{
+		Members: make([]SecurityMember, len(members)),
+	}
+	for i, member := range members {
+		result.Members[i] = SecurityMember{
+			Address:    member.Address,
+			ID:         member.ID,
+			JoinedTime: time.Unix(int64(member.JoinedTime), 0),
+		}
+	}
+	return result
+}
+
+// Create the result of `pdao status`
+func NewPdaoStatus(status api.PDAOStatusResponse, hasClaimableBonds bool) PdaoStatus {
+	result := PdaoStatus{
+		AccountAddress:            status.AccountAddress,
+		BlockNumber:               status.BlockNumber,
+		SnapshotVotingDelegate:    status.SnapshotVotingDelegate,
+		SnapshotError:             status.SnapshotResponse.Error,
+		IsVotingInitialized:       status.IsVotingInitialized,
+		OnchainVotingDelegate:     status.OnchainVotingDelegate,
+		VotingPower:               status.VotingPower,
+		TotalDelegatedVotingPower: status.TotalDelegatedVp,
+		SumVotingPower:            status.SumVotingPower,
+		IsRplLockingAllowed:       status.IsRPLLockingAllowed,
+		NodeRplLocked:             status.NodeRPLLocked,
+		HasClaimableBonds:         hasClaimableBonds,
+		VerifyEnabled:             status.VerifyEnabled,
+	}
+	if status.SnapshotResponse.Error == "" {
+		result.ActiveSnapshotProposals = len(status.SnapshotResponse.ActiveSnapshotProposals)
+		for _, activeProposal := range status.SnapshotResponse.ActiveSnapshotProposals {
+			for _, votedProposal := range status.SnapshotResponse.ProposalVotes {
+				if votedProposal.Proposal.Id == activeProposal.Id {
+					result.VotedSnapshotProposals++
+					break
+				}
+			}
+		}
+	}
+	return result
+}
+
+// Create the result of `pdao proposals list` for the provided proposals
+func NewPdaoProposals(proposals []api.PDAOProposalWithNodeVoteDirection) PdaoProposals {
+	result := PdaoProposals{
+		Proposals: make([]PdaoProposal, len(proposals)),
+	}
+	for i, proposal := range proposals {
+		result.Proposals[i] = NewPdaoProposal(proposal)
+	}
+	return result
+}
+
+// Create a Protocol DAO proposal, which is also the result of `pdao proposals details`
+func NewPdaoProposal(proposal api.PDAOProposalWithNodeVoteDirection) PdaoProposal {
+	return PdaoProposal{
+		ID:                   proposal.ID,
+		State:                getName(rptypes.ProtocolDaoProposalStates, int(proposal.State)),
+		Proposer:             proposal.ProposerAddress,
+		Message:              proposal.Message,
+		CreatedTime:          proposal.CreatedTime,
+		VotingStartTime:      proposal.VotingStartTime,
+		Phase1EndTime:        proposal.Phase1EndTime,
+		Phase2EndTime:        proposal.Phase2EndTime,
+		ExpiryTime:           proposal.ExpiryTime,
+		VotingPowerRequired:  proposal.VotingPowerRequired,
+		VotingPowerFor:       proposal.VotingPowerFor,
+		VotingPowerAgainst:   proposal.VotingPowerAgainst,
+		VotingPowerAbstained: proposal.VotingPowerAbstained,
+		VotingPowerToVeto:    proposal.VotingPowerToVeto,
+		Payload:              proposal.PayloadStr,
+		NodeVoteDirection:    getName(rptypes.VoteDirections, int(proposal.NodeVoteDirection)),
+	}
+}
+
+// Create the result of `pdao voting-power`
+func NewPdaoVotingPower(response api.PDAOVotingPowerResponse) PdaoVotingPower {
+	result := PdaoVotingPower{
+		AccountAddress:       response.AccountAddress,
+		BlockNumber:          response.BlockNumber,
+		VotingPower:          response.VotingPower,
+		DelegatedVotingPower: response.DelegatedVotingPower,
+		TotalVotingPower:     response.TotalVotingPower,
+		MaxStakeFraction:     response.MaxStakeFraction,
+		Current:              newVotingPowerEstimate(response.Current),
+		History:              make([]VotingPowerHistoryEntry, len(response.History)),
+	}
+	if response.WhatIf != nil {
+		whatIf := newVotingPowerEstimate(*response.WhatIf)
+		result.WhatIf = &whatIf
+	}
+	for i, entry := range response.History {
+		result.History[i] = VotingPowerHistoryEntry{
+			ProposalID:  entry.ProposalID,
+			Message:     entry.Message,
+			CreatedTime: entry.CreatedTime,
+			TargetBlock: entry.TargetBlock,
+			VotingPower: entry.VotingPower,
+		}
+		if entry.IsSnapshotCached {
+			result.History[i].DelegatedVotingPower = entry.DelegatedVotingPower
+			result.History[i].TotalVotingPower = entry.TotalVotingPower
+		}
+	}
+	return result
+}
+
+// Get the inputs and result of a voting power calculation
+func newVotingPowerEstimate(estimate api.PDAOVotingPowerEstimate) VotingPowerEstimate {
+	return VotingPowerEstimate{
+		RplStake:       estimate.RplStake,
+		BondedEth:      estimate.BondedEth,
+		MaxVotingStake: estimate.MaxVotingStake,
+		VotingPower:    estimate.VotingPower,
+	}
+}
+
+// The names of the challenge states of a Protocol DAO tree node, by value
+var challengeStates = []string{"unchallenged", "challenged", "responded", "paid"}
+
+// Get the name of an enum value, or an empty string if it's out of range
+func getName(names []string, value int) string {
+	if value < 0 || value >= len(names) {
+		return ""
+	}
+	return names[value]
+}
+
+// Create the result of `minipool scrub-check`
+func NewMinipoolScrubCheck(response api.MinipoolScrubCheckResponse) MinipoolScrubCheck {
+	result := MinipoolScrubCheck{
+		BlockNumber: response.BlockNumber,
+		Minipools:   make([]MinipoolScrubResult, len(response.Minipools)),
+	}
+	for i, check := range response.Minipools {
+		result.Minipools[i] = MinipoolScrubResult{
+			Address:                       check.Address,
+			ValidatorPubkey:               check.Pubkey,
+			Result:                        string(check.Result),
+			Failed:                        check.IsFailed(),
+			Reason:                        check.Reason,
+			ExpectedWithdrawalCredentials: check.ExpectedWithdrawalCredentials,
+			ActualWithdrawalCredentials:   check.ActualWithdrawalCredentials,
+			InvalidDeposits:               make([]ScrubDeposit, len(check.InvalidDeposits)),
+			PrelaunchTime:                 check.PrelaunchTime,
+			ScrubPeriodEnd:                check.ScrubPeriodEnd,
+			Notes:                         check.Notes,
+		}
+		if check.OffendingDeposit != nil {
+			deposit := ScrubDeposit(*check.OffendingDeposit)
+			result.Minipools[i].OffendingDeposit = &deposit
+		}
+		for j, deposit := range check.InvalidDeposits {
+			result.Minipools[i].InvalidDeposits[j] = ScrubDeposit(deposit)
+		}
+	}
+	return result
+}
+
+// Create the result of `network timezone-map`
+func NewNetworkTimezones(response api.NetworkTimezonesResponse) NetworkTimezones {
+	return NetworkTimezones{
+		NodeTotal:      response.NodeTotal,
+		TimezoneTotal:  response.TimezoneTotal,
+		TimezoneCounts: response.TimezoneCounts,
+	}
+}
+
+// Create the result of `network dao-proposals`; only the votes on active proposals are included
+func NewNetworkDaoProposals(response api.NetworkDAOProposalsResponse, onchainVotingDelegate common.Address) NetworkDaoProposals {
+	result := NetworkDaoProposals{
+		AccountAddress:         response.AccountAddress,
+		SnapshotVotingDelegate: response.VotingDelegate,
+		OnchainVotingDelegate:  onchainVotingDelegate,
+		SnapshotProposals:      make([]SnapshotProposal, len(response.ActiveSnapshotProposals)),
+	}
+	for i, proposal := range response.ActiveSnapshotProposals {
+		result.SnapshotProposals[i] = SnapshotProposal{
+			ID:          proposal.Id,
+			Title:       proposal.Title,
+			State:       proposal.State,
+			Author:      proposal.Author,
+			Start:       time.Unix(proposal.Start, 0),
+			End:         time.Unix(proposal.End, 0),
+			Choices:     proposal.Choices,
+			Scores:      proposal.Scores,
+			ScoresTotal: proposal.ScoresTotal,
+			Quorum:      proposal.Quorum,
+			Link:        proposal.Link,
+			Votes:       []SnapshotVote{},
+		}
+		for _, vote := range response.ProposalVotes {
+			if vote.Proposal.Id == proposal.Id {
+				result.SnapshotProposals[i].Votes = append(result.SnapshotProposals[i].Votes, SnapshotVote{
+					Voter:  vote.Voter,
+					Choice: vote.Choice,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// Create the result of `node proposals`
+func NewNodeProposals(response api.NodeProposalsResponse) NodeProposals {
+	result := NodeProposals{
+		ProposalCount:     response.ProposalCount,
+		TotalPayloadValue: response.TotalPayloadValue,
+		QueryRelayData:    response.QueryRelayData,
+		Intervals:         make([]ProposalInterval, len(response.Intervals)),
+		Proposals:         make([]BlockProposal, len(response.Proposals)),
+	}
+	for i, interval := range response.Intervals {
+		result.Intervals[i] = ProposalInterval{
+			Index:                  interval.Index,
+			Start:                  interval.Start,
+			ProposalCount:          interval.ProposalCount,
+			RelayProposalCount:     interval.RelayProposalCount,
+			TotalPayloadValue:      interval.TotalPayloadValue,
+			HasSmoothingPoolStats:  interval.HasSmoothingPoolStats,
+			SmoothingPoolBalance:   interval.SmoothingPoolBalance,
+			SmoothingPoolNodeCount: interval.SmoothingPoolNodeCount,
+			SmoothingPoolNodeAvg:   interval.SmoothingPoolNodeAvg,
+		}
+	}
+	for i, proposal := range response.Proposals {
+		result.Proposals[i] = BlockProposal{
+			Slot:                 proposal.Slot,
+			Time:                 proposal.Time,
+			BlockNumber:          proposal.BlockNumber,
+			ValidatorIndex:       proposal.ValidatorIndex,
+			ValidatorPubkey:      proposal.ValidatorPubkey,
+			MinipoolAddress:      proposal.MinipoolAddress,
+			FeeRecipient:         proposal.FeeRecipient,
+			ExpectedFeeRecipient: proposal.ExpectedFeeRecipient,
+			IsCorrect:            proposal.IsCorrect,
+			IsInSmoothingPool:    proposal.IsInSmoothingPool,
+			IsMevBlock:           proposal.IsMevBlock,
+			MevRecipient:         proposal.MevRecipient,
+			MevReward:            proposal.MevReward,
+			RewardsInterval:      proposal.RewardsInterval,
+			PayloadValue:         proposal.PayloadValue,
+			IsFromRelay:          proposal.IsFromRelay,
+			RelayName:            proposal.RelayName,
+		}
+	}
+	return result
+}
+
+// Create the result of `node deferred-txs`
+func NewNodeDeferredTxs(response api.NodeDeferredTxsResponse) NodeDeferredTxs {
+	result := NodeDeferredTxs{
+		UpdatedTime:  response.UpdatedTime,
+		Transactions: make([]DeferredTx, len(response.Actions)),
+	}
+	for i, action := range response.Actions {
+		result.Transactions[i] = DeferredTx{
+			Type:                string(action.Type),
+			Description:         action.Description,
+			FirstSeen:           action.FirstSeen,
+			Deadline:            action.Deadline,
+			TargetMaxFeeGwei:    action.TargetMaxFeeGwei,
+			MaxCostEth:          action.MaxCostEth,
+			GasLimit:            action.GasLimit,
+			LastChecked:         action.LastChecked,
+			LastMaxFeeGwei:      action.LastMaxFeeGwei,
+			LastCostEth:         action.LastCostEth,
+			EstimatedSubmission: action.EstimatedSubmission,
+			Reason:              action.Reason,
+		}
+	}
+	return result
+}
+
+// Create the result of `node export-ledger`
+func NewNodeLedger(response api.NodeExportLedgerResponse) NodeLedger {
+	result := NodeLedger{
+		NodeAddress: response.NodeAddress,
+		FromBlock:   response.FromBlock,
+		ToBlock:     response.ToBlock,
+		FromTime:    response.FromTime,
+		ToTime:      response.ToTime,
+		Entries:     make([]LedgerEntry, len(response.Entries)),
+		Warnings:    response.Warnings,
+	}
+	for i, entry := range response.Entries {
+		result.Entries[i] = LedgerEntry{
+			Type:     string(entry.Type),
+			Block:    entry.Block,
+			Time:     entry.Time,
+			TxHash:   entry.TxHash,
+			Contract: entry.Contract,
+			Interval: entry.Interval,
+			Asset:    entry.Asset,
+			Amount:   entry.Amount,
+			RplPrice: entry.RplPrice,
+		}
+	}
+	if result.Warnings == nil {
+		result.Warnings = []string{}
+	}
+	return result
+}
+
+// Create the result of `odao participation`
+func NewOdaoParticipation(response api.TNDAOParticipationResponse) OdaoParticipation {
+	result := OdaoParticipation{
+		LastScannedBlock: response.LastScannedBlock,
+		RecentWindow:     response.RecentWindow,
+		Members:          make([]OdaoMemberParticipation, len(response.Members)),
+		RecentRounds:     make([]OdaoDutyRound, len(response.RecentRounds)),
+	}
+	for i, member := range response.Members {
+		result.Members[i] = OdaoMemberParticipation{
+			Address:     member.Address,
+			ID:          member.ID,
+			IsLocalNode: member.IsLocalNode,
+			Duties:      make([]OdaoDutyParticipation, len(member.Duties)),
+		}
+		for j, duty := range member.Duties {
+			result.Members[i].Duties[j] = OdaoDutyParticipation{
+				Duty:              string(duty.Duty),
+				Expected:          duty.Expected,
+				Submitted:         duty.Submitted,
+				MissedRecent:      duty.MissedRecent,
+				LastSubmittedTime: duty.LastSubmittedTime,
+			}
+		}
+	}
+	for i, round := range response.RecentRounds {
+		result.RecentRounds[i] = OdaoDutyRound{
+			Duty:              string(round.Duty),
+			Target:            round.Target,
+			Round:             round.Round,
+			Time:              round.Time,
+			ConsensusReached:  round.ConsensusReached,
+			Members:           round.Members,
+			Submitters:        round.Submitters,
+			ResponsibleMember: round.ResponsibleMember,
+		}
+	}
+	return result
+}
+
+// Create the result of `odao watchtower-status`
+func NewOdaoWatchtowerStatus(response api.TNDAOWatchtowerStatusResponse) OdaoWatchtowerStatus {
+	result := OdaoWatchtowerStatus{
+		UpdatedTime: response.UpdatedTime,
+		Tasks:       make([]WatchtowerTask, len(response.Tasks)),
+	}
+	for i, task := range response.Tasks {
+		result.Tasks[i] = WatchtowerTask(task)
+	}
+	return result
+}
+
+// Create the result of `odao member-settings`
+func NewOdaoMemberSettings(response api.GetTNDAOMemberSettingsResponse) OdaoMemberSettings {
+	return OdaoMemberSettings{
+		Quorum:              response.Quorum,
+		RplBond:             response.RPLBond,
+		MinipoolUnbondedMax: response.MinipoolUnbondedMax,
+		ChallengeCooldown:   response.ChallengeCooldown,
+		ChallengeWindow:     response.ChallengeWindow,
+		ChallengeCost:       response.ChallengeCost,
+	}
+}
+
+// Create the result of `odao proposal-settings`
+func NewOdaoProposalSettings(response api.GetTNDAOProposalSettingsResponse) OdaoProposalSettings {
+	return OdaoProposalSettings{
+		Cooldown:      time.Duration(response.Cooldown) * time.Second,
+		VoteTime:      time.Duration(response.VoteTime) * time.Second,
+		VoteDelayTime: time.Duration(response.VoteDelayTime) * time.Second,
+		ExecuteTime:   time.Duration(response.ExecuteTime) * time.Second,
+		ActionTime:    time.Duration(response.ActionTime) * time.Second,
+	}
+}
+
+// Create the result of `odao minipool-settings`
+func NewOdaoMinipoolSettings(response api.GetTNDAOMinipoolSettingsResponse) OdaoMinipoolSettings {
+	return OdaoMinipoolSettings{
+		ScrubPeriod:               time.Duration(response.ScrubPeriod) * time.Second,
+		PromotionScrubPeriod:      time.Duration(response.PromotionScrubPeriod) * time.Second,
+		ScrubPenaltyEnabled:       response.ScrubPenaltyEnabled,
+		BondReductionWindowStart:  time.Duration(response.BondReductionWindowStart) * time.Second,
+		BondReductionWindowLength: time.Duration(response.BondReductionWindowLength) * time.Second,
+	}
+}
+
+// Create an Oracle DAO or security council proposal; the proposer ID is empty if the proposer is no longer a member
+func NewDaoProposal(proposal dao.ProposalDetails, proposerID string) DaoProposal {
+	return DaoProposal{
+		ID:              proposal.ID,
+		DAO:             proposal.DAO,
+		State:           proposal.State.String(),
+		Proposer:        proposal.ProposerAddress,
+		ProposerID:      proposerID,
+		Message:         proposal.Message,
+		Payload:         proposal.PayloadStr,
+		PayloadData:     hex.EncodeToString(proposal.Payload),
+		CreatedTime:     time.Unix(int64(proposal.CreatedTime), 0),
+		StartTime:       time.Unix(int64(proposal.StartTime), 0),
+		EndTime:         time.Unix(int64(proposal.EndTime), 0),
+		ExpiryTime:      time.Unix(int64(proposal.ExpiryTime), 0),
+		VotesRequired:   proposal.VotesRequired,
+		VotesFor:        proposal.VotesFor,
+		VotesAgainst:    proposal.VotesAgainst,
+		MemberVoted:     proposal.MemberVoted,
+		MemberSupported: proposal.MemberSupported,
+		IsCancelled:     proposal.IsCancelled,
+		IsExecuted:      proposal.IsExecuted,
+	}
+}
+
+// Create the result of `pdao settings`
+func NewPdaoSettings(response api.GetPDAOSettingsResponse) PdaoSettings {
+	return PdaoSettings{
+		Auction:   PdaoAuctionSettings(response.Auction),
+		Deposit:   PdaoDepositSettings(response.Deposit),
+		Inflation: PdaoInflationSettings(response.Inflation),
+		Minipool:  PdaoMinipoolSettings(response.Minipool),
+		Network:   PdaoNetworkSettings(response.Network),
+		Node:      PdaoNodeSettings(response.Node),
+		Proposals: PdaoProposalsSettings(response.Proposals),
+		Rewards:   PdaoRewardsSettings(response.Rewards),
+		Security:  PdaoSecuritySettings(response.Security),
+	}
+}
+
+// Create the result of `pdao rewards-percentages`
+func NewPdaoRewardsPercentages(response api.PDAOGetRewardsPercentagesResponse) PdaoRewardsPercentages {
+	return PdaoRewardsPercentages{
+		Node:        response.Node,
+		OracleDao:   response.OracleDao,
+		ProtocolDao: response.ProtocolDao,
+	}
+}
+
+// Create the result of `pdao delegates`
+func NewPdaoDelegates(response api.PDAODelegatesResponse) PdaoDelegates {
+	result := PdaoDelegates{
+		AccountAddress:        response.AccountAddress,
+		BlockNumber:           response.BlockNumber,
+		OnchainVotingDelegate: response.OnchainVotingDelegate,
+		NodeVotingPower:       response.NodeVotingPower,
+		DelegatedVotingPower:  response.DelegatedVotingPower,
+		TotalVotingPower:      response.TotalVotingPower,
+		Delegates:             make([]PdaoDelegate, len(response.Delegates)),
+		Delegators:            make([]PdaoDelegator, len(response.Delegators)),
+		DelegateVotes:         make([]PdaoDelegateVote, len(response.DelegateVotes)),
+	}
+	for i, delegate := range response.Delegates {
+		result.Delegates[i] = PdaoDelegate(delegate)
+	}
+	for i, delegator := range response.Delegators {
+		result.Delegators[i] = PdaoDelegator(delegator)
+	}
+	for i, vote := range response.DelegateVotes {
+		result.DelegateVotes[i] = PdaoDelegateVote{
+			ProposalID:            vote.ProposalID,
+			Message:               vote.Message,
+			State:                 getName(rptypes.ProtocolDaoProposalStates, int(vote.State)),
+			Phase1EndTime:         vote.Phase1EndTime,
+			DelegateVoteDirection: getName(rptypes.VoteDirections, int(vote.DelegateVoteDirection)),
+			NodeVoteDirection:     getName(rptypes.VoteDirections, int(vote.NodeVoteDirection)),
+			IsAwaitingDelegate:    vote.IsAwaitingDelegate,
+		}
+	}
+	return result
+}
+
+// Create the result of `pdao audit-proposal`
+func NewPdaoProposalAudit(response api.PDAOProposalAuditResponse) PdaoProposalAudit {
+	result := PdaoProposalAudit{
+		ProposalID:      response.ProposalID,
+		State:           getName(rptypes.ProtocolDaoProposalStates, int(response.State)),
+		Proposer:        response.Proposer,
+		TargetBlock:     response.TargetBlock,
+		VotingStartTime: response.VotingStartTime,
+		NodeCount:       response.NodeCount,
+		LocalRoot:       response.LocalRoot,
+		ProposedRoot:    response.ProposedRoot,
+		RootMatches:     response.LocalRoot.Hash == response.ProposedRoot.Hash && response.LocalRoot.Sum.Cmp(response.ProposedRoot.Sum) == 0,
+		RootSubmissions: make([]PdaoRootSubmission, len(response.RootSubmissions)),
+		Challenges:      make([]PdaoChallenge, len(response.Challenges)),
+		IsDefeatable:    response.IsDefeatable,
+		DefeatIndex:     response.DefeatIndex,
+	}
+	for i, submission := range response.RootSubmissions {
+		result.RootSubmissions[i] = PdaoRootSubmission{
+			Index:          submission.Index,
+			Submitter:      submission.Submitter,
+			Timestamp:      submission.Timestamp,
+			ChallengeState: getName(challengeStates, int(submission.ChallengeState)),
+			Mismatches:     make([]VotingTreeMismatch, len(submission.Mismatches)),
+		}
+		for j, mismatch := range submission.Mismatches {
+			result.RootSubmissions[i].Mismatches[j] = VotingTreeMismatch(mismatch)
+		}
+	}
+	for i, challenge := range response.Challenges {
+		result.Challenges[i] = PdaoChallenge{
+			Index:         challenge.Index,
+			Challenger:    challenge.Challenger,
+			Timestamp:     challenge.Timestamp,
+			State:         getName(challengeStates, int(challenge.State)),
+			CanBeDefeated: challenge.CanBeDefeated,
+		}
+	}
+	return result
+}
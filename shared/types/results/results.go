@@ -0,0 +1,1209 @@
+package results
+
+import (
+	"math/big"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The result type of each CLI command that supports machine-readable output; the published schemas are generated from these types.
+// Every command must either be here or in unsupportedCommands.
+var commandResults = map[string]reflect.Type{
+	// Reads
+	"auction lots":               reflect.TypeOf(AuctionLots{}),
+	"auction status":             reflect.TypeOf(AuctionStatus{}),
+	"fleet context list":         reflect.TypeOf(FleetContexts{}),
+	"fleet rewards":              reflect.TypeOf(FleetRewards{}),
+	"fleet status":               reflect.TypeOf(FleetStatus{}),
+	"minipool overrides":         reflect.TypeOf(MinipoolOverrides{}),
+	"minipool scrub-check":       reflect.TypeOf(MinipoolScrubCheck{}),
+	"minipool status":            reflect.TypeOf(MinipoolStatus{}),
+	"network dao-proposals":      reflect.TypeOf(NetworkDaoProposals{}),
+	"network node-fee":           reflect.TypeOf(NetworkNodeFee{}),
+	"network rpl-price":          reflect.TypeOf(NetworkRplPrice{}),
+	"network stats":              reflect.TypeOf(NetworkStats{}),
+	"network timezone-map":       reflect.TypeOf(NetworkTimezones{}),
+	"node deferred-txs":          reflect.TypeOf(NodeDeferredTxs{}),
+	"node export-ledger":         reflect.TypeOf(NodeLedger{}),
+	"node proposals":             reflect.TypeOf(NodeProposals{}),
+	"node rewards":               reflect.TypeOf(NodeRewards{}),
+	"node sign-message":          reflect.TypeOf(NodeSignedMessage{}),
+	"node status":                reflect.TypeOf(NodeStatus{}),
+	"node sync":                  reflect.TypeOf(NodeSync{}),
+	"odao member-settings":       reflect.TypeOf(OdaoMemberSettings{}),
+	"odao members":               reflect.TypeOf(OdaoMembers{}),
+	"odao minipool-settings":     reflect.TypeOf(OdaoMinipoolSettings{}),
+	"odao participation":         reflect.TypeOf(OdaoParticipation{}),
+	"odao proposal-settings":     reflect.TypeOf(OdaoProposalSettings{}),
+	"odao proposals details":     reflect.TypeOf(DaoProposal{}),
+	"odao proposals list":        reflect.TypeOf(DaoProposals{}),
+	"odao status":                reflect.TypeOf(OdaoStatus{}),
+	"odao watchtower-status":     reflect.TypeOf(OdaoWatchtowerStatus{}),
+	"pdao audit-proposal":        reflect.TypeOf(PdaoProposalAudit{}),
+	"pdao delegates":             reflect.TypeOf(PdaoDelegates{}),
+	"pdao proposals details":     reflect.TypeOf(PdaoProposal{}),
+	"pdao proposals list":        reflect.TypeOf(PdaoProposals{}),
+	"pdao rewards-percentages":   reflect.TypeOf(PdaoRewardsPercentages{}),
+	"pdao settings":              reflect.TypeOf(PdaoSettings{}),
+	"pdao status":                reflect.TypeOf(PdaoStatus{}),
+	"pdao voting-power":          reflect.TypeOf(PdaoVotingPower{}),
+	"queue status":               reflect.TypeOf(QueueStatus{}),
+	"security members":           reflect.TypeOf(SecurityMembers{}),
+	"security proposals details": reflect.TypeOf(DaoProposal{}),
+	"security proposals list":    reflect.TypeOf(DaoProposals{}),
+	"security status":            reflect.TypeOf(SecurityStatus{}),
+	"service check-cpu-features": reflect.TypeOf(ServiceCpuFeatures{}),
+	"service check-relays":       reflect.TypeOf(ServiceRelays{}),
+	"service version":            reflect.TypeOf(ServiceVersion{}),
+	"wallet status":              reflect.TypeOf(WalletStatus{}),
+
+	// Writes
+	"auction bid-lot":                                              reflect.TypeOf(Transactions{}),
+	"auction claim-lot":                                            reflect.TypeOf(Transactions{}),
+	"auction create-lot":                                           reflect.TypeOf(Transactions{}),
+	"auction recover-lot":                                          reflect.TypeOf(Transactions{}),
+	"minipool begin-bond-reduction":                                reflect.TypeOf(Transactions{}),
+	"minipool close":                                               reflect.TypeOf(Transactions{}),
+	"minipool delegate-rollback":                                   reflect.TypeOf(Transactions{}),
+	"minipool delegate-upgrade":                                    reflect.TypeOf(Transactions{}),
+	"minipool distribute-balance":                                  reflect.TypeOf(Transactions{}),
+	"minipool promote":                                             reflect.TypeOf(Transactions{}),
+	"minipool reduce-bond":                                         reflect.TypeOf(Transactions{}),
+	"minipool refund":                                              reflect.TypeOf(Transactions{}),
+	"minipool rescue-dissolved":                                    reflect.TypeOf(Transactions{}),
+	"minipool set-use-latest-delegate":                             reflect.TypeOf(Transactions{}),
+	"minipool stake":                                               reflect.TypeOf(Transactions{}),
+	"node add-address-to-stake-rpl-whitelist":                      reflect.TypeOf(Transactions{}),
+	"node allow-rpl-locking":                                       reflect.TypeOf(Transactions{}),
+	"node claim-rewards":                                           reflect.TypeOf(Transactions{}),
+	"node clear-voting-delegate":                                   reflect.TypeOf(Transactions{}),
+	"node confirm-primary-withdrawal-address":                      reflect.TypeOf(Transactions{}),
+	"node confirm-rpl-withdrawal-address":                          reflect.TypeOf(Transactions{}),
+	"node create-vacant-minipool":                                  reflect.TypeOf(Transactions{}),
+	"node deny-rpl-locking":                                        reflect.TypeOf(Transactions{}),
+	"node deposit":                                                 reflect.TypeOf(Transactions{}),
+	"node distribute-fees":                                         reflect.TypeOf(Transactions{}),
+	"node initialize-fee-distributor":                              reflect.TypeOf(Transactions{}),
+	"node join-smoothing-pool":                                     reflect.TypeOf(Transactions{}),
+	"node leave-smoothing-pool":                                    reflect.TypeOf(Transactions{}),
+	"node register":                                                reflect.TypeOf(Transactions{}),
+	"node remove-address-from-stake-rpl-whitelist":                 reflect.TypeOf(Transactions{}),
+	"node send":                                                    reflect.TypeOf(Transactions{}),
+	"node send-message":                                            reflect.TypeOf(Transactions{}),
+	"node set-primary-withdrawal-address":                          reflect.TypeOf(Transactions{}),
+	"node set-rpl-withdrawal-address":                              reflect.TypeOf(Transactions{}),
+	"node set-timezone":                                            reflect.TypeOf(Transactions{}),
+	"node set-voting-delegate":                                     reflect.TypeOf(Transactions{}),
+	"node stake-rpl":                                               reflect.TypeOf(Transactions{}),
+	"node swap-rpl":                                                reflect.TypeOf(Transactions{}),
+	"node withdraw-eth":                                            reflect.TypeOf(Transactions{}),
+	"node withdraw-rpl":                                            reflect.TypeOf(Transactions{}),
+	"odao join":                                                    reflect.TypeOf(Transactions{}),
+	"odao leave":                                                   reflect.TypeOf(Transactions{}),
+	"odao proposals cancel":                                        reflect.TypeOf(Transactions{}),
+	"odao proposals execute":                                       reflect.TypeOf(Transactions{}),
+	"odao proposals vote":                                          reflect.TypeOf(Transactions{}),
+	"odao propose member invite":                                   reflect.TypeOf(Transactions{}),
+	"odao propose member kick":                                     reflect.TypeOf(Transactions{}),
+	"odao propose member leave":                                    reflect.TypeOf(Transactions{}),
+	"odao propose setting bond-reduction-window-length":            reflect.TypeOf(Transactions{}),
+	"odao propose setting bond-reduction-window-start":             reflect.TypeOf(Transactions{}),
+	"odao propose setting members-minipool-unbonded-max":           reflect.TypeOf(Transactions{}),
+	"odao propose setting members-quorum":                          reflect.TypeOf(Transactions{}),
+	"odao propose setting members-rplbond":                         reflect.TypeOf(Transactions{}),
+	"odao propose setting promotion-scrub-period":                  reflect.TypeOf(Transactions{}),
+	"odao propose setting proposal-action-timespan":                reflect.TypeOf(Transactions{}),
+	"odao propose setting proposal-cooldown":                       reflect.TypeOf(Transactions{}),
+	"odao propose setting proposal-execute-timespan":               reflect.TypeOf(Transactions{}),
+	"odao propose setting proposal-vote-delay-timespan":            reflect.TypeOf(Transactions{}),
+	"odao propose setting proposal-vote-timespan":                  reflect.TypeOf(Transactions{}),
+	"odao propose setting scrub-penalty-enabled":                   reflect.TypeOf(Transactions{}),
+	"odao propose setting scrub-period":                            reflect.TypeOf(Transactions{}),
+	"pdao claim-bonds":                                             reflect.TypeOf(Transactions{}),
+	"pdao initialize-voting":                                       reflect.TypeOf(Transactions{}),
+	"pdao proposals defeat":                                        reflect.TypeOf(Transactions{}),
+	"pdao proposals execute":                                       reflect.TypeOf(Transactions{}),
+	"pdao proposals finalize":                                      reflect.TypeOf(Transactions{}),
+	"pdao proposals vote":                                          reflect.TypeOf(Transactions{}),
+	"pdao propose one-time-spend":                                  reflect.TypeOf(Transactions{}),
+	"pdao propose recurring-spend":                                 reflect.TypeOf(Transactions{}),
+	"pdao propose recurring-spend-update":                          reflect.TypeOf(Transactions{}),
+	"pdao propose rewards-percentages":                             reflect.TypeOf(Transactions{}),
+	"pdao propose security-council invite":                         reflect.TypeOf(Transactions{}),
+	"pdao propose security-council kick":                           reflect.TypeOf(Transactions{}),
+	"pdao propose security-council replace":                        reflect.TypeOf(Transactions{}),
+	"pdao propose setting auction is-bid-on-lot-enabled":           reflect.TypeOf(Transactions{}),
+	"pdao propose setting auction is-create-lot-enabled":           reflect.TypeOf(Transactions{}),
+	"pdao propose setting auction lot-duration":                    reflect.TypeOf(Transactions{}),
+	"pdao propose setting auction lot-maximum-eth-value":           reflect.TypeOf(Transactions{}),
+	"pdao propose setting auction lot-minimum-eth-value":           reflect.TypeOf(Transactions{}),
+	"pdao propose setting auction lot-reserve-price-ratio":         reflect.TypeOf(Transactions{}),
+	"pdao propose setting auction lot-starting-price-ratio":        reflect.TypeOf(Transactions{}),
+	"pdao propose setting deposit are-deposit-assignments-enabled": reflect.TypeOf(Transactions{}),
+	"pdao propose setting deposit deposit-fee":                     reflect.TypeOf(Transactions{}),
+	"pdao propose setting deposit is-depositing-enabled":           reflect.TypeOf(Transactions{}),
+	"pdao propose setting deposit maximum-assignments-per-deposit": reflect.TypeOf(Transactions{}),
+	"pdao propose setting deposit maximum-deposit-pool-size":       reflect.TypeOf(Transactions{}),
+	"pdao propose setting deposit maximum-socialised-assignments-per-deposit": reflect.TypeOf(Transactions{}),
+	"pdao propose setting deposit minimum-deposit":                            reflect.TypeOf(Transactions{}),
+	"pdao propose setting minipool is-bond-reduction-enabled":                 reflect.TypeOf(Transactions{}),
+	"pdao propose setting minipool is-submit-withdrawable-enabled":            reflect.TypeOf(Transactions{}),
+	"pdao propose setting minipool launch-timeout":                            reflect.TypeOf(Transactions{}),
+	"pdao propose setting minipool max-count":                                 reflect.TypeOf(Transactions{}),
+	"pdao propose setting minipool user-distribute-window-length":             reflect.TypeOf(Transactions{}),
+	"pdao propose setting minipool user-distribute-window-start":              reflect.TypeOf(Transactions{}),
+	"pdao propose setting network is-submit-balances-enabled":                 reflect.TypeOf(Transactions{}),
+	"pdao propose setting network is-submit-prices-enabled":                   reflect.TypeOf(Transactions{}),
+	"pdao propose setting network is-submit-rewards-enabled":                  reflect.TypeOf(Transactions{}),
+	"pdao propose setting network maximum-node-fee":                           reflect.TypeOf(Transactions{}),
+	"pdao propose setting network minimum-node-fee":                           reflect.TypeOf(Transactions{}),
+	"pdao propose setting network node-fee-demand-range":                      reflect.TypeOf(Transactions{}),
+	"pdao propose setting network node-penalty-threshold":                     reflect.TypeOf(Transactions{}),
+	"pdao propose setting network oracle-dao-consensus-threshold":             reflect.TypeOf(Transactions{}),
+	"pdao propose setting network per-penalty-rate":                           reflect.TypeOf(Transactions{}),
+	"pdao propose setting network submit-balances-frequency":                  reflect.TypeOf(Transactions{}),
+	"pdao propose setting network submit-prices-frequency":                    reflect.TypeOf(Transactions{}),
+	"pdao propose setting network target-node-fee":                            reflect.TypeOf(Transactions{}),
+	"pdao propose setting network target-reth-collateral-rate":                reflect.TypeOf(Transactions{}),
+	"pdao propose setting node are-vacant-minipools-enabled":                  reflect.TypeOf(Transactions{}),
+	"pdao propose setting node is-depositing-enabled":                         reflect.TypeOf(Transactions{}),
+	"pdao propose setting node is-registration-enabled":                       reflect.TypeOf(Transactions{}),
+	"pdao propose setting node is-smoothing-pool-registration-enabled":        reflect.TypeOf(Transactions{}),
+	"pdao propose setting node maximum-per-minipool-stake":                    reflect.TypeOf(Transactions{}),
+	"pdao propose setting node minimum-per-minipool-stake":                    reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals challenge-bond":                           reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals challenge-period":                         reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals execute-time":                             reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals max-block-age":                            reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals proposal-bond":                            reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals quorum":                                   reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals veto-quorum":                              reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals vote-delay-time":                          reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals vote-phase1-time":                         reflect.TypeOf(Transactions{}),
+	"pdao propose setting proposals vote-phase2-time":                         reflect.TypeOf(Transactions{}),
+	"pdao propose setting rewards interval-periods":                           reflect.TypeOf(Transactions{}),
+	"pdao propose setting security members-leave-time":                        reflect.TypeOf(Transactions{}),
+	"pdao propose setting security members-quorum":                            reflect.TypeOf(Transactions{}),
+	"pdao propose setting security proposal-action-time":                      reflect.TypeOf(Transactions{}),
+	"pdao propose setting security proposal-execute-time":                     reflect.TypeOf(Transactions{}),
+	"pdao propose setting security proposal-vote-time":                        reflect.TypeOf(Transactions{}),
+	"pdao set-voting-delegate":                                                reflect.TypeOf(Transactions{}),
+	"queue process":                                                           reflect.TypeOf(Transactions{}),
+	"security join":                                                           reflect.TypeOf(Transactions{}),
+	"security leave":                                                          reflect.TypeOf(Transactions{}),
+	"security proposals cancel":                                               reflect.TypeOf(Transactions{}),
+	"security proposals execute":                                              reflect.TypeOf(Transactions{}),
+	"security proposals vote":                                                 reflect.TypeOf(Transactions{}),
+	"security propose member leave":                                           reflect.TypeOf(Transactions{}),
+	"security propose setting auction is-bid-on-lot-enabled":                  reflect.TypeOf(Transactions{}),
+	"security propose setting auction is-create-lot-enabled":                  reflect.TypeOf(Transactions{}),
+	"security propose setting deposit are-deposit-assignments-enabled":        reflect.TypeOf(Transactions{}),
+	"security propose setting deposit is-depositing-enabled":                  reflect.TypeOf(Transactions{}),
+	"security propose setting minipool is-bond-reduction-enabled":             reflect.TypeOf(Transactions{}),
+	"security propose setting minipool is-submit-withdrawable-enabled":        reflect.TypeOf(Transactions{}),
+	"security propose setting network is-submit-balances-enabled":             reflect.TypeOf(Transactions{}),
+	"security propose setting network is-submit-prices-enabled":               reflect.TypeOf(Transactions{}),
+	"security propose setting network is-submit-rewards-enabled":              reflect.TypeOf(Transactions{}),
+	"security propose setting node are-vacant-minipools-enabled":              reflect.TypeOf(Transactions{}),
+	"security propose setting node is-depositing-enabled":                     reflect.TypeOf(Transactions{}),
+	"security propose setting node is-registration-enabled":                   reflect.TypeOf(Transactions{}),
+	"security propose setting node is-smoothing-pool-registration-enabled":    reflect.TypeOf(Transactions{}),
+	"wallet set-ens-name":                                                     reflect.TypeOf(Transactions{}),
+}
+
+// The CLI commands that deliberately don't support machine-readable output, and why.
+// These either prompt for secrets, only change local files, or have no result beyond what they print.
+var unsupportedCommands = map[string]string{
+	"fleet context add":              "it only edits the local contexts file",
+	"fleet context remove":           "it only edits the local contexts file",
+	"fleet update":                   "it runs interactive updates on each context's machine",
+	"minipool clear-overrides":       "it only edits the local config",
+	"minipool exit":                  "it signs Beacon Chain exits, which don't have transaction hashes",
+	"minipool find-vanity-address":   "it's a long-running local search",
+	"minipool import-key":            "it prompts for the validator's mnemonic",
+	"minipool set-fee-recipient":     "it only edits the local config",
+	"minipool set-graffiti":          "it only edits the local config",
+	"minipool set-withdrawal-creds":  "it prompts for the validator's mnemonic",
+	"network generate-rewards-tree":  "it only asks the watchtower to generate the tree in the background",
+	"service compose":                "it manages the Smart Node's services and passes their output through",
+	"service config":                 "it manages the Smart Node's services and passes their output through",
+	"service export-eth1-data":       "it manages the Smart Node's services and passes their output through",
+	"service get-config-yaml":        "it manages the Smart Node's services and passes their output through",
+	"service import-eth1-data":       "it manages the Smart Node's services and passes their output through",
+	"service install":                "it manages the Smart Node's services and passes their output through",
+	"service install-update-tracker": "it manages the Smart Node's services and passes their output through",
+	"service logs":                   "it manages the Smart Node's services and passes their output through",
+	"service migrate-in":             "it manages the Smart Node's services and passes their output through",
+	"service migrate-out":            "it manages the Smart Node's services and passes their output through",
+	"service pause":                  "it manages the Smart Node's services and passes their output through",
+	"service prune-docker":           "it manages the Smart Node's services and passes their output through",
+	"service prune-eth1":             "it manages the Smart Node's services and passes their output through",
+	"service reset-docker":           "it manages the Smart Node's services and passes their output through",
+	"service resync-eth1":            "it manages the Smart Node's services and passes their output through",
+	"service resync-eth2":            "it manages the Smart Node's services and passes their output through",
+	"service start":                  "it manages the Smart Node's services and passes their output through",
+	"service stats":                  "it manages the Smart Node's services and passes their output through",
+	"service status":                 "it manages the Smart Node's services and passes their output through",
+	"service stop":                   "it manages the Smart Node's services and passes their output through",
+	"service terminate":              "it manages the Smart Node's services and passes their output through",
+	"top":                            "it's a full-screen dashboard",
+	"wallet export":                  "it prints the wallet's secrets",
+	"wallet init":                    "it prompts for the wallet's password and prints its mnemonic",
+	"wallet purge":                   "it deletes the wallet and validator keys after a prompt",
+	"wallet rebuild":                 "it only regenerates the validator keys on disk",
+	"wallet recover":                 "it prompts for the wallet's mnemonic",
+	"wallet test-recovery":           "it prompts for the wallet's mnemonic",
+}
+
+// Get the names of the CLI commands that support machine-readable output, in order
+func GetCommandNames() []string {
+	names := make([]string, 0, len(commandResults))
+	for name := range commandResults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get the result type of a CLI command
+func GetResultType(command string) (reflect.Type, bool) {
+	resultType, exists := commandResults[command]
+	return resultType, exists
+}
+
+// Get the reason a CLI command doesn't support machine-readable output, if it's one of the commands that deliberately don't
+func GetUnsupportedReason(command string) (string, bool) {
+	reason, exists := unsupportedCommands[command]
+	return reason, exists
+}
+
+// The result of a command that sends transactions; the hashes are in the order they were submitted
+type Transactions struct {
+	TxHashes []common.Hash `json:"txHashes"`
+}
+
+// The result of `wallet status`
+type WalletStatus struct {
+	PasswordSet       bool           `json:"passwordSet"`
+	WalletInitialized bool           `json:"walletInitialized"`
+	AccountAddress    common.Address `json:"accountAddress"`
+}
+
+// The result of `node status`; amounts are in wei
+type NodeStatus struct {
+	AccountAddress           common.Address     `json:"accountAddress"`
+	Registered               bool               `json:"registered"`
+	Trusted                  bool               `json:"trusted"`
+	TimezoneLocation         string             `json:"timezoneLocation"`
+	PrimaryWithdrawalAddress common.Address     `json:"primaryWithdrawalAddress"`
+	RplWithdrawalAddress     common.Address     `json:"rplWithdrawalAddress"`
+	EthBalance               *big.Int           `json:"ethBalance"`
+	RplBalance               *big.Int           `json:"rplBalance"`
+	CreditBalance            *big.Int           `json:"creditBalance"`
+	RplStake                 *big.Int           `json:"rplStake"`
+	EffectiveRplStake        *big.Int           `json:"effectiveRplStake"`
+	MinimumRplStake          *big.Int           `json:"minimumRplStake"`
+	MaximumRplStake          *big.Int           `json:"maximumRplStake"`
+	BorrowedCollateralRatio  float64            `json:"borrowedCollateralRatio"`
+	BondedCollateralRatio    float64            `json:"bondedCollateralRatio"`
+	EthMatched               *big.Int           `json:"ethMatched"`
+	EthMatchedLimit          *big.Int           `json:"ethMatchedLimit"`
+	IsInSmoothingPool        bool               `json:"isInSmoothingPool"`
+	FeeDistributorAddress    common.Address     `json:"feeDistributorAddress"`
+	FeeDistributorBalance    *big.Int           `json:"feeDistributorBalance"`
+	MinipoolCounts           NodeMinipoolCounts `json:"minipoolCounts"`
+}
+
+// The number of a node's minipools in each state
+type NodeMinipoolCounts struct {
+	Total        int `json:"total"`
+	Initialized  int `json:"initialized"`
+	Prelaunch    int `json:"prelaunch"`
+	Staking      int `json:"staking"`
+	Withdrawable int `json:"withdrawable"`
+	Dissolved    int `json:"dissolved"`
+	Finalised    int `json:"finalised"`
+}
+
+// The result of `node rewards`; amounts are in ETH and RPL
+type NodeRewards struct {
+	Registered                  bool          `json:"registered"`
+	Trusted                     bool          `json:"trusted"`
+	RewardsInterval             time.Duration `json:"rewardsInterval"`
+	LastCheckpoint              time.Time     `json:"lastCheckpoint"`
+	EffectiveRplStake           float64       `json:"effectiveRplStake"`
+	TotalRplStake               float64       `json:"totalRplStake"`
+	EstimatedRplRewards         float64       `json:"estimatedRplRewards"`
+	EstimatedTrustedRplRewards  float64       `json:"estimatedTrustedRplRewards"`
+	CumulativeRplRewards        float64       `json:"cumulativeRplRewards"`
+	CumulativeTrustedRplRewards float64       `json:"cumulativeTrustedRplRewards"`
+	CumulativeEthRewards        float64       `json:"cumulativeEthRewards"`
+	UnclaimedRplRewards         float64       `json:"unclaimedRplRewards"`
+	UnclaimedTrustedRplRewards  float64       `json:"unclaimedTrustedRplRewards"`
+	UnclaimedEthRewards         float64       `json:"unclaimedEthRewards"`
+	BeaconRewards               float64       `json:"beaconRewards"`
+}
+
+// The result of `node sync`
+type NodeSync struct {
+	ExecutionClients ClientSync `json:"executionClients"`
+	BeaconClients    ClientSync `json:"beaconClients"`
+}
+
+// The sync status of a primary client and its fallback
+type ClientSync struct {
+	Primary         ClientSyncStatus  `json:"primary"`
+	FallbackEnabled bool              `json:"fallbackEnabled"`
+	Fallback        *ClientSyncStatus `json:"fallback"`
+}
+
+// The sync status of one client
+type ClientSyncStatus struct {
+	IsWorking    bool    `json:"isWorking"`
+	IsSynced     bool    `json:"isSynced"`
+	SyncProgress float64 `json:"syncProgress"`
+	Error        string  `json:"error"`
+}
+
+// The result of `minipool status`; amounts are in wei
+type MinipoolStatus struct {
+	Minipools []Minipool `json:"minipools"`
+}
+
+// A minipool of the node
+type Minipool struct {
+	Address            common.Address          `json:"address"`
+	ValidatorPubkey    rptypes.ValidatorPubkey `json:"validatorPubkey"`
+	Status             rptypes.MinipoolStatus  `json:"status"`
+	StatusTime         time.Time               `json:"statusTime"`
+	IsVacant           bool                    `json:"isVacant"`
+	Finalised          bool                    `json:"finalised"`
+	NodeFee            float64                 `json:"nodeFee"`
+	NodeDepositBalance *big.Int                `json:"nodeDepositBalance"`
+	UserDepositBalance *big.Int                `json:"userDepositBalance"`
+	Balance            *big.Int                `json:"balance"`
+	NodeShareOfBalance *big.Int                `json:"nodeShareOfBalance"`
+	ValidatorIndex     string                  `json:"validatorIndex"`
+	ValidatorActive    bool                    `json:"validatorActive"`
+	BeaconBalance      *big.Int                `json:"beaconBalance"`
+	NodeBeaconBalance  *big.Int                `json:"nodeBeaconBalance"`
+	CanStake           bool                    `json:"canStake"`
+	RefundAvailable    bool                    `json:"refundAvailable"`
+	CloseAvailable     bool                    `json:"closeAvailable"`
+	Delegate           common.Address          `json:"delegate"`
+	UseLatestDelegate  bool                    `json:"useLatestDelegate"`
+	Penalties          uint64                  `json:"penalties"`
+}
+
+// The result of `network stats`; amounts are in ETH and RPL
+type NetworkStats struct {
+	TotalValueLocked          float64        `json:"totalValueLocked"`
+	DepositPoolBalance        float64        `json:"depositPoolBalance"`
+	MinipoolCapacity          float64        `json:"minipoolCapacity"`
+	StakerUtilization         float64        `json:"stakerUtilization"`
+	NodeFee                   float64        `json:"nodeFee"`
+	NodeCount                 uint64         `json:"nodeCount"`
+	InitializedMinipoolCount  uint64         `json:"initializedMinipoolCount"`
+	PrelaunchMinipoolCount    uint64         `json:"prelaunchMinipoolCount"`
+	StakingMinipoolCount      uint64         `json:"stakingMinipoolCount"`
+	WithdrawableMinipoolCount uint64         `json:"withdrawableMinipoolCount"`
+	DissolvedMinipoolCount    uint64         `json:"dissolvedMinipoolCount"`
+	FinalizedMinipoolCount    uint64         `json:"finalizedMinipoolCount"`
+	RplPrice                  float64        `json:"rplPrice"`
+	TotalRplStaked            float64        `json:"totalRplStaked"`
+	EffectiveRplStaked        float64        `json:"effectiveRplStaked"`
+	RethPrice                 float64        `json:"rethPrice"`
+	SmoothingPoolNodes        uint64         `json:"smoothingPoolNodes"`
+	SmoothingPoolAddress      common.Address `json:"smoothingPoolAddress"`
+	SmoothingPoolBalance      float64        `json:"smoothingPoolBalance"`
+}
+
+// The result of `network node-fee`; rates are fractions, so 0.14 is 14%
+type NetworkNodeFee struct {
+	NodeFee       float64 `json:"nodeFee"`
+	MinNodeFee    float64 `json:"minNodeFee"`
+	TargetNodeFee float64 `json:"targetNodeFee"`
+	MaxNodeFee    float64 `json:"maxNodeFee"`
+}
+
+// The result of `network rpl-price`; amounts are in wei
+type NetworkRplPrice struct {
+	RplPrice                    *big.Int `json:"rplPrice"`
+	RplPriceBlock               uint64   `json:"rplPriceBlock"`
+	MinPer8EthMinipoolRplStake  *big.Int `json:"minPer8EthMinipoolRplStake"`
+	MinPer16EthMinipoolRplStake *big.Int `json:"minPer16EthMinipoolRplStake"`
+}
+
+// The result of `queue status`; amounts are in wei
+type QueueStatus struct {
+	DepositPoolBalance    *big.Int `json:"depositPoolBalance"`
+	MinipoolQueueLength   uint64   `json:"minipoolQueueLength"`
+	MinipoolQueueCapacity *big.Int `json:"minipoolQueueCapacity"`
+}
+
+// The result of `auction status`; amounts are in wei
+type AuctionStatus struct {
+	TotalRplBalance     *big.Int         `json:"totalRplBalance"`
+	AllottedRplBalance  *big.Int         `json:"allottedRplBalance"`
+	RemainingRplBalance *big.Int         `json:"remainingRplBalance"`
+	CanCreateLot        bool             `json:"canCreateLot"`
+	LotCounts           AuctionLotCounts `json:"lotCounts"`
+}
+
+// The number of lots the node can act on
+type AuctionLotCounts struct {
+	ClaimAvailable       int `json:"claimAvailable"`
+	BiddingAvailable     int `json:"biddingAvailable"`
+	RplRecoveryAvailable int `json:"rplRecoveryAvailable"`
+}
+
+// The result of `auction lots`; amounts and prices are in wei
+type AuctionLots struct {
+	Lots []AuctionLot `json:"lots"`
+}
+
+// An RPL auction lot; the bid amount is the node's
+type AuctionLot struct {
+	Index                uint64   `json:"index"`
+	StartBlock           uint64   `json:"startBlock"`
+	EndBlock             uint64   `json:"endBlock"`
+	StartPrice           *big.Int `json:"startPrice"`
+	ReservePrice         *big.Int `json:"reservePrice"`
+	CurrentPrice         *big.Int `json:"currentPrice"`
+	TotalRplAmount       *big.Int `json:"totalRplAmount"`
+	ClaimedRplAmount     *big.Int `json:"claimedRplAmount"`
+	RemainingRplAmount   *big.Int `json:"remainingRplAmount"`
+	TotalBidAmount       *big.Int `json:"totalBidAmount"`
+	AddressBidAmount     *big.Int `json:"addressBidAmount"`
+	Cleared              bool     `json:"cleared"`
+	RplRecovered         bool     `json:"rplRecovered"`
+	ClaimAvailable       bool     `json:"claimAvailable"`
+	BiddingAvailable     bool     `json:"biddingAvailable"`
+	RplRecoveryAvailable bool     `json:"rplRecoveryAvailable"`
+}
+
+// The number of a DAO's proposals in each state
+type DaoProposalCounts struct {
+	Total     int `json:"total"`
+	Pending   int `json:"pending"`
+	Active    int `json:"active"`
+	Cancelled int `json:"cancelled"`
+	Defeated  int `json:"defeated"`
+	Succeeded int `json:"succeeded"`
+	Expired   int `json:"expired"`
+	Executed  int `json:"executed"`
+}
+
+// The result of `odao status`
+type OdaoStatus struct {
+	IsMember       bool              `json:"isMember"`
+	CanJoin        bool              `json:"canJoin"`
+	CanLeave       bool              `json:"canLeave"`
+	CanReplace     bool              `json:"canReplace"`
+	TotalMembers   uint64            `json:"totalMembers"`
+	ProposalCounts DaoProposalCounts `json:"proposalCounts"`
+}
+
+// The result of `odao members`; bonds are in wei
+type OdaoMembers struct {
+	Members []OdaoMember `json:"members"`
+}
+
+// A member of the Oracle DAO
+type OdaoMember struct {
+	Address                common.Address `json:"address"`
+	ID                     string         `json:"id"`
+	Url                    string         `json:"url"`
+	JoinedTime             time.Time      `json:"joinedTime"`
+	LastProposalTime       time.Time      `json:"lastProposalTime"`
+	RplBondAmount          *big.Int       `json:"rplBondAmount"`
+	UnbondedValidatorCount uint64         `json:"unbondedValidatorCount"`
+}
+
+// The result of `security status`
+type SecurityStatus struct {
+	IsMember       bool              `json:"isMember"`
+	CanJoin        bool              `json:"canJoin"`
+	CanLeave       bool              `json:"canLeave"`
+	TotalMembers   uint64            `json:"totalMembers"`
+	ProposalCounts DaoProposalCounts `json:"proposalCounts"`
+}
+
+// The result of `security members`
+type SecurityMembers struct {
+	Members []SecurityMember `json:"members"`
+}
+
+// A member of the security council
+type SecurityMember struct {
+	Address    common.Address `json:"address"`
+	ID         string         `json:"id"`
+	JoinedTime time.Time      `json:"joinedTime"`
+}
+
+// The result of `pdao status`; amounts and voting power are in wei.
+// The Snapshot counts are zero if Snapshot couldn't be queried; the error says why.
+type PdaoStatus struct {
+	AccountAddress            common.Address `json:"accountAddress"`
+	BlockNumber               uint32         `json:"blockNumber"`
+	SnapshotVotingDelegate    common.Address `json:"snapshotVotingDelegate"`
+	SnapshotError             string         `json:"snapshotError"`
+	ActiveSnapshotProposals   int            `json:"activeSnapshotProposals"`
+	VotedSnapshotProposals    int            `json:"votedSnapshotProposals"`
+	IsVotingInitialized       bool           `json:"isVotingInitialized"`
+	OnchainVotingDelegate     common.Address `json:"onchainVotingDelegate"`
+	VotingPower               *big.Int       `json:"votingPower"`
+	TotalDelegatedVotingPower *big.Int       `json:"totalDelegatedVotingPower"`
+	SumVotingPower            *big.Int       `json:"sumVotingPower"`
+	IsRplLockingAllowed       bool           `json:"isRplLockingAllowed"`
+	NodeRplLocked             *big.Int       `json:"nodeRplLocked"`
+	HasClaimableBonds         bool           `json:"hasClaimableBonds"`
+	VerifyEnabled             bool           `json:"verifyEnabled"`
+}
+
+// The result of `service version`; the clients are null in native mode, and MEV-Boost is null if it's disabled
+type ServiceVersion struct {
+	ClientVersion   string         `json:"clientVersion"`
+	ServiceVersion  string         `json:"serviceVersion"`
+	IsNativeMode    bool           `json:"isNativeMode"`
+	ExecutionClient *ServiceClient `json:"executionClient"`
+	ConsensusClient *ServiceClient `json:"consensusClient"`
+	MevBoost        *ServiceClient `json:"mevBoost"`
+}
+
+// A client the Smart Node is configured to use; the images are empty for the parts that are externally managed
+type ServiceClient struct {
+	Name    string        `json:"name"`
+	Mode    cfgtypes.Mode `json:"mode"`
+	Image   string        `json:"image"`
+	VcImage string        `json:"vcImage"`
+}
+
+// The result of `pdao proposals list`; voting power is in wei
+type PdaoProposals struct {
+	Proposals []PdaoProposal `json:"proposals"`
+}
+
+// A Protocol DAO proposal, which is also the result of `pdao proposals details`
+type PdaoProposal struct {
+	ID                   uint64         `json:"id"`
+	State                string         `json:"state"`
+	Proposer             common.Address `json:"proposer"`
+	Message              string         `json:"message"`
+	CreatedTime          time.Time      `json:"createdTime"`
+	VotingStartTime      time.Time      `json:"votingStartTime"`
+	Phase1EndTime        time.Time      `json:"phase1EndTime"`
+	Phase2EndTime        time.Time      `json:"phase2EndTime"`
+	ExpiryTime           time.Time      `json:"expiryTime"`
+	VotingPowerRequired  *big.Int       `json:"votingPowerRequired"`
+	VotingPowerFor       *big.Int       `json:"votingPowerFor"`
+	VotingPowerAgainst   *big.Int       `json:"votingPowerAgainst"`
+	VotingPowerAbstained *big.Int       `json:"votingPowerAbstained"`
+	VotingPowerToVeto    *big.Int       `json:"votingPowerToVeto"`
+	Payload              string         `json:"payload"`
+	NodeVoteDirection    string         `json:"nodeVoteDirection"`
+}
+
+// The result of `pdao voting-power`; amounts and voting power are in wei
+type PdaoVotingPower struct {
+	AccountAddress       common.Address            `json:"accountAddress"`
+	BlockNumber          uint32                    `json:"blockNumber"`
+	VotingPower          *big.Int                  `json:"votingPower"`
+	DelegatedVotingPower *big.Int                  `json:"delegatedVotingPower"`
+	TotalVotingPower     *big.Int                  `json:"totalVotingPower"`
+	MaxStakeFraction     *big.Int                  `json:"maxStakeFraction"`
+	Current              VotingPowerEstimate       `json:"current"`
+	WhatIf               *VotingPowerEstimate      `json:"whatIf"`
+	History              []VotingPowerHistoryEntry `json:"history"`
+}
+
+// The inputs and result of a voting power calculation
+type VotingPowerEstimate struct {
+	RplStake       *big.Int `json:"rplStake"`
+	BondedEth      *big.Int `json:"bondedEth"`
+	MaxVotingStake *big.Int `json:"maxVotingStake"`
+	VotingPower    *big.Int `json:"votingPower"`
+}
+
+// The node's voting power at the target block of a past proposal; the delegated and total voting power are null if the proposal's voting tree isn't cached
+type VotingPowerHistoryEntry struct {
+	ProposalID           uint64    `json:"proposalId"`
+	Message              string    `json:"message"`
+	CreatedTime          time.Time `json:"createdTime"`
+	TargetBlock          uint32    `json:"targetBlock"`
+	VotingPower          *big.Int  `json:"votingPower"`
+	DelegatedVotingPower *big.Int  `json:"delegatedVotingPower"`
+	TotalVotingPower     *big.Int  `json:"totalVotingPower"`
+}
+
+// The result of `fleet status`; amounts are in wei
+type FleetStatus struct {
+	Contexts []FleetContextStatus `json:"contexts"`
+}
+
+// The status of one context's Smart Node
+type FleetContextStatus struct {
+	Context                 string         `json:"context"`
+	Error                   string         `json:"error"`
+	Version                 string         `json:"version"`
+	AccountAddress          common.Address `json:"accountAddress"`
+	Registered              bool           `json:"registered"`
+	EthBalance              *big.Int       `json:"ethBalance"`
+	RplStake                *big.Int       `json:"rplStake"`
+	BorrowedCollateralRatio float64        `json:"borrowedCollateralRatio"`
+	Minipools               int            `json:"minipools"`
+	ActiveValidators        int            `json:"activeValidators"`
+	NodeBeaconBalance       *big.Int       `json:"nodeBeaconBalance"`
+}
+
+// The result of `fleet rewards`
+type FleetRewards struct {
+	Contexts []FleetContextRewards `json:"contexts"`
+}
+
+// The rewards of one context's Smart Node; the rewards are null if the context couldn't be queried
+type FleetContextRewards struct {
+	Context string       `json:"context"`
+	Error   string       `json:"error"`
+	Rewards *NodeRewards `json:"rewards"`
+}
+
+// The result of `fleet context list`; access tokens are left out
+type FleetContexts struct {
+	Contexts []FleetContext `json:"contexts"`
+}
+
+// A saved context; the paths are empty if the context uses the defaults
+type FleetContext struct {
+	Name       string `json:"name"`
+	Target     string `json:"target"`
+	ConfigPath string `json:"configPath"`
+	DaemonPath string `json:"daemonPath"`
+}
+
+// The result of `minipool scrub-check`
+type MinipoolScrubCheck struct {
+	BlockNumber uint64                `json:"blockNumber"`
+	Minipools   []MinipoolScrubResult `json:"minipools"`
+}
+
+// The scrub check of a prelaunch minipool; the result is one of the scrub package's check results, and failed is true if the Oracle DAO will scrub it
+type MinipoolScrubResult struct {
+	Address                       common.Address          `json:"address"`
+	ValidatorPubkey               rptypes.ValidatorPubkey `json:"validatorPubkey"`
+	Result                        string                  `json:"result"`
+	Failed                        bool                    `json:"failed"`
+	Reason                        string                  `json:"reason"`
+	ExpectedWithdrawalCredentials common.Hash             `json:"expectedWithdrawalCredentials"`
+	ActualWithdrawalCredentials   common.Hash             `json:"actualWithdrawalCredentials"`
+	OffendingDeposit              *ScrubDeposit           `json:"offendingDeposit"`
+	InvalidDeposits               []ScrubDeposit          `json:"invalidDeposits"`
+	PrelaunchTime                 time.Time               `json:"prelaunchTime"`
+	ScrubPeriodEnd                time.Time               `json:"scrubPeriodEnd"`
+	Notes                         []string                `json:"notes"`
+}
+
+// A deposit to the Beacon deposit contract for a minipool's validator; amounts are in gwei
+type ScrubDeposit struct {
+	TxHash                common.Hash `json:"txHash"`
+	BlockNumber           uint64      `json:"blockNumber"`
+	TxIndex               uint        `json:"txIndex"`
+	DepositIndex          int         `json:"depositIndex"`
+	Amount                uint64      `json:"amount"`
+	WithdrawalCredentials common.Hash `json:"withdrawalCredentials"`
+	SignatureError        string      `json:"signatureError"`
+}
+
+// The result of `minipool overrides`.
+// Graffiti overrides aren't applied if the graffiti isn't supported, which is the case for Teku.
+type MinipoolOverrides struct {
+	GraffitiSupported bool               `json:"graffitiSupported"`
+	Minipools         []MinipoolOverride `json:"minipools"`
+}
+
+// The overrides of a minipool; the graffiti is formatted as it would be proposed, and it and the fee recipient are null if the minipool uses the node-wide default
+type MinipoolOverride struct {
+	Address      common.Address  `json:"address"`
+	Graffiti     *string         `json:"graffiti"`
+	FeeRecipient *common.Address `json:"feeRecipient"`
+}
+
+// The result of `network timezone-map`
+type NetworkTimezones struct {
+	NodeTotal      uint64            `json:"nodeTotal"`
+	TimezoneTotal  uint64            `json:"timezoneTotal"`
+	TimezoneCounts map[string]uint64 `json:"timezoneCounts"`
+}
+
+// The result of `network dao-proposals`
+type NetworkDaoProposals struct {
+	AccountAddress         common.Address     `json:"accountAddress"`
+	SnapshotVotingDelegate common.Address     `json:"snapshotVotingDelegate"`
+	OnchainVotingDelegate  common.Address     `json:"onchainVotingDelegate"`
+	SnapshotProposals      []SnapshotProposal `json:"snapshotProposals"`
+}
+
+// An active Snapshot proposal and the votes cast on it by the node or its delegate
+type SnapshotProposal struct {
+	ID          string         `json:"id"`
+	Title       string         `json:"title"`
+	State       string         `json:"state"`
+	Author      string         `json:"author"`
+	Start       time.Time      `json:"start"`
+	End         time.Time      `json:"end"`
+	Choices     []string       `json:"choices"`
+	Scores      []float64      `json:"scores"`
+	ScoresTotal float64        `json:"scoresTotal"`
+	Quorum      float64        `json:"quorum"`
+	Link        string         `json:"link"`
+	Votes       []SnapshotVote `json:"votes"`
+}
+
+// A Snapshot vote; the choice is in Snapshot's format for the proposal's voting type, with 1-based choice numbers
+type SnapshotVote struct {
+	Voter  common.Address `json:"voter"`
+	Choice interface{}    `json:"choice"`
+}
+
+// The result of `node proposals`; values are in wei
+type NodeProposals struct {
+	ProposalCount     int                `json:"proposalCount"`
+	TotalPayloadValue *big.Int           `json:"totalPayloadValue"`
+	QueryRelayData    bool               `json:"queryRelayData"`
+	Intervals         []ProposalInterval `json:"intervals"`
+	Proposals         []BlockProposal    `json:"proposals"`
+}
+
+// The value of the node's proposals during a rewards interval, compared to the Smoothing Pool.
+// The Smoothing Pool figures are only set if hasSmoothingPoolStats is true.
+type ProposalInterval struct {
+	Index                  uint64    `json:"index"`
+	Start                  time.Time `json:"start"`
+	ProposalCount          int       `json:"proposalCount"`
+	RelayProposalCount     int       `json:"relayProposalCount"`
+	TotalPayloadValue      *big.Int  `json:"totalPayloadValue"`
+	HasSmoothingPoolStats  bool      `json:"hasSmoothingPoolStats"`
+	SmoothingPoolBalance   *big.Int  `json:"smoothingPoolBalance"`
+	SmoothingPoolNodeCount uint64    `json:"smoothingPoolNodeCount"`
+	SmoothingPoolNodeAvg   *big.Int  `json:"smoothingPoolNodeAvg"`
+}
+
+// A block proposed by one of the node's validators; the payload value is null if it couldn't be determined
+type BlockProposal struct {
+	Slot                 uint64                  `json:"slot"`
+	Time                 time.Time               `json:"time"`
+	BlockNumber          uint64                  `json:"blockNumber"`
+	ValidatorIndex       string                  `json:"validatorIndex"`
+	ValidatorPubkey      rptypes.ValidatorPubkey `json:"validatorPubkey"`
+	MinipoolAddress      common.Address          `json:"minipoolAddress"`
+	FeeRecipient         common.Address          `json:"feeRecipient"`
+	ExpectedFeeRecipient common.Address          `json:"expectedFeeRecipient"`
+	IsCorrect            bool                    `json:"isCorrect"`
+	IsInSmoothingPool    bool                    `json:"isInSmoothingPool"`
+	IsMevBlock           bool                    `json:"isMevBlock"`
+	MevRecipient         common.Address          `json:"mevRecipient"`
+	MevReward            *big.Int                `json:"mevReward"`
+	RewardsInterval      uint64                  `json:"rewardsInterval"`
+	PayloadValue         *big.Int                `json:"payloadValue"`
+	IsFromRelay          bool                    `json:"isFromRelay"`
+	RelayName            string                  `json:"relayName"`
+}
+
+// The result of `node deferred-txs`; the updated time is zero if the node daemon hasn't saved its schedule yet
+type NodeDeferredTxs struct {
+	UpdatedTime  time.Time    `json:"updatedTime"`
+	Transactions []DeferredTx `json:"transactions"`
+}
+
+// An automatic transaction the node daemon is waiting for cheaper gas to submit; the deadline and estimated submission are zero if there isn't one
+type DeferredTx struct {
+	Type                string    `json:"type"`
+	Description         string    `json:"description"`
+	FirstSeen           time.Time `json:"firstSeen"`
+	Deadline            time.Time `json:"deadline"`
+	TargetMaxFeeGwei    float64   `json:"targetMaxFeeGwei"`
+	MaxCostEth          float64   `json:"maxCostEth"`
+	GasLimit            uint64    `json:"gasLimit"`
+	LastChecked         time.Time `json:"lastChecked"`
+	LastMaxFeeGwei      float64   `json:"lastMaxFeeGwei"`
+	LastCostEth         float64   `json:"lastCostEth"`
+	EstimatedSubmission time.Time `json:"estimatedSubmission"`
+	Reason              string    `json:"reason"`
+}
+
+// The result of `node export-ledger`; amounts are in wei
+type NodeLedger struct {
+	NodeAddress common.Address `json:"nodeAddress"`
+	FromBlock   uint64         `json:"fromBlock"`
+	ToBlock     uint64         `json:"toBlock"`
+	FromTime    time.Time      `json:"fromTime"`
+	ToTime      time.Time      `json:"toTime"`
+	Entries     []LedgerEntry  `json:"entries"`
+	Warnings    []string       `json:"warnings"`
+}
+
+// A movement of ETH or RPL; the amount is negative if the node paid it.
+// The interval is null for entries that aren't rewards, and the RPL price is null if it wasn't available at the entry's block.
+type LedgerEntry struct {
+	Type     string         `json:"type"`
+	Block    uint64         `json:"block"`
+	Time     time.Time      `json:"time"`
+	TxHash   common.Hash    `json:"txHash"`
+	Contract common.Address `json:"contract"`
+	Interval *uint64        `json:"interval"`
+	Asset    string         `json:"asset"`
+	Amount   *big.Int       `json:"amount"`
+	RplPrice *big.Int       `json:"rplPrice"`
+}
+
+// The result of `node sign-message`
+type NodeSignedMessage struct {
+	Address   common.Address `json:"address"`
+	Message   string         `json:"message"`
+	Signature string         `json:"signature"`
+	Version   string         `json:"version"`
+}
+
+// The result of `odao participation`
+type OdaoParticipation struct {
+	LastScannedBlock uint64                    `json:"lastScannedBlock"`
+	RecentWindow     int                       `json:"recentWindow"`
+	Members          []OdaoMemberParticipation `json:"members"`
+	RecentRounds     []OdaoDutyRound           `json:"recentRounds"`
+}
+
+// The submissions of an Oracle DAO member; the ID is empty if it's no longer a member
+type OdaoMemberParticipation struct {
+	Address     common.Address          `json:"address"`
+	ID          string                  `json:"id"`
+	IsLocalNode bool                    `json:"isLocalNode"`
+	Duties      []OdaoDutyParticipation `json:"duties"`
+}
+
+// A member's submissions for one duty; the missed count covers the latest rounds of the duty
+type OdaoDutyParticipation struct {
+	Duty              string    `json:"duty"`
+	Expected          int       `json:"expected"`
+	Submitted         int       `json:"submitted"`
+	MissedRecent      int       `json:"missedRecent"`
+	LastSubmittedTime time.Time `json:"lastSubmittedTime"`
+}
+
+// A round of an Oracle DAO duty; the responsible member is only set for duties that take turns
+type OdaoDutyRound struct {
+	Duty              string           `json:"duty"`
+	Target            string           `json:"target"`
+	Round             uint64           `json:"round"`
+	Time              time.Time        `json:"time"`
+	ConsensusReached  bool             `json:"consensusReached"`
+	Members           []common.Address `json:"members"`
+	Submitters        []common.Address `json:"submitters"`
+	ResponsibleMember *common.Address  `json:"responsibleMember"`
+}
+
+// The result of `odao watchtower-status`
+type OdaoWatchtowerStatus struct {
+	UpdatedTime time.Time        `json:"updatedTime"`
+	Tasks       []WatchtowerTask `json:"tasks"`
+}
+
+// The status of a watchtower task; times are zero if the event hasn't happened yet
+type WatchtowerTask struct {
+	Name                string        `json:"name"`
+	Interval            time.Duration `json:"interval"`
+	IsRunning           bool          `json:"isRunning"`
+	LastRun             time.Time     `json:"lastRun"`
+	LastSuccess         time.Time     `json:"lastSuccess"`
+	LastDuration        time.Duration `json:"lastDuration"`
+	NextRun             time.Time     `json:"nextRun"`
+	LastError           string        `json:"lastError"`
+	LastErrorTime       time.Time     `json:"lastErrorTime"`
+	ConsecutiveFailures int           `json:"consecutiveFailures"`
+	SkippedRuns         int           `json:"skippedRuns"`
+}
+
+// The result of `odao member-settings`; the quorum is a fraction, amounts are in wei and the challenge times are in blocks
+type OdaoMemberSettings struct {
+	Quorum              float64  `json:"quorum"`
+	RplBond             *big.Int `json:"rplBond"`
+	MinipoolUnbondedMax uint64   `json:"minipoolUnbondedMax"`
+	ChallengeCooldown   uint64   `json:"challengeCooldown"`
+	ChallengeWindow     uint64   `json:"challengeWindow"`
+	ChallengeCost       *big.Int `json:"challengeCost"`
+}
+
+// The result of `odao proposal-settings`
+type OdaoProposalSettings struct {
+	Cooldown      time.Duration `json:"cooldown"`
+	VoteTime      time.Duration `json:"voteTime"`
+	VoteDelayTime time.Duration `json:"voteDelayTime"`
+	ExecuteTime   time.Duration `json:"executeTime"`
+	ActionTime    time.Duration `json:"actionTime"`
+}
+
+// The result of `odao minipool-settings`
+type OdaoMinipoolSettings struct {
+	ScrubPeriod               time.Duration `json:"scrubPeriod"`
+	PromotionScrubPeriod      time.Duration `json:"promotionScrubPeriod"`
+	ScrubPenaltyEnabled       bool          `json:"scrubPenaltyEnabled"`
+	BondReductionWindowStart  time.Duration `json:"bondReductionWindowStart"`
+	BondReductionWindowLength time.Duration `json:"bondReductionWindowLength"`
+}
+
+// The result of `odao proposals list` and `security proposals list`
+type DaoProposals struct {
+	Proposals []DaoProposal `json:"proposals"`
+}
+
+// An Oracle DAO or security council proposal, which is also the result of `odao proposals details` and `security proposals details`.
+// The proposer ID is empty if the proposer is no longer a member.
+type DaoProposal struct {
+	ID              uint64         `json:"id"`
+	DAO             string         `json:"dao"`
+	State           string         `json:"state"`
+	Proposer        common.Address `json:"proposer"`
+	ProposerID      string         `json:"proposerId"`
+	Message         string         `json:"message"`
+	Payload         string         `json:"payload"`
+	PayloadData     string         `json:"payloadData"`
+	CreatedTime     time.Time      `json:"createdTime"`
+	StartTime       time.Time      `json:"startTime"`
+	EndTime         time.Time      `json:"endTime"`
+	ExpiryTime      time.Time      `json:"expiryTime"`
+	VotesRequired   float64        `json:"votesRequired"`
+	VotesFor        float64        `json:"votesFor"`
+	VotesAgainst    float64        `json:"votesAgainst"`
+	MemberVoted     bool           `json:"memberVoted"`
+	MemberSupported bool           `json:"memberSupported"`
+	IsCancelled     bool           `json:"isCancelled"`
+	IsExecuted      bool           `json:"isExecuted"`
+}
+
+// The result of `pdao settings`; amounts are in wei, and rates are fractions in wei so 1e18 is 100%
+type PdaoSettings struct {
+	Auction   PdaoAuctionSettings   `json:"auction"`
+	Deposit   PdaoDepositSettings   `json:"deposit"`
+	Inflation PdaoInflationSettings `json:"inflation"`
+	Minipool  PdaoMinipoolSettings  `json:"minipool"`
+	Network   PdaoNetworkSettings   `json:"network"`
+	Node      PdaoNodeSettings      `json:"node"`
+	Proposals PdaoProposalsSettings `json:"proposals"`
+	Rewards   PdaoRewardsSettings   `json:"rewards"`
+	Security  PdaoSecuritySettings  `json:"security"`
+}
+
+// The Protocol DAO's auction settings
+type PdaoAuctionSettings struct {
+	IsCreateLotEnabled    bool          `json:"isCreateLotEnabled"`
+	IsBidOnLotEnabled     bool          `json:"isBidOnLotEnabled"`
+	LotMinimumEthValue    *big.Int      `json:"lotMinimumEthValue"`
+	LotMaximumEthValue    *big.Int      `json:"lotMaximumEthValue"`
+	LotDuration           time.Duration `json:"lotDuration"`
+	LotStartingPriceRatio *big.Int      `json:"lotStartingPriceRatio"`
+	LotReservePriceRatio  *big.Int      `json:"lotReservePriceRatio"`
+}
+
+// The Protocol DAO's deposit settings
+type PdaoDepositSettings struct {
+	IsDepositingEnabled                    bool     `json:"isDepositingEnabled"`
+	AreDepositAssignmentsEnabled           bool     `json:"areDepositAssignmentsEnabled"`
+	MinimumDeposit                         *big.Int `json:"minimumDeposit"`
+	MaximumDepositPoolSize                 *big.Int `json:"maximumDepositPoolSize"`
+	MaximumAssignmentsPerDeposit           uint64   `json:"maximumAssignmentsPerDeposit"`
+	MaximumSocialisedAssignmentsPerDeposit uint64   `json:"maximumSocialisedAssignmentsPerDeposit"`
+	DepositFee                             *big.Int `json:"depositFee"`
+}
+
+// The Protocol DAO's inflation settings
+type PdaoInflationSettings struct {
+	IntervalRate *big.Int  `json:"intervalRate"`
+	StartTime    time.Time `json:"startTime"`
+}
+
+// The Protocol DAO's minipool settings
+type PdaoMinipoolSettings struct {
+	IsSubmitWithdrawableEnabled bool          `json:"isSubmitWithdrawableEnabled"`
+	LaunchTimeout               time.Duration `json:"launchTimeout"`
+	IsBondReductionEnabled      bool          `json:"isBondReductionEnabled"`
+	MaximumCount                uint64        `json:"maximumCount"`
+	UserDistributeWindowStart   time.Duration `json:"userDistributeWindowStart"`
+	UserDistributeWindowLength  time.Duration `json:"userDistributeWindowLength"`
+}
+
+// The Protocol DAO's network settings
+type PdaoNetworkSettings struct {
+	OracleDaoConsensusThreshold *big.Int      `json:"oracleDaoConsensusThreshold"`
+	NodePenaltyThreshold        *big.Int      `json:"nodePenaltyThreshold"`
+	PerPenaltyRate              *big.Int      `json:"perPenaltyRate"`
+	IsSubmitBalancesEnabled     bool          `json:"isSubmitBalancesEnabled"`
+	SubmitBalancesFrequency     time.Duration `json:"submitBalancesFrequency"`
+	IsSubmitPricesEnabled       bool          `json:"isSubmitPricesEnabled"`
+	SubmitPricesFrequency       time.Duration `json:"submitPricesFrequency"`
+	MinimumNodeFee              *big.Int      `json:"minimumNodeFee"`
+	TargetNodeFee               *big.Int      `json:"targetNodeFee"`
+	MaximumNodeFee              *big.Int      `json:"maximumNodeFee"`
+	NodeFeeDemandRange          *big.Int      `json:"nodeFeeDemandRange"`
+	TargetRethCollateralRate    *big.Int      `json:"targetRethCollateralRate"`
+	IsSubmitRewardsEnabled      bool          `json:"isSubmitRewardsEnabled"`
+}
+
+// The Protocol DAO's node settings
+type PdaoNodeSettings struct {
+	IsRegistrationEnabled              bool     `json:"isRegistrationEnabled"`
+	IsSmoothingPoolRegistrationEnabled bool     `json:"isSmoothingPoolRegistrationEnabled"`
+	IsDepositingEnabled                bool     `json:"isDepositingEnabled"`
+	AreVacantMinipoolsEnabled          bool     `json:"areVacantMinipoolsEnabled"`
+	MinimumPerMinipoolStake            *big.Int `json:"minimumPerMinipoolStake"`
+	MaximumPerMinipoolStake            *big.Int `json:"maximumPerMinipoolStake"`
+}
+
+// The Protocol DAO's proposal settings; the max block age is in blocks
+type PdaoProposalsSettings struct {
+	VotePhase1Time  time.Duration `json:"votePhase1Time"`
+	VotePhase2Time  time.Duration `json:"votePhase2Time"`
+	VoteDelayTime   time.Duration `json:"voteDelayTime"`
+	ExecuteTime     time.Duration `json:"executeTime"`
+	ProposalBond    *big.Int      `json:"proposalBond"`
+	ChallengeBond   *big.Int      `json:"challengeBond"`
+	ChallengePeriod time.Duration `json:"challengePeriod"`
+	Quorum          *big.Int      `json:"quorum"`
+	VetoQuorum      *big.Int      `json:"vetoQuorum"`
+	MaxBlockAge     uint64        `json:"maxBlockAge"`
+}
+
+// The Protocol DAO's rewards settings
+type PdaoRewardsSettings struct {
+	IntervalTime time.Duration `json:"intervalTime"`
+}
+
+// The Protocol DAO's security council settings
+type PdaoSecuritySettings struct {
+	MembersQuorum       *big.Int      `json:"membersQuorum"`
+	MembersLeaveTime    time.Duration `json:"membersLeaveTime"`
+	ProposalVoteTime    time.Duration `json:"proposalVoteTime"`
+	ProposalExecuteTime time.Duration `json:"proposalExecuteTime"`
+	ProposalActionTime  time.Duration `json:"proposalActionTime"`
+}
+
+// The result of `pdao rewards-percentages`; the shares are fractions in wei, so 1e18 is 100%
+type PdaoRewardsPercentages struct {
+	Node        *big.Int `json:"node"`
+	OracleDao   *big.Int `json:"oracleDao"`
+	ProtocolDao *big.Int `json:"protocolDao"`
+}
+
+// The result of `pdao delegates`; voting power is in wei
+type PdaoDelegates struct {
+	AccountAddress        common.Address     `json:"accountAddress"`
+	BlockNumber           uint32             `json:"blockNumber"`
+	OnchainVotingDelegate common.Address     `json:"onchainVotingDelegate"`
+	NodeVotingPower       *big.Int           `json:"nodeVotingPower"`
+	DelegatedVotingPower  *big.Int           `json:"delegatedVotingPower"`
+	TotalVotingPower      *big.Int           `json:"totalVotingPower"`
+	Delegates             []PdaoDelegate     `json:"delegates"`
+	Delegators            []PdaoDelegator    `json:"delegators"`
+	DelegateVotes         []PdaoDelegateVote `json:"delegateVotes"`
+}
+
+// A delegate and the voting power delegated to it, including its own
+type PdaoDelegate struct {
+	Delegate       common.Address `json:"delegate"`
+	VotingPower    *big.Int       `json:"votingPower"`
+	DelegatorCount uint64         `json:"delegatorCount"`
+}
+
+// A node that delegates its voting power to this node
+type PdaoDelegator struct {
+	NodeAddress common.Address `json:"nodeAddress"`
+	VotingPower *big.Int       `json:"votingPower"`
+}
+
+// How the node's delegate voted on a proposal, and the node's own vote if it overrode it
+type PdaoDelegateVote struct {
+	ProposalID            uint64    `json:"proposalId"`
+	Message               string    `json:"message"`
+	State                 string    `json:"state"`
+	Phase1EndTime         time.Time `json:"phase1EndTime"`
+	DelegateVoteDirection string    `json:"delegateVoteDirection"`
+	NodeVoteDirection     string    `json:"nodeVoteDirection"`
+	IsAwaitingDelegate    bool      `json:"isAwaitingDelegate"`
+}
+
+// The result of `pdao audit-proposal`; voting power is in wei
+type PdaoProposalAudit struct {
+	ProposalID      uint64                 `json:"proposalId"`
+	State           string                 `json:"state"`
+	Proposer        common.Address         `json:"proposer"`
+	TargetBlock     uint32                 `json:"targetBlock"`
+	VotingStartTime time.Time              `json:"votingStartTime"`
+	NodeCount       uint64                 `json:"nodeCount"`
+	LocalRoot       rptypes.VotingTreeNode `json:"localRoot"`
+	ProposedRoot    rptypes.VotingTreeNode `json:"proposedRoot"`
+	RootMatches     bool                   `json:"rootMatches"`
+	RootSubmissions []PdaoRootSubmission   `json:"rootSubmissions"`
+	Challenges      []PdaoChallenge        `json:"challenges"`
+	IsDefeatable    bool                   `json:"isDefeatable"`
+	DefeatIndex     uint64                 `json:"defeatIndex"`
+}
+
+// A root submitted for a proposal (its pollard or a challenge response) and the tree nodes in it that don't match the local voting tree
+type PdaoRootSubmission struct {
+	Index          uint64               `json:"index"`
+	Submitter      common.Address       `json:"submitter"`
+	Timestamp      time.Time            `json:"timestamp"`
+	ChallengeState string               `json:"challengeState"`
+	Mismatches     []VotingTreeMismatch `json:"mismatches"`
+}
+
+// A tree node that doesn't match the local voting tree.
+// The tree owner is null for tree nodes in the network tree, and the node address is only set for leaves.
+type VotingTreeMismatch struct {
+	Index             uint64                 `json:"index"`
+	TreeOwner         *common.Address        `json:"treeOwner"`
+	FirstNodeIndex    uint64                 `json:"firstNodeIndex"`
+	LastNodeIndex     uint64                 `json:"lastNodeIndex"`
+	NodeAddress       *common.Address        `json:"nodeAddress"`
+	IsDelegationTotal bool                   `json:"isDelegationTotal"`
+	LocalNode         rptypes.VotingTreeNode `json:"localNode"`
+	ProposedNode      rptypes.VotingTreeNode `json:"proposedNode"`
+}
+
+// A challenge raised against a proposal; it can be used to defeat the proposal if it went unanswered
+type PdaoChallenge struct {
+	Index         uint64         `json:"index"`
+	Challenger    common.Address `json:"challenger"`
+	Timestamp     time.Time      `json:"timestamp"`
+	State         string         `json:"state"`
+	CanBeDefeated bool           `json:"canBeDefeated"`
+}
+
+// The result of `service check-relays`; the registry source is empty for the built-in registry
+type ServiceRelays struct {
+	RegistrySource  string       `json:"registrySource"`
+	RegistryVersion uint64       `json:"registryVersion"`
+	RegistryError   string       `json:"registryError"`
+	Relays          []RelayCheck `json:"relays"`
+}
+
+// The outcome of probing a MEV-Boost relay; the latency is zero if it couldn't be reached
+type RelayCheck struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	Url       string        `json:"url"`
+	Enabled   bool          `json:"enabled"`
+	Reachable bool          `json:"reachable"`
+	Latency   time.Duration `json:"latency"`
+	Error     string        `json:"error"`
+}
+
+// The result of `service check-cpu-features`
+type ServiceCpuFeatures struct {
+	MissingFeatures      []string `json:"missingFeatures"`
+	SupportsModernImages bool     `json:"supportsModernImages"`
+}
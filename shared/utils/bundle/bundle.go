@@ -0,0 +1,330 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Settings
+const (
+	FileExtension string = ".rpmig"
+	Version       uint32 = 1
+
+	manifestName string = "manifest.json"
+	magic        string = "RPMIG001"
+	saltLength   int    = 32
+	scryptN      int    = 1 << 18
+	scryptR      int    = 8
+	scryptP      int    = 1
+	keyLength    int    = 32
+)
+
+// Metadata describing the contents of a migration bundle
+type Manifest struct {
+	Version          uint32                  `json:"version"`
+	SmartnodeVersion string                  `json:"smartnodeVersion"`
+	Network          string                  `json:"network"`
+	NodeAddress      common.Address          `json:"nodeAddress"`
+	CreatedAt        time.Time               `json:"createdAt"`
+	StopEpoch        uint64                  `json:"stopEpoch"`
+	ValidatorPubkeys []types.ValidatorPubkey `json:"validatorPubkeys"`
+	Entries          []string                `json:"entries"`
+}
+
+// A file or folder to store in a bundle under the given entry name
+type Source struct {
+	Name string
+	Path string
+}
+
+// A decrypted migration bundle
+type Bundle struct {
+	Manifest Manifest
+	files    map[string]*tar.Header
+	contents map[string][]byte
+}
+
+// Archives the provided sources, encrypts them with the passphrase, and writes the result to w.
+// Sources that don't exist on disk are skipped and left out of the manifest.
+func Write(w io.Writer, passphrase string, manifest Manifest, sources []Source) error {
+
+	// Build the archive
+	archive := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(archive)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifest.Version = Version
+	manifest.Entries = []string{}
+	for _, source := range sources {
+		_, err := os.Stat(source.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error checking [%s]: %w", source.Path, err)
+		}
+		if err := addToArchive(tarWriter, source.Name, source.Path); err != nil {
+			return fmt.Errorf("error archiving %s: %w", source.Name, err)
+		}
+		manifest.Entries = append(manifest.Entries, source.Name)
+	}
+
+	// Add the manifest last so it reflects what was actually archived
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return fmt.Errorf("error serializing bundle manifest: %w", err)
+	}
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name:    manifestName,
+		Mode:    0600,
+		Size:    int64(len(manifestBytes)),
+		ModTime: manifest.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("error writing bundle manifest header: %w", err)
+	}
+	if _, err := tarWriter.Write(manifestBytes); err != nil {
+		return fmt.Errorf("error writing bundle manifest: %w", err)
+	}
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("error finalizing archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("error compressing archive: %w", err)
+	}
+
+	// Encrypt it
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("error generating salt: %w", err)
+	}
+	aead, err := getCipher(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("error generating nonce: %w", err)
+	}
+	header := append([]byte(magic), salt...)
+	header = append(header, nonce...)
+	ciphertext := aead.Seal(nil, nonce, archive.Bytes(), header)
+
+	// Write it out
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("error writing bundle header: %w", err)
+	}
+	if _, err := w.Write(ciphertext); err != nil {
+		return fmt.Errorf("error writing bundle: %w", err)
+	}
+	return nil
+
+}
+
+// Decrypts and loads a bundle that was created with Write
+func Read(r io.Reader, passphrase string) (*Bundle, error) {
+
+	// Read the header
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading bundle: %w", err)
+	}
+	if len(data) < len(magic)+saltLength || string(data[:len(magic)]) != magic {
+		return nil, fmt.Errorf("this is not a Rocket Pool migration bundle, or it was made with an unsupported version of the Smartnode")
+	}
+	salt := data[len(magic) : len(magic)+saltLength]
+
+	// Decrypt the archive
+	aead, err := getCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	headerLength := len(magic) + saltLength + aead.NonceSize()
+	if len(data) < headerLength {
+		return nil, fmt.Errorf("bundle is truncated")
+	}
+	nonce := data[len(magic)+saltLength : headerLength]
+	archive, err := aead.Open(nil, nonce, data[headerLength:], data[:headerLength])
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt bundle; the passphrase is incorrect or the file is corrupted")
+	}
+
+	// Unpack it
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing bundle: %w", err)
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+	bundle := &Bundle{
+		files:    map[string]*tar.Header{},
+		contents: map[string][]byte{},
+	}
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading bundle archive: %w", err)
+		}
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("bundle contains an invalid path [%s]", header.Name)
+		}
+		bundle.files[name] = header
+		if header.Typeflag == tar.TypeReg {
+			contents, err := io.ReadAll(tarReader)
+			if err != nil {
+				return nil, fmt.Errorf("error reading [%s] from bundle: %w", name, err)
+			}
+			bundle.contents[name] = contents
+		}
+	}
+
+	// Load the manifest
+	manifestBytes, exists := bundle.contents[manifestName]
+	if !exists {
+		return nil, fmt.Errorf("bundle is missing its manifest")
+	}
+	if err := json.Unmarshal(manifestBytes, &bundle.Manifest); err != nil {
+		return nil, fmt.Errorf("error decoding bundle manifest: %w", err)
+	}
+	if bundle.Manifest.Version != Version {
+		return nil, fmt.Errorf("unsupported bundle version %d (expected %d)", bundle.Manifest.Version, Version)
+	}
+	return bundle, nil
+
+}
+
+// Check if the bundle has an entry with the given name
+func (b *Bundle) HasEntry(name string) bool {
+	_, exists := b.files[name]
+	return exists
+}
+
+// Get the contents of a file entry in the bundle
+func (b *Bundle) ReadFile(name string) ([]byte, error) {
+	contents, exists := b.contents[name]
+	if !exists {
+		return nil, fmt.Errorf("bundle does not contain a file named [%s]", name)
+	}
+	return contents, nil
+}
+
+// Extracts the file or folder entry with the given name to the target path
+func (b *Bundle) Extract(name string, targetPath string) error {
+	header, exists := b.files[name]
+	if !exists {
+		return fmt.Errorf("bundle does not contain [%s]", name)
+	}
+
+	// Single file
+	if header.Typeflag == tar.TypeReg {
+		return writeFile(targetPath, b.contents[name], header)
+	}
+
+	// Folder
+	prefix := name + "/"
+	for entryName, entryHeader := range b.files {
+		if !strings.HasPrefix(entryName, prefix) {
+			continue
+		}
+		entryPath := filepath.Join(targetPath, filepath.FromSlash(strings.TrimPrefix(entryName, prefix)))
+		switch entryHeader.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(entryPath, fs.FileMode(entryHeader.Mode)|0700); err != nil {
+				return fmt.Errorf("error creating folder [%s]: %w", entryPath, err)
+			}
+		case tar.TypeReg:
+			if err := writeFile(entryPath, b.contents[entryName], entryHeader); err != nil {
+				return err
+			}
+		}
+	}
+	return os.MkdirAll(targetPath, fs.FileMode(header.Mode)|0700)
+}
+
+// Derive the AEAD cipher for a passphrase and salt
+func getCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, keyLength)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving bundle key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating bundle cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Adds a file or folder to the archive under the given name
+func addToArchive(tarWriter *tar.Writer, name string, sourcePath string) error {
+	return filepath.WalkDir(sourcePath, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		// Skip sockets, pipes, links and the like; nothing the Smartnode stores needs them
+		if !info.Mode().IsRegular() && !info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(sourcePath, filePath)
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = path.Join(name, filepath.ToSlash(relPath))
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tarWriter, file)
+		return err
+	})
+}
+
+// Writes a file from the bundle to disk, preserving its permissions
+func writeFile(targetPath string, contents []byte, header *tar.Header) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0700); err != nil {
+		return fmt.Errorf("error creating folder for [%s]: %w", targetPath, err)
+	}
+	if err := os.WriteFile(targetPath, contents, fs.FileMode(header.Mode)&fs.ModePerm); err != nil {
+		return fmt.Errorf("error writing [%s]: %w", targetPath, err)
+	}
+	return nil
+}
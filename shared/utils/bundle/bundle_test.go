@@ -0,0 +1,138 @@
+package bundle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const testPassphrase string = "correct horse battery staple"
+
+// Write a bundle with a single file and a folder holding another file
+func writeTestBundle(t *testing.T) ([]byte, Manifest) {
+	sourceDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(sourceDir, "wallet"), []byte("wallet contents"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	keysDir := filepath.Join(sourceDir, "validators")
+	if err := os.MkdirAll(filepath.Join(keysDir, "keys"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(keysDir, "keys", "0x01.json"), []byte("key contents"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	manifest := Manifest{
+		SmartnodeVersion: "1.0.0",
+		Network:          "holesky",
+		NodeAddress:      common.HexToAddress("0x1234567890123456789012345678901234567890"),
+		CreatedAt:        time.Unix(1700000000, 0).UTC(),
+		StopEpoch:        1234,
+	}
+	buffer := new(bytes.Buffer)
+	err := Write(buffer, testPassphrase, manifest, []Source{
+		{Name: "wallet", Path: filepath.Join(sourceDir, "wallet")},
+		{Name: "validators", Path: keysDir},
+		{Name: "missing", Path: filepath.Join(sourceDir, "missing")},
+	})
+	if err != nil {
+		t.Fatalf("error writing bundle: %s", err.Error())
+	}
+	return buffer.Bytes(), manifest
+}
+
+func TestRoundTrip(t *testing.T) {
+	data, manifest := writeTestBundle(t)
+	bundle, err := Read(bytes.NewReader(data), testPassphrase)
+	if err != nil {
+		t.Fatalf("error reading bundle: %s", err.Error())
+	}
+
+	// Check the manifest
+	if bundle.Manifest.Version != Version {
+		t.Errorf("version was %d, expected %d", bundle.Manifest.Version, Version)
+	}
+	if bundle.Manifest.NodeAddress != manifest.NodeAddress || bundle.Manifest.StopEpoch != manifest.StopEpoch || !bundle.Manifest.CreatedAt.Equal(manifest.CreatedAt) {
+		t.Errorf("manifest was %+v, expected %+v", bundle.Manifest, manifest)
+	}
+	if strings.Join(bundle.Manifest.Entries, ",") != "wallet,validators" {
+		t.Errorf("entries were %v, expected the sources that exist", bundle.Manifest.Entries)
+	}
+	if bundle.HasEntry("missing") {
+		t.Error("bundle has an entry for a source that doesn't exist")
+	}
+
+	// Check the contents
+	wallet, err := bundle.ReadFile("wallet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(wallet) != "wallet contents" {
+		t.Errorf("wallet was [%s]", string(wallet))
+	}
+	targetDir := t.TempDir()
+	if err := bundle.Extract("validators", filepath.Join(targetDir, "validators")); err != nil {
+		t.Fatalf("error extracting folder: %s", err.Error())
+	}
+	key, err := os.ReadFile(filepath.Join(targetDir, "validators", "keys", "0x01.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(key) != "key contents" {
+		t.Errorf("key was [%s]", string(key))
+	}
+	info, err := os.Stat(filepath.Join(targetDir, "validators", "keys", "0x01.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("key permissions were %s, expected 0600", info.Mode().Perm())
+	}
+}
+
+func TestReadWithWrongPassphrase(t *testing.T) {
+	data, _ := writeTestBundle(t)
+	_, err := Read(bytes.NewReader(data), "wrong passphrase")
+	if err == nil {
+		t.Fatal("bundle was read with the wrong passphrase")
+	}
+	if !strings.Contains(err.Error(), "passphrase is incorrect") {
+		t.Errorf("unexpected error: %s", err.Error())
+	}
+}
+
+func TestReadTamperedBundle(t *testing.T) {
+	data, _ := writeTestBundle(t)
+	headerLength := len(magic) + saltLength + 12 // GCM uses a 12 byte nonce
+
+	// Flip a bit in the ciphertext
+	tampered := bytes.Clone(data)
+	tampered[headerLength+10] ^= 0x01
+	if _, err := Read(bytes.NewReader(tampered), testPassphrase); err == nil {
+		t.Error("bundle with a tampered ciphertext was read")
+	}
+
+	// Flip a bit in the nonce, which is authenticated as part of the header
+	tampered = bytes.Clone(data)
+	tampered[headerLength-1] ^= 0x01
+	if _, err := Read(bytes.NewReader(tampered), testPassphrase); err == nil {
+		t.Error("bundle with a tampered header was read")
+	}
+
+	// Truncate it
+	if _, err := Read(bytes.NewReader(data[:len(data)-1]), testPassphrase); err == nil {
+		t.Error("truncated bundle was read")
+	}
+}
+
+func TestReadRejectsOtherFiles(t *testing.T) {
+	_, err := Read(strings.NewReader("not a bundle"), testPassphrase)
+	if err == nil || !strings.Contains(err.Error(), "not a Rocket Pool migration bundle") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,272 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/types/results"
+)
+
+// The result type of commands that send transactions
+var transactionsType = reflect.TypeOf(results.Transactions{})
+
+// The version of the machine-readable output's layout; bump it when the envelope changes incompatibly
+const EnvelopeVersion int = 2
+
+// The format the CLI prints its results in
+type Mode string
+
+const (
+	Mode_Text Mode = "text"
+	Mode_Json Mode = "json"
+	Mode_Yaml Mode = "yaml"
+)
+
+// The key used to store the recorder on the CLI app's metadata
+const metadataKey string = "output"
+
+// The document a command prints in a machine-readable mode.
+// Result has the command's result type (see 'rocketpool schema'), or is null if the command failed before it had one.
+type Envelope struct {
+	Version int         `json:"version"`
+	Command string      `json:"command"`
+	Status  string      `json:"status"`
+	Error   string      `json:"error"`
+	Result  interface{} `json:"result"`
+}
+
+// Collects the result of a command so it can be printed in a machine-readable mode
+type Recorder struct {
+	mode     Mode
+	stdout   io.Writer
+	result   interface{}
+	txHashes []common.Hash
+	lock     *sync.Mutex
+}
+
+// Parse an output mode
+func ParseMode(value string) (Mode, error) {
+	switch mode := Mode(strings.ToLower(value)); mode {
+	case Mode_Text, Mode_Json, Mode_Yaml:
+		return mode, nil
+	case "":
+		return Mode_Text, nil
+	default:
+		return "", fmt.Errorf("unknown output format [%s]; must be text, json or yaml", value)
+	}
+}
+
+// Set up the CLI for an output mode.
+// In a machine-readable mode, everything the commands print for humans (including prompts) goes to stderr, so stdout only has the envelope.
+func Setup(c *cli.Context, mode Mode) {
+	if mode == Mode_Text {
+		return
+	}
+	c.App.Metadata[metadataKey] = &Recorder{
+		mode:     mode,
+		stdout:   os.Stdout,
+		txHashes: []common.Hash{},
+		lock:     &sync.Mutex{},
+	}
+	os.Stdout = os.Stderr
+}
+
+// Get the recorder for the command, or nil if the CLI is printing text
+func GetRecorder(c *cli.Context) *Recorder {
+	if recorder, ok := c.App.Metadata[metadataKey]; ok {
+		return recorder.(*Recorder)
+	}
+	return nil
+}
+
+// Set the result of the command; it's only printed in a machine-readable mode
+func SetResult(c *cli.Context, result interface{}) {
+	recorder := GetRecorder(c)
+	if recorder == nil {
+		return
+	}
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	recorder.result = result
+}
+
+// Record the hash of a transaction the command submitted, for commands that return their transactions
+func (r *Recorder) RecordTransaction(hash common.Hash) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.txHashes = append(r.txHashes, hash)
+}
+
+// Print the envelope for a finished command
+func (r *Recorder) Write(command string, resultType reflect.Type, commandErr error) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	envelope := Envelope{
+		Version: EnvelopeVersion,
+		Command: command,
+		Status:  "success",
+		Result:  r.result,
+	}
+	if resultType == transactionsType {
+		// Transactions are recorded as they're submitted, so the ones that went through are reported even if a later one failed
+		envelope.Result = results.Transactions{
+			TxHashes: r.txHashes,
+		}
+	}
+	if commandErr != nil {
+		envelope.Status = "error"
+		envelope.Error = commandErr.Error()
+	}
+
+	document, err := json.MarshalIndent(envelope, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing output: %w", err)
+	}
+	if r.mode == Mode_Yaml {
+		document, err = jsonToYaml(document)
+		if err != nil {
+			return fmt.Errorf("error converting output to YAML: %w", err)
+		}
+	}
+	_, err = fmt.Fprintln(r.stdout, strings.TrimSpace(string(document)))
+	return err
+}
+
+// Print a document as JSON, or as YAML if the CLI is in YAML mode; it always goes to stdout
+func Print(c *cli.Context, document interface{}) error {
+	serialized, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error serializing output: %w", err)
+	}
+
+	var stdout io.Writer = os.Stdout
+	if recorder := GetRecorder(c); recorder != nil {
+		stdout = recorder.stdout
+		if recorder.mode == Mode_Yaml {
+			serialized, err = jsonToYaml(serialized)
+			if err != nil {
+				return fmt.Errorf("error converting output to YAML: %w", err)
+			}
+		}
+	}
+	_, err = fmt.Fprintln(stdout, strings.TrimSpace(string(serialized)))
+	return err
+}
+
+// Wrap the actions of the commands and all of their subcommands so they print an envelope in a machine-readable mode
+func WrapCommands(commands []cli.Command) {
+	wrapCommands(commands, "")
+}
+
+// Wrap the actions of commands under a parent command
+func wrapCommands(commands []cli.Command, parent string) {
+	for i := range commands {
+		name := strings.TrimSpace(parent + " " + commands[i].Name)
+		if action, ok := commands[i].Action.(func(*cli.Context) error); ok {
+			commands[i].Action = wrapAction(name, action)
+		}
+		wrapCommands(commands[i].Subcommands, name)
+	}
+}
+
+// Wrap a command's action so it prints an envelope in a machine-readable mode.
+// Commands without a result type are refused before they run, so they can't do anything the caller has no way to see.
+func wrapAction(command string, action func(*cli.Context) error) func(*cli.Context) error {
+	return func(c *cli.Context) error {
+		recorder := GetRecorder(c)
+		if recorder == nil {
+			return action(c)
+		}
+
+		resultType, exists := results.GetResultType(command)
+		var err error
+		if exists {
+			err = action(c)
+		} else if reason, unsupported := results.GetUnsupportedReason(command); unsupported {
+			err = fmt.Errorf("the %s command doesn't support machine-readable output because %s", command, reason)
+		} else {
+			err = fmt.Errorf("the %s command doesn't support machine-readable output; run 'rocketpool schema --list' to see the commands that do", command)
+		}
+		if writeErr := recorder.Write(command, resultType, err); writeErr != nil {
+			return writeErr
+		}
+		return err
+	}
+}
+
+// Convert a JSON document to YAML, keeping the order of its keys.
+// Integers too large for 64 bits are quoted, since YAML parsers would otherwise round them to floats.
+func jsonToYaml(document []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(document))
+	decoder.UseNumber()
+	value, err := readYamlValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(value)
+}
+
+// Read the next JSON value as a value yaml.v2 can serialize
+func readYamlValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token := token.(type) {
+	case json.Delim:
+		if token == '{' {
+			object := yaml.MapSlice{}
+			for decoder.More() {
+				key, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				value, err := readYamlValue(decoder)
+				if err != nil {
+					return nil, err
+				}
+				object = append(object, yaml.MapItem{Key: key, Value: value})
+			}
+			_, err = decoder.Token()
+			return object, err
+		}
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := readYamlValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err = decoder.Token()
+		return array, err
+
+	case json.Number:
+		if value, err := token.Int64(); err == nil {
+			return value, nil
+		}
+		if value, err := strconv.ParseUint(token.String(), 10, 64); err == nil {
+			return value, nil
+		}
+		if !strings.ContainsAny(token.String(), ".eE") {
+			return token.String(), nil
+		}
+		return token.Float64()
+
+	default:
+		return token, nil
+	}
+}
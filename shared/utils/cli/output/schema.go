@@ -0,0 +1,179 @@
+package output
+
+import (
+	"encoding"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+// The JSON Schema draft the schemas follow
+const schemaDraft string = "https://json-schema.org/draft/2020-12/schema"
+
+// A JSON Schema document
+type Schema map[string]interface{}
+
+// Types with a custom JSON encoding that isn't a string
+var (
+	bigIntType   = reflect.TypeOf(big.Int{})
+	addressType  = reflect.TypeOf(common.Address{})
+	hashType     = reflect.TypeOf(common.Hash{})
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+	rawType      = reflect.TypeOf(json.RawMessage{})
+
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// Generate the JSON Schema of a type, as the API serializes it
+func GenerateSchema(t reflect.Type) Schema {
+	schema := generateSchema(t, map[reflect.Type]bool{})
+	schema["$schema"] = schemaDraft
+	return schema
+}
+
+// Generate the JSON Schema of the envelope a command prints, given the schema of its result.
+// If the command is blank, the schema covers every command and allows any result.
+func GenerateEnvelopeSchema(command string, resultSchema Schema) Schema {
+	commandSchema := Schema{"type": "string", "description": "The CLI command that was run"}
+	result := Schema{"description": "The command's result, or null if it failed before it had one"}
+	title := "Envelope"
+	if command != "" {
+		commandSchema["const"] = command
+		delete(resultSchema, "$schema")
+		result["anyOf"] = []Schema{resultSchema, {"type": "null"}}
+		title = fmt.Sprintf("Envelope of '%s'", command)
+	}
+
+	return Schema{
+		"$schema": schemaDraft,
+		"title":   title,
+		"type":    "object",
+		"properties": Schema{
+			"version": Schema{"type": "integer", "const": EnvelopeVersion},
+			"command": commandSchema,
+			"status":  Schema{"type": "string", "enum": []string{"success", "error"}},
+			"error":   Schema{"type": "string"},
+			"result":  result,
+		},
+		"required": []string{"version", "command", "status", "error", "result"},
+	}
+}
+
+// Generate the schema of a type; types already being generated are left open to break cycles
+func generateSchema(t reflect.Type, inProgress map[reflect.Type]bool) Schema {
+
+	// Pointers can be null
+	if t.Kind() == reflect.Pointer {
+		schema := generateSchema(t.Elem(), inProgress)
+		if schemaType, ok := schema["type"].(string); ok {
+			schema["type"] = []string{schemaType, "null"}
+		}
+		return schema
+	}
+
+	// Types with their own encoding
+	switch t {
+	case bigIntType:
+		return Schema{"type": "integer"}
+	case addressType:
+		return Schema{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}
+	case hashType:
+		return Schema{"type": "string", "pattern": "^0x[0-9a-fA-F]{64}$"}
+	case timeType:
+		return Schema{"type": "string", "format": "date-time"}
+	case durationType:
+		return Schema{"type": "integer", "description": "A duration in nanoseconds"}
+	case rawType:
+		return Schema{}
+	}
+	if t.Implements(jsonMarshalerType) || t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+		return Schema{"type": "string", "title": t.Name()}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return Schema{"type": "string", "contentEncoding": "base64"}
+		}
+		return Schema{"type": []string{"array", "null"}, "items": generateSchema(t.Elem(), inProgress)}
+	case reflect.Map:
+		return Schema{"type": []string{"object", "null"}, "additionalProperties": generateSchema(t.Elem(), inProgress)}
+	case reflect.Struct:
+		if inProgress[t] {
+			return Schema{"title": t.Name()}
+		}
+		inProgress[t] = true
+		defer delete(inProgress, t)
+
+		properties := Schema{}
+		required := []string{}
+		addStructFields(t, properties, &required, inProgress)
+		sort.Strings(required)
+		schema := Schema{
+			"type":       "object",
+			"properties": properties,
+		}
+		if t.Name() != "" {
+			schema["title"] = t.Name()
+		}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return Schema{}
+	}
+
+}
+
+// Add the serialized fields of a struct to a schema, including the ones of embedded structs
+func addStructFields(t reflect.Type, properties Schema, required *[]string, inProgress map[reflect.Type]bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		// Embedded structs without a name are flattened into their parent
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(embedded, properties, required, inProgress)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = generateSchema(field.Type, inProgress)
+		if !strings.Contains(options, "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
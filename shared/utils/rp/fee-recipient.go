@@ -68,6 +68,17 @@ func GetFeeRecipientInfo(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddres
 }
 
 func GetFeeRecipientInfoWithoutState(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, opts *bind.CallOpts) (*FeeRecipientInfo, error) {
+	return getFeeRecipientInfo(rp, bc, nodeAddress, opts, nil)
+}
+
+// Get the fee recipient info as it was at an earlier epoch, such as the one a block was proposed in.
+// The opts should point at a block from that epoch; the opt-out cooldown is checked against the epoch instead of the Beacon head.
+func GetFeeRecipientInfoAtEpoch(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, opts *bind.CallOpts, epoch uint64) (*FeeRecipientInfo, error) {
+	return getFeeRecipientInfo(rp, bc, nodeAddress, opts, &epoch)
+}
+
+// Get the fee recipient info; the opt-out cooldown is checked against the reference epoch, or the finalized epoch of the Beacon head if it's nil
+func getFeeRecipientInfo(rp *rocketpool.RocketPool, bc beacon.Client, nodeAddress common.Address, opts *bind.CallOpts, referenceEpoch *uint64) (*FeeRecipientInfo, error) {
 	info := &FeeRecipientInfo{
 		IsInOptOutCooldown: false,
 		OptOutEpoch:        0,
@@ -124,9 +135,12 @@ func GetFeeRecipientInfoWithoutState(rp *rocketpool.RocketPool, bc beacon.Client
 		if err != nil {
 			return nil, fmt.Errorf("Error getting Beacon config: %w", err)
 		}
-		beaconHead, err := bc.GetBeaconHead()
-		if err != nil {
-			return nil, fmt.Errorf("Error getting Beacon head: %w", err)
+		if referenceEpoch == nil {
+			beaconHead, err := bc.GetBeaconHead()
+			if err != nil {
+				return nil, fmt.Errorf("Error getting Beacon head: %w", err)
+			}
+			referenceEpoch = &beaconHead.FinalizedEpoch
 		}
 
 		// Check if the user just opted out
@@ -136,9 +150,9 @@ func GetFeeRecipientInfoWithoutState(rp *rocketpool.RocketPool, bc beacon.Client
 			secondsSinceGenesis := optOutTime.Sub(genesisTime)
 			epoch := uint64(secondsSinceGenesis.Seconds()) / beaconConfig.SecondsPerEpoch
 
-			// Make sure epoch + 1 is finalized - if not, they're still on cooldown
+			// Make sure the reference epoch has reached epoch + 1 - if not, they're still on cooldown
 			targetEpoch := epoch + 1
-			if beaconHead.FinalizedEpoch < targetEpoch {
+			if *referenceEpoch < targetEpoch {
 				info.IsInOptOutCooldown = true
 				info.OptOutEpoch = targetEpoch
 			}
@@ -148,3 +162,16 @@ func GetFeeRecipientInfoWithoutState(rp *rocketpool.RocketPool, bc beacon.Client
 	return info, nil
 
 }
+
+// Checks whether a validator can use the given fee recipient without being penalized.
+// The smoothing pool and rETH contract are always acceptable; the node's fee distributor is only acceptable
+// if the node isn't in the smoothing pool and isn't waiting out an opt-out cooldown.
+func IsFeeRecipientAllowed(info *FeeRecipientInfo, rethAddress common.Address, feeRecipient common.Address) bool {
+	if feeRecipient == info.SmoothingPoolAddress || feeRecipient == rethAddress {
+		return true
+	}
+	if info.IsInSmoothingPool || info.IsInOptOutCooldown {
+		return false
+	}
+	return feeRecipient == info.FeeDistributorAddress
+}